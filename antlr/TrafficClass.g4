@@ -4,6 +4,7 @@ WHITESPACE: [ \r\n\t]+ -> skip;
 DIGITS: '0' | [1-9] [0-9]*;
 HEX_DIGITS: ('a' .. 'f' | 'A' .. 'F' | [0-9])+;
 NET: DIGITS '.' DIGITS '.' DIGITS '.' DIGITS '/' DIGITS;
+NET6: HEX_DIGITS? ':' (HEX_DIGITS | ':' | '.')* '/' DIGITS;
 
 ANY: 'ANY' | 'any';
 ALL: 'ALL' | 'all';
@@ -16,14 +17,25 @@ TOS: 'TOS' | 'tos';
 PROTOCOL: 'PROTOCOL' | 'protocol';
 SRCPORT: 'SRCPORT' | 'srcport';
 DSTPORT: 'DSTPORT' | 'dstport';
+TC: 'TC' | 'tc';
+DSCP6: 'DSCP6' | 'dscp6';
+FLOWLABEL: 'FLOWLABEL' | 'flowlabel';
+PROTOCOL6: 'PROTOCOL6' | 'protocol6';
 
-STRING: [a-zA-Z]+;
+STRING: [a-zA-Z] [a-zA-Z0-9]*;
 
 matchSrc: SRC '=' NET;
 matchDst: DST '=' NET;
 matchDSCP: DSCP '=0x' (HEX_DIGITS | DIGITS);
 matchTOS: TOS '=0x' (HEX_DIGITS | DIGITS);
-matchProtocol: PROTOCOL '=' STRING;
+matchProtocol: PROTOCOL '=' (STRING | DIGITS);
+
+matchSrc6: SRC '=' NET6;
+matchDst6: DST '=' NET6;
+matchDSCP6: DSCP6 '=0x' (HEX_DIGITS | DIGITS);
+matchTC: TC '=0x' (HEX_DIGITS | DIGITS);
+matchFlowLabel: FLOWLABEL '=0x' (HEX_DIGITS | DIGITS);
+matchProtocol6: PROTOCOL6 '=' (STRING | DIGITS);
 
 matchSrcPort: SRCPORT '=' DIGITS;
 matchSrcPortRange: SRCPORT '=' DIGITS '-' DIGITS;
@@ -37,7 +49,8 @@ condNot: NOT '(' cond ')';
 condBool: BOOL '=' ('true' | 'false');
 
 condIPv4: matchSrc | matchDst | matchDSCP | matchTOS | matchProtocol;
+condIPv6: matchSrc6 | matchDst6 | matchDSCP6 | matchTC | matchFlowLabel | matchProtocol6;
 condPort: matchSrcPort | matchSrcPortRange | matchDstPort | matchDstPortRange;
-cond: condAll | condAny | condNot | condIPv4 | condPort | condCls | condBool;
+cond: condAll | condAny | condNot | condIPv4 | condIPv6 | condPort | condCls | condBool;
 
 trafficClass: cond EOF;
@@ -50,6 +50,42 @@ func (s *BaseTrafficClassListener) EnterMatchProtocol(ctx *MatchProtocolContext)
 // ExitMatchProtocol is called when production matchProtocol is exited.
 func (s *BaseTrafficClassListener) ExitMatchProtocol(ctx *MatchProtocolContext) {}
 
+// EnterMatchSrc6 is called when production matchSrc6 is entered.
+func (s *BaseTrafficClassListener) EnterMatchSrc6(ctx *MatchSrc6Context) {}
+
+// ExitMatchSrc6 is called when production matchSrc6 is exited.
+func (s *BaseTrafficClassListener) ExitMatchSrc6(ctx *MatchSrc6Context) {}
+
+// EnterMatchDst6 is called when production matchDst6 is entered.
+func (s *BaseTrafficClassListener) EnterMatchDst6(ctx *MatchDst6Context) {}
+
+// ExitMatchDst6 is called when production matchDst6 is exited.
+func (s *BaseTrafficClassListener) ExitMatchDst6(ctx *MatchDst6Context) {}
+
+// EnterMatchDSCP6 is called when production matchDSCP6 is entered.
+func (s *BaseTrafficClassListener) EnterMatchDSCP6(ctx *MatchDSCP6Context) {}
+
+// ExitMatchDSCP6 is called when production matchDSCP6 is exited.
+func (s *BaseTrafficClassListener) ExitMatchDSCP6(ctx *MatchDSCP6Context) {}
+
+// EnterMatchTC is called when production matchTC is entered.
+func (s *BaseTrafficClassListener) EnterMatchTC(ctx *MatchTCContext) {}
+
+// ExitMatchTC is called when production matchTC is exited.
+func (s *BaseTrafficClassListener) ExitMatchTC(ctx *MatchTCContext) {}
+
+// EnterMatchFlowLabel is called when production matchFlowLabel is entered.
+func (s *BaseTrafficClassListener) EnterMatchFlowLabel(ctx *MatchFlowLabelContext) {}
+
+// ExitMatchFlowLabel is called when production matchFlowLabel is exited.
+func (s *BaseTrafficClassListener) ExitMatchFlowLabel(ctx *MatchFlowLabelContext) {}
+
+// EnterMatchProtocol6 is called when production matchProtocol6 is entered.
+func (s *BaseTrafficClassListener) EnterMatchProtocol6(ctx *MatchProtocol6Context) {}
+
+// ExitMatchProtocol6 is called when production matchProtocol6 is exited.
+func (s *BaseTrafficClassListener) ExitMatchProtocol6(ctx *MatchProtocol6Context) {}
+
 // EnterMatchSrcPort is called when production matchSrcPort is entered.
 func (s *BaseTrafficClassListener) EnterMatchSrcPort(ctx *MatchSrcPortContext) {}
 
@@ -110,6 +146,12 @@ func (s *BaseTrafficClassListener) EnterCondIPv4(ctx *CondIPv4Context) {}
 // ExitCondIPv4 is called when production condIPv4 is exited.
 func (s *BaseTrafficClassListener) ExitCondIPv4(ctx *CondIPv4Context) {}
 
+// EnterCondIPv6 is called when production condIPv6 is entered.
+func (s *BaseTrafficClassListener) EnterCondIPv6(ctx *CondIPv6Context) {}
+
+// ExitCondIPv6 is called when production condIPv6 is exited.
+func (s *BaseTrafficClassListener) ExitCondIPv6(ctx *CondIPv6Context) {}
+
 // EnterCondPort is called when production condPort is entered.
 func (s *BaseTrafficClassListener) EnterCondPort(ctx *CondPortContext) {}
 
@@ -14,113 +14,152 @@ var _ = fmt.Printf
 var _ = unicode.IsLetter
 
 var serializedLexerAtn = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 27, 238,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 32, 322,
 	8, 1, 4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7,
 	9, 7, 4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12,
 	4, 13, 9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4,
 	18, 9, 18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 4, 22, 9, 22, 4, 23,
-	9, 23, 4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 3, 2, 3, 2, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 6, 3, 6, 3, 7,
-	3, 7, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 10, 3, 10, 3, 10, 3,
-	10, 3, 10, 3, 10, 3, 11, 6, 11, 85, 10, 11, 13, 11, 14, 11, 86, 3, 11,
-	3, 11, 3, 12, 3, 12, 3, 12, 7, 12, 94, 10, 12, 12, 12, 14, 12, 97, 11,
-	12, 5, 12, 99, 10, 12, 3, 13, 6, 13, 102, 10, 13, 13, 13, 14, 13, 103,
-	3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3,
-	15, 3, 15, 3, 15, 3, 15, 3, 15, 3, 15, 5, 15, 122, 10, 15, 3, 16, 3, 16,
-	3, 16, 3, 16, 3, 16, 3, 16, 5, 16, 130, 10, 16, 3, 17, 3, 17, 3, 17, 3,
-	17, 3, 17, 3, 17, 5, 17, 138, 10, 17, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18,
-	3, 18, 3, 18, 3, 18, 5, 18, 148, 10, 18, 3, 19, 3, 19, 3, 19, 3, 19, 3,
-	19, 3, 19, 5, 19, 156, 10, 19, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20,
-	5, 20, 164, 10, 20, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3,
-	21, 5, 21, 174, 10, 21, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 5, 22,
-	182, 10, 22, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3,
-	23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 5, 23, 200, 10, 23,
+	9, 23, 4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 4, 27, 9, 27, 4, 28, 9,
+	28, 4, 29, 9, 29, 4, 30, 9, 30, 4, 31, 9, 31, 3, 2, 3, 2, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 6, 3, 6, 3, 7, 3,
+	7, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 10, 3, 10, 3, 10, 3, 10,
+	3, 10, 3, 10, 3, 11, 6, 11, 95, 10, 11, 13, 11, 14, 11, 96, 3, 11, 3, 11,
+	3, 12, 3, 12, 3, 12, 7, 12, 104, 10, 12, 12, 12, 14, 12, 107, 11, 12, 5,
+	12, 109, 10, 12, 3, 13, 6, 13, 112, 10, 13, 13, 13, 14, 13, 113, 3, 14,
+	3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 14, 3, 15, 5,
+	15, 127, 10, 15, 3, 15, 3, 15, 3, 15, 7, 15, 132, 10, 15, 12, 15, 14, 15,
+	135, 11, 15, 3, 15, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16, 3, 16, 3, 16, 3,
+	16, 5, 16, 146, 10, 16, 3, 17, 3, 17, 3, 17, 3, 17, 3, 17, 3, 17, 5, 17,
+	154, 10, 17, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 5, 18, 162, 10,
+	18, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 3, 19, 5, 19, 172,
+	10, 19, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 5, 20, 180, 10, 20, 3,
+	21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 5, 21, 188, 10, 21, 3, 22, 3, 22,
+	3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 5, 22, 198, 10, 22, 3, 23, 3,
+	23, 3, 23, 3, 23, 3, 23, 3, 23, 5, 23, 206, 10, 23, 3, 24, 3, 24, 3, 24,
 	3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3, 24, 3,
-	24, 3, 24, 3, 24, 3, 24, 5, 24, 216, 10, 24, 3, 25, 3, 25, 3, 25, 3, 25,
-	3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 5,
-	25, 232, 10, 25, 3, 26, 6, 26, 235, 10, 26, 13, 26, 14, 26, 236, 2, 2,
-	27, 3, 3, 5, 4, 7, 5, 9, 6, 11, 7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12,
-	23, 13, 25, 14, 27, 15, 29, 16, 31, 17, 33, 18, 35, 19, 37, 20, 39, 21,
-	41, 22, 43, 23, 45, 24, 47, 25, 49, 26, 51, 27, 3, 2, 7, 5, 2, 11, 12,
-	15, 15, 34, 34, 3, 2, 51, 59, 3, 2, 50, 59, 5, 2, 50, 59, 67, 72, 99, 104,
-	4, 2, 67, 92, 99, 124, 2, 253, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7,
-	3, 2, 2, 2, 2, 9, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2,
-	15, 3, 2, 2, 2, 2, 17, 3, 2, 2, 2, 2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 2,
-	2, 23, 3, 2, 2, 2, 2, 25, 3, 2, 2, 2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 2,
-	2, 2, 31, 3, 2, 2, 2, 2, 33, 3, 2, 2, 2, 2, 35, 3, 2, 2, 2, 2, 37, 3, 2,
-	2, 2, 2, 39, 3, 2, 2, 2, 2, 41, 3, 2, 2, 2, 2, 43, 3, 2, 2, 2, 2, 45, 3,
-	2, 2, 2, 2, 47, 3, 2, 2, 2, 2, 49, 3, 2, 2, 2, 2, 51, 3, 2, 2, 2, 3, 53,
-	3, 2, 2, 2, 5, 55, 3, 2, 2, 2, 7, 59, 3, 2, 2, 2, 9, 61, 3, 2, 2, 2, 11,
-	66, 3, 2, 2, 2, 13, 68, 3, 2, 2, 2, 15, 70, 3, 2, 2, 2, 17, 72, 3, 2, 2,
-	2, 19, 77, 3, 2, 2, 2, 21, 84, 3, 2, 2, 2, 23, 98, 3, 2, 2, 2, 25, 101,
-	3, 2, 2, 2, 27, 105, 3, 2, 2, 2, 29, 121, 3, 2, 2, 2, 31, 129, 3, 2, 2,
-	2, 33, 137, 3, 2, 2, 2, 35, 147, 3, 2, 2, 2, 37, 155, 3, 2, 2, 2, 39, 163,
-	3, 2, 2, 2, 41, 173, 3, 2, 2, 2, 43, 181, 3, 2, 2, 2, 45, 199, 3, 2, 2,
-	2, 47, 215, 3, 2, 2, 2, 49, 231, 3, 2, 2, 2, 51, 234, 3, 2, 2, 2, 53, 54,
-	7, 63, 2, 2, 54, 4, 3, 2, 2, 2, 55, 56, 7, 63, 2, 2, 56, 57, 7, 50, 2,
-	2, 57, 58, 7, 122, 2, 2, 58, 6, 3, 2, 2, 2, 59, 60, 7, 47, 2, 2, 60, 8,
-	3, 2, 2, 2, 61, 62, 7, 101, 2, 2, 62, 63, 7, 110, 2, 2, 63, 64, 7, 117,
-	2, 2, 64, 65, 7, 63, 2, 2, 65, 10, 3, 2, 2, 2, 66, 67, 7, 42, 2, 2, 67,
-	12, 3, 2, 2, 2, 68, 69, 7, 46, 2, 2, 69, 14, 3, 2, 2, 2, 70, 71, 7, 43,
-	2, 2, 71, 16, 3, 2, 2, 2, 72, 73, 7, 118, 2, 2, 73, 74, 7, 116, 2, 2, 74,
-	75, 7, 119, 2, 2, 75, 76, 7, 103, 2, 2, 76, 18, 3, 2, 2, 2, 77, 78, 7,
-	104, 2, 2, 78, 79, 7, 99, 2, 2, 79, 80, 7, 110, 2, 2, 80, 81, 7, 117, 2,
-	2, 81, 82, 7, 103, 2, 2, 82, 20, 3, 2, 2, 2, 83, 85, 9, 2, 2, 2, 84, 83,
-	3, 2, 2, 2, 85, 86, 3, 2, 2, 2, 86, 84, 3, 2, 2, 2, 86, 87, 3, 2, 2, 2,
-	87, 88, 3, 2, 2, 2, 88, 89, 8, 11, 2, 2, 89, 22, 3, 2, 2, 2, 90, 99, 7,
-	50, 2, 2, 91, 95, 9, 3, 2, 2, 92, 94, 9, 4, 2, 2, 93, 92, 3, 2, 2, 2, 94,
-	97, 3, 2, 2, 2, 95, 93, 3, 2, 2, 2, 95, 96, 3, 2, 2, 2, 96, 99, 3, 2, 2,
-	2, 97, 95, 3, 2, 2, 2, 98, 90, 3, 2, 2, 2, 98, 91, 3, 2, 2, 2, 99, 24,
-	3, 2, 2, 2, 100, 102, 9, 5, 2, 2, 101, 100, 3, 2, 2, 2, 102, 103, 3, 2,
-	2, 2, 103, 101, 3, 2, 2, 2, 103, 104, 3, 2, 2, 2, 104, 26, 3, 2, 2, 2,
-	105, 106, 5, 23, 12, 2, 106, 107, 7, 48, 2, 2, 107, 108, 5, 23, 12, 2,
-	108, 109, 7, 48, 2, 2, 109, 110, 5, 23, 12, 2, 110, 111, 7, 48, 2, 2, 111,
-	112, 5, 23, 12, 2, 112, 113, 7, 49, 2, 2, 113, 114, 5, 23, 12, 2, 114,
-	28, 3, 2, 2, 2, 115, 116, 7, 67, 2, 2, 116, 117, 7, 80, 2, 2, 117, 122,
-	7, 91, 2, 2, 118, 119, 7, 99, 2, 2, 119, 120, 7, 112, 2, 2, 120, 122, 7,
-	123, 2, 2, 121, 115, 3, 2, 2, 2, 121, 118, 3, 2, 2, 2, 122, 30, 3, 2, 2,
-	2, 123, 124, 7, 67, 2, 2, 124, 125, 7, 78, 2, 2, 125, 130, 7, 78, 2, 2,
-	126, 127, 7, 99, 2, 2, 127, 128, 7, 110, 2, 2, 128, 130, 7, 110, 2, 2,
-	129, 123, 3, 2, 2, 2, 129, 126, 3, 2, 2, 2, 130, 32, 3, 2, 2, 2, 131, 132,
-	7, 80, 2, 2, 132, 133, 7, 81, 2, 2, 133, 138, 7, 86, 2, 2, 134, 135, 7,
-	112, 2, 2, 135, 136, 7, 113, 2, 2, 136, 138, 7, 118, 2, 2, 137, 131, 3,
-	2, 2, 2, 137, 134, 3, 2, 2, 2, 138, 34, 3, 2, 2, 2, 139, 140, 7, 68, 2,
-	2, 140, 141, 7, 81, 2, 2, 141, 142, 7, 81, 2, 2, 142, 148, 7, 78, 2, 2,
-	143, 144, 7, 100, 2, 2, 144, 145, 7, 113, 2, 2, 145, 146, 7, 113, 2, 2,
-	146, 148, 7, 110, 2, 2, 147, 139, 3, 2, 2, 2, 147, 143, 3, 2, 2, 2, 148,
-	36, 3, 2, 2, 2, 149, 150, 7, 85, 2, 2, 150, 151, 7, 84, 2, 2, 151, 156,
-	7, 69, 2, 2, 152, 153, 7, 117, 2, 2, 153, 154, 7, 116, 2, 2, 154, 156,
-	7, 101, 2, 2, 155, 149, 3, 2, 2, 2, 155, 152, 3, 2, 2, 2, 156, 38, 3, 2,
-	2, 2, 157, 158, 7, 70, 2, 2, 158, 159, 7, 85, 2, 2, 159, 164, 7, 86, 2,
-	2, 160, 161, 7, 102, 2, 2, 161, 162, 7, 117, 2, 2, 162, 164, 7, 118, 2,
-	2, 163, 157, 3, 2, 2, 2, 163, 160, 3, 2, 2, 2, 164, 40, 3, 2, 2, 2, 165,
-	166, 7, 70, 2, 2, 166, 167, 7, 85, 2, 2, 167, 168, 7, 69, 2, 2, 168, 174,
-	7, 82, 2, 2, 169, 170, 7, 102, 2, 2, 170, 171, 7, 117, 2, 2, 171, 172,
-	7, 101, 2, 2, 172, 174, 7, 114, 2, 2, 173, 165, 3, 2, 2, 2, 173, 169, 3,
-	2, 2, 2, 174, 42, 3, 2, 2, 2, 175, 176, 7, 86, 2, 2, 176, 177, 7, 81, 2,
-	2, 177, 182, 7, 85, 2, 2, 178, 179, 7, 118, 2, 2, 179, 180, 7, 113, 2,
-	2, 180, 182, 7, 117, 2, 2, 181, 175, 3, 2, 2, 2, 181, 178, 3, 2, 2, 2,
-	182, 44, 3, 2, 2, 2, 183, 184, 7, 82, 2, 2, 184, 185, 7, 84, 2, 2, 185,
-	186, 7, 81, 2, 2, 186, 187, 7, 86, 2, 2, 187, 188, 7, 81, 2, 2, 188, 189,
-	7, 69, 2, 2, 189, 190, 7, 81, 2, 2, 190, 200, 7, 78, 2, 2, 191, 192, 7,
-	114, 2, 2, 192, 193, 7, 116, 2, 2, 193, 194, 7, 113, 2, 2, 194, 195, 7,
-	118, 2, 2, 195, 196, 7, 113, 2, 2, 196, 197, 7, 101, 2, 2, 197, 198, 7,
-	113, 2, 2, 198, 200, 7, 110, 2, 2, 199, 183, 3, 2, 2, 2, 199, 191, 3, 2,
-	2, 2, 200, 46, 3, 2, 2, 2, 201, 202, 7, 85, 2, 2, 202, 203, 7, 84, 2, 2,
-	203, 204, 7, 69, 2, 2, 204, 205, 7, 82, 2, 2, 205, 206, 7, 81, 2, 2, 206,
-	207, 7, 84, 2, 2, 207, 216, 7, 86, 2, 2, 208, 209, 7, 117, 2, 2, 209, 210,
-	7, 116, 2, 2, 210, 211, 7, 101, 2, 2, 211, 212, 7, 114, 2, 2, 212, 213,
-	7, 113, 2, 2, 213, 214, 7, 116, 2, 2, 214, 216, 7, 118, 2, 2, 215, 201,
-	3, 2, 2, 2, 215, 208, 3, 2, 2, 2, 216, 48, 3, 2, 2, 2, 217, 218, 7, 70,
-	2, 2, 218, 219, 7, 85, 2, 2, 219, 220, 7, 86, 2, 2, 220, 221, 7, 82, 2,
-	2, 221, 222, 7, 81, 2, 2, 222, 223, 7, 84, 2, 2, 223, 232, 7, 86, 2, 2,
-	224, 225, 7, 102, 2, 2, 225, 226, 7, 117, 2, 2, 226, 227, 7, 118, 2, 2,
-	227, 228, 7, 114, 2, 2, 228, 229, 7, 113, 2, 2, 229, 230, 7, 116, 2, 2,
-	230, 232, 7, 118, 2, 2, 231, 217, 3, 2, 2, 2, 231, 224, 3, 2, 2, 2, 232,
-	50, 3, 2, 2, 2, 233, 235, 9, 6, 2, 2, 234, 233, 3, 2, 2, 2, 235, 236, 3,
-	2, 2, 2, 236, 234, 3, 2, 2, 2, 236, 237, 3, 2, 2, 2, 237, 52, 3, 2, 2,
-	2, 20, 2, 86, 95, 98, 101, 103, 121, 129, 137, 147, 155, 163, 173, 181,
-	199, 215, 231, 236, 3, 8, 2, 2,
+	24, 3, 24, 3, 24, 5, 24, 224, 10, 24, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25,
+	3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 5, 25, 240,
+	10, 25, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 3, 26,
+	3, 26, 3, 26, 3, 26, 3, 26, 3, 26, 5, 26, 256, 10, 26, 3, 27, 3, 27, 3,
+	27, 3, 27, 5, 27, 262, 10, 27, 3, 28, 3, 28, 3, 28, 3, 28, 3, 28, 3, 28,
+	3, 28, 3, 28, 3, 28, 3, 28, 5, 28, 274, 10, 28, 3, 29, 3, 29, 3, 29, 3,
+	29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 3, 29,
+	3, 29, 3, 29, 3, 29, 3, 29, 5, 29, 294, 10, 29, 3, 30, 3, 30, 3, 30, 3,
+	30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30, 3, 30,
+	3, 30, 3, 30, 3, 30, 3, 30, 5, 30, 314, 10, 30, 3, 31, 3, 31, 7, 31, 318,
+	10, 31, 12, 31, 14, 31, 321, 11, 31, 2, 2, 32, 3, 3, 5, 4, 7, 5, 9, 6,
+	11, 7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12, 23, 13, 25, 14, 27, 15, 29,
+	16, 31, 17, 33, 18, 35, 19, 37, 20, 39, 21, 41, 22, 43, 23, 45, 24, 47,
+	25, 49, 26, 51, 27, 53, 28, 55, 29, 57, 30, 59, 31, 61, 32, 3, 2, 9, 5,
+	2, 11, 12, 15, 15, 34, 34, 3, 2, 51, 59, 3, 2, 50, 59, 5, 2, 50, 59, 67,
+	72, 99, 104, 4, 2, 48, 48, 60, 60, 4, 2, 67, 92, 99, 124, 5, 2, 50, 59,
+	67, 92, 99, 124, 2, 344, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7, 3, 2,
+	2, 2, 2, 9, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2, 15, 3,
+	2, 2, 2, 2, 17, 3, 2, 2, 2, 2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 2, 2, 23,
+	3, 2, 2, 2, 2, 25, 3, 2, 2, 2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 2, 2, 2,
+	31, 3, 2, 2, 2, 2, 33, 3, 2, 2, 2, 2, 35, 3, 2, 2, 2, 2, 37, 3, 2, 2, 2,
+	2, 39, 3, 2, 2, 2, 2, 41, 3, 2, 2, 2, 2, 43, 3, 2, 2, 2, 2, 45, 3, 2, 2,
+	2, 2, 47, 3, 2, 2, 2, 2, 49, 3, 2, 2, 2, 2, 51, 3, 2, 2, 2, 2, 53, 3, 2,
+	2, 2, 2, 55, 3, 2, 2, 2, 2, 57, 3, 2, 2, 2, 2, 59, 3, 2, 2, 2, 2, 61, 3,
+	2, 2, 2, 3, 63, 3, 2, 2, 2, 5, 65, 3, 2, 2, 2, 7, 69, 3, 2, 2, 2, 9, 71,
+	3, 2, 2, 2, 11, 76, 3, 2, 2, 2, 13, 78, 3, 2, 2, 2, 15, 80, 3, 2, 2, 2,
+	17, 82, 3, 2, 2, 2, 19, 87, 3, 2, 2, 2, 21, 94, 3, 2, 2, 2, 23, 108, 3,
+	2, 2, 2, 25, 111, 3, 2, 2, 2, 27, 115, 3, 2, 2, 2, 29, 126, 3, 2, 2, 2,
+	31, 145, 3, 2, 2, 2, 33, 153, 3, 2, 2, 2, 35, 161, 3, 2, 2, 2, 37, 171,
+	3, 2, 2, 2, 39, 179, 3, 2, 2, 2, 41, 187, 3, 2, 2, 2, 43, 197, 3, 2, 2,
+	2, 45, 205, 3, 2, 2, 2, 47, 223, 3, 2, 2, 2, 49, 239, 3, 2, 2, 2, 51, 255,
+	3, 2, 2, 2, 53, 261, 3, 2, 2, 2, 55, 273, 3, 2, 2, 2, 57, 293, 3, 2, 2,
+	2, 59, 313, 3, 2, 2, 2, 61, 315, 3, 2, 2, 2, 63, 64, 7, 63, 2, 2, 64, 4,
+	3, 2, 2, 2, 65, 66, 7, 63, 2, 2, 66, 67, 7, 50, 2, 2, 67, 68, 7, 122, 2,
+	2, 68, 6, 3, 2, 2, 2, 69, 70, 7, 47, 2, 2, 70, 8, 3, 2, 2, 2, 71, 72, 7,
+	101, 2, 2, 72, 73, 7, 110, 2, 2, 73, 74, 7, 117, 2, 2, 74, 75, 7, 63, 2,
+	2, 75, 10, 3, 2, 2, 2, 76, 77, 7, 42, 2, 2, 77, 12, 3, 2, 2, 2, 78, 79,
+	7, 46, 2, 2, 79, 14, 3, 2, 2, 2, 80, 81, 7, 43, 2, 2, 81, 16, 3, 2, 2,
+	2, 82, 83, 7, 118, 2, 2, 83, 84, 7, 116, 2, 2, 84, 85, 7, 119, 2, 2, 85,
+	86, 7, 103, 2, 2, 86, 18, 3, 2, 2, 2, 87, 88, 7, 104, 2, 2, 88, 89, 7,
+	99, 2, 2, 89, 90, 7, 110, 2, 2, 90, 91, 7, 117, 2, 2, 91, 92, 7, 103, 2,
+	2, 92, 20, 3, 2, 2, 2, 93, 95, 9, 2, 2, 2, 94, 93, 3, 2, 2, 2, 95, 96,
+	3, 2, 2, 2, 96, 94, 3, 2, 2, 2, 96, 97, 3, 2, 2, 2, 97, 98, 3, 2, 2, 2,
+	98, 99, 8, 11, 2, 2, 99, 22, 3, 2, 2, 2, 100, 109, 7, 50, 2, 2, 101, 105,
+	9, 3, 2, 2, 102, 104, 9, 4, 2, 2, 103, 102, 3, 2, 2, 2, 104, 107, 3, 2,
+	2, 2, 105, 103, 3, 2, 2, 2, 105, 106, 3, 2, 2, 2, 106, 109, 3, 2, 2, 2,
+	107, 105, 3, 2, 2, 2, 108, 100, 3, 2, 2, 2, 108, 101, 3, 2, 2, 2, 109,
+	24, 3, 2, 2, 2, 110, 112, 9, 5, 2, 2, 111, 110, 3, 2, 2, 2, 112, 113, 3,
+	2, 2, 2, 113, 111, 3, 2, 2, 2, 113, 114, 3, 2, 2, 2, 114, 26, 3, 2, 2,
+	2, 115, 116, 5, 23, 12, 2, 116, 117, 7, 48, 2, 2, 117, 118, 5, 23, 12,
+	2, 118, 119, 7, 48, 2, 2, 119, 120, 5, 23, 12, 2, 120, 121, 7, 48, 2, 2,
+	121, 122, 5, 23, 12, 2, 122, 123, 7, 49, 2, 2, 123, 124, 5, 23, 12, 2,
+	124, 28, 3, 2, 2, 2, 125, 127, 5, 25, 13, 2, 126, 125, 3, 2, 2, 2, 126,
+	127, 3, 2, 2, 2, 127, 128, 3, 2, 2, 2, 128, 133, 7, 60, 2, 2, 129, 132,
+	5, 25, 13, 2, 130, 132, 9, 6, 2, 2, 131, 129, 3, 2, 2, 2, 131, 130, 3,
+	2, 2, 2, 132, 135, 3, 2, 2, 2, 133, 131, 3, 2, 2, 2, 133, 134, 3, 2, 2,
+	2, 134, 136, 3, 2, 2, 2, 135, 133, 3, 2, 2, 2, 136, 137, 7, 49, 2, 2, 137,
+	138, 5, 23, 12, 2, 138, 30, 3, 2, 2, 2, 139, 140, 7, 67, 2, 2, 140, 141,
+	7, 80, 2, 2, 141, 146, 7, 91, 2, 2, 142, 143, 7, 99, 2, 2, 143, 144, 7,
+	112, 2, 2, 144, 146, 7, 123, 2, 2, 145, 139, 3, 2, 2, 2, 145, 142, 3, 2,
+	2, 2, 146, 32, 3, 2, 2, 2, 147, 148, 7, 67, 2, 2, 148, 149, 7, 78, 2, 2,
+	149, 154, 7, 78, 2, 2, 150, 151, 7, 99, 2, 2, 151, 152, 7, 110, 2, 2, 152,
+	154, 7, 110, 2, 2, 153, 147, 3, 2, 2, 2, 153, 150, 3, 2, 2, 2, 154, 34,
+	3, 2, 2, 2, 155, 156, 7, 80, 2, 2, 156, 157, 7, 81, 2, 2, 157, 162, 7,
+	86, 2, 2, 158, 159, 7, 112, 2, 2, 159, 160, 7, 113, 2, 2, 160, 162, 7,
+	118, 2, 2, 161, 155, 3, 2, 2, 2, 161, 158, 3, 2, 2, 2, 162, 36, 3, 2, 2,
+	2, 163, 164, 7, 68, 2, 2, 164, 165, 7, 81, 2, 2, 165, 166, 7, 81, 2, 2,
+	166, 172, 7, 78, 2, 2, 167, 168, 7, 100, 2, 2, 168, 169, 7, 113, 2, 2,
+	169, 170, 7, 113, 2, 2, 170, 172, 7, 110, 2, 2, 171, 163, 3, 2, 2, 2, 171,
+	167, 3, 2, 2, 2, 172, 38, 3, 2, 2, 2, 173, 174, 7, 85, 2, 2, 174, 175,
+	7, 84, 2, 2, 175, 180, 7, 69, 2, 2, 176, 177, 7, 117, 2, 2, 177, 178, 7,
+	116, 2, 2, 178, 180, 7, 101, 2, 2, 179, 173, 3, 2, 2, 2, 179, 176, 3, 2,
+	2, 2, 180, 40, 3, 2, 2, 2, 181, 182, 7, 70, 2, 2, 182, 183, 7, 85, 2, 2,
+	183, 188, 7, 86, 2, 2, 184, 185, 7, 102, 2, 2, 185, 186, 7, 117, 2, 2,
+	186, 188, 7, 118, 2, 2, 187, 181, 3, 2, 2, 2, 187, 184, 3, 2, 2, 2, 188,
+	42, 3, 2, 2, 2, 189, 190, 7, 70, 2, 2, 190, 191, 7, 85, 2, 2, 191, 192,
+	7, 69, 2, 2, 192, 198, 7, 82, 2, 2, 193, 194, 7, 102, 2, 2, 194, 195, 7,
+	117, 2, 2, 195, 196, 7, 101, 2, 2, 196, 198, 7, 114, 2, 2, 197, 189, 3,
+	2, 2, 2, 197, 193, 3, 2, 2, 2, 198, 44, 3, 2, 2, 2, 199, 200, 7, 86, 2,
+	2, 200, 201, 7, 81, 2, 2, 201, 206, 7, 85, 2, 2, 202, 203, 7, 118, 2, 2,
+	203, 204, 7, 113, 2, 2, 204, 206, 7, 117, 2, 2, 205, 199, 3, 2, 2, 2, 205,
+	202, 3, 2, 2, 2, 206, 46, 3, 2, 2, 2, 207, 208, 7, 82, 2, 2, 208, 209,
+	7, 84, 2, 2, 209, 210, 7, 81, 2, 2, 210, 211, 7, 86, 2, 2, 211, 212, 7,
+	81, 2, 2, 212, 213, 7, 69, 2, 2, 213, 214, 7, 81, 2, 2, 214, 224, 7, 78,
+	2, 2, 215, 216, 7, 114, 2, 2, 216, 217, 7, 116, 2, 2, 217, 218, 7, 113,
+	2, 2, 218, 219, 7, 118, 2, 2, 219, 220, 7, 113, 2, 2, 220, 221, 7, 101,
+	2, 2, 221, 222, 7, 113, 2, 2, 222, 224, 7, 110, 2, 2, 223, 207, 3, 2, 2,
+	2, 223, 215, 3, 2, 2, 2, 224, 48, 3, 2, 2, 2, 225, 226, 7, 85, 2, 2, 226,
+	227, 7, 84, 2, 2, 227, 228, 7, 69, 2, 2, 228, 229, 7, 82, 2, 2, 229, 230,
+	7, 81, 2, 2, 230, 231, 7, 84, 2, 2, 231, 240, 7, 86, 2, 2, 232, 233, 7,
+	117, 2, 2, 233, 234, 7, 116, 2, 2, 234, 235, 7, 101, 2, 2, 235, 236, 7,
+	114, 2, 2, 236, 237, 7, 113, 2, 2, 237, 238, 7, 116, 2, 2, 238, 240, 7,
+	118, 2, 2, 239, 225, 3, 2, 2, 2, 239, 232, 3, 2, 2, 2, 240, 50, 3, 2, 2,
+	2, 241, 242, 7, 70, 2, 2, 242, 243, 7, 85, 2, 2, 243, 244, 7, 86, 2, 2,
+	244, 245, 7, 82, 2, 2, 245, 246, 7, 81, 2, 2, 246, 247, 7, 84, 2, 2, 247,
+	256, 7, 86, 2, 2, 248, 249, 7, 102, 2, 2, 249, 250, 7, 117, 2, 2, 250,
+	251, 7, 118, 2, 2, 251, 252, 7, 114, 2, 2, 252, 253, 7, 113, 2, 2, 253,
+	254, 7, 116, 2, 2, 254, 256, 7, 118, 2, 2, 255, 241, 3, 2, 2, 2, 255, 248,
+	3, 2, 2, 2, 256, 52, 3, 2, 2, 2, 257, 258, 7, 86, 2, 2, 258, 262, 7, 69,
+	2, 2, 259, 260, 7, 118, 2, 2, 260, 262, 7, 101, 2, 2, 261, 257, 3, 2, 2,
+	2, 261, 259, 3, 2, 2, 2, 262, 54, 3, 2, 2, 2, 263, 264, 7, 70, 2, 2, 264,
+	265, 7, 85, 2, 2, 265, 266, 7, 69, 2, 2, 266, 267, 7, 82, 2, 2, 267, 274,
+	7, 56, 2, 2, 268, 269, 7, 102, 2, 2, 269, 270, 7, 117, 2, 2, 270, 271,
+	7, 101, 2, 2, 271, 272, 7, 114, 2, 2, 272, 274, 7, 56, 2, 2, 273, 263,
+	3, 2, 2, 2, 273, 268, 3, 2, 2, 2, 274, 56, 3, 2, 2, 2, 275, 276, 7, 72,
+	2, 2, 276, 277, 7, 78, 2, 2, 277, 278, 7, 81, 2, 2, 278, 279, 7, 89, 2,
+	2, 279, 280, 7, 78, 2, 2, 280, 281, 7, 67, 2, 2, 281, 282, 7, 68, 2, 2,
+	282, 283, 7, 71, 2, 2, 283, 294, 7, 78, 2, 2, 284, 285, 7, 104, 2, 2, 285,
+	286, 7, 110, 2, 2, 286, 287, 7, 113, 2, 2, 287, 288, 7, 121, 2, 2, 288,
+	289, 7, 110, 2, 2, 289, 290, 7, 99, 2, 2, 290, 291, 7, 100, 2, 2, 291,
+	292, 7, 103, 2, 2, 292, 294, 7, 110, 2, 2, 293, 275, 3, 2, 2, 2, 293, 284,
+	3, 2, 2, 2, 294, 58, 3, 2, 2, 2, 295, 296, 7, 82, 2, 2, 296, 297, 7, 84,
+	2, 2, 297, 298, 7, 81, 2, 2, 298, 299, 7, 86, 2, 2, 299, 300, 7, 81, 2,
+	2, 300, 301, 7, 69, 2, 2, 301, 302, 7, 81, 2, 2, 302, 303, 7, 78, 2, 2,
+	303, 314, 7, 56, 2, 2, 304, 305, 7, 114, 2, 2, 305, 306, 7, 116, 2, 2,
+	306, 307, 7, 113, 2, 2, 307, 308, 7, 118, 2, 2, 308, 309, 7, 113, 2, 2,
+	309, 310, 7, 101, 2, 2, 310, 311, 7, 113, 2, 2, 311, 312, 7, 110, 2, 2,
+	312, 314, 7, 56, 2, 2, 313, 295, 3, 2, 2, 2, 313, 304, 3, 2, 2, 2, 314,
+	60, 3, 2, 2, 2, 315, 319, 9, 7, 2, 2, 316, 318, 9, 8, 2, 2, 317, 316, 3,
+	2, 2, 2, 318, 321, 3, 2, 2, 2, 319, 317, 3, 2, 2, 2, 319, 320, 3, 2, 2,
+	2, 320, 62, 3, 2, 2, 2, 321, 319, 3, 2, 2, 2, 27, 2, 96, 105, 108, 111,
+	113, 126, 131, 133, 145, 153, 161, 171, 179, 187, 197, 205, 223, 239, 255,
+	261, 273, 293, 313, 319, 3, 8, 2, 2,
 }
 
 var lexerDeserializer = antlr.NewATNDeserializer(nil)
@@ -140,14 +179,16 @@ var lexerLiteralNames = []string{
 
 var lexerSymbolicNames = []string{
 	"", "", "", "", "", "", "", "", "", "", "WHITESPACE", "DIGITS", "HEX_DIGITS",
-	"NET", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS", "PROTOCOL",
-	"SRCPORT", "DSTPORT", "STRING",
+	"NET", "NET6", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS",
+	"PROTOCOL", "SRCPORT", "DSTPORT", "TC", "DSCP6", "FLOWLABEL", "PROTOCOL6",
+	"STRING",
 }
 
 var lexerRuleNames = []string{
 	"T__0", "T__1", "T__2", "T__3", "T__4", "T__5", "T__6", "T__7", "T__8",
-	"WHITESPACE", "DIGITS", "HEX_DIGITS", "NET", "ANY", "ALL", "NOT", "BOOL",
-	"SRC", "DST", "DSCP", "TOS", "PROTOCOL", "SRCPORT", "DSTPORT", "STRING",
+	"WHITESPACE", "DIGITS", "HEX_DIGITS", "NET", "NET6", "ANY", "ALL", "NOT",
+	"BOOL", "SRC", "DST", "DSCP", "TOS", "PROTOCOL", "SRCPORT", "DSTPORT",
+	"TC", "DSCP6", "FLOWLABEL", "PROTOCOL6", "STRING",
 }
 
 type TrafficClassLexer struct {
@@ -198,16 +239,21 @@ const (
 	TrafficClassLexerDIGITS     = 11
 	TrafficClassLexerHEX_DIGITS = 12
 	TrafficClassLexerNET        = 13
-	TrafficClassLexerANY        = 14
-	TrafficClassLexerALL        = 15
-	TrafficClassLexerNOT        = 16
-	TrafficClassLexerBOOL       = 17
-	TrafficClassLexerSRC        = 18
-	TrafficClassLexerDST        = 19
-	TrafficClassLexerDSCP       = 20
-	TrafficClassLexerTOS        = 21
-	TrafficClassLexerPROTOCOL   = 22
-	TrafficClassLexerSRCPORT    = 23
-	TrafficClassLexerDSTPORT    = 24
-	TrafficClassLexerSTRING     = 25
+	TrafficClassLexerNET6       = 14
+	TrafficClassLexerANY        = 15
+	TrafficClassLexerALL        = 16
+	TrafficClassLexerNOT        = 17
+	TrafficClassLexerBOOL       = 18
+	TrafficClassLexerSRC        = 19
+	TrafficClassLexerDST        = 20
+	TrafficClassLexerDSCP       = 21
+	TrafficClassLexerTOS        = 22
+	TrafficClassLexerPROTOCOL   = 23
+	TrafficClassLexerSRCPORT    = 24
+	TrafficClassLexerDSTPORT    = 25
+	TrafficClassLexerTC         = 26
+	TrafficClassLexerDSCP6      = 27
+	TrafficClassLexerFLOWLABEL  = 28
+	TrafficClassLexerPROTOCOL6  = 29
+	TrafficClassLexerSTRING     = 30
 )
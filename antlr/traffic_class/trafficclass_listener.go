@@ -22,6 +22,24 @@ type TrafficClassListener interface {
 	// EnterMatchProtocol is called when entering the matchProtocol production.
 	EnterMatchProtocol(c *MatchProtocolContext)
 
+	// EnterMatchSrc6 is called when entering the matchSrc6 production.
+	EnterMatchSrc6(c *MatchSrc6Context)
+
+	// EnterMatchDst6 is called when entering the matchDst6 production.
+	EnterMatchDst6(c *MatchDst6Context)
+
+	// EnterMatchDSCP6 is called when entering the matchDSCP6 production.
+	EnterMatchDSCP6(c *MatchDSCP6Context)
+
+	// EnterMatchTC is called when entering the matchTC production.
+	EnterMatchTC(c *MatchTCContext)
+
+	// EnterMatchFlowLabel is called when entering the matchFlowLabel production.
+	EnterMatchFlowLabel(c *MatchFlowLabelContext)
+
+	// EnterMatchProtocol6 is called when entering the matchProtocol6 production.
+	EnterMatchProtocol6(c *MatchProtocol6Context)
+
 	// EnterMatchSrcPort is called when entering the matchSrcPort production.
 	EnterMatchSrcPort(c *MatchSrcPortContext)
 
@@ -52,6 +70,9 @@ type TrafficClassListener interface {
 	// EnterCondIPv4 is called when entering the condIPv4 production.
 	EnterCondIPv4(c *CondIPv4Context)
 
+	// EnterCondIPv6 is called when entering the condIPv6 production.
+	EnterCondIPv6(c *CondIPv6Context)
+
 	// EnterCondPort is called when entering the condPort production.
 	EnterCondPort(c *CondPortContext)
 
@@ -76,6 +97,24 @@ type TrafficClassListener interface {
 	// ExitMatchProtocol is called when exiting the matchProtocol production.
 	ExitMatchProtocol(c *MatchProtocolContext)
 
+	// ExitMatchSrc6 is called when exiting the matchSrc6 production.
+	ExitMatchSrc6(c *MatchSrc6Context)
+
+	// ExitMatchDst6 is called when exiting the matchDst6 production.
+	ExitMatchDst6(c *MatchDst6Context)
+
+	// ExitMatchDSCP6 is called when exiting the matchDSCP6 production.
+	ExitMatchDSCP6(c *MatchDSCP6Context)
+
+	// ExitMatchTC is called when exiting the matchTC production.
+	ExitMatchTC(c *MatchTCContext)
+
+	// ExitMatchFlowLabel is called when exiting the matchFlowLabel production.
+	ExitMatchFlowLabel(c *MatchFlowLabelContext)
+
+	// ExitMatchProtocol6 is called when exiting the matchProtocol6 production.
+	ExitMatchProtocol6(c *MatchProtocol6Context)
+
 	// ExitMatchSrcPort is called when exiting the matchSrcPort production.
 	ExitMatchSrcPort(c *MatchSrcPortContext)
 
@@ -106,6 +145,9 @@ type TrafficClassListener interface {
 	// ExitCondIPv4 is called when exiting the condIPv4 production.
 	ExitCondIPv4(c *CondIPv4Context)
 
+	// ExitCondIPv6 is called when exiting the condIPv6 production.
+	ExitCondIPv6(c *CondIPv6Context)
+
 	// ExitCondPort is called when exiting the condPort production.
 	ExitCondPort(c *CondPortContext)
 
@@ -15,62 +15,82 @@ var _ = reflect.Copy
 var _ = strconv.Itoa
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 27, 140,
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 32, 187,
 	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7,
 	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13,
 	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9,
-	18, 4, 19, 9, 19, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 4,
-	3, 4, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 7,
-	3, 7, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9,
-	3, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 10, 3, 11, 3, 11, 3, 11, 3,
-	12, 3, 12, 3, 12, 3, 12, 3, 12, 7, 12, 87, 10, 12, 12, 12, 14, 12, 90,
-	11, 12, 3, 12, 3, 12, 3, 13, 3, 13, 3, 13, 3, 13, 3, 13, 7, 13, 99, 10,
-	13, 12, 13, 14, 13, 102, 11, 13, 3, 13, 3, 13, 3, 14, 3, 14, 3, 14, 3,
-	14, 3, 14, 3, 15, 3, 15, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16, 3, 16, 3, 16,
-	5, 16, 120, 10, 16, 3, 17, 3, 17, 3, 17, 3, 17, 5, 17, 126, 10, 17, 3,
-	18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 3, 18, 5, 18, 135, 10, 18, 3, 19,
-	3, 19, 3, 19, 3, 19, 2, 2, 20, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22,
-	24, 26, 28, 30, 32, 34, 36, 2, 4, 3, 2, 13, 14, 3, 2, 10, 11, 2, 136, 2,
-	38, 3, 2, 2, 2, 4, 42, 3, 2, 2, 2, 6, 46, 3, 2, 2, 2, 8, 50, 3, 2, 2, 2,
-	10, 54, 3, 2, 2, 2, 12, 58, 3, 2, 2, 2, 14, 62, 3, 2, 2, 2, 16, 68, 3,
-	2, 2, 2, 18, 72, 3, 2, 2, 2, 20, 78, 3, 2, 2, 2, 22, 81, 3, 2, 2, 2, 24,
-	93, 3, 2, 2, 2, 26, 105, 3, 2, 2, 2, 28, 110, 3, 2, 2, 2, 30, 119, 3, 2,
-	2, 2, 32, 125, 3, 2, 2, 2, 34, 134, 3, 2, 2, 2, 36, 136, 3, 2, 2, 2, 38,
-	39, 7, 20, 2, 2, 39, 40, 7, 3, 2, 2, 40, 41, 7, 15, 2, 2, 41, 3, 3, 2,
-	2, 2, 42, 43, 7, 21, 2, 2, 43, 44, 7, 3, 2, 2, 44, 45, 7, 15, 2, 2, 45,
-	5, 3, 2, 2, 2, 46, 47, 7, 22, 2, 2, 47, 48, 7, 4, 2, 2, 48, 49, 9, 2, 2,
-	2, 49, 7, 3, 2, 2, 2, 50, 51, 7, 23, 2, 2, 51, 52, 7, 4, 2, 2, 52, 53,
-	9, 2, 2, 2, 53, 9, 3, 2, 2, 2, 54, 55, 7, 24, 2, 2, 55, 56, 7, 3, 2, 2,
-	56, 57, 7, 27, 2, 2, 57, 11, 3, 2, 2, 2, 58, 59, 7, 25, 2, 2, 59, 60, 7,
-	3, 2, 2, 60, 61, 7, 13, 2, 2, 61, 13, 3, 2, 2, 2, 62, 63, 7, 25, 2, 2,
-	63, 64, 7, 3, 2, 2, 64, 65, 7, 13, 2, 2, 65, 66, 7, 5, 2, 2, 66, 67, 7,
-	13, 2, 2, 67, 15, 3, 2, 2, 2, 68, 69, 7, 26, 2, 2, 69, 70, 7, 3, 2, 2,
-	70, 71, 7, 13, 2, 2, 71, 17, 3, 2, 2, 2, 72, 73, 7, 26, 2, 2, 73, 74, 7,
-	3, 2, 2, 74, 75, 7, 13, 2, 2, 75, 76, 7, 5, 2, 2, 76, 77, 7, 13, 2, 2,
-	77, 19, 3, 2, 2, 2, 78, 79, 7, 6, 2, 2, 79, 80, 7, 13, 2, 2, 80, 21, 3,
-	2, 2, 2, 81, 82, 7, 16, 2, 2, 82, 83, 7, 7, 2, 2, 83, 88, 5, 34, 18, 2,
-	84, 85, 7, 8, 2, 2, 85, 87, 5, 34, 18, 2, 86, 84, 3, 2, 2, 2, 87, 90, 3,
-	2, 2, 2, 88, 86, 3, 2, 2, 2, 88, 89, 3, 2, 2, 2, 89, 91, 3, 2, 2, 2, 90,
-	88, 3, 2, 2, 2, 91, 92, 7, 9, 2, 2, 92, 23, 3, 2, 2, 2, 93, 94, 7, 17,
-	2, 2, 94, 95, 7, 7, 2, 2, 95, 100, 5, 34, 18, 2, 96, 97, 7, 8, 2, 2, 97,
-	99, 5, 34, 18, 2, 98, 96, 3, 2, 2, 2, 99, 102, 3, 2, 2, 2, 100, 98, 3,
-	2, 2, 2, 100, 101, 3, 2, 2, 2, 101, 103, 3, 2, 2, 2, 102, 100, 3, 2, 2,
-	2, 103, 104, 7, 9, 2, 2, 104, 25, 3, 2, 2, 2, 105, 106, 7, 18, 2, 2, 106,
-	107, 7, 7, 2, 2, 107, 108, 5, 34, 18, 2, 108, 109, 7, 9, 2, 2, 109, 27,
-	3, 2, 2, 2, 110, 111, 7, 19, 2, 2, 111, 112, 7, 3, 2, 2, 112, 113, 9, 3,
-	2, 2, 113, 29, 3, 2, 2, 2, 114, 120, 5, 2, 2, 2, 115, 120, 5, 4, 3, 2,
-	116, 120, 5, 6, 4, 2, 117, 120, 5, 8, 5, 2, 118, 120, 5, 10, 6, 2, 119,
-	114, 3, 2, 2, 2, 119, 115, 3, 2, 2, 2, 119, 116, 3, 2, 2, 2, 119, 117,
-	3, 2, 2, 2, 119, 118, 3, 2, 2, 2, 120, 31, 3, 2, 2, 2, 121, 126, 5, 12,
-	7, 2, 122, 126, 5, 14, 8, 2, 123, 126, 5, 16, 9, 2, 124, 126, 5, 18, 10,
-	2, 125, 121, 3, 2, 2, 2, 125, 122, 3, 2, 2, 2, 125, 123, 3, 2, 2, 2, 125,
-	124, 3, 2, 2, 2, 126, 33, 3, 2, 2, 2, 127, 135, 5, 24, 13, 2, 128, 135,
-	5, 22, 12, 2, 129, 135, 5, 26, 14, 2, 130, 135, 5, 30, 16, 2, 131, 135,
-	5, 32, 17, 2, 132, 135, 5, 20, 11, 2, 133, 135, 5, 28, 15, 2, 134, 127,
-	3, 2, 2, 2, 134, 128, 3, 2, 2, 2, 134, 129, 3, 2, 2, 2, 134, 130, 3, 2,
-	2, 2, 134, 131, 3, 2, 2, 2, 134, 132, 3, 2, 2, 2, 134, 133, 3, 2, 2, 2,
-	135, 35, 3, 2, 2, 2, 136, 137, 5, 34, 18, 2, 137, 138, 7, 2, 2, 3, 138,
-	37, 3, 2, 2, 2, 7, 88, 100, 119, 125, 134,
+	18, 4, 19, 9, 19, 4, 20, 9, 20, 4, 21, 9, 21, 4, 22, 9, 22, 4, 23, 9, 23,
+	4, 24, 9, 24, 4, 25, 9, 25, 4, 26, 9, 26, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 6,
+	3, 6, 3, 6, 3, 6, 3, 7, 3, 7, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 9,
+	3, 9, 3, 9, 3, 9, 3, 10, 3, 10, 3, 10, 3, 10, 3, 11, 3, 11, 3, 11, 3, 11,
+	3, 12, 3, 12, 3, 12, 3, 12, 3, 13, 3, 13, 3, 13, 3, 13, 3, 14, 3, 14, 3,
+	14, 3, 14, 3, 14, 3, 14, 3, 15, 3, 15, 3, 15, 3, 15, 3, 16, 3, 16, 3, 16,
+	3, 16, 3, 16, 3, 16, 3, 17, 3, 17, 3, 17, 3, 18, 3, 18, 3, 18, 3, 18, 3,
+	18, 7, 18, 125, 10, 18, 12, 18, 14, 18, 128, 11, 18, 3, 18, 3, 18, 3, 19,
+	3, 19, 3, 19, 3, 19, 3, 19, 7, 19, 137, 10, 19, 12, 19, 14, 19, 140, 11,
+	19, 3, 19, 3, 19, 3, 20, 3, 20, 3, 20, 3, 20, 3, 20, 3, 21, 3, 21, 3, 21,
+	3, 21, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 5, 22, 158, 10, 22, 3, 23, 3,
+	23, 3, 23, 3, 23, 3, 23, 3, 23, 5, 23, 166, 10, 23, 3, 24, 3, 24, 3, 24,
+	3, 24, 5, 24, 172, 10, 24, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3, 25, 3,
+	25, 3, 25, 5, 25, 182, 10, 25, 3, 26, 3, 26, 3, 26, 3, 26, 2, 2, 27, 2,
+	4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40,
+	42, 44, 46, 48, 50, 2, 5, 3, 2, 13, 14, 4, 2, 13, 13, 32, 32, 3, 2, 10,
+	11, 2, 182, 2, 52, 3, 2, 2, 2, 4, 56, 3, 2, 2, 2, 6, 60, 3, 2, 2, 2, 8,
+	64, 3, 2, 2, 2, 10, 68, 3, 2, 2, 2, 12, 72, 3, 2, 2, 2, 14, 76, 3, 2, 2,
+	2, 16, 80, 3, 2, 2, 2, 18, 84, 3, 2, 2, 2, 20, 88, 3, 2, 2, 2, 22, 92,
+	3, 2, 2, 2, 24, 96, 3, 2, 2, 2, 26, 100, 3, 2, 2, 2, 28, 106, 3, 2, 2,
+	2, 30, 110, 3, 2, 2, 2, 32, 116, 3, 2, 2, 2, 34, 119, 3, 2, 2, 2, 36, 131,
+	3, 2, 2, 2, 38, 143, 3, 2, 2, 2, 40, 148, 3, 2, 2, 2, 42, 157, 3, 2, 2,
+	2, 44, 165, 3, 2, 2, 2, 46, 171, 3, 2, 2, 2, 48, 181, 3, 2, 2, 2, 50, 183,
+	3, 2, 2, 2, 52, 53, 7, 21, 2, 2, 53, 54, 7, 3, 2, 2, 54, 55, 7, 15, 2,
+	2, 55, 3, 3, 2, 2, 2, 56, 57, 7, 22, 2, 2, 57, 58, 7, 3, 2, 2, 58, 59,
+	7, 15, 2, 2, 59, 5, 3, 2, 2, 2, 60, 61, 7, 23, 2, 2, 61, 62, 7, 4, 2, 2,
+	62, 63, 9, 2, 2, 2, 63, 7, 3, 2, 2, 2, 64, 65, 7, 24, 2, 2, 65, 66, 7,
+	4, 2, 2, 66, 67, 9, 2, 2, 2, 67, 9, 3, 2, 2, 2, 68, 69, 7, 25, 2, 2, 69,
+	70, 7, 3, 2, 2, 70, 71, 9, 3, 2, 2, 71, 11, 3, 2, 2, 2, 72, 73, 7, 21,
+	2, 2, 73, 74, 7, 3, 2, 2, 74, 75, 7, 16, 2, 2, 75, 13, 3, 2, 2, 2, 76,
+	77, 7, 22, 2, 2, 77, 78, 7, 3, 2, 2, 78, 79, 7, 16, 2, 2, 79, 15, 3, 2,
+	2, 2, 80, 81, 7, 29, 2, 2, 81, 82, 7, 4, 2, 2, 82, 83, 9, 2, 2, 2, 83,
+	17, 3, 2, 2, 2, 84, 85, 7, 28, 2, 2, 85, 86, 7, 4, 2, 2, 86, 87, 9, 2,
+	2, 2, 87, 19, 3, 2, 2, 2, 88, 89, 7, 30, 2, 2, 89, 90, 7, 4, 2, 2, 90,
+	91, 9, 2, 2, 2, 91, 21, 3, 2, 2, 2, 92, 93, 7, 31, 2, 2, 93, 94, 7, 3,
+	2, 2, 94, 95, 9, 3, 2, 2, 95, 23, 3, 2, 2, 2, 96, 97, 7, 26, 2, 2, 97,
+	98, 7, 3, 2, 2, 98, 99, 7, 13, 2, 2, 99, 25, 3, 2, 2, 2, 100, 101, 7, 26,
+	2, 2, 101, 102, 7, 3, 2, 2, 102, 103, 7, 13, 2, 2, 103, 104, 7, 5, 2, 2,
+	104, 105, 7, 13, 2, 2, 105, 27, 3, 2, 2, 2, 106, 107, 7, 27, 2, 2, 107,
+	108, 7, 3, 2, 2, 108, 109, 7, 13, 2, 2, 109, 29, 3, 2, 2, 2, 110, 111,
+	7, 27, 2, 2, 111, 112, 7, 3, 2, 2, 112, 113, 7, 13, 2, 2, 113, 114, 7,
+	5, 2, 2, 114, 115, 7, 13, 2, 2, 115, 31, 3, 2, 2, 2, 116, 117, 7, 6, 2,
+	2, 117, 118, 7, 13, 2, 2, 118, 33, 3, 2, 2, 2, 119, 120, 7, 17, 2, 2, 120,
+	121, 7, 7, 2, 2, 121, 126, 5, 48, 25, 2, 122, 123, 7, 8, 2, 2, 123, 125,
+	5, 48, 25, 2, 124, 122, 3, 2, 2, 2, 125, 128, 3, 2, 2, 2, 126, 124, 3,
+	2, 2, 2, 126, 127, 3, 2, 2, 2, 127, 129, 3, 2, 2, 2, 128, 126, 3, 2, 2,
+	2, 129, 130, 7, 9, 2, 2, 130, 35, 3, 2, 2, 2, 131, 132, 7, 18, 2, 2, 132,
+	133, 7, 7, 2, 2, 133, 138, 5, 48, 25, 2, 134, 135, 7, 8, 2, 2, 135, 137,
+	5, 48, 25, 2, 136, 134, 3, 2, 2, 2, 137, 140, 3, 2, 2, 2, 138, 136, 3,
+	2, 2, 2, 138, 139, 3, 2, 2, 2, 139, 141, 3, 2, 2, 2, 140, 138, 3, 2, 2,
+	2, 141, 142, 7, 9, 2, 2, 142, 37, 3, 2, 2, 2, 143, 144, 7, 19, 2, 2, 144,
+	145, 7, 7, 2, 2, 145, 146, 5, 48, 25, 2, 146, 147, 7, 9, 2, 2, 147, 39,
+	3, 2, 2, 2, 148, 149, 7, 20, 2, 2, 149, 150, 7, 3, 2, 2, 150, 151, 9, 4,
+	2, 2, 151, 41, 3, 2, 2, 2, 152, 158, 5, 2, 2, 2, 153, 158, 5, 4, 3, 2,
+	154, 158, 5, 6, 4, 2, 155, 158, 5, 8, 5, 2, 156, 158, 5, 10, 6, 2, 157,
+	152, 3, 2, 2, 2, 157, 153, 3, 2, 2, 2, 157, 154, 3, 2, 2, 2, 157, 155,
+	3, 2, 2, 2, 157, 156, 3, 2, 2, 2, 158, 43, 3, 2, 2, 2, 159, 166, 5, 12,
+	7, 2, 160, 166, 5, 14, 8, 2, 161, 166, 5, 16, 9, 2, 162, 166, 5, 18, 10,
+	2, 163, 166, 5, 20, 11, 2, 164, 166, 5, 22, 12, 2, 165, 159, 3, 2, 2, 2,
+	165, 160, 3, 2, 2, 2, 165, 161, 3, 2, 2, 2, 165, 162, 3, 2, 2, 2, 165,
+	163, 3, 2, 2, 2, 165, 164, 3, 2, 2, 2, 166, 45, 3, 2, 2, 2, 167, 172, 5,
+	24, 13, 2, 168, 172, 5, 26, 14, 2, 169, 172, 5, 28, 15, 2, 170, 172, 5,
+	30, 16, 2, 171, 167, 3, 2, 2, 2, 171, 168, 3, 2, 2, 2, 171, 169, 3, 2,
+	2, 2, 171, 170, 3, 2, 2, 2, 172, 47, 3, 2, 2, 2, 173, 182, 5, 36, 19, 2,
+	174, 182, 5, 34, 18, 2, 175, 182, 5, 38, 20, 2, 176, 182, 5, 42, 22, 2,
+	177, 182, 5, 44, 23, 2, 178, 182, 5, 46, 24, 2, 179, 182, 5, 32, 17, 2,
+	180, 182, 5, 40, 21, 2, 181, 173, 3, 2, 2, 2, 181, 174, 3, 2, 2, 2, 181,
+	175, 3, 2, 2, 2, 181, 176, 3, 2, 2, 2, 181, 177, 3, 2, 2, 2, 181, 178,
+	3, 2, 2, 2, 181, 179, 3, 2, 2, 2, 181, 180, 3, 2, 2, 2, 182, 49, 3, 2,
+	2, 2, 183, 184, 5, 48, 25, 2, 184, 185, 7, 2, 2, 3, 185, 51, 3, 2, 2, 2,
+	8, 126, 138, 157, 165, 171, 181,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
@@ -80,14 +100,17 @@ var literalNames = []string{
 }
 var symbolicNames = []string{
 	"", "", "", "", "", "", "", "", "", "", "WHITESPACE", "DIGITS", "HEX_DIGITS",
-	"NET", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS", "PROTOCOL",
-	"SRCPORT", "DSTPORT", "STRING",
+	"NET", "NET6", "ANY", "ALL", "NOT", "BOOL", "SRC", "DST", "DSCP", "TOS",
+	"PROTOCOL", "SRCPORT", "DSTPORT", "TC", "DSCP6", "FLOWLABEL", "PROTOCOL6",
+	"STRING",
 }
 
 var ruleNames = []string{
-	"matchSrc", "matchDst", "matchDSCP", "matchTOS", "matchProtocol", "matchSrcPort",
-	"matchSrcPortRange", "matchDstPort", "matchDstPortRange", "condCls", "condAny",
-	"condAll", "condNot", "condBool", "condIPv4", "condPort", "cond", "trafficClass",
+	"matchSrc", "matchDst", "matchDSCP", "matchTOS", "matchProtocol", "matchSrc6",
+	"matchDst6", "matchDSCP6", "matchTC", "matchFlowLabel", "matchProtocol6",
+	"matchSrcPort", "matchSrcPortRange", "matchDstPort", "matchDstPortRange",
+	"condCls", "condAny", "condAll", "condNot", "condBool", "condIPv4", "condIPv6",
+	"condPort", "cond", "trafficClass",
 }
 var decisionToDFA = make([]*antlr.DFA, len(deserializedATN.DecisionToState))
 
@@ -131,18 +154,23 @@ const (
 	TrafficClassParserDIGITS     = 11
 	TrafficClassParserHEX_DIGITS = 12
 	TrafficClassParserNET        = 13
-	TrafficClassParserANY        = 14
-	TrafficClassParserALL        = 15
-	TrafficClassParserNOT        = 16
-	TrafficClassParserBOOL       = 17
-	TrafficClassParserSRC        = 18
-	TrafficClassParserDST        = 19
-	TrafficClassParserDSCP       = 20
-	TrafficClassParserTOS        = 21
-	TrafficClassParserPROTOCOL   = 22
-	TrafficClassParserSRCPORT    = 23
-	TrafficClassParserDSTPORT    = 24
-	TrafficClassParserSTRING     = 25
+	TrafficClassParserNET6       = 14
+	TrafficClassParserANY        = 15
+	TrafficClassParserALL        = 16
+	TrafficClassParserNOT        = 17
+	TrafficClassParserBOOL       = 18
+	TrafficClassParserSRC        = 19
+	TrafficClassParserDST        = 20
+	TrafficClassParserDSCP       = 21
+	TrafficClassParserTOS        = 22
+	TrafficClassParserPROTOCOL   = 23
+	TrafficClassParserSRCPORT    = 24
+	TrafficClassParserDSTPORT    = 25
+	TrafficClassParserTC         = 26
+	TrafficClassParserDSCP6      = 27
+	TrafficClassParserFLOWLABEL  = 28
+	TrafficClassParserPROTOCOL6  = 29
+	TrafficClassParserSTRING     = 30
 )
 
 // TrafficClassParser rules.
@@ -152,19 +180,26 @@ const (
 	TrafficClassParserRULE_matchDSCP         = 2
 	TrafficClassParserRULE_matchTOS          = 3
 	TrafficClassParserRULE_matchProtocol     = 4
-	TrafficClassParserRULE_matchSrcPort      = 5
-	TrafficClassParserRULE_matchSrcPortRange = 6
-	TrafficClassParserRULE_matchDstPort      = 7
-	TrafficClassParserRULE_matchDstPortRange = 8
-	TrafficClassParserRULE_condCls           = 9
-	TrafficClassParserRULE_condAny           = 10
-	TrafficClassParserRULE_condAll           = 11
-	TrafficClassParserRULE_condNot           = 12
-	TrafficClassParserRULE_condBool          = 13
-	TrafficClassParserRULE_condIPv4          = 14
-	TrafficClassParserRULE_condPort          = 15
-	TrafficClassParserRULE_cond              = 16
-	TrafficClassParserRULE_trafficClass      = 17
+	TrafficClassParserRULE_matchSrc6         = 5
+	TrafficClassParserRULE_matchDst6         = 6
+	TrafficClassParserRULE_matchDSCP6        = 7
+	TrafficClassParserRULE_matchTC           = 8
+	TrafficClassParserRULE_matchFlowLabel    = 9
+	TrafficClassParserRULE_matchProtocol6    = 10
+	TrafficClassParserRULE_matchSrcPort      = 11
+	TrafficClassParserRULE_matchSrcPortRange = 12
+	TrafficClassParserRULE_matchDstPort      = 13
+	TrafficClassParserRULE_matchDstPortRange = 14
+	TrafficClassParserRULE_condCls           = 15
+	TrafficClassParserRULE_condAny           = 16
+	TrafficClassParserRULE_condAll           = 17
+	TrafficClassParserRULE_condNot           = 18
+	TrafficClassParserRULE_condBool          = 19
+	TrafficClassParserRULE_condIPv4          = 20
+	TrafficClassParserRULE_condIPv6          = 21
+	TrafficClassParserRULE_condPort          = 22
+	TrafficClassParserRULE_cond              = 23
+	TrafficClassParserRULE_trafficClass      = 24
 )
 
 // IMatchSrcContext is an interface to support dynamic dispatch.
@@ -255,15 +290,15 @@ func (p *TrafficClassParser) MatchSrc() (localctx IMatchSrcContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(36)
+		p.SetState(50)
 		p.Match(TrafficClassParserSRC)
 	}
 	{
-		p.SetState(37)
+		p.SetState(51)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(38)
+		p.SetState(52)
 		p.Match(TrafficClassParserNET)
 	}
 
@@ -358,15 +393,15 @@ func (p *TrafficClassParser) MatchDst() (localctx IMatchDstContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(40)
+		p.SetState(54)
 		p.Match(TrafficClassParserDST)
 	}
 	{
-		p.SetState(41)
+		p.SetState(55)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(42)
+		p.SetState(56)
 		p.Match(TrafficClassParserNET)
 	}
 
@@ -466,15 +501,15 @@ func (p *TrafficClassParser) MatchDSCP() (localctx IMatchDSCPContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(44)
+		p.SetState(58)
 		p.Match(TrafficClassParserDSCP)
 	}
 	{
-		p.SetState(45)
+		p.SetState(59)
 		p.Match(TrafficClassParserT__1)
 	}
 	{
-		p.SetState(46)
+		p.SetState(60)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
@@ -513,54 +548,720 @@ func NewEmptyMatchTOSContext() *MatchTOSContext {
 
 func (*MatchTOSContext) IsMatchTOSContext() {}
 
-func NewMatchTOSContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchTOSContext {
-	var p = new(MatchTOSContext)
+func NewMatchTOSContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchTOSContext {
+	var p = new(MatchTOSContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchTOS
+
+	return p
+}
+
+func (s *MatchTOSContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchTOSContext) TOS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserTOS, 0)
+}
+
+func (s *MatchTOSContext) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+}
+
+func (s *MatchTOSContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchTOSContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchTOSContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchTOSContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchTOS(s)
+	}
+}
+
+func (s *MatchTOSContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchTOS(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
+	localctx = NewMatchTOSContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 6, TrafficClassParserRULE_matchTOS)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(62)
+		p.Match(TrafficClassParserTOS)
+	}
+	{
+		p.SetState(63)
+		p.Match(TrafficClassParserT__1)
+	}
+	{
+		p.SetState(64)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchProtocolContext is an interface to support dynamic dispatch.
+type IMatchProtocolContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchProtocolContext differentiates from other interfaces.
+	IsMatchProtocolContext()
+}
+
+type MatchProtocolContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchProtocolContext() *MatchProtocolContext {
+	var p = new(MatchProtocolContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchProtocol
+	return p
+}
+
+func (*MatchProtocolContext) IsMatchProtocolContext() {}
+
+func NewMatchProtocolContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchProtocolContext {
+	var p = new(MatchProtocolContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchProtocol
+
+	return p
+}
+
+func (s *MatchProtocolContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchProtocolContext) PROTOCOL() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserPROTOCOL, 0)
+}
+
+func (s *MatchProtocolContext) STRING() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserSTRING, 0)
+}
+
+func (s *MatchProtocolContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchProtocolContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchProtocolContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchProtocolContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchProtocol(s)
+	}
+}
+
+func (s *MatchProtocolContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchProtocol(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchProtocol() (localctx IMatchProtocolContext) {
+	localctx = NewMatchProtocolContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, TrafficClassParserRULE_matchProtocol)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(66)
+		p.Match(TrafficClassParserPROTOCOL)
+	}
+	{
+		p.SetState(67)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(68)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserSTRING) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchSrc6Context is an interface to support dynamic dispatch.
+type IMatchSrc6Context interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchSrc6Context differentiates from other interfaces.
+	IsMatchSrc6Context()
+}
+
+type MatchSrc6Context struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchSrc6Context() *MatchSrc6Context {
+	var p = new(MatchSrc6Context)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchSrc6
+	return p
+}
+
+func (*MatchSrc6Context) IsMatchSrc6Context() {}
+
+func NewMatchSrc6Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchSrc6Context {
+	var p = new(MatchSrc6Context)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchSrc6
+
+	return p
+}
+
+func (s *MatchSrc6Context) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchSrc6Context) SRC() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserSRC, 0)
+}
+
+func (s *MatchSrc6Context) NET6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNET6, 0)
+}
+
+func (s *MatchSrc6Context) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchSrc6Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchSrc6Context) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchSrc6(s)
+	}
+}
+
+func (s *MatchSrc6Context) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchSrc6(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchSrc6() (localctx IMatchSrc6Context) {
+	localctx = NewMatchSrc6Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 10, TrafficClassParserRULE_matchSrc6)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(70)
+		p.Match(TrafficClassParserSRC)
+	}
+	{
+		p.SetState(71)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(72)
+		p.Match(TrafficClassParserNET6)
+	}
+
+	return localctx
+}
+
+// IMatchDst6Context is an interface to support dynamic dispatch.
+type IMatchDst6Context interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchDst6Context differentiates from other interfaces.
+	IsMatchDst6Context()
+}
+
+type MatchDst6Context struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchDst6Context() *MatchDst6Context {
+	var p = new(MatchDst6Context)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchDst6
+	return p
+}
+
+func (*MatchDst6Context) IsMatchDst6Context() {}
+
+func NewMatchDst6Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchDst6Context {
+	var p = new(MatchDst6Context)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchDst6
+
+	return p
+}
+
+func (s *MatchDst6Context) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchDst6Context) DST() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDST, 0)
+}
+
+func (s *MatchDst6Context) NET6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserNET6, 0)
+}
+
+func (s *MatchDst6Context) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchDst6Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchDst6Context) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchDst6(s)
+	}
+}
+
+func (s *MatchDst6Context) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchDst6(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchDst6() (localctx IMatchDst6Context) {
+	localctx = NewMatchDst6Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 12, TrafficClassParserRULE_matchDst6)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(74)
+		p.Match(TrafficClassParserDST)
+	}
+	{
+		p.SetState(75)
+		p.Match(TrafficClassParserT__0)
+	}
+	{
+		p.SetState(76)
+		p.Match(TrafficClassParserNET6)
+	}
+
+	return localctx
+}
+
+// IMatchDSCP6Context is an interface to support dynamic dispatch.
+type IMatchDSCP6Context interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchDSCP6Context differentiates from other interfaces.
+	IsMatchDSCP6Context()
+}
+
+type MatchDSCP6Context struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchDSCP6Context() *MatchDSCP6Context {
+	var p = new(MatchDSCP6Context)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchDSCP6
+	return p
+}
+
+func (*MatchDSCP6Context) IsMatchDSCP6Context() {}
+
+func NewMatchDSCP6Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchDSCP6Context {
+	var p = new(MatchDSCP6Context)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchDSCP6
+
+	return p
+}
+
+func (s *MatchDSCP6Context) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchDSCP6Context) DSCP6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDSCP6, 0)
+}
+
+func (s *MatchDSCP6Context) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+}
+
+func (s *MatchDSCP6Context) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchDSCP6Context) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchDSCP6Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchDSCP6Context) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchDSCP6(s)
+	}
+}
+
+func (s *MatchDSCP6Context) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchDSCP6(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchDSCP6() (localctx IMatchDSCP6Context) {
+	localctx = NewMatchDSCP6Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 14, TrafficClassParserRULE_matchDSCP6)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(78)
+		p.Match(TrafficClassParserDSCP6)
+	}
+	{
+		p.SetState(79)
+		p.Match(TrafficClassParserT__1)
+	}
+	{
+		p.SetState(80)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchTCContext is an interface to support dynamic dispatch.
+type IMatchTCContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchTCContext differentiates from other interfaces.
+	IsMatchTCContext()
+}
+
+type MatchTCContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchTCContext() *MatchTCContext {
+	var p = new(MatchTCContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchTC
+	return p
+}
+
+func (*MatchTCContext) IsMatchTCContext() {}
+
+func NewMatchTCContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchTCContext {
+	var p = new(MatchTCContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_matchTC
+
+	return p
+}
+
+func (s *MatchTCContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MatchTCContext) TC() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserTC, 0)
+}
+
+func (s *MatchTCContext) HEX_DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
+}
+
+func (s *MatchTCContext) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchTCContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MatchTCContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MatchTCContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterMatchTC(s)
+	}
+}
+
+func (s *MatchTCContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitMatchTC(s)
+	}
+}
+
+func (p *TrafficClassParser) MatchTC() (localctx IMatchTCContext) {
+	localctx = NewMatchTCContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 16, TrafficClassParserRULE_matchTC)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(82)
+		p.Match(TrafficClassParserTC)
+	}
+	{
+		p.SetState(83)
+		p.Match(TrafficClassParserT__1)
+	}
+	{
+		p.SetState(84)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMatchFlowLabelContext is an interface to support dynamic dispatch.
+type IMatchFlowLabelContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsMatchFlowLabelContext differentiates from other interfaces.
+	IsMatchFlowLabelContext()
+}
+
+type MatchFlowLabelContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMatchFlowLabelContext() *MatchFlowLabelContext {
+	var p = new(MatchFlowLabelContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_matchFlowLabel
+	return p
+}
+
+func (*MatchFlowLabelContext) IsMatchFlowLabelContext() {}
+
+func NewMatchFlowLabelContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchFlowLabelContext {
+	var p = new(MatchFlowLabelContext)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_matchTOS
+	p.RuleIndex = TrafficClassParserRULE_matchFlowLabel
 
 	return p
 }
 
-func (s *MatchTOSContext) GetParser() antlr.Parser { return s.parser }
+func (s *MatchFlowLabelContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *MatchTOSContext) TOS() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserTOS, 0)
+func (s *MatchFlowLabelContext) FLOWLABEL() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserFLOWLABEL, 0)
 }
 
-func (s *MatchTOSContext) HEX_DIGITS() antlr.TerminalNode {
+func (s *MatchFlowLabelContext) HEX_DIGITS() antlr.TerminalNode {
 	return s.GetToken(TrafficClassParserHEX_DIGITS, 0)
 }
 
-func (s *MatchTOSContext) DIGITS() antlr.TerminalNode {
+func (s *MatchFlowLabelContext) DIGITS() antlr.TerminalNode {
 	return s.GetToken(TrafficClassParserDIGITS, 0)
 }
 
-func (s *MatchTOSContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchFlowLabelContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *MatchTOSContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchFlowLabelContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *MatchTOSContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchFlowLabelContext) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterMatchTOS(s)
+		listenerT.EnterMatchFlowLabel(s)
 	}
 }
 
-func (s *MatchTOSContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchFlowLabelContext) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitMatchTOS(s)
+		listenerT.ExitMatchFlowLabel(s)
 	}
 }
 
-func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
-	localctx = NewMatchTOSContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 6, TrafficClassParserRULE_matchTOS)
+func (p *TrafficClassParser) MatchFlowLabel() (localctx IMatchFlowLabelContext) {
+	localctx = NewMatchFlowLabelContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 18, TrafficClassParserRULE_matchFlowLabel)
 	var _la int
 
 	defer func() {
@@ -581,15 +1282,15 @@ func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(48)
-		p.Match(TrafficClassParserTOS)
+		p.SetState(86)
+		p.Match(TrafficClassParserFLOWLABEL)
 	}
 	{
-		p.SetState(49)
+		p.SetState(87)
 		p.Match(TrafficClassParserT__1)
 	}
 	{
-		p.SetState(50)
+		p.SetState(88)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserHEX_DIGITS) {
@@ -603,75 +1304,80 @@ func (p *TrafficClassParser) MatchTOS() (localctx IMatchTOSContext) {
 	return localctx
 }
 
-// IMatchProtocolContext is an interface to support dynamic dispatch.
-type IMatchProtocolContext interface {
+// IMatchProtocol6Context is an interface to support dynamic dispatch.
+type IMatchProtocol6Context interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
-	// IsMatchProtocolContext differentiates from other interfaces.
-	IsMatchProtocolContext()
+	// IsMatchProtocol6Context differentiates from other interfaces.
+	IsMatchProtocol6Context()
 }
 
-type MatchProtocolContext struct {
+type MatchProtocol6Context struct {
 	*antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyMatchProtocolContext() *MatchProtocolContext {
-	var p = new(MatchProtocolContext)
+func NewEmptyMatchProtocol6Context() *MatchProtocol6Context {
+	var p = new(MatchProtocol6Context)
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
-	p.RuleIndex = TrafficClassParserRULE_matchProtocol
+	p.RuleIndex = TrafficClassParserRULE_matchProtocol6
 	return p
 }
 
-func (*MatchProtocolContext) IsMatchProtocolContext() {}
+func (*MatchProtocol6Context) IsMatchProtocol6Context() {}
 
-func NewMatchProtocolContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchProtocolContext {
-	var p = new(MatchProtocolContext)
+func NewMatchProtocol6Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MatchProtocol6Context {
+	var p = new(MatchProtocol6Context)
 
 	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = TrafficClassParserRULE_matchProtocol
+	p.RuleIndex = TrafficClassParserRULE_matchProtocol6
 
 	return p
 }
 
-func (s *MatchProtocolContext) GetParser() antlr.Parser { return s.parser }
+func (s *MatchProtocol6Context) GetParser() antlr.Parser { return s.parser }
 
-func (s *MatchProtocolContext) PROTOCOL() antlr.TerminalNode {
-	return s.GetToken(TrafficClassParserPROTOCOL, 0)
+func (s *MatchProtocol6Context) PROTOCOL6() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserPROTOCOL6, 0)
 }
 
-func (s *MatchProtocolContext) STRING() antlr.TerminalNode {
+func (s *MatchProtocol6Context) STRING() antlr.TerminalNode {
 	return s.GetToken(TrafficClassParserSTRING, 0)
 }
 
-func (s *MatchProtocolContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchProtocol6Context) DIGITS() antlr.TerminalNode {
+	return s.GetToken(TrafficClassParserDIGITS, 0)
+}
+
+func (s *MatchProtocol6Context) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *MatchProtocolContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *MatchProtocol6Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (s *MatchProtocolContext) EnterRule(listener antlr.ParseTreeListener) {
+func (s *MatchProtocol6Context) EnterRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.EnterMatchProtocol(s)
+		listenerT.EnterMatchProtocol6(s)
 	}
 }
 
-func (s *MatchProtocolContext) ExitRule(listener antlr.ParseTreeListener) {
+func (s *MatchProtocol6Context) ExitRule(listener antlr.ParseTreeListener) {
 	if listenerT, ok := listener.(TrafficClassListener); ok {
-		listenerT.ExitMatchProtocol(s)
+		listenerT.ExitMatchProtocol6(s)
 	}
 }
 
-func (p *TrafficClassParser) MatchProtocol() (localctx IMatchProtocolContext) {
-	localctx = NewMatchProtocolContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 8, TrafficClassParserRULE_matchProtocol)
+func (p *TrafficClassParser) MatchProtocol6() (localctx IMatchProtocol6Context) {
+	localctx = NewMatchProtocol6Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 20, TrafficClassParserRULE_matchProtocol6)
+	var _la int
 
 	defer func() {
 		p.ExitRule()
@@ -691,16 +1397,23 @@ func (p *TrafficClassParser) MatchProtocol() (localctx IMatchProtocolContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(52)
-		p.Match(TrafficClassParserPROTOCOL)
+		p.SetState(90)
+		p.Match(TrafficClassParserPROTOCOL6)
 	}
 	{
-		p.SetState(53)
+		p.SetState(91)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(54)
-		p.Match(TrafficClassParserSTRING)
+		p.SetState(92)
+		_la = p.GetTokenStream().LA(1)
+
+		if !(_la == TrafficClassParserDIGITS || _la == TrafficClassParserSTRING) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
 	}
 
 	return localctx
@@ -774,7 +1487,7 @@ func (s *MatchSrcPortContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) MatchSrcPort() (localctx IMatchSrcPortContext) {
 	localctx = NewMatchSrcPortContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, TrafficClassParserRULE_matchSrcPort)
+	p.EnterRule(localctx, 22, TrafficClassParserRULE_matchSrcPort)
 
 	defer func() {
 		p.ExitRule()
@@ -794,15 +1507,15 @@ func (p *TrafficClassParser) MatchSrcPort() (localctx IMatchSrcPortContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(56)
+		p.SetState(94)
 		p.Match(TrafficClassParserSRCPORT)
 	}
 	{
-		p.SetState(57)
+		p.SetState(95)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(58)
+		p.SetState(96)
 		p.Match(TrafficClassParserDIGITS)
 	}
 
@@ -881,7 +1594,7 @@ func (s *MatchSrcPortRangeContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) MatchSrcPortRange() (localctx IMatchSrcPortRangeContext) {
 	localctx = NewMatchSrcPortRangeContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, TrafficClassParserRULE_matchSrcPortRange)
+	p.EnterRule(localctx, 24, TrafficClassParserRULE_matchSrcPortRange)
 
 	defer func() {
 		p.ExitRule()
@@ -901,23 +1614,23 @@ func (p *TrafficClassParser) MatchSrcPortRange() (localctx IMatchSrcPortRangeCon
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(60)
+		p.SetState(98)
 		p.Match(TrafficClassParserSRCPORT)
 	}
 	{
-		p.SetState(61)
+		p.SetState(99)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(62)
+		p.SetState(100)
 		p.Match(TrafficClassParserDIGITS)
 	}
 	{
-		p.SetState(63)
+		p.SetState(101)
 		p.Match(TrafficClassParserT__2)
 	}
 	{
-		p.SetState(64)
+		p.SetState(102)
 		p.Match(TrafficClassParserDIGITS)
 	}
 
@@ -992,7 +1705,7 @@ func (s *MatchDstPortContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) MatchDstPort() (localctx IMatchDstPortContext) {
 	localctx = NewMatchDstPortContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, TrafficClassParserRULE_matchDstPort)
+	p.EnterRule(localctx, 26, TrafficClassParserRULE_matchDstPort)
 
 	defer func() {
 		p.ExitRule()
@@ -1012,15 +1725,15 @@ func (p *TrafficClassParser) MatchDstPort() (localctx IMatchDstPortContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(66)
+		p.SetState(104)
 		p.Match(TrafficClassParserDSTPORT)
 	}
 	{
-		p.SetState(67)
+		p.SetState(105)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(68)
+		p.SetState(106)
 		p.Match(TrafficClassParserDIGITS)
 	}
 
@@ -1099,7 +1812,7 @@ func (s *MatchDstPortRangeContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) MatchDstPortRange() (localctx IMatchDstPortRangeContext) {
 	localctx = NewMatchDstPortRangeContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 16, TrafficClassParserRULE_matchDstPortRange)
+	p.EnterRule(localctx, 28, TrafficClassParserRULE_matchDstPortRange)
 
 	defer func() {
 		p.ExitRule()
@@ -1119,23 +1832,23 @@ func (p *TrafficClassParser) MatchDstPortRange() (localctx IMatchDstPortRangeCon
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(70)
+		p.SetState(108)
 		p.Match(TrafficClassParserDSTPORT)
 	}
 	{
-		p.SetState(71)
+		p.SetState(109)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(72)
+		p.SetState(110)
 		p.Match(TrafficClassParserDIGITS)
 	}
 	{
-		p.SetState(73)
+		p.SetState(111)
 		p.Match(TrafficClassParserT__2)
 	}
 	{
-		p.SetState(74)
+		p.SetState(112)
 		p.Match(TrafficClassParserDIGITS)
 	}
 
@@ -1206,7 +1919,7 @@ func (s *CondClsContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
 	localctx = NewCondClsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, TrafficClassParserRULE_condCls)
+	p.EnterRule(localctx, 30, TrafficClassParserRULE_condCls)
 
 	defer func() {
 		p.ExitRule()
@@ -1226,11 +1939,11 @@ func (p *TrafficClassParser) CondCls() (localctx ICondClsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(76)
+		p.SetState(114)
 		p.Match(TrafficClassParserT__3)
 	}
 	{
-		p.SetState(77)
+		p.SetState(115)
 		p.Match(TrafficClassParserDIGITS)
 	}
 
@@ -1324,7 +2037,7 @@ func (s *CondAnyContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 	localctx = NewCondAnyContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, TrafficClassParserRULE_condAny)
+	p.EnterRule(localctx, 32, TrafficClassParserRULE_condAny)
 	var _la int
 
 	defer func() {
@@ -1345,37 +2058,37 @@ func (p *TrafficClassParser) CondAny() (localctx ICondAnyContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(79)
+		p.SetState(117)
 		p.Match(TrafficClassParserANY)
 	}
 	{
-		p.SetState(80)
+		p.SetState(118)
 		p.Match(TrafficClassParserT__4)
 	}
 	{
-		p.SetState(81)
+		p.SetState(119)
 		p.Cond()
 	}
-	p.SetState(86)
+	p.SetState(124)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == TrafficClassParserT__5 {
 		{
-			p.SetState(82)
+			p.SetState(120)
 			p.Match(TrafficClassParserT__5)
 		}
 		{
-			p.SetState(83)
+			p.SetState(121)
 			p.Cond()
 		}
 
-		p.SetState(88)
+		p.SetState(126)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(89)
+		p.SetState(127)
 		p.Match(TrafficClassParserT__6)
 	}
 
@@ -1469,7 +2182,7 @@ func (s *CondAllContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 	localctx = NewCondAllContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, TrafficClassParserRULE_condAll)
+	p.EnterRule(localctx, 34, TrafficClassParserRULE_condAll)
 	var _la int
 
 	defer func() {
@@ -1490,37 +2203,37 @@ func (p *TrafficClassParser) CondAll() (localctx ICondAllContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(91)
+		p.SetState(129)
 		p.Match(TrafficClassParserALL)
 	}
 	{
-		p.SetState(92)
+		p.SetState(130)
 		p.Match(TrafficClassParserT__4)
 	}
 	{
-		p.SetState(93)
+		p.SetState(131)
 		p.Cond()
 	}
-	p.SetState(98)
+	p.SetState(136)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 	for _la == TrafficClassParserT__5 {
 		{
-			p.SetState(94)
+			p.SetState(132)
 			p.Match(TrafficClassParserT__5)
 		}
 		{
-			p.SetState(95)
+			p.SetState(133)
 			p.Cond()
 		}
 
-		p.SetState(100)
+		p.SetState(138)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
 	{
-		p.SetState(101)
+		p.SetState(139)
 		p.Match(TrafficClassParserT__6)
 	}
 
@@ -1601,7 +2314,7 @@ func (s *CondNotContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
 	localctx = NewCondNotContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 24, TrafficClassParserRULE_condNot)
+	p.EnterRule(localctx, 36, TrafficClassParserRULE_condNot)
 
 	defer func() {
 		p.ExitRule()
@@ -1621,19 +2334,19 @@ func (p *TrafficClassParser) CondNot() (localctx ICondNotContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(103)
+		p.SetState(141)
 		p.Match(TrafficClassParserNOT)
 	}
 	{
-		p.SetState(104)
+		p.SetState(142)
 		p.Match(TrafficClassParserT__4)
 	}
 	{
-		p.SetState(105)
+		p.SetState(143)
 		p.Cond()
 	}
 	{
-		p.SetState(106)
+		p.SetState(144)
 		p.Match(TrafficClassParserT__6)
 	}
 
@@ -1704,7 +2417,7 @@ func (s *CondBoolContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 	localctx = NewCondBoolContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 26, TrafficClassParserRULE_condBool)
+	p.EnterRule(localctx, 38, TrafficClassParserRULE_condBool)
 	var _la int
 
 	defer func() {
@@ -1725,15 +2438,15 @@ func (p *TrafficClassParser) CondBool() (localctx ICondBoolContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(108)
+		p.SetState(146)
 		p.Match(TrafficClassParserBOOL)
 	}
 	{
-		p.SetState(109)
+		p.SetState(147)
 		p.Match(TrafficClassParserT__0)
 	}
 	{
-		p.SetState(110)
+		p.SetState(148)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == TrafficClassParserT__7 || _la == TrafficClassParserT__8) {
@@ -1857,7 +2570,7 @@ func (s *CondIPv4Context) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
 	localctx = NewCondIPv4Context(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 28, TrafficClassParserRULE_condIPv4)
+	p.EnterRule(localctx, 40, TrafficClassParserRULE_condIPv4)
 
 	defer func() {
 		p.ExitRule()
@@ -1875,42 +2588,42 @@ func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
 		}
 	}()
 
-	p.SetState(117)
+	p.SetState(155)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case TrafficClassParserSRC:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(112)
+			p.SetState(150)
 			p.MatchSrc()
 		}
 
 	case TrafficClassParserDST:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(113)
+			p.SetState(151)
 			p.MatchDst()
 		}
 
 	case TrafficClassParserDSCP:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(114)
+			p.SetState(152)
 			p.MatchDSCP()
 		}
 
 	case TrafficClassParserTOS:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(115)
+			p.SetState(153)
 			p.MatchTOS()
 		}
 
 	case TrafficClassParserPROTOCOL:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(116)
+			p.SetState(154)
 			p.MatchProtocol()
 		}
 
@@ -1921,6 +2634,197 @@ func (p *TrafficClassParser) CondIPv4() (localctx ICondIPv4Context) {
 	return localctx
 }
 
+// ICondIPv6Context is an interface to support dynamic dispatch.
+type ICondIPv6Context interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsCondIPv6Context differentiates from other interfaces.
+	IsCondIPv6Context()
+}
+
+type CondIPv6Context struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyCondIPv6Context() *CondIPv6Context {
+	var p = new(CondIPv6Context)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = TrafficClassParserRULE_condIPv6
+	return p
+}
+
+func (*CondIPv6Context) IsCondIPv6Context() {}
+
+func NewCondIPv6Context(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *CondIPv6Context {
+	var p = new(CondIPv6Context)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = TrafficClassParserRULE_condIPv6
+
+	return p
+}
+
+func (s *CondIPv6Context) GetParser() antlr.Parser { return s.parser }
+
+func (s *CondIPv6Context) MatchSrc6() IMatchSrc6Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchSrc6Context)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchSrc6Context)
+}
+
+func (s *CondIPv6Context) MatchDst6() IMatchDst6Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchDst6Context)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchDst6Context)
+}
+
+func (s *CondIPv6Context) MatchDSCP6() IMatchDSCP6Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchDSCP6Context)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchDSCP6Context)
+}
+
+func (s *CondIPv6Context) MatchTC() IMatchTCContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchTCContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchTCContext)
+}
+
+func (s *CondIPv6Context) MatchFlowLabel() IMatchFlowLabelContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchFlowLabelContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchFlowLabelContext)
+}
+
+func (s *CondIPv6Context) MatchProtocol6() IMatchProtocol6Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMatchProtocol6Context)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMatchProtocol6Context)
+}
+
+func (s *CondIPv6Context) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CondIPv6Context) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *CondIPv6Context) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.EnterCondIPv6(s)
+	}
+}
+
+func (s *CondIPv6Context) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(TrafficClassListener); ok {
+		listenerT.ExitCondIPv6(s)
+	}
+}
+
+func (p *TrafficClassParser) CondIPv6() (localctx ICondIPv6Context) {
+	localctx = NewCondIPv6Context(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 42, TrafficClassParserRULE_condIPv6)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.SetState(163)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case TrafficClassParserSRC:
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(157)
+			p.MatchSrc6()
+		}
+
+	case TrafficClassParserDST:
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(158)
+			p.MatchDst6()
+		}
+
+	case TrafficClassParserDSCP6:
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(159)
+			p.MatchDSCP6()
+		}
+
+	case TrafficClassParserTC:
+		p.EnterOuterAlt(localctx, 4)
+		{
+			p.SetState(160)
+			p.MatchTC()
+		}
+
+	case TrafficClassParserFLOWLABEL:
+		p.EnterOuterAlt(localctx, 5)
+		{
+			p.SetState(161)
+			p.MatchFlowLabel()
+		}
+
+	case TrafficClassParserPROTOCOL6:
+		p.EnterOuterAlt(localctx, 6)
+		{
+			p.SetState(162)
+			p.MatchProtocol6()
+		}
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+	}
+
+	return localctx
+}
+
 // ICondPortContext is an interface to support dynamic dispatch.
 type ICondPortContext interface {
 	antlr.ParserRuleContext
@@ -2021,7 +2925,7 @@ func (s *CondPortContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) CondPort() (localctx ICondPortContext) {
 	localctx = NewCondPortContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 30, TrafficClassParserRULE_condPort)
+	p.EnterRule(localctx, 44, TrafficClassParserRULE_condPort)
 
 	defer func() {
 		p.ExitRule()
@@ -2039,34 +2943,34 @@ func (p *TrafficClassParser) CondPort() (localctx ICondPortContext) {
 		}
 	}()
 
-	p.SetState(123)
+	p.SetState(169)
 	p.GetErrorHandler().Sync(p)
-	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 3, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 4, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(119)
+			p.SetState(165)
 			p.MatchSrcPort()
 		}
 
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(120)
+			p.SetState(166)
 			p.MatchSrcPortRange()
 		}
 
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(121)
+			p.SetState(167)
 			p.MatchDstPort()
 		}
 
 	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(122)
+			p.SetState(168)
 			p.MatchDstPortRange()
 		}
 
@@ -2153,6 +3057,16 @@ func (s *CondContext) CondIPv4() ICondIPv4Context {
 	return t.(ICondIPv4Context)
 }
 
+func (s *CondContext) CondIPv6() ICondIPv6Context {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondIPv6Context)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ICondIPv6Context)
+}
+
 func (s *CondContext) CondPort() ICondPortContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*ICondPortContext)(nil)).Elem(), 0)
 
@@ -2205,7 +3119,7 @@ func (s *CondContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) Cond() (localctx ICondContext) {
 	localctx = NewCondContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 32, TrafficClassParserRULE_cond)
+	p.EnterRule(localctx, 46, TrafficClassParserRULE_cond)
 
 	defer func() {
 		p.ExitRule()
@@ -2223,61 +3137,65 @@ func (p *TrafficClassParser) Cond() (localctx ICondContext) {
 		}
 	}()
 
-	p.SetState(132)
+	p.SetState(179)
 	p.GetErrorHandler().Sync(p)
-
-	switch p.GetTokenStream().LA(1) {
-	case TrafficClassParserALL:
+	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 5, p.GetParserRuleContext()) {
+	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(125)
+			p.SetState(171)
 			p.CondAll()
 		}
 
-	case TrafficClassParserANY:
+	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(126)
+			p.SetState(172)
 			p.CondAny()
 		}
 
-	case TrafficClassParserNOT:
+	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(127)
+			p.SetState(173)
 			p.CondNot()
 		}
 
-	case TrafficClassParserSRC, TrafficClassParserDST, TrafficClassParserDSCP, TrafficClassParserTOS, TrafficClassParserPROTOCOL:
+	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(128)
+			p.SetState(174)
 			p.CondIPv4()
 		}
 
-	case TrafficClassParserSRCPORT, TrafficClassParserDSTPORT:
+	case 5:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(129)
-			p.CondPort()
+			p.SetState(175)
+			p.CondIPv6()
 		}
 
-	case TrafficClassParserT__3:
+	case 6:
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(130)
-			p.CondCls()
+			p.SetState(176)
+			p.CondPort()
 		}
 
-	case TrafficClassParserBOOL:
+	case 7:
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(131)
+			p.SetState(177)
+			p.CondCls()
+		}
+
+	case 8:
+		p.EnterOuterAlt(localctx, 8)
+		{
+			p.SetState(178)
 			p.CondBool()
 		}
 
-	default:
-		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 	return localctx
@@ -2357,7 +3275,7 @@ func (s *TrafficClassContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *TrafficClassParser) TrafficClass() (localctx ITrafficClassContext) {
 	localctx = NewTrafficClassContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 34, TrafficClassParserRULE_trafficClass)
+	p.EnterRule(localctx, 48, TrafficClassParserRULE_trafficClass)
 
 	defer func() {
 		p.ExitRule()
@@ -2377,11 +3295,11 @@ func (p *TrafficClassParser) TrafficClass() (localctx ITrafficClassContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(134)
+		p.SetState(181)
 		p.Cond()
 	}
 	{
-		p.SetState(135)
+		p.SetState(182)
 		p.Match(TrafficClassParserEOF)
 	}
 
@@ -17,6 +17,7 @@ package config
 
 import (
 	"io"
+	"net"
 	"strings"
 	"time"
 
@@ -44,6 +45,8 @@ const (
 	DefaultQueryInterval = 5 * time.Minute
 	// DefaultMaxASValidity is the default validity period for renewed AS certificates.
 	DefaultMaxASValidity = 3 * 24 * time.Hour
+	// DefaultDRKeyEpochDuration is the default duration of a DRKey epoch.
+	DefaultDRKeyEpochDuration = 24 * time.Hour
 )
 
 var _ config.Config = (*Config)(nil)
@@ -64,6 +67,7 @@ type Config struct {
 	PS          PSConfig           `toml:"path,omitempty"`
 	CA          CA                 `toml:"ca,omitempty"`
 	TrustEngine trustengine.Config `toml:"trustengine,omitempty"`
+	DRKey       DRKeyConfig        `toml:"drkey,omitempty"`
 }
 
 // InitDefaults initializes the default values for all parts of the config.
@@ -82,6 +86,7 @@ func (cfg *Config) InitDefaults() {
 		&cfg.PS,
 		&cfg.CA,
 		&cfg.TrustEngine,
+		&cfg.DRKey,
 	)
 }
 
@@ -100,6 +105,7 @@ func (cfg *Config) Validate() error {
 		&cfg.PS,
 		&cfg.CA,
 		&cfg.TrustEngine,
+		&cfg.DRKey,
 	)
 }
 
@@ -138,6 +144,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.PS,
 		&cfg.CA,
 		&cfg.TrustEngine,
+		&cfg.DRKey,
 	)
 }
 
@@ -333,3 +340,50 @@ func (cfg *CAService) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
 func (cfg *CAService) ConfigName() string {
 	return "service"
 }
+
+var _ config.Config = (*DRKeyConfig)(nil)
+
+// DRKeyConfig is the DRKey configuration.
+type DRKeyConfig struct {
+	// EpochDuration is the duration of the DRKey epochs of the local AS.
+	EpochDuration util.DurWrap `toml:"epoch_duration,omitempty"`
+	// AllowedHosts are the IP addresses of the hosts in the local AS that may
+	// fetch level 1 keys from the control service, e.g., the SCION daemon or
+	// the border routers.
+	AllowedHosts []string `toml:"allowed_hosts,omitempty"`
+}
+
+func (cfg *DRKeyConfig) InitDefaults() {
+	initDurWrap(&cfg.EpochDuration, DefaultDRKeyEpochDuration)
+}
+
+func (cfg *DRKeyConfig) Validate() error {
+	if cfg.EpochDuration.Duration < time.Minute {
+		return serrors.New("epoch_duration must be at least one minute",
+			"epoch_duration", cfg.EpochDuration)
+	}
+	for _, host := range cfg.AllowedHosts {
+		if net.ParseIP(host) == nil {
+			return serrors.New("invalid IP address in allowed_hosts", "host", host)
+		}
+	}
+	return nil
+}
+
+// AllowedHostIPs returns the parsed IP addresses of the allowed hosts. It
+// must only be called on a validated config.
+func (cfg *DRKeyConfig) AllowedHostIPs() []net.IP {
+	ips := make([]net.IP, 0, len(cfg.AllowedHosts))
+	for _, host := range cfg.AllowedHosts {
+		ips = append(ips, net.ParseIP(host))
+	}
+	return ips
+}
+
+func (cfg *DRKeyConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, drkeySample)
+}
+
+func (cfg *DRKeyConfig) ConfigName() string {
+	return "drkey"
+}
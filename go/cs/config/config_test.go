@@ -16,6 +16,7 @@ package config
 
 import (
 	"bytes"
+	"net"
 	"testing"
 
 	"github.com/pelletier/go-toml"
@@ -69,6 +70,7 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	CheckTestBSConfig(t, &cfg.BS)
 	CheckTestPSConfig(t, &cfg.PS, id)
 	CheckTestCA(t, &cfg.CA)
+	CheckTestDRKey(t, &cfg.DRKey)
 }
 
 func CheckTestBSConfig(t *testing.T, cfg *BSConfig) {
@@ -109,3 +111,10 @@ func CheckTestService(t *testing.T, cfg *CAService) {
 	assert.Equal(t, jwtauth.DefaultTokenLifetime, cfg.Lifetime.Duration)
 	assert.Empty(t, cfg.ClientID)
 }
+
+func CheckTestDRKey(t *testing.T, cfg *DRKeyConfig) {
+	assert.Equal(t, DefaultDRKeyEpochDuration, cfg.EpochDuration.Duration)
+	assert.Equal(t, []string{"127.0.0.1"}, cfg.AllowedHosts)
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, []net.IP{net.ParseIP("127.0.0.1")}, cfg.AllowedHostIPs())
+}
@@ -62,3 +62,14 @@ lifetime = "10m"
 # authorization tokens. If not set, the SCION ID is used instead.
 client_id = ""
 `
+
+const drkeySample = `
+# The duration of the DRKey epochs of the local AS. Level 1 keys derived by the
+# local AS are valid for one epoch. (default 24h)
+epoch_duration = "24h"
+
+# The IP addresses of the hosts in the local AS that may fetch level 1 keys,
+# e.g., the SCION daemon or the border routers. Requests from all other hosts
+# are rejected. (default [])
+allowed_hosts = ["127.0.0.1"]
+`
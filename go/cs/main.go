@@ -64,6 +64,8 @@ import (
 	"github.com/scionproto/scion/go/pkg/command"
 	"github.com/scionproto/scion/go/pkg/cs"
 	"github.com/scionproto/scion/go/pkg/cs/api"
+	csdrkey "github.com/scionproto/scion/go/pkg/cs/drkey"
+	drkeygrpc "github.com/scionproto/scion/go/pkg/cs/drkey/grpc"
 	cstrustgrpc "github.com/scionproto/scion/go/pkg/cs/trust/grpc"
 	cstrustmetrics "github.com/scionproto/scion/go/pkg/cs/trust/metrics"
 	"github.com/scionproto/scion/go/pkg/discovery"
@@ -423,6 +425,32 @@ func realMain() error {
 		cppb.RegisterChainRenewalServiceServer(tcpServer, renewalServer)
 	}
 
+	// Register DRKey related handlers. The level-1 keys are exchanged with
+	// remote control services over QUIC, the intra-AS service is only exposed
+	// to the allowed local hosts over TCP. Remote control services must present
+	// a client certificate that is verified against the TRCs, requests without
+	// one are rejected.
+	secretValues, err := cs.NewSecretValueStore(globalCfg.General.ConfigDir,
+		globalCfg.DRKey.EpochDuration.Duration)
+	if err != nil {
+		return serrors.WrapStr("initializing DRKey secret values", err)
+	}
+	drkeyServer := drkeygrpc.Server{
+		Engine: &csdrkey.ServiceEngine{
+			LocalIA:      topo.IA(),
+			SecretValues: secretValues,
+			Fetcher: drkeygrpc.Lvl1Fetcher{
+				LocalIA: topo.IA(),
+				Dialer:  dialer,
+				Router:  segreq.NewRouter(fetcherCfg),
+			},
+		},
+		ClientVerifier: trust.NewTLSCryptoManager(nil, trustDB),
+		AllowedHosts:   globalCfg.DRKey.AllowedHostIPs(),
+	}
+	cppb.RegisterDRKeyInterServiceServer(quicServer, drkeyServer)
+	cppb.RegisterDRKeyIntraServiceServer(tcpServer, drkeyServer)
+
 	// Frequently regenerate signers to catch problems, and update the metrics.
 	periodic.Start(
 		periodic.Func{
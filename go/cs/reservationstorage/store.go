@@ -27,6 +27,8 @@ import (
 type Store interface {
 	AdmitSegmentReservation(ctx context.Context, req *sgt.SetupReq) (
 		base.MessageWithPath, error)
+	AddSegmentTokenHopField(ctx context.Context, res *sgt.ResponseSetupSuccess) (
+		base.MessageWithPath, error)
 	ConfirmSegmentReservation(ctx context.Context, req *sgt.IndexConfirmationReq) (
 		base.MessageWithPath, error)
 	CleanupSegmentReservation(ctx context.Context, req *sgt.CleanupReq) (
@@ -16,6 +16,7 @@ package reservationstore
 
 import (
 	"context"
+	"hash"
 	"math"
 	"time"
 
@@ -25,23 +26,28 @@ import (
 	"github.com/scionproto/scion/go/cs/reservation/segment/admission"
 	"github.com/scionproto/scion/go/cs/reservationstorage"
 	"github.com/scionproto/scion/go/cs/reservationstorage/backend"
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 )
 
 // Store is the reservation store.
 type Store struct {
 	db       backend.DB         // aka reservation map
 	admitter admission.Admitter // the chosen admission entity
+	macGen   func() hash.Hash   // the hop field MAC generator of this AS
 }
 
 var _ reservationstorage.Store = (*Store)(nil)
 
-// NewStore creates a new reservation store.
-func NewStore(db backend.DB, admitter admission.Admitter) *Store {
+// NewStore creates a new reservation store. The MAC generator must be the
+// same that the border routers of this AS use to verify hop fields.
+func NewStore(db backend.DB, admitter admission.Admitter, macGen func() hash.Hash) *Store {
 	return &Store{
 		db:       db,
 		admitter: admitter,
+		macGen:   macGen,
 	}
 }
 
@@ -91,6 +97,8 @@ func (s *Store) AdmitSegmentReservation(ctx context.Context, req *segment.SetupR
 		// setup, create reservation and an index
 		rsv = segment.NewReservation()
 		rsv.ID = req.ID
+		rsv.Ingress = req.Ingress
+		rsv.Egress = req.Egress
 		err = tx.NewSegmentRsv(ctx, rsv)
 		if err != nil {
 			return failedResponse, serrors.WrapStr(
@@ -131,16 +139,67 @@ func (s *Store) AdmitSegmentReservation(ctx context.Context, req *segment.SetupR
 	}
 
 	if req.IsLastAS() {
-		// TODO(juagargi) update token here
+		// On-path ASes don't store the token, the response carries it back to
+		// the initiator and every AS on the way adds its hop field.
+		tok.HopFields = []reservation.HopField{
+			s.hopField(tok, req.ID.ASID, req.ID.Suffix[:], true, req.Ingress, req.Egress),
+		}
 		return &segment.ResponseSetupSuccess{
 			Response: *morphSegmentResponseToSuccess(response),
-			Token:    *index.Token,
+			Token:    *tok,
 		}, nil
 	}
 	// TODO(juagargi) refactor function
 	return req, nil
 }
 
+// AddSegmentTokenHopField processes a successful setup response on its way
+// back to the initiator of the reservation. The hop field of this AS is
+// prepended to the token, so that the token contains the hop fields of all ASes
+// in the direction of the reservation once it reaches the initiator, which
+// stores it.
+func (s *Store) AddSegmentTokenHopField(ctx context.Context, res *segment.ResponseSetupSuccess) (
+	base.MessageWithPath, error) {
+
+	if err := s.validateAuthenticators(&res.RequestMetadata); err != nil {
+		return nil, serrors.WrapStr("error validating response", err, "id", res.ID)
+	}
+	tx, err := s.db.BeginTransaction(ctx, nil)
+	if err != nil {
+		return nil, serrors.WrapStr("cannot create transaction", err, "id", res.ID)
+	}
+	defer tx.Rollback()
+
+	rsv, err := tx.GetSegmentRsvFromID(ctx, &res.ID)
+	if err != nil {
+		return nil, serrors.WrapStr("cannot obtain segment reservation", err, "id", res.ID)
+	}
+	if rsv == nil {
+		return nil, serrors.New("segment reservation not found", "id", res.ID)
+	}
+	index := rsv.Index(res.Token.Idx)
+	if index == nil {
+		return nil, serrors.New("index of the token not found", "id", res.ID,
+			"idx", res.Token.Idx)
+	}
+	hf := s.hopField(&res.Token, res.ID.ASID, res.ID.Suffix[:], true, rsv.Ingress, rsv.Egress)
+	res.Token.HopFields = append([]reservation.HopField{hf}, res.Token.HopFields...)
+
+	if res.IsLastAS() {
+		tok := res.Token
+		tok.HopFields = append([]reservation.HopField{}, res.Token.HopFields...)
+		index.Token = &tok
+		if err := tx.PersistSegmentRsv(ctx, rsv); err != nil {
+			return nil, serrors.WrapStr("cannot persist segment reservation", err,
+				"id", res.ID)
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, serrors.WrapStr("cannot commit transaction", err, "id", res.ID)
+		}
+	}
+	return res, nil
+}
+
 // ConfirmSegmentReservation changes the state of an index from temporary to confirmed.
 func (s *Store) ConfirmSegmentReservation(ctx context.Context, req *segment.IndexConfirmationReq) (
 	base.MessageWithPath, error) {
@@ -407,7 +466,11 @@ func (s *Store) AdmitE2EReservation(ctx context.Context, request e2e.SetupReques
 	}
 
 	// admitted so far
-	// TODO(juagargi) update token here
+	if index.Token != nil {
+		ingress, egress := req.Path().IngressEgressIFIDs()
+		index.Token.HopFields = append(index.Token.HopFields,
+			s.hopField(index.Token, req.ID.ASID, req.ID.Suffix[:], false, ingress, egress))
+	}
 	if err := tx.PersistE2ERsv(ctx, rsv); err != nil {
 		return failedResponse, serrors.WrapStr("cannot persist e2e reservation", err,
 			"id", req.ID)
@@ -488,6 +551,29 @@ func (s *Store) DeleteExpiredIndices(ctx context.Context) (int, error) {
 	return s.db.DeleteExpiredIndices(ctx, time.Now())
 }
 
+// hopField computes the hop field of this AS for the reservation of the token.
+// The interfaces are those in the direction of the reservation. The border
+// routers of this AS verify the MAC of the hop field with the same key.
+func (s *Store) hopField(tok *reservation.Token, asID addr.AS, suffix []byte, segment bool,
+	ingress, egress uint16) reservation.HopField {
+
+	info := colibri.InfoField{
+		C:       segment,
+		S:       segment,
+		ExpTick: uint32(tok.ExpirationTick),
+		BWCls:   uint8(tok.BWCls),
+		RLC:     uint8(tok.RLC),
+		Idx:     uint8(tok.Idx),
+	}
+	copy(info.ResIDSuffix[:], suffix)
+	hf := colibri.HopField{IngressID: ingress, EgressID: egress}
+	return reservation.HopField{
+		Ingress: ingress,
+		Egress:  egress,
+		Mac:     colibri.MAC(s.macGen(), &info, &hf, asID),
+	}
+}
+
 // validateAuthenticators checks that the authenticators are correct.
 func (s *Store) validateAuthenticators(req *base.RequestMetadata) error {
 	// TODO(juagargi) validate request
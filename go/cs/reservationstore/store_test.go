@@ -15,12 +15,174 @@
 package reservationstore
 
 import (
+	"context"
+	"hash"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/reservation/segment"
+	"github.com/scionproto/scion/go/cs/reservation/test"
 	"github.com/scionproto/scion/go/cs/reservationstorage"
+	"github.com/scionproto/scion/go/cs/reservationstorage/backend/mock_backend"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/xtest"
 )
 
 func TestStore(t *testing.T) {
 	var s reservationstorage.Store = &Store{}
 	_ = s
 }
+
+func TestHopField(t *testing.T) {
+	macGen, err := scrypto.HFMacFactory([]byte("0123456789abcdef"))
+	require.NoError(t, err)
+	s := NewStore(nil, nil, macGen)
+	tok := &reservation.Token{
+		InfoField: reservation.InfoField{
+			ExpirationTick: 1000,
+			BWCls:          5,
+			RLC:            2,
+			Idx:            3,
+		},
+	}
+	id := reservation.E2EID{ASID: xtest.MustParseAS("ff00:0:111")}
+	copy(id.Suffix[:], xtest.MustParseHexString("0123456789abcdef0123"))
+	hf := s.hopField(tok, id.ASID, id.Suffix[:], false, 1, 2)
+
+	assert.Equal(t, uint16(1), hf.Ingress)
+	assert.Equal(t, uint16(2), hf.Egress)
+	// The border router verifies the MAC on the COLIBRI path built from the token.
+	info := colibri.InfoField{ExpTick: 1000, BWCls: 5, RLC: 2, Idx: 3}
+	copy(info.ResIDSuffix[:], id.Suffix[:])
+	expected := colibri.MAC(macGen(), &info, &colibri.HopField{IngressID: 1, EgressID: 2},
+		id.ASID)
+	assert.Equal(t, expected, hf.Mac)
+}
+
+// TestSegmentTokenHopFields sets up a segment reservation over three ASes and
+// checks that the token contains the hop fields of all of them.
+func TestSegmentTokenHopFields(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+
+	ctx := context.Background()
+	id := reservation.SegmentID{ASID: xtest.MustParseAS("ff00:0:110")}
+	copy(id.Suffix[:], xtest.MustParseHexString("01234567"))
+	info := reservation.InfoField{
+		ExpirationTick: reservation.TickFromTime(time.Now().Add(time.Minute)),
+		BWCls:          5,
+		RLC:            2,
+		Idx:            0,
+	}
+	// The hops of the reservation: 1-ff00:0:110 (0,1) -> 1-ff00:0:111 (2,3) ->
+	// 1-ff00:0:112 (4,0).
+	hops := []struct {
+		ingress, egress uint16
+		masterKey       []byte
+	}{
+		{ingress: 0, egress: 1, masterKey: []byte("master key AS110")},
+		{ingress: 2, egress: 3, masterKey: []byte("master key AS111")},
+		{ingress: 4, egress: 0, masterKey: []byte("master key AS112")},
+	}
+	macGens := make([]func() hash.Hash, len(hops))
+	for i, hop := range hops {
+		var err error
+		macGens[i], err = scrypto.HFMacFactory(hop.masterKey)
+		require.NoError(t, err)
+	}
+	onPathRsv := func(i int) *segment.Reservation {
+		rsv := segment.NewReservation()
+		rsv.ID = id
+		rsv.Ingress, rsv.Egress = hops[i].ingress, hops[i].egress
+		_, err := rsv.NewIndexFromToken(&reservation.Token{InfoField: info}, 1, 10)
+		require.NoError(t, err)
+		return rsv
+	}
+
+	// The last AS admits the reservation and creates the token.
+	db := mock_backend.NewMockDB(mctrl)
+	tx := mock_backend.NewMockTransaction(mctrl)
+	db.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(tx, nil)
+	tx.EXPECT().GetSegmentRsvFromID(gomock.Any(), &id).Return(nil, nil)
+	tx.EXPECT().NewSegmentRsv(gomock.Any(), gomock.Any())
+	tx.EXPECT().PersistSegmentRsv(gomock.Any(), gomock.Any())
+	tx.EXPECT().Commit()
+	tx.EXPECT().Rollback()
+	path := &test.TestColibriPath{HopCount: 3, CurrentHop: 2, Ingress: 4, Egress: 0}
+	baseReq, err := segment.NewRequest(time.Now(), &id, 0, path)
+	require.NoError(t, err)
+	req := &segment.SetupReq{
+		Request:    *baseReq,
+		InfoField:  info,
+		MinBW:      1,
+		MaxBW:      10,
+		AllocTrail: reservation.AllocationBeads{{AllocBW: 5, MaxBW: 10}, {AllocBW: 5, MaxBW: 10}},
+	}
+	msg, err := NewStore(db, admitAll{}, macGens[2]).AdmitSegmentReservation(ctx, req)
+	require.NoError(t, err)
+	res, ok := msg.(*segment.ResponseSetupSuccess)
+	require.True(t, ok, "unexpected response %T", msg)
+	require.Len(t, res.Token.HopFields, 1)
+
+	// The transit AS adds its hop field on the way back.
+	db = mock_backend.NewMockDB(mctrl)
+	tx = mock_backend.NewMockTransaction(mctrl)
+	db.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(tx, nil)
+	tx.EXPECT().GetSegmentRsvFromID(gomock.Any(), &id).Return(onPathRsv(1), nil)
+	tx.EXPECT().Rollback()
+	res.Path().(*test.TestColibriPath).CurrentHop = 1
+	_, err = NewStore(db, nil, macGens[1]).AddSegmentTokenHopField(ctx, res)
+	require.NoError(t, err)
+
+	// The initiator adds its hop field and stores the complete token.
+	db = mock_backend.NewMockDB(mctrl)
+	tx = mock_backend.NewMockTransaction(mctrl)
+	initiatorRsv := onPathRsv(0)
+	db.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(tx, nil)
+	tx.EXPECT().GetSegmentRsvFromID(gomock.Any(), &id).Return(initiatorRsv, nil)
+	tx.EXPECT().PersistSegmentRsv(gomock.Any(), initiatorRsv)
+	tx.EXPECT().Commit()
+	tx.EXPECT().Rollback()
+	res.Path().(*test.TestColibriPath).CurrentHop = 2
+	_, err = NewStore(db, nil, macGens[0]).AddSegmentTokenHopField(ctx, res)
+	require.NoError(t, err)
+
+	require.Len(t, res.Token.HopFields, len(hops))
+	colInfo := colibri.InfoField{
+		C:       true,
+		S:       true,
+		ExpTick: uint32(info.ExpirationTick),
+		BWCls:   uint8(info.BWCls),
+		RLC:     uint8(info.RLC),
+		Idx:     uint8(info.Idx),
+	}
+	copy(colInfo.ResIDSuffix[:], id.Suffix[:])
+	for i, hop := range hops {
+		hf := res.Token.HopFields[i]
+		assert.Equal(t, hop.ingress, hf.Ingress, "hop %d", i)
+		assert.Equal(t, hop.egress, hf.Egress, "hop %d", i)
+		expected := colibri.MAC(macGens[i](), &colInfo,
+			&colibri.HopField{IngressID: hop.ingress, EgressID: hop.egress}, id.ASID)
+		assert.Equal(t, expected, hf.Mac, "hop %d", i)
+	}
+	stored := initiatorRsv.Index(info.Idx).Token
+	require.NotNil(t, stored)
+	assert.Equal(t, res.Token, *stored)
+}
+
+// admitAll admits every request with the maximum bandwidth.
+type admitAll struct{}
+
+func (admitAll) AdmitRsv(_ context.Context, req *segment.SetupReq) error {
+	req.AllocTrail = append(req.AllocTrail, reservation.AllocationBead{
+		AllocBW: req.MaxBW,
+		MaxBW:   req.MaxBW,
+	})
+	return nil
+}
@@ -21,6 +21,8 @@ import (
 	_ "net/http/pprof"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/cors"
 	promgrpc "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
@@ -44,7 +46,10 @@ import (
 	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/pkg/app/launcher"
 	"github.com/scionproto/scion/go/pkg/daemon"
+	"github.com/scionproto/scion/go/pkg/daemon/api"
 	"github.com/scionproto/scion/go/pkg/daemon/config"
+	"github.com/scionproto/scion/go/pkg/daemon/drkey"
+	drkeygrpc "github.com/scionproto/scion/go/pkg/daemon/drkey/grpc"
 	"github.com/scionproto/scion/go/pkg/daemon/fetcher"
 	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
 	"github.com/scionproto/scion/go/pkg/hiddenpath"
@@ -82,7 +87,8 @@ func realMain() error {
 	}
 	defer closer.Close()
 
-	revCache := storage.NewRevocationStorage()
+	// The revocation cache notifies the path subscriptions about revocations.
+	revCache := revcache.NewNotifyingRevCache(storage.NewRevocationStorage())
 	pathDB, err := storage.NewPathStorage(globalCfg.PathDB)
 	if err != nil {
 		return serrors.WrapStr("initializing path storage", err)
@@ -173,22 +179,26 @@ func realMain() error {
 		}}
 	}
 
+	fetcherCfg := fetcher.FetcherConfig{
+		RPC:          requester,
+		PathDB:       pathDB,
+		Inspector:    engine,
+		Verifier:     createVerifier(),
+		RevCache:     revCache,
+		Cfg:          globalCfg.SD,
+		TopoProvider: itopo.Provider(),
+	}
+	pathFetcher := fetcher.NewFetcher(fetcherCfg)
+
 	server := grpc.NewServer(libgrpc.UnaryServerInterceptor())
 	sdpb.RegisterDaemonServiceServer(server, daemon.NewServer(daemon.ServerConfig{
-		Fetcher: fetcher.NewFetcher(
-			fetcher.FetcherConfig{
-				RPC:          requester,
-				PathDB:       pathDB,
-				Inspector:    engine,
-				Verifier:     createVerifier(),
-				RevCache:     revCache,
-				Cfg:          globalCfg.SD,
-				TopoProvider: itopo.Provider(),
-			},
-		),
+		Fetcher:      pathFetcher,
 		Engine:       engine,
 		RevCache:     revCache,
 		TopoProvider: itopo.Provider(),
+		DRKeyClient: &drkey.ClientEngine{
+			Fetcher: drkeygrpc.Lvl1Fetcher{Dialer: dialer},
+		},
 	}))
 
 	promgrpc.Register(server)
@@ -199,6 +209,32 @@ func realMain() error {
 		}
 	}()
 
+	if globalCfg.API.Addr != "" {
+		r := chi.NewRouter()
+		r.Use(cors.Handler(cors.Options{
+			AllowedOrigins: []string{"*"},
+		}))
+		server := api.Server{
+			Segments:     pathDB,
+			Revocations:  revCache,
+			Paths:        fetcher.NewCachedFetcher(fetcherCfg),
+			TopoProvider: itopo.Provider(),
+			Config:       service.NewConfigStatusPage(globalCfg).Handler,
+			Info:         service.NewInfoStatusPage().Handler,
+			LogLevel:     service.NewLogLevelStatusPage().Handler,
+			Topology:     itopo.TopologyHandler,
+			TrustDB:      trustDB,
+		}
+		log.Info("Exposing API", "addr", globalCfg.API.Addr)
+		h := api.HandlerFromMux(&server, r)
+		go func() {
+			defer log.HandlePanic()
+			if err := http.ListenAndServe(globalCfg.API.Addr, h); err != nil {
+				fatal.Fatal(serrors.WrapStr("serving HTTP API", err))
+			}
+		}()
+	}
+
 	// Start HTTP endpoints.
 	statusPages := service.StatusPages{
 		"info":      service.NewInfoStatusPage(),
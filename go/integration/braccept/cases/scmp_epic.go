@@ -0,0 +1,227 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cases
+
+import (
+	"hash"
+	"net"
+	"path/filepath"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/integration/braccept/runner"
+	"github.com/scionproto/scion/go/lib/common"
+	libepic "github.com/scionproto/scion/go/lib/epic"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/epic"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+// SCMPEPICExpiredTimestamp tests an EPIC packet from the parent to the local
+// AS with an expired packet timestamp.
+func SCMPEPICExpiredTimestamp(artifactsDir string, mac hash.Hash) runner.Case {
+	return scmpEPIC("SCMPEPICExpiredTimestamp", artifactsDir, mac, true)
+}
+
+// SCMPEPICInvalidHVF tests an EPIC packet from the parent to the local AS with
+// an invalid last hop validation field. The packet timestamp is only valid for
+// a few seconds after the case is created, so it must be run right away.
+func SCMPEPICInvalidHVF(artifactsDir string, mac hash.Hash) runner.Case {
+	return scmpEPIC("SCMPEPICInvalidHVF", artifactsDir, mac, false)
+}
+
+// scmpEPIC returns a case with an EPIC packet that fails the verification on
+// the last hop. If expired is set, the packet timestamp is expired, otherwise
+// the last hop validation field is invalid.
+func scmpEPIC(name, artifactsDir string, mac hash.Hash, expired bool) runner.Case {
+	options := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+
+	// Ethernet: SrcMAC=f0:0d:ca:fe:be:ef DstMAC=f0:0d:ca:fe:00:13 EthernetType=IPv4
+	ethernet := &layers.Ethernet{
+		SrcMAC:       net.HardwareAddr{0xf0, 0x0d, 0xca, 0xfe, 0xbe, 0xef},
+		DstMAC:       net.HardwareAddr{0xf0, 0x0d, 0xca, 0xfe, 0x00, 0x13},
+		EthernetType: layers.EthernetTypeIPv4,
+	}
+	// IP4: Src=192.168.13.3 Dst=192.168.13.2 NextHdr=UDP Flags=DF
+	ip := &layers.IPv4{
+		Version:  4,
+		IHL:      5,
+		TTL:      64,
+		SrcIP:    net.IP{192, 168, 13, 3},
+		DstIP:    net.IP{192, 168, 13, 2},
+		Protocol: layers.IPProtocolUDP,
+		Flags:    layers.IPv4DontFragment,
+	}
+	// UDP: Src=40000 Dst=50000
+	udp := &layers.UDP{
+		SrcPort: layers.UDPPort(40000),
+		DstPort: layers.UDPPort(50000),
+	}
+	udp.SetNetworkLayerForChecksum(ip)
+
+	// The path was created an hour ago, such that an EPIC timestamp of zero is
+	// expired. The hop fields are valid for 6h.
+	now := time.Now()
+	created := now.Add(-time.Hour)
+	sp := &scion.Decoded{
+		Base: scion.Base{
+			PathMeta: scion.MetaHdr{
+				CurrHF: 1,
+				SegLen: [3]uint8{2, 0, 0},
+			},
+			NumINF:  1,
+			NumHops: 2,
+		},
+		InfoFields: []*path.InfoField{
+			{
+				SegID:     0x111,
+				ConsDir:   true,
+				Timestamp: util.TimeToSecs(created),
+			},
+		},
+		HopFields: []*path.HopField{
+			{ConsIngress: 0, ConsEgress: 311, ExpTime: 63},
+			{ConsIngress: 131, ConsEgress: 0, ExpTime: 63},
+		},
+	}
+	sp.HopFields[1].Mac = path.MAC(mac, sp.InfoFields[0], sp.HopFields[1])
+	raw, err := sp.ToRaw()
+	if err != nil {
+		panic(err)
+	}
+
+	var epicTS uint32
+	if !expired {
+		if epicTS, err = libepic.CreateTimestamp(created, now); err != nil {
+			panic(err)
+		}
+	}
+	ep := &epic.Path{
+		PktID: epic.PktID{
+			Timestamp: epicTS,
+			Counter:   libepic.PktCounterFromCore(1, 2),
+		},
+		// The hop validation fields are not valid, they are only verified if
+		// the timestamp is valid.
+		PHVF:      make([]byte, epic.HVFLen),
+		LHVF:      make([]byte, epic.HVFLen),
+		ScionPath: raw,
+	}
+
+	scionL := &slayers.SCION{
+		Version:      0,
+		TrafficClass: 0xb8,
+		FlowID:       0xdead,
+		NextHdr:      common.L4UDP,
+		PathType:     epic.PathType,
+		SrcIA:        xtest.MustParseIA("1-ff00:0:3"),
+		DstIA:        xtest.MustParseIA("1-ff00:0:1"),
+		Path:         ep,
+	}
+	srcA := &net.IPAddr{IP: net.ParseIP("172.16.3.1")}
+	if err := scionL.SetSrcAddr(srcA); err != nil {
+		panic(err)
+	}
+	if err := scionL.SetDstAddr(&net.IPAddr{IP: net.ParseIP("192.168.0.51")}); err != nil {
+		panic(err)
+	}
+
+	scionudp := &slayers.UDP{}
+	scionudp.SrcPort = 40111
+	scionudp.DstPort = 40222
+	scionudp.SetNetworkLayerForChecksum(scionL)
+
+	payload := []byte("actualpayloadbytes")
+	code := slayers.SCMPCodePathExpired
+	pointer := slayers.CmnHdrLen + scionL.AddrHdrLen()
+	if !expired {
+		code = slayers.SCMPCodeInvalidHopFieldMAC
+		pointer += epic.PktIDLen + epic.HVFLen
+	}
+
+	// Prepare input packet
+	input := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(input, options,
+		ethernet, ip, udp, scionL, scionudp, gopacket.Payload(payload),
+	); err != nil {
+		panic(err)
+	}
+
+	// Prepare want packet
+	want := gopacket.NewSerializeBuffer()
+	// Ethernet: SrcMAC=f0:0d:ca:fe:00:13 DstMAC=f0:0d:ca:fe:be:ef
+	ethernet.SrcMAC = net.HardwareAddr{0xf0, 0x0d, 0xca, 0xfe, 0x00, 0x13}
+	ethernet.DstMAC = net.HardwareAddr{0xf0, 0x0d, 0xca, 0xfe, 0xbe, 0xef}
+	// 	IP4: Src=192.168.13.2 Dst=192.168.13.3 Checksum=0
+	ip.SrcIP = net.IP{192, 168, 13, 2}
+	ip.DstIP = net.IP{192, 168, 13, 3}
+	// 	UDP: Src=50000 Dst=40000
+	udp.SrcPort, udp.DstPort = udp.DstPort, udp.SrcPort
+
+	// The SCMP message is sent back on the reversed SCION path.
+	scionL.DstIA = scionL.SrcIA
+	scionL.SrcIA = xtest.MustParseIA("1-ff00:0:1")
+	if err := scionL.SetDstAddr(srcA); err != nil {
+		panic(err)
+	}
+	intlA := &net.IPAddr{IP: net.IP{192, 168, 0, 11}}
+	if err := scionL.SetSrcAddr(intlA); err != nil {
+		panic(err)
+	}
+	p, err := sp.Reverse()
+	if err != nil {
+		panic(err)
+	}
+	sp = p.(*scion.Decoded)
+	if err := sp.IncPath(); err != nil {
+		panic(err)
+	}
+	scionL.PathType = scion.PathType
+	scionL.Path = sp
+	scionL.NextHdr = common.L4SCMP
+	scmpH := &slayers.SCMP{
+		TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem, code),
+	}
+	scmpH.SetNetworkLayerForChecksum(scionL)
+	scmpP := &slayers.SCMPParameterProblem{
+		Pointer: uint16(pointer),
+	}
+
+	// Skip Ethernet + IPv4 + UDP
+	quoteStart := 14 + 20 + 8
+	quote := input.Bytes()[quoteStart:]
+	if err := gopacket.SerializeLayers(want, options,
+		ethernet, ip, udp, scionL, scmpH, scmpP, gopacket.Payload(quote),
+	); err != nil {
+		panic(err)
+	}
+
+	return runner.Case{
+		Name:     name,
+		WriteTo:  "veth_131_host",
+		ReadFrom: "veth_131_host",
+		Input:    input.Bytes(),
+		Want:     want.Bytes(),
+		StoreDir: filepath.Join(artifactsDir, name),
+	}
+}
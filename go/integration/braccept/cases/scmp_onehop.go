@@ -0,0 +1,316 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cases
+
+import (
+	"hash"
+	"net"
+	"path/filepath"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/integration/braccept/runner"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/empty"
+	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func oneHopNormalizePacket(pkt gopacket.Packet) {
+	// Apply all the standard normalizations.
+	runner.DefaultNormalizePacket(pkt)
+	for _, l := range pkt.Layers() {
+		switch v := l.(type) {
+		case *slayers.SCION:
+			switch p := v.Path.(type) {
+			case *onehop.Path:
+				// Timestamps are generated by the sender from the current time.
+				p.Info.Timestamp = 0
+				// MACs are different because of different timestamps, and the
+				// SegID is updated with the MAC.
+				p.Info.SegID = 0
+				p.FirstHop.Mac = []byte{}
+			}
+		}
+	}
+}
+
+// SCMPOneHopBadMAC tests a one-hop packet with a bad MAC that is sent from the
+// local AS.
+func SCMPOneHopBadMAC(artifactsDir string, mac hash.Hash) runner.Case {
+	return scmpOutgoingOneHop("SCMPOneHopBadMAC", artifactsDir, 141,
+		slayers.SCMPCodeInvalidHopFieldMAC)
+}
+
+// SCMPOneHopUnknownEgress tests a one-hop packet that is sent from the local AS
+// on an interface that does not exist.
+func SCMPOneHopUnknownEgress(artifactsDir string, mac hash.Hash) runner.Case {
+	return scmpOutgoingOneHop("SCMPOneHopUnknownEgress", artifactsDir, 999,
+		slayers.SCMPCodeUnknownHopFieldEgress)
+}
+
+// scmpOutgoingOneHop returns a case with a one-hop packet without MAC that is
+// sent from the local AS on the given interface. The SCMP message with the
+// given code is sent back to the local host on an empty path.
+func scmpOutgoingOneHop(name, artifactsDir string, egress uint16,
+	code slayers.SCMPCode) runner.Case {
+
+	options := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+	ethernet := &layers.Ethernet{
+		SrcMAC:       net.HardwareAddr{0xf0, 0x0d, 0xca, 0xfe, 0xbe, 0xef},
+		DstMAC:       net.HardwareAddr{0xf0, 0x0d, 0xca, 0xfe, 0x00, 0x01},
+		EthernetType: layers.EthernetTypeIPv4,
+	}
+	ip := &layers.IPv4{
+		Version:  4,
+		IHL:      5,
+		TTL:      64,
+		SrcIP:    net.IP{192, 168, 0, 71},
+		DstIP:    net.IP{192, 168, 0, 11},
+		Protocol: layers.IPProtocolUDP,
+		Flags:    layers.IPv4DontFragment,
+	}
+	udp := &layers.UDP{
+		SrcPort: layers.UDPPort(30041),
+		DstPort: layers.UDPPort(30001),
+	}
+	udp.SetNetworkLayerForChecksum(ip)
+	ohp := &onehop.Path{
+		Info: path.InfoField{
+			ConsDir:   true,
+			SegID:     0x111,
+			Timestamp: util.TimeToSecs(time.Now()),
+		},
+		FirstHop: path.HopField{
+			ConsIngress: 0,
+			ConsEgress:  egress,
+			Mac:         []byte{0, 0, 0, 0, 0, 0},
+		},
+	}
+
+	scionL := &slayers.SCION{
+		Version:      0,
+		TrafficClass: 0xb8,
+		FlowID:       0xdead,
+		NextHdr:      common.L4UDP,
+		PathType:     onehop.PathType,
+		SrcIA:        xtest.MustParseIA("1-ff00:0:1"),
+		DstIA:        xtest.MustParseIA("1-ff00:0:4"),
+		Path:         ohp,
+	}
+	srcA := &net.IPAddr{IP: net.ParseIP("192.168.0.71")}
+	if err := scionL.SetSrcAddr(srcA); err != nil {
+		panic(err)
+	}
+	if err := scionL.SetDstAddr(&net.IPAddr{IP: net.ParseIP("172.16.4.1")}); err != nil {
+		panic(err)
+	}
+
+	scionudp := &slayers.UDP{}
+	scionudp.SrcPort = 2345
+	scionudp.DstPort = 53
+	scionudp.SetNetworkLayerForChecksum(scionL)
+
+	payload := []byte("actualpayloadbytes")
+	pointer := slayers.CmnHdrLen + scionL.AddrHdrLen() + path.InfoLen
+
+	// Prepare input packet
+	input := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(input, options,
+		ethernet, ip, udp, scionL, scionudp, gopacket.Payload(payload),
+	); err != nil {
+		panic(err)
+	}
+
+	// Prepare want packet
+	want := gopacket.NewSerializeBuffer()
+	ethernet.SrcMAC = net.HardwareAddr{0xf0, 0x0d, 0xca, 0xfe, 0x00, 0x01}
+	ethernet.DstMAC = net.HardwareAddr{0xf0, 0x0d, 0xca, 0xfe, 0xbe, 0xef}
+	ip.SrcIP = net.IP{192, 168, 0, 11}
+	ip.DstIP = net.IP{192, 168, 0, 71}
+	udp.SrcPort, udp.DstPort = udp.DstPort, udp.SrcPort
+
+	// The SCMP message is delivered in the local AS.
+	scionL.DstIA = scionL.SrcIA
+	if err := scionL.SetDstAddr(srcA); err != nil {
+		panic(err)
+	}
+	if err := scionL.SetSrcAddr(&net.IPAddr{IP: net.IP{192, 168, 0, 11}}); err != nil {
+		panic(err)
+	}
+	scionL.PathType = empty.PathType
+	scionL.Path = &empty.Path{}
+	scionL.NextHdr = common.L4SCMP
+	scmpH := &slayers.SCMP{
+		TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem, code),
+	}
+	scmpH.SetNetworkLayerForChecksum(scionL)
+	scmpP := &slayers.SCMPParameterProblem{
+		Pointer: uint16(pointer),
+	}
+
+	// Skip Ethernet + IPv4 + UDP
+	quoteStart := 14 + 20 + 8
+	quote := input.Bytes()[quoteStart:]
+	if err := gopacket.SerializeLayers(want, options,
+		ethernet, ip, udp, scionL, scmpH, scmpP, gopacket.Payload(quote),
+	); err != nil {
+		panic(err)
+	}
+
+	return runner.Case{
+		Name:     name,
+		WriteTo:  "veth_int_host",
+		ReadFrom: "veth_int_host",
+		Input:    input.Bytes(),
+		Want:     want.Bytes(),
+		StoreDir: filepath.Join(artifactsDir, name),
+	}
+}
+
+// SCMPOneHopReversed tests a one-hop packet against construction direction
+// that is sent from the remote AS to the local AS. The SCMP message is sent
+// back to the remote AS on a new one-hop path.
+func SCMPOneHopReversed(artifactsDir string, mac hash.Hash) runner.Case {
+	options := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+	ethernet := &layers.Ethernet{
+		SrcMAC:       net.HardwareAddr{0xf0, 0x0d, 0xca, 0xfe, 0xbe, 0xef},
+		DstMAC:       net.HardwareAddr{0xf0, 0x0d, 0xca, 0xfe, 0x00, 0x13},
+		EthernetType: layers.EthernetTypeIPv4,
+	}
+	ip := &layers.IPv4{
+		Version:  4,
+		IHL:      5,
+		TTL:      64,
+		SrcIP:    net.IP{192, 168, 13, 3},
+		DstIP:    net.IP{192, 168, 13, 2},
+		Protocol: layers.IPProtocolUDP,
+		Flags:    layers.IPv4DontFragment,
+	}
+	udp := &layers.UDP{
+		SrcPort: layers.UDPPort(40000),
+		DstPort: layers.UDPPort(50000),
+	}
+	udp.SetNetworkLayerForChecksum(ip)
+	ohp := &onehop.Path{
+		Info: path.InfoField{
+			ConsDir:   false,
+			SegID:     0x111,
+			Timestamp: util.TimeToSecs(time.Now()),
+		},
+		FirstHop:  path.HopField{ConsIngress: 0, ConsEgress: 311},
+		SecondHop: path.HopField{ConsIngress: 0, ConsEgress: 0},
+	}
+	ohp.FirstHop.Mac = path.MAC(mac, &ohp.Info, &ohp.FirstHop)
+
+	scionL := &slayers.SCION{
+		Version:      0,
+		TrafficClass: 0xb8,
+		FlowID:       0xdead,
+		NextHdr:      common.L4UDP,
+		PathType:     onehop.PathType,
+		SrcIA:        xtest.MustParseIA("1-ff00:0:3"),
+		DstIA:        xtest.MustParseIA("1-ff00:0:1"),
+		Path:         ohp,
+	}
+	srcA := &net.IPAddr{IP: net.ParseIP("172.16.3.1")}
+	if err := scionL.SetSrcAddr(srcA); err != nil {
+		panic(err)
+	}
+	if err := scionL.SetDstAddr(&net.IPAddr{IP: net.ParseIP("192.168.0.71")}); err != nil {
+		panic(err)
+	}
+
+	scionudp := &slayers.UDP{}
+	scionudp.SrcPort = 2345
+	scionudp.DstPort = 53
+	scionudp.SetNetworkLayerForChecksum(scionL)
+
+	payload := []byte("actualpayloadbytes")
+	pointer := slayers.CmnHdrLen + scionL.AddrHdrLen()
+
+	// Prepare input packet
+	input := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(input, options,
+		ethernet, ip, udp, scionL, scionudp, gopacket.Payload(payload),
+	); err != nil {
+		panic(err)
+	}
+
+	// Prepare want packet
+	want := gopacket.NewSerializeBuffer()
+	ethernet.SrcMAC = net.HardwareAddr{0xf0, 0x0d, 0xca, 0xfe, 0x00, 0x13}
+	ethernet.DstMAC = net.HardwareAddr{0xf0, 0x0d, 0xca, 0xfe, 0xbe, 0xef}
+	ip.SrcIP = net.IP{192, 168, 13, 2}
+	ip.DstIP = net.IP{192, 168, 13, 3}
+	udp.SrcPort, udp.DstPort = udp.DstPort, udp.SrcPort
+
+	// The SCMP message is sent on a one-hop path, on which the first hop is
+	// already processed. Timestamp, SegID and MAC are normalized.
+	scionL.DstIA = scionL.SrcIA
+	scionL.SrcIA = xtest.MustParseIA("1-ff00:0:1")
+	if err := scionL.SetDstAddr(srcA); err != nil {
+		panic(err)
+	}
+	if err := scionL.SetSrcAddr(&net.IPAddr{IP: net.IP{192, 168, 0, 11}}); err != nil {
+		panic(err)
+	}
+	scionL.Path = &onehop.Path{
+		Info: path.InfoField{ConsDir: true},
+		FirstHop: path.HopField{
+			ConsEgress: 131,
+			ExpTime:    63,
+		},
+	}
+	scionL.NextHdr = common.L4SCMP
+	scmpH := &slayers.SCMP{
+		TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+			slayers.SCMPCodeInvalidPath),
+	}
+	scmpH.SetNetworkLayerForChecksum(scionL)
+	scmpP := &slayers.SCMPParameterProblem{
+		Pointer: uint16(pointer),
+	}
+
+	// Skip Ethernet + IPv4 + UDP
+	quoteStart := 14 + 20 + 8
+	quote := input.Bytes()[quoteStart:]
+	if err := gopacket.SerializeLayers(want, options,
+		ethernet, ip, udp, scionL, scmpH, scmpP, gopacket.Payload(quote),
+	); err != nil {
+		panic(err)
+	}
+
+	return runner.Case{
+		Name:            "SCMPOneHopReversed",
+		WriteTo:         "veth_131_host",
+		ReadFrom:        "veth_131_host",
+		Input:           input.Bytes(),
+		Want:            want.Bytes(),
+		StoreDir:        filepath.Join(artifactsDir, "SCMPOneHopReversed"),
+		NormalizePacket: oneHopNormalizePacket,
+	}
+}
@@ -80,6 +80,9 @@ func realMain() int {
 	log.Info("BR V2 acceptance tests:")
 
 	multi := []runner.Case{
+		// The EPIC packet timestamp is only valid for a few seconds, the case
+		// runs first.
+		cases.SCMPEPICInvalidHVF(artifactsDir, hfMAC),
 		cases.ParentToChild(artifactsDir, hfMAC),
 		cases.ParentToInternalHost(artifactsDir, hfMAC),
 		cases.ParentToInternalHostMultiSegment(artifactsDir, hfMAC),
@@ -91,6 +94,7 @@ func realMain() int {
 		cases.InternalHostToChild(artifactsDir, hfMAC),
 		cases.InternalParentToChild(artifactsDir, hfMAC),
 		cases.SCMPDestinationUnreachable(artifactsDir, hfMAC),
+		cases.SCMPInvalidDestinationAddress(artifactsDir, hfMAC),
 		cases.SCMPBadMAC(artifactsDir, hfMAC),
 		cases.SCMPBadMACInternal(artifactsDir, hfMAC),
 		cases.SCMPExpiredHopAfterXover(artifactsDir, hfMAC),
@@ -116,6 +120,10 @@ func realMain() int {
 		cases.SCMPBadPktLen(artifactsDir, hfMAC),
 		cases.SCMPQuoteCut(artifactsDir, hfMAC),
 		cases.NoSCMPReplyForSCMPError(artifactsDir, hfMAC),
+		cases.SCMPEPICExpiredTimestamp(artifactsDir, hfMAC),
+		cases.SCMPOneHopBadMAC(artifactsDir, hfMAC),
+		cases.SCMPOneHopUnknownEgress(artifactsDir, hfMAC),
+		cases.SCMPOneHopReversed(artifactsDir, hfMAC),
 		cases.IncomingOneHop(artifactsDir, hfMAC),
 		cases.OutgoingOneHop(artifactsDir, hfMAC),
 		cases.SVC(artifactsDir, hfMAC),
@@ -21,6 +21,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/topology"
@@ -29,6 +30,39 @@ import (
 type PathReqFlags struct {
 	Refresh bool
 	Hidden  bool
+	// Policy is evaluated by the daemon, which only returns the paths that the
+	// policy accepts. If nil, all paths are returned.
+	Policy *pathpol.Policy
+	// Ordering is the criterion by which the daemon orders the paths.
+	Ordering PathOrdering
+	// MaxPaths is the maximum number of paths the daemon returns. If 0, all
+	// paths are returned.
+	MaxPaths int
+}
+
+// PathOrdering is a criterion by which the daemon orders the paths it returns.
+type PathOrdering int
+
+const (
+	// PathOrderingNone keeps the order in which the daemon found the paths.
+	PathOrderingNone PathOrdering = iota
+	// PathOrderingLatency orders the paths by their announced latency, lowest
+	// first.
+	PathOrderingLatency
+	// PathOrderingHops orders the paths by their number of hops, fewest first.
+	PathOrderingHops
+	// PathOrderingBandwidth orders the paths by their announced bottleneck
+	// bandwidth, highest first.
+	PathOrderingBandwidth
+	// PathOrderingMTU orders the paths by their MTU, largest first.
+	PathOrderingMTU
+)
+
+// PathsUpdate is an update of a paths subscription. It either contains the
+// complete set of paths, or the error that ended the subscription.
+type PathsUpdate struct {
+	Paths []snet.Path
+	Err   error
 }
 
 // ASInfo provides information about the local AS.
@@ -19,11 +19,13 @@ package daemon
 import (
 	"context"
 	"net"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/daemon/internal/metrics"
+	"github.com/scionproto/scion/go/lib/drkey"
 	libmetrics "github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -54,6 +56,7 @@ func NewService(name string) Service {
 			InterfacesRequests:         libmetrics.NewPromCounter(metrics.IFInfos.CounterVec()),
 			ServicesRequests:           libmetrics.NewPromCounter(metrics.SVCInfos.CounterVec()),
 			InterfaceDownNotifications: libmetrics.NewPromCounter(metrics.Revocations.CounterVec()),
+			DRKeyRequests:              libmetrics.NewPromCounter(metrics.DRKeys.CounterVec()),
 		},
 	}
 }
@@ -65,6 +68,13 @@ type Connector interface {
 	LocalIA(ctx context.Context) (addr.IA, error)
 	// Paths requests from the daemon a set of end to end paths between the source and destination.
 	Paths(ctx context.Context, dst, src addr.IA, f PathReqFlags) ([]snet.Path, error)
+	// WatchPaths subscribes to the end to end paths between the source and
+	// destination. The current paths are sent on the returned channel right
+	// away, and the complete set of paths is sent again whenever paths appear,
+	// expire or are revoked. Unlike the other methods, WatchPaths does not
+	// block until the subscription ends. The channel is closed when the context
+	// is canceled, or after an update with an error is sent.
+	WatchPaths(ctx context.Context, dst, src addr.IA) (<-chan PathsUpdate, error)
 	// ASInfo requests from the daemon information about AS ia, the zero IA can be
 	// use to detect the local IA.
 	ASInfo(ctx context.Context, ia addr.IA) (ASInfo, error)
@@ -78,8 +88,15 @@ type Connector interface {
 	// service types is returned. The reply is a map from service type to URI of
 	// the service.
 	SVCInfo(ctx context.Context, svcTypes []addr.HostSVC) (map[addr.HostSVC]string, error)
+	// PortRange requests from the daemon the range of ports on which end
+	// hosts in the local AS receive SCION packets directly from the border
+	// routers. Both ports are zero if the AS does not define a range.
+	PortRange(ctx context.Context) (uint16, uint16, error)
 	// RevNotification sends a RevocationInfo message to the daemon.
 	RevNotification(ctx context.Context, revInfo *path_mgmt.RevInfo) error
+	// DRKeyLvl2 requests from the daemon the DRKey level 2 key described by
+	// meta that is valid at valTime.
+	DRKeyLvl2(ctx context.Context, meta drkey.Lvl2Meta, valTime time.Time) (drkey.Lvl2Key, error)
 	// Close shuts down the connection to the daemon.
 	Close(ctx context.Context) error
 }
@@ -25,6 +25,8 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/daemon"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
 	snetpath "github.com/scionproto/scion/go/lib/snet/path"
@@ -136,6 +138,52 @@ type connector struct {
 func (c connector) Paths(_ context.Context, _, _ addr.IA,
 	flags daemon.PathReqFlags) ([]snet.Path, error) {
 
+	entry := c.entry()
+	if entry == nil {
+		return nil, serrors.New("path not found")
+	}
+	return c.adapter(entry.Paths), nil
+}
+
+// WatchPaths sends the paths of the current script entry, and checks every
+// second whether the script moved on to another entry.
+func (c connector) WatchPaths(ctx context.Context, _, _ addr.IA) (<-chan daemon.PathsUpdate,
+	error) {
+
+	updates := make(chan daemon.PathsUpdate)
+	go func() {
+		defer log.HandlePanic()
+		defer close(updates)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		var sent *Entry
+		for {
+			if entry := c.entry(); entry != sent || entry == nil {
+				update := daemon.PathsUpdate{Err: serrors.New("path not found")}
+				if entry != nil {
+					update = daemon.PathsUpdate{Paths: c.adapter(entry.Paths)}
+				}
+				select {
+				case updates <- update:
+				case <-ctx.Done():
+					return
+				}
+				if update.Err != nil {
+					return
+				}
+				sent = entry
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return updates, nil
+}
+
+func (c connector) entry() *Entry {
 	secondsElapsed := int(time.Since(c.creationTime).Seconds())
 
 	var entry *Entry
@@ -145,10 +193,7 @@ func (c connector) Paths(_ context.Context, _, _ addr.IA,
 			break
 		}
 	}
-	if entry == nil {
-		return nil, serrors.New("path not found")
-	}
-	return c.adapter(entry.Paths), nil
+	return entry
 }
 
 func (c connector) adapter(paths []*Path) []snet.Path {
@@ -173,6 +218,10 @@ func (c connector) IFInfo(ctx context.Context,
 	panic("not implemented")
 }
 
+func (c connector) PortRange(ctx context.Context) (uint16, uint16, error) {
+	panic("not implemented")
+}
+
 func (c connector) SVCInfo(ctx context.Context,
 	svcTypes []addr.HostSVC) (map[addr.HostSVC]string, error) {
 
@@ -185,6 +234,12 @@ func (c connector) RevNotification(ctx context.Context,
 	panic("not implemented")
 }
 
+func (c connector) DRKeyLvl2(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	panic("not implemented")
+}
+
 func (c connector) Close(ctx context.Context) error {
 	return nil
 }
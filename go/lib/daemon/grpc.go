@@ -16,14 +16,19 @@ package daemon
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/grpc"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -75,13 +80,29 @@ func (c grpcConn) LocalIA(ctx context.Context) (addr.IA, error) {
 func (c grpcConn) Paths(ctx context.Context, dst, src addr.IA,
 	f PathReqFlags) ([]snet.Path, error) {
 
-	client := sdpb.NewDaemonServiceClient(c.conn)
-	response, err := client.Paths(ctx, &sdpb.PathsRequest{
+	ordering, err := pathOrderingToPB(f.Ordering)
+	if err != nil {
+		c.metrics.incPaths(err)
+		return nil, err
+	}
+	req := &sdpb.PathsRequest{
 		SourceIsdAs:      uint64(src.IAInt()),
 		DestinationIsdAs: uint64(dst.IAInt()),
 		Hidden:           f.Hidden,
 		Refresh:          f.Refresh,
-	})
+		Ordering:         ordering,
+		MaxPaths:         uint32(f.MaxPaths),
+	}
+	if f.Policy != nil {
+		raw, err := json.Marshal(f.Policy)
+		if err != nil {
+			c.metrics.incPaths(err)
+			return nil, serrors.WrapStr("encoding path policy", err)
+		}
+		req.Policy = string(raw)
+	}
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	response, err := client.Paths(ctx, req)
 	if err != nil {
 		c.metrics.incPaths(err)
 		return nil, err
@@ -91,6 +112,51 @@ func (c grpcConn) Paths(ctx context.Context, dst, src addr.IA,
 	return paths, err
 }
 
+func (c grpcConn) WatchPaths(ctx context.Context, dst, src addr.IA) (<-chan PathsUpdate, error) {
+	// The stream is canceled when the subscription ends because of an error.
+	ctx, cancelF := context.WithCancel(ctx)
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	stream, err := client.WatchPaths(ctx, &sdpb.WatchPathsRequest{
+		SourceIsdAs:      uint64(src.IAInt()),
+		DestinationIsdAs: uint64(dst.IAInt()),
+	})
+	if err != nil {
+		cancelF()
+		c.metrics.incPaths(err)
+		return nil, err
+	}
+	updates := make(chan PathsUpdate)
+	go func() {
+		defer log.HandlePanic()
+		defer cancelF()
+		defer close(updates)
+		for {
+			var update PathsUpdate
+			response, err := stream.Recv()
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err == io.EOF:
+				update.Err = serrors.New("subscription ended by the daemon")
+			case err != nil:
+				update.Err = err
+			default:
+				update.Paths, update.Err = pathResponseToPaths(response.Paths, dst)
+			}
+			c.metrics.incPaths(update.Err)
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return
+			}
+			if update.Err != nil {
+				return
+			}
+		}
+	}()
+	return updates, nil
+}
+
 func (c grpcConn) ASInfo(ctx context.Context, ia addr.IA) (ASInfo, error) {
 	client := sdpb.NewDaemonServiceClient(c.conn)
 	response, err := client.AS(ctx, &sdpb.ASRequest{IsdAs: uint64(ia.IAInt())})
@@ -127,6 +193,15 @@ func (c grpcConn) IFInfo(ctx context.Context,
 	return result, nil
 }
 
+func (c grpcConn) PortRange(ctx context.Context) (uint16, uint16, error) {
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	response, err := client.PortRange(ctx, &sdpb.PortRangeRequest{})
+	if err != nil {
+		return 0, 0, err
+	}
+	return uint16(response.DispatchedPortStart), uint16(response.DispatchedPortEnd), nil
+}
+
 func (c grpcConn) SVCInfo(ctx context.Context, _ []addr.HostSVC) (map[addr.HostSVC]string, error) {
 	client := sdpb.NewDaemonServiceClient(c.conn)
 	response, err := client.Services(ctx, &sdpb.ServicesRequest{})
@@ -157,6 +232,46 @@ func (c grpcConn) RevNotification(ctx context.Context, revInfo *path_mgmt.RevInf
 
 }
 
+func (c grpcConn) DRKeyLvl2(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	key, err := c.drkeyLvl2(ctx, meta, valTime)
+	c.metrics.incDRKey(err)
+	return key, err
+}
+
+func (c grpcConn) drkeyLvl2(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	req, err := lvl2RequestToPB(meta, valTime)
+	if err != nil {
+		return drkey.Lvl2Key{}, err
+	}
+	client := sdpb.NewDaemonServiceClient(c.conn)
+	response, err := client.DRKeyLvl2(ctx, req)
+	if err != nil {
+		return drkey.Lvl2Key{}, err
+	}
+	notBefore, err := ptypes.Timestamp(response.EpochBegin)
+	if err != nil {
+		return drkey.Lvl2Key{}, serrors.WrapStr("parsing epoch begin", err)
+	}
+	notAfter, err := ptypes.Timestamp(response.EpochEnd)
+	if err != nil {
+		return drkey.Lvl2Key{}, serrors.WrapStr("parsing epoch end", err)
+	}
+	if len(response.Key) != drkey.KeyLen {
+		return drkey.Lvl2Key{}, serrors.New("invalid key length", "expected", drkey.KeyLen,
+			"actual", len(response.Key))
+	}
+	key := drkey.Lvl2Key{
+		Lvl2Meta: meta,
+		Epoch:    drkey.Epoch{NotBefore: notBefore, NotAfter: notAfter},
+	}
+	copy(key.Key[:], response.Key)
+	return key, nil
+}
+
 func (c grpcConn) Close(_ context.Context) error {
 	return c.conn.Close()
 }
@@ -226,10 +341,31 @@ func convertPath(p *sdpb.Path, dst addr.IA) (path.Path, error) {
 			LinkType:     linkType,
 			InternalHops: p.InternalHops,
 			Notes:        p.Notes,
+			EpicAuths: spath.EpicAuths{
+				AuthPHVF: p.GetEpicAuths().GetAuthPhvf(),
+				AuthLHVF: p.GetEpicAuths().GetAuthLhvf(),
+			},
 		},
 	}, nil
 }
 
+func pathOrderingToPB(ordering PathOrdering) (sdpb.PathOrdering, error) {
+	switch ordering {
+	case PathOrderingNone:
+		return sdpb.PathOrdering_PATH_ORDERING_UNSPECIFIED, nil
+	case PathOrderingLatency:
+		return sdpb.PathOrdering_PATH_ORDERING_LATENCY, nil
+	case PathOrderingHops:
+		return sdpb.PathOrdering_PATH_ORDERING_HOPS, nil
+	case PathOrderingBandwidth:
+		return sdpb.PathOrdering_PATH_ORDERING_BANDWIDTH, nil
+	case PathOrderingMTU:
+		return sdpb.PathOrdering_PATH_ORDERING_MTU, nil
+	default:
+		return 0, serrors.New("unsupported path ordering", "ordering", ordering)
+	}
+}
+
 func linkTypeFromPB(lt sdpb.LinkType) snet.LinkType {
 	switch lt {
 	case sdpb.LinkType_LINK_TYPE_DIRECT:
@@ -253,3 +389,33 @@ func topoServiceTypeToSVCAddr(st topology.ServiceType) addr.HostSVC {
 		return addr.SvcNone
 	}
 }
+
+func lvl2RequestToPB(meta drkey.Lvl2Meta, valTime time.Time) (*sdpb.DRKeyLvl2Request, error) {
+	ts, err := ptypes.TimestampProto(valTime)
+	if err != nil {
+		return nil, serrors.WrapStr("converting validity time", err)
+	}
+	req := &sdpb.DRKeyLvl2Request{
+		ValTime:  ts,
+		Protocol: meta.Protocol,
+		SrcIsdAs: uint64(meta.SrcIA.IAInt()),
+		DstIsdAs: uint64(meta.DstIA.IAInt()),
+	}
+	switch meta.KeyType {
+	case drkey.AS2AS:
+		req.KeyType = sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_AS_AS
+	case drkey.AS2Host:
+		req.KeyType = sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_AS_HOST
+	case drkey.Host2Host:
+		req.KeyType = sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_HOST_HOST
+	default:
+		return nil, serrors.New("unsupported key type", "type", meta.KeyType)
+	}
+	if meta.SrcHost != nil {
+		req.SrcHost = meta.SrcHost.IP().String()
+	}
+	if meta.DstHost != nil {
+		req.DstHost = meta.DstHost.IP().String()
+	}
+	return req, nil
+}
@@ -30,6 +30,7 @@ const (
 	subsystemIFInfo     = "if_info"
 	subsystemSVCInfo    = "service_info"
 	subsystemRevocation = "revocation"
+	subsystemDRKey      = "drkey"
 )
 
 // Result values
@@ -67,6 +68,8 @@ var (
 	SVCInfos = newSVCInfo()
 	// Conns contains metrics for connections to SCIOND.
 	Conns = newConn()
+	// DRKeys contains metrics for DRKey level 2 requests.
+	DRKeys = newDRKey()
 )
 
 // Request is the generic metric for requests.
@@ -124,3 +127,10 @@ func newIFInfo() Request {
 			"The amount of IF info requests sent.", resultLabel{}),
 	}
 }
+
+func newDRKey() Request {
+	return Request{
+		count: prom.NewCounterVecWithLabels(Namespace, subsystemDRKey, "requests_total",
+			"The amount of DRKey level 2 requests sent.", resultLabel{}),
+	}
+}
@@ -29,6 +29,7 @@ type Metrics struct {
 	InterfacesRequests         metrics.Counter
 	ServicesRequests           metrics.Counter
 	InterfaceDownNotifications metrics.Counter
+	DRKeyRequests              metrics.Counter
 }
 
 func (m Metrics) incConnects(err error)  { incMetric(m.Connects, err) }
@@ -37,6 +38,7 @@ func (m Metrics) incAS(err error)        { incMetric(m.ASRequests, err) }
 func (m Metrics) incInterface(err error) { incMetric(m.InterfacesRequests, err) }
 func (m Metrics) incServcies(err error)  { incMetric(m.ServicesRequests, err) }
 func (m Metrics) incIfDown(err error)    { incMetric(m.InterfaceDownNotifications, err) }
+func (m Metrics) incDRKey(err error)     { incMetric(m.DRKeyRequests, err) }
 
 func incMetric(c metrics.Counter, err error) {
 	if c == nil {
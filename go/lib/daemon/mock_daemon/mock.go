@@ -8,12 +8,14 @@ import (
 	context "context"
 	net "net"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	addr "github.com/scionproto/scion/go/lib/addr"
 	common "github.com/scionproto/scion/go/lib/common"
 	path_mgmt "github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	daemon "github.com/scionproto/scion/go/lib/daemon"
+	drkey "github.com/scionproto/scion/go/lib/drkey"
 	snet "github.com/scionproto/scion/go/lib/snet"
 )
 
@@ -69,6 +71,21 @@ func (mr *MockConnectorMockRecorder) Close(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockConnector)(nil).Close), arg0)
 }
 
+// DRKeyLvl2 mocks base method.
+func (m *MockConnector) DRKeyLvl2(arg0 context.Context, arg1 drkey.Lvl2Meta, arg2 time.Time) (drkey.Lvl2Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DRKeyLvl2", arg0, arg1, arg2)
+	ret0, _ := ret[0].(drkey.Lvl2Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DRKeyLvl2 indicates an expected call of DRKeyLvl2.
+func (mr *MockConnectorMockRecorder) DRKeyLvl2(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DRKeyLvl2", reflect.TypeOf((*MockConnector)(nil).DRKeyLvl2), arg0, arg1, arg2)
+}
+
 // IFInfo mocks base method.
 func (m *MockConnector) IFInfo(arg0 context.Context, arg1 []common.IFIDType) (map[common.IFIDType]*net.UDPAddr, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +131,22 @@ func (mr *MockConnectorMockRecorder) Paths(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Paths", reflect.TypeOf((*MockConnector)(nil).Paths), arg0, arg1, arg2, arg3)
 }
 
+// PortRange mocks base method.
+func (m *MockConnector) PortRange(arg0 context.Context) (uint16, uint16, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PortRange", arg0)
+	ret0, _ := ret[0].(uint16)
+	ret1, _ := ret[1].(uint16)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PortRange indicates an expected call of PortRange.
+func (mr *MockConnectorMockRecorder) PortRange(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PortRange", reflect.TypeOf((*MockConnector)(nil).PortRange), arg0)
+}
+
 // RevNotification mocks base method.
 func (m *MockConnector) RevNotification(arg0 context.Context, arg1 *path_mgmt.RevInfo) error {
 	m.ctrl.T.Helper()
@@ -142,3 +175,18 @@ func (mr *MockConnectorMockRecorder) SVCInfo(arg0, arg1 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SVCInfo", reflect.TypeOf((*MockConnector)(nil).SVCInfo), arg0, arg1)
 }
+
+// WatchPaths mocks base method.
+func (m *MockConnector) WatchPaths(arg0 context.Context, arg1, arg2 addr.IA) (<-chan daemon.PathsUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchPaths", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan daemon.PathsUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchPaths indicates an expected call of WatchPaths.
+func (mr *MockConnectorMockRecorder) WatchPaths(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchPaths", reflect.TypeOf((*MockConnector)(nil).WatchPaths), arg0, arg1, arg2)
+}
@@ -0,0 +1,229 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// DefaultRouterIdleTimeout is the default time after the last route request
+// for a destination after which the WatchingRouter ends the subscription to
+// the paths to the destination.
+const DefaultRouterIdleTimeout = 5 * time.Minute
+
+// WatchingRouter is an snet.Router that subscribes to the paths to the
+// destinations it is asked for, instead of querying the daemon for every
+// request. The first request for a destination blocks until the daemon sent
+// the paths, later requests are served from the paths the daemon pushed last.
+type WatchingRouter struct {
+	// Connector is used to subscribe to the paths.
+	Connector Connector
+	// IA is the local ISD-AS.
+	IA addr.IA
+	// IdleTimeout is the time after the last request for a destination after
+	// which the subscription is ended. If zero, DefaultRouterIdleTimeout is
+	// used.
+	IdleTimeout time.Duration
+
+	mtx     sync.Mutex
+	closed  bool
+	watches map[addr.IA]*routerWatch
+}
+
+// Route returns the first path from the local AS to dst.
+func (r *WatchingRouter) Route(ctx context.Context, dst addr.IA) (snet.Path, error) {
+	paths, err := r.AllRoutes(ctx, dst)
+	if err != nil || len(paths) == 0 {
+		return nil, err
+	}
+	return paths[0], nil
+}
+
+// AllRoutes returns the paths from the local AS to dst.
+func (r *WatchingRouter) AllRoutes(ctx context.Context, dst addr.IA) ([]snet.Path, error) {
+	w, err := r.watch(dst)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-w.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return w.get()
+}
+
+// Close ends all subscriptions. Route requests fail after the router is
+// closed.
+func (r *WatchingRouter) Close() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.closed = true
+	for _, w := range r.watches {
+		w.cancel()
+	}
+	r.watches = nil
+}
+
+// watch returns the subscription to the paths to dst. If there is none, it is
+// started.
+func (r *WatchingRouter) watch(dst addr.IA) (*routerWatch, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.closed {
+		return nil, serrors.New("router closed")
+	}
+	if w, ok := r.watches[dst]; ok {
+		w.touch()
+		return w, nil
+	}
+	if r.watches == nil {
+		r.watches = make(map[addr.IA]*routerWatch)
+	}
+	ctx, cancelF := context.WithCancel(context.Background())
+	w := &routerWatch{
+		ready:    make(chan struct{}),
+		cancel:   cancelF,
+		lastUsed: time.Now(),
+	}
+	r.watches[dst] = w
+	go func() {
+		defer log.HandlePanic()
+		r.run(ctx, dst, w)
+	}()
+	return w, nil
+}
+
+// run receives the updates of the subscription until it ends, either because
+// of an error or because the destination is idle.
+func (r *WatchingRouter) run(ctx context.Context, dst addr.IA, w *routerWatch) {
+	defer r.remove(dst, w)
+	defer w.cancel()
+
+	idleTimeout := r.IdleTimeout
+	if idleTimeout == 0 {
+		idleTimeout = DefaultRouterIdleTimeout
+	}
+	idleCheck := time.NewTicker(idleTimeout / 2)
+	defer idleCheck.Stop()
+
+	updates, err := r.Connector.WatchPaths(ctx, dst, r.IA)
+	if err != nil {
+		w.fail(serrors.WrapStr("subscribing to paths", err, "dst", dst))
+		return
+	}
+	for {
+		select {
+		case update, ok := <-updates:
+			switch {
+			case !ok:
+				w.fail(serrors.New("path subscription ended", "dst", dst))
+				return
+			case update.Err != nil:
+				w.fail(serrors.WrapStr("path subscription failed", update.Err, "dst", dst))
+				return
+			}
+			w.set(update.Paths)
+		case <-idleCheck.C:
+			if w.idle() > idleTimeout {
+				return
+			}
+		case <-ctx.Done():
+			w.fail(serrors.New("router closed"))
+			return
+		}
+	}
+}
+
+// remove removes the subscription to the paths to dst, such that the next
+// request starts a new one.
+func (r *WatchingRouter) remove(dst addr.IA, w *routerWatch) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.watches[dst] == w {
+		delete(r.watches, dst)
+	}
+}
+
+// routerWatch holds the paths of a subscription.
+type routerWatch struct {
+	// ready is closed when the first update is received.
+	ready  chan struct{}
+	cancel context.CancelFunc
+
+	mtx      sync.Mutex
+	paths    []snet.Path
+	err      error
+	lastUsed time.Time
+}
+
+func (w *routerWatch) get() ([]snet.Path, error) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if w.err != nil {
+		return nil, w.err
+	}
+	return append([]snet.Path(nil), w.paths...), nil
+}
+
+func (w *routerWatch) set(paths []snet.Path) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.paths = paths
+	w.markReady()
+}
+
+// fail makes the requests that are waiting for the first update fail. If the
+// subscription already received paths, they are served until the next request
+// starts a new subscription.
+func (w *routerWatch) fail(err error) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	select {
+	case <-w.ready:
+	default:
+		w.err = err
+		close(w.ready)
+	}
+}
+
+// markReady closes the ready channel if it is not closed yet. The caller must
+// hold mtx.
+func (w *routerWatch) markReady() {
+	select {
+	case <-w.ready:
+	default:
+		close(w.ready)
+	}
+}
+
+func (w *routerWatch) touch() {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.lastUsed = time.Now()
+}
+
+func (w *routerWatch) idle() time.Duration {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return time.Since(w.lastUsed)
+}
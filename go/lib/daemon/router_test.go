@@ -0,0 +1,157 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/daemon"
+	"github.com/scionproto/scion/go/lib/daemon/mock_daemon"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var _ snet.Router = (*daemon.WatchingRouter)(nil)
+
+func TestWatchingRouter(t *testing.T) {
+	local := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("1-ff00:0:111")
+	p1, p2 := routerTestPath(dst, 1), routerTestPath(dst, 2)
+
+	t.Run("updates", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		updates := make(chan daemon.PathsUpdate)
+		conn := mock_daemon.NewMockConnector(mctrl)
+		conn.EXPECT().WatchPaths(gomock.Any(), dst, local).Return(updates, nil)
+		r := &daemon.WatchingRouter{Connector: conn, IA: local}
+		defer r.Close()
+
+		go func() { updates <- daemon.PathsUpdate{Paths: []snet.Path{p1, p2}} }()
+		paths, err := r.AllRoutes(context.Background(), dst)
+		require.NoError(t, err)
+		assert.Equal(t, []snet.Path{p1, p2}, paths)
+
+		updates <- daemon.PathsUpdate{Paths: []snet.Path{p2}}
+		assert.Eventually(t, func() bool {
+			path, err := r.Route(context.Background(), dst)
+			return err == nil && snet.Fingerprint(path) == snet.Fingerprint(p2)
+		}, time.Second, 10*time.Millisecond)
+	})
+	t.Run("subscription error", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		conn := mock_daemon.NewMockConnector(mctrl)
+		gomock.InOrder(
+			conn.EXPECT().WatchPaths(gomock.Any(), dst, local).
+				DoAndReturn(func(context.Context, addr.IA,
+					addr.IA) (<-chan daemon.PathsUpdate, error) {
+
+					updates := make(chan daemon.PathsUpdate, 1)
+					updates <- daemon.PathsUpdate{Err: serrors.New("test")}
+					close(updates)
+					return updates, nil
+				}),
+			conn.EXPECT().WatchPaths(gomock.Any(), dst, local).
+				DoAndReturn(func(context.Context, addr.IA,
+					addr.IA) (<-chan daemon.PathsUpdate, error) {
+
+					updates := make(chan daemon.PathsUpdate, 1)
+					updates <- daemon.PathsUpdate{Paths: []snet.Path{p1}}
+					return updates, nil
+				}),
+		)
+		r := &daemon.WatchingRouter{Connector: conn, IA: local}
+		defer r.Close()
+
+		_, err := r.AllRoutes(context.Background(), dst)
+		assert.Error(t, err)
+		// The failed subscription is replaced by a new one.
+		assert.Eventually(t, func() bool {
+			paths, err := r.AllRoutes(context.Background(), dst)
+			return err == nil && len(paths) == 1
+		}, time.Second, 10*time.Millisecond)
+	})
+	t.Run("context done", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		conn := mock_daemon.NewMockConnector(mctrl)
+		conn.EXPECT().WatchPaths(gomock.Any(), dst, local).
+			Return(make(chan daemon.PathsUpdate), nil)
+		r := &daemon.WatchingRouter{Connector: conn, IA: local}
+		defer r.Close()
+
+		ctx, cancelF := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancelF()
+		_, err := r.AllRoutes(ctx, dst)
+		assert.Error(t, err)
+	})
+	t.Run("idle", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		ended := make(chan struct{})
+		conn := mock_daemon.NewMockConnector(mctrl)
+		conn.EXPECT().WatchPaths(gomock.Any(), dst, local).
+			DoAndReturn(func(ctx context.Context, _,
+				_ addr.IA) (<-chan daemon.PathsUpdate, error) {
+
+				go func() {
+					<-ctx.Done()
+					close(ended)
+				}()
+				updates := make(chan daemon.PathsUpdate, 1)
+				updates <- daemon.PathsUpdate{Paths: []snet.Path{p1}}
+				return updates, nil
+			})
+		r := &daemon.WatchingRouter{Connector: conn, IA: local, IdleTimeout: 20 * time.Millisecond}
+		defer r.Close()
+
+		_, err := r.AllRoutes(context.Background(), dst)
+		require.NoError(t, err)
+		select {
+		case <-ended:
+		case <-time.After(time.Second):
+			t.Fatal("idle subscription not ended")
+		}
+	})
+	t.Run("closed", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		r := &daemon.WatchingRouter{Connector: mock_daemon.NewMockConnector(mctrl), IA: local}
+		r.Close()
+
+		_, err := r.AllRoutes(context.Background(), dst)
+		assert.Error(t, err)
+	})
+}
+
+func routerTestPath(dst addr.IA, ifID common.IFIDType) snet.Path {
+	return path.Path{
+		Dst: dst,
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{{IA: dst, ID: ifID}},
+		},
+	}
+}
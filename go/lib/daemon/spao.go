@@ -0,0 +1,111 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// DefaultSPAOProtocol is the DRKey protocol identifier used by the
+// SPAOKeyProvider if none is configured.
+const DefaultSPAOProtocol = "spao"
+
+var _ snet.SPAOKeyProvider = (*SPAOKeyProvider)(nil)
+
+// SPAOKeyProvider provides the keys to authenticate packets with the SCION
+// packet authenticator option. It uses the DRKey host-to-host keys provided by
+// the SCION daemon. The destination of a packet is the fast side of the key,
+// such that the receiver derives the key without contacting a remote AS. The
+// keys are kept in memory until they expire.
+type SPAOKeyProvider struct {
+	// Connector is the connection to the SCION daemon.
+	Connector Connector
+	// Protocol is the DRKey protocol identifier. If it is empty,
+	// DefaultSPAOProtocol is used.
+	Protocol string
+
+	mtx   sync.Mutex
+	cache map[string]drkey.Lvl2Key
+}
+
+// SPAOKey returns the key that authenticates the packets sent from src to dst.
+func (p *SPAOKeyProvider) SPAOKey(ctx context.Context, src,
+	dst snet.SCIONAddress) ([]byte, error) {
+
+	if src.Host == nil || src.Host.IP() == nil {
+		return nil, serrors.New("source host must be an IP address", "src", src)
+	}
+	if dst.Host == nil || dst.Host.IP() == nil {
+		return nil, serrors.New("destination host must be an IP address", "dst", dst)
+	}
+	protocol := p.Protocol
+	if protocol == "" {
+		protocol = DefaultSPAOProtocol
+	}
+	meta := drkey.Lvl2Meta{
+		KeyType:  drkey.Host2Host,
+		Protocol: protocol,
+		SrcIA:    dst.IA,
+		DstIA:    src.IA,
+		SrcHost:  dst.Host,
+		DstHost:  src.Host,
+	}
+	cacheKey := fmt.Sprintf("%s %s %s %s", dst.IA, dst.Host, src.IA, src.Host)
+	now := time.Now()
+	if key, ok := p.cached(cacheKey, now); ok {
+		return key.Key[:], nil
+	}
+	key, err := p.Connector.DRKeyLvl2(ctx, meta, now)
+	if err != nil {
+		return nil, serrors.WrapStr("requesting DRKey", err)
+	}
+	if !key.Epoch.Contains(now) {
+		return nil, serrors.New("DRKey not valid", "epoch", key.Epoch)
+	}
+	p.store(cacheKey, key)
+	return key.Key[:], nil
+}
+
+func (p *SPAOKeyProvider) cached(cacheKey string, now time.Time) (drkey.Lvl2Key, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	key, ok := p.cache[cacheKey]
+	if !ok || !key.Epoch.Contains(now) {
+		return drkey.Lvl2Key{}, false
+	}
+	return key, true
+}
+
+func (p *SPAOKeyProvider) store(cacheKey string, key drkey.Lvl2Key) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.cache == nil {
+		p.cache = make(map[string]drkey.Lvl2Key)
+	}
+	now := time.Now()
+	for k, cached := range p.cache {
+		if !now.Before(cached.Epoch.NotAfter) {
+			delete(p.cache, k)
+		}
+	}
+	p.cache[cacheKey] = key
+}
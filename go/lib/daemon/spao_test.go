@@ -0,0 +1,96 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/daemon"
+	"github.com/scionproto/scion/go/lib/daemon/mock_daemon"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestSPAOKeyProviderSPAOKey(t *testing.T) {
+	src := snet.SCIONAddress{
+		IA:   xtest.MustParseIA("1-ff00:0:110"),
+		Host: addr.HostFromIP(net.IP{127, 0, 0, 1}),
+	}
+	dst := snet.SCIONAddress{
+		IA:   xtest.MustParseIA("1-ff00:0:111"),
+		Host: addr.HostFromIP(net.IP{127, 0, 0, 2}),
+	}
+	meta := drkey.Lvl2Meta{
+		KeyType:  drkey.Host2Host,
+		Protocol: daemon.DefaultSPAOProtocol,
+		SrcIA:    dst.IA,
+		DstIA:    src.IA,
+		SrcHost:  dst.Host,
+		DstHost:  src.Host,
+	}
+
+	t.Run("cached", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		conn := mock_daemon.NewMockConnector(mctrl)
+		conn.EXPECT().DRKeyLvl2(gomock.Any(), meta, gomock.Any()).DoAndReturn(
+			func(_ context.Context, meta drkey.Lvl2Meta,
+				valTime time.Time) (drkey.Lvl2Key, error) {
+
+				return drkey.Lvl2Key{
+					Lvl2Meta: meta,
+					Epoch:    drkey.NewEpoch(valTime, time.Hour),
+					Key:      drkey.Key{1, 2, 3},
+				}, nil
+			},
+		)
+		p := &daemon.SPAOKeyProvider{Connector: conn}
+		key, err := p.SPAOKey(context.Background(), src, dst)
+		require.NoError(t, err)
+		expected := drkey.Key{1, 2, 3}
+		assert.Equal(t, expected[:], key)
+		again, err := p.SPAOKey(context.Background(), src, dst)
+		require.NoError(t, err)
+		assert.Equal(t, key, again)
+	})
+	t.Run("daemon error", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		conn := mock_daemon.NewMockConnector(mctrl)
+		conn.EXPECT().DRKeyLvl2(gomock.Any(), meta, gomock.Any()).Return(
+			drkey.Lvl2Key{}, serrors.New("internal"))
+		p := &daemon.SPAOKeyProvider{Connector: conn}
+		_, err := p.SPAOKey(context.Background(), src, dst)
+		assert.Error(t, err)
+	})
+	t.Run("SVC destination", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		p := &daemon.SPAOKeyProvider{Connector: mock_daemon.NewMockConnector(mctrl)}
+		_, err := p.SPAOKey(context.Background(), src,
+			snet.SCIONAddress{IA: dst.IA, Host: addr.SvcCS})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var svSalt = []byte("Derive DRKey Key")
+
+// DeriveSV derives the secret value for the epoch from the AS master key.
+func DeriveSV(masterKey []byte, epoch Epoch) (SV, error) {
+	if len(masterKey) == 0 {
+		return SV{}, serrors.New("empty master key")
+	}
+	key := pbkdf2.Key(masterKey, svSalt, 1000, KeyLen, sha256.New)
+	input := make([]byte, 16)
+	binary.BigEndian.PutUint64(input[:8], uint64(epoch.NotBefore.Unix()))
+	binary.BigEndian.PutUint64(input[8:], uint64(epoch.NotAfter.Unix()))
+	k, err := prf(key, input)
+	if err != nil {
+		return SV{}, err
+	}
+	return SV{Epoch: epoch, Key: k}, nil
+}
+
+// DeriveLvl1 derives the level 1 key K_{srcIA->dstIA} from the secret value of
+// srcIA.
+func DeriveLvl1(sv SV, meta Lvl1Meta) (Lvl1Key, error) {
+	input := make([]byte, addr.IABytes)
+	meta.DstIA.Write(input)
+	k, err := prf(sv.Key[:], input)
+	if err != nil {
+		return Lvl1Key{}, err
+	}
+	return Lvl1Key{Lvl1Meta: meta, Epoch: sv.Epoch, Key: k}, nil
+}
+
+// DeriveLvl2 derives the level 2 key described by meta from the level 1 key.
+// The level 1 key must match the ASes in meta.
+func DeriveLvl2(lvl1 Lvl1Key, meta Lvl2Meta) (Lvl2Key, error) {
+	if !lvl1.SrcIA.Equal(meta.SrcIA) || !lvl1.DstIA.Equal(meta.DstIA) {
+		return Lvl2Key{}, serrors.New("level 1 key does not match",
+			"lvl1_src", lvl1.SrcIA, "lvl1_dst", lvl1.DstIA,
+			"src", meta.SrcIA, "dst", meta.DstIA)
+	}
+	if len(meta.Protocol) == 0 || len(meta.Protocol) > 255 {
+		return Lvl2Key{}, serrors.New("invalid protocol length", "len", len(meta.Protocol))
+	}
+	var k Key
+	var err error
+	switch meta.KeyType {
+	case AS2AS:
+		k, err = prf(lvl1.Key[:], lvl2Input(AS2AS, meta.Protocol, nil))
+	case AS2Host:
+		if meta.DstHost == nil {
+			return Lvl2Key{}, serrors.New("destination host missing")
+		}
+		k, err = prf(lvl1.Key[:], lvl2Input(AS2Host, meta.Protocol, meta.DstHost))
+	case Host2Host:
+		if meta.SrcHost == nil || meta.DstHost == nil {
+			return Lvl2Key{}, serrors.New("host missing",
+				"src_host", meta.SrcHost, "dst_host", meta.DstHost)
+		}
+		// K_{A:H_A->B:H_B} is derived from K_{A->B:H_B}.
+		if k, err = prf(lvl1.Key[:], lvl2Input(AS2Host, meta.Protocol, meta.DstHost)); err != nil {
+			break
+		}
+		k, err = prf(k[:], lvl2Input(Host2Host, meta.Protocol, meta.SrcHost))
+	default:
+		return Lvl2Key{}, serrors.New("unknown key type", "type", meta.KeyType)
+	}
+	if err != nil {
+		return Lvl2Key{}, err
+	}
+	return Lvl2Key{Lvl2Meta: meta, Epoch: lvl1.Epoch, Key: k}, nil
+}
+
+// lvl2Input returns the PRF input: type (1B) | len(protocol) (1B) | protocol |
+// host type (1B) | host.
+func lvl2Input(t Lvl2KeyType, protocol string, host addr.HostAddr) []byte {
+	input := append([]byte{byte(t), byte(len(protocol))}, protocol...)
+	if host != nil {
+		input = append(input, byte(host.Type()))
+		input = append(input, host.Pack()...)
+	}
+	return input
+}
+
+func prf(key, input []byte) (Key, error) {
+	mac, err := scrypto.InitMac(key)
+	if err != nil {
+		return Key{}, err
+	}
+	mac.Write(input)
+	var k Key
+	copy(k[:], mac.Sum(nil))
+	return k, nil
+}
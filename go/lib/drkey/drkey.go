@@ -0,0 +1,150 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drkey implements the dynamically recreatable keys (DRKey) key
+// hierarchy.
+//
+// Every AS derives a secret value (SV) per epoch from its master key. From the
+// secret value, the AS derives a level 1 key for every other AS on the fly.
+// The level 1 key K_{A->B} is derived by AS A (the fast side) and is fetched by
+// the control service of AS B (the slow side). Level 2 keys are derived from
+// level 1 keys for a specific protocol, and optionally for specific hosts:
+//
+//   - AS-to-AS:     K_{A->B}^p
+//   - AS-to-host:   K_{A->B:H_B}^p
+//   - host-to-host: K_{A:H_A->B:H_B}^p
+//
+// All derivations use AES-CMAC as pseudo-random function.
+package drkey
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+)
+
+// KeyLen is the length of all DRKeys in bytes.
+const KeyLen = 16
+
+// Key is a DRKey of any level.
+type Key [KeyLen]byte
+
+// String does not print the key material to avoid leaking it in logs.
+func (Key) String() string {
+	return "[redacted key]"
+}
+
+// Epoch is the validity period of a DRKey. The beginning of the period is
+// inclusive, the end is exclusive.
+type Epoch struct {
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// NewEpoch returns the epoch of the given duration that contains t. Epochs
+// are aligned to the UNIX epoch, i.e., all ASes with the same epoch duration
+// use the same epoch boundaries.
+func NewEpoch(t time.Time, duration time.Duration) Epoch {
+	begin := time.Unix(0, 0).Add(t.Sub(time.Unix(0, 0)).Truncate(duration))
+	return Epoch{
+		NotBefore: begin.UTC(),
+		NotAfter:  begin.Add(duration).UTC(),
+	}
+}
+
+// Contains indicates whether t is within the epoch.
+func (e Epoch) Contains(t time.Time) bool {
+	return !t.Before(e.NotBefore) && t.Before(e.NotAfter)
+}
+
+func (e Epoch) String() string {
+	return fmt.Sprintf("[%s, %s)", e.NotBefore.Format(time.RFC3339),
+		e.NotAfter.Format(time.RFC3339))
+}
+
+// SV is the secret value of an AS for a given epoch.
+type SV struct {
+	Epoch Epoch
+	Key   Key
+}
+
+// Lvl1Meta identifies a level 1 key.
+type Lvl1Meta struct {
+	// SrcIA is the AS that derives the key from its secret value.
+	SrcIA addr.IA
+	// DstIA is the AS the key is derived for.
+	DstIA addr.IA
+}
+
+// Lvl1Key is the level 1 key K_{SrcIA->DstIA}.
+type Lvl1Key struct {
+	Lvl1Meta
+	Epoch Epoch
+	Key   Key
+}
+
+// Lvl2KeyType is the type of a level 2 key.
+type Lvl2KeyType uint8
+
+const (
+	// AS2AS is the type of the key K_{A->B}^p.
+	AS2AS Lvl2KeyType = iota
+	// AS2Host is the type of the key K_{A->B:H_B}^p.
+	AS2Host
+	// Host2Host is the type of the key K_{A:H_A->B:H_B}^p.
+	Host2Host
+)
+
+func (t Lvl2KeyType) String() string {
+	switch t {
+	case AS2AS:
+		return "as_as"
+	case AS2Host:
+		return "as_host"
+	case Host2Host:
+		return "host_host"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(t))
+	}
+}
+
+// Lvl2Meta identifies a level 2 key.
+type Lvl2Meta struct {
+	KeyType Lvl2KeyType
+	// Protocol is the protocol the key is derived for.
+	Protocol string
+	// SrcIA is the fast side of the key, i.e., the AS whose secret value the
+	// key is derived from.
+	SrcIA addr.IA
+	// DstIA is the slow side of the key.
+	DstIA addr.IA
+	// SrcHost is the host in SrcIA. It is only set for host-to-host keys.
+	SrcHost addr.HostAddr
+	// DstHost is the host in DstIA. It is not set for AS-to-AS keys.
+	DstHost addr.HostAddr
+}
+
+// Lvl1 returns the metadata of the level 1 key the level 2 key is derived
+// from.
+func (m Lvl2Meta) Lvl1() Lvl1Meta {
+	return Lvl1Meta{SrcIA: m.SrcIA, DstIA: m.DstIA}
+}
+
+// Lvl2Key is a level 2 key.
+type Lvl2Key struct {
+	Lvl2Meta
+	Epoch Epoch
+	Key   Key
+}
@@ -0,0 +1,142 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestNewEpoch(t *testing.T) {
+	now := time.Date(2021, 6, 15, 13, 37, 0, 0, time.UTC)
+	epoch := drkey.NewEpoch(now, 24*time.Hour)
+	assert.Equal(t, time.Date(2021, 6, 15, 0, 0, 0, 0, time.UTC), epoch.NotBefore)
+	assert.Equal(t, time.Date(2021, 6, 16, 0, 0, 0, 0, time.UTC), epoch.NotAfter)
+	assert.True(t, epoch.Contains(now))
+	assert.True(t, epoch.Contains(epoch.NotBefore))
+	assert.False(t, epoch.Contains(epoch.NotAfter))
+	assert.Equal(t, epoch, drkey.NewEpoch(epoch.NotBefore, 24*time.Hour))
+}
+
+func TestDeriveSV(t *testing.T) {
+	now := time.Now()
+	epoch := drkey.NewEpoch(now, time.Hour)
+	sv, err := drkey.DeriveSV([]byte("master key"), epoch)
+	require.NoError(t, err)
+	assert.Equal(t, epoch, sv.Epoch)
+
+	again, err := drkey.DeriveSV([]byte("master key"), epoch)
+	require.NoError(t, err)
+	assert.Equal(t, sv, again)
+
+	next, err := drkey.DeriveSV([]byte("master key"), drkey.NewEpoch(epoch.NotAfter, time.Hour))
+	require.NoError(t, err)
+	assert.NotEqual(t, sv.Key, next.Key)
+
+	other, err := drkey.DeriveSV([]byte("other key"), epoch)
+	require.NoError(t, err)
+	assert.NotEqual(t, sv.Key, other.Key)
+
+	_, err = drkey.DeriveSV(nil, epoch)
+	assert.Error(t, err)
+}
+
+func TestDeriveLvl2(t *testing.T) {
+	srcIA, dstIA := xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("1-ff00:0:111")
+	srcHost := addr.HostFromIP(net.ParseIP("10.0.0.1"))
+	dstHost := addr.HostFromIP(net.ParseIP("10.0.0.2"))
+
+	sv, err := drkey.DeriveSV([]byte("master key"), drkey.NewEpoch(time.Now(), time.Hour))
+	require.NoError(t, err)
+	lvl1, err := drkey.DeriveLvl1(sv, drkey.Lvl1Meta{SrcIA: srcIA, DstIA: dstIA})
+	require.NoError(t, err)
+	assert.Equal(t, sv.Epoch, lvl1.Epoch)
+	otherLvl1, err := drkey.DeriveLvl1(sv, drkey.Lvl1Meta{SrcIA: srcIA, DstIA: srcIA})
+	require.NoError(t, err)
+	assert.NotEqual(t, lvl1.Key, otherLvl1.Key)
+
+	meta := drkey.Lvl2Meta{
+		Protocol: "dns",
+		SrcIA:    srcIA,
+		DstIA:    dstIA,
+		SrcHost:  srcHost,
+		DstHost:  dstHost,
+	}
+	keys := make(map[drkey.Key]drkey.Lvl2KeyType)
+	for _, keyType := range []drkey.Lvl2KeyType{drkey.AS2AS, drkey.AS2Host, drkey.Host2Host} {
+		m := meta
+		m.KeyType = keyType
+		key, err := drkey.DeriveLvl2(lvl1, m)
+		require.NoError(t, err)
+		assert.Equal(t, m, key.Lvl2Meta)
+		assert.Equal(t, lvl1.Epoch, key.Epoch)
+		again, err := drkey.DeriveLvl2(lvl1, m)
+		require.NoError(t, err)
+		assert.Equal(t, key, again)
+		keys[key.Key] = keyType
+	}
+	assert.Len(t, keys, 3, "keys of different types must differ")
+
+	t.Run("host to host keys depend on hosts", func(t *testing.T) {
+		m := meta
+		m.KeyType = drkey.Host2Host
+		key, err := drkey.DeriveLvl2(lvl1, m)
+		require.NoError(t, err)
+		m.SrcHost = addr.HostFromIP(net.ParseIP("10.0.0.3"))
+		other, err := drkey.DeriveLvl2(lvl1, m)
+		require.NoError(t, err)
+		assert.NotEqual(t, key.Key, other.Key)
+	})
+	t.Run("protocol is part of the derivation", func(t *testing.T) {
+		m := meta
+		key, err := drkey.DeriveLvl2(lvl1, m)
+		require.NoError(t, err)
+		m.Protocol = "ntp"
+		other, err := drkey.DeriveLvl2(lvl1, m)
+		require.NoError(t, err)
+		assert.NotEqual(t, key.Key, other.Key)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		tests := map[string]func(m *drkey.Lvl2Meta){
+			"mismatching lvl1": func(m *drkey.Lvl2Meta) { m.DstIA = srcIA },
+			"no protocol":      func(m *drkey.Lvl2Meta) { m.Protocol = "" },
+			"no dst host": func(m *drkey.Lvl2Meta) {
+				m.KeyType = drkey.AS2Host
+				m.DstHost = nil
+			},
+			"no src host": func(m *drkey.Lvl2Meta) {
+				m.KeyType = drkey.Host2Host
+				m.SrcHost = nil
+			},
+			"unknown type": func(m *drkey.Lvl2Meta) { m.KeyType = 42 },
+		}
+		for name, modify := range tests {
+			t.Run(name, func(t *testing.T) {
+				m := meta
+				modify(&m)
+				_, err := drkey.DeriveLvl2(lvl1, m)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
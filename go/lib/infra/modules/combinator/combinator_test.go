@@ -25,10 +25,12 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/ctrl/seg/extensions/epic"
 	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/slayers/path"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
@@ -541,6 +543,82 @@ func TestComputePath(t *testing.T) {
 		})
 	}
 }
+
+func TestEpicAuths(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+
+	// withEpic adds the detached EPIC extension to all AS entries of the
+	// segment.
+	withEpic := func(ps *seg.PathSegment) *seg.PathSegment {
+		for i := range ps.ASEntries {
+			ps.ASEntries[i].UnsignedExtensions.EpicDetached = &epic.Detached{
+				AuthHopEntry: bytes.Repeat([]byte{byte(i + 1)}, epic.AuthLen),
+			}
+		}
+		return ps
+	}
+	// auth returns the expected EPIC authenticator of the hop entry.
+	auth := func(ps *seg.PathSegment, idx int) []byte {
+		entry := ps.ASEntries[idx]
+		return append(append([]byte(nil), entry.HopEntry.HopField.MAC...),
+			entry.UnsignedExtensions.EpicDetached.AuthHopEntry...)
+	}
+
+	up := withEpic(g.Beacon([]common.IFIDType{graph.If_130_A_131_X}))
+	core := withEpic(g.Beacon([]common.IFIDType{graph.If_120_A_130_B}))
+	down := withEpic(g.Beacon([]common.IFIDType{graph.If_120_X_111_B}))
+	plainDown := g.Beacon([]common.IFIDType{graph.If_120_X_111_B})
+
+	testCases := map[string]struct {
+		SrcIA    addr.IA
+		DstIA    addr.IA
+		Ups      []*seg.PathSegment
+		Cores    []*seg.PathSegment
+		Downs    []*seg.PathSegment
+		Expected spath.EpicAuths
+	}{
+		"up core down": {
+			SrcIA: xtest.MustParseIA("1-ff00:0:131"),
+			DstIA: xtest.MustParseIA("1-ff00:0:111"),
+			Ups:   []*seg.PathSegment{up},
+			Cores: []*seg.PathSegment{core},
+			Downs: []*seg.PathSegment{down},
+			Expected: spath.EpicAuths{
+				AuthPHVF: auth(down, 0),
+				AuthLHVF: auth(down, 1),
+			},
+		},
+		"up only": {
+			SrcIA: xtest.MustParseIA("1-ff00:0:131"),
+			DstIA: xtest.MustParseIA("1-ff00:0:130"),
+			Ups:   []*seg.PathSegment{up},
+			Expected: spath.EpicAuths{
+				AuthPHVF: auth(up, 1),
+				AuthLHVF: auth(up, 0),
+			},
+		},
+		"last segment without extension": {
+			SrcIA: xtest.MustParseIA("1-ff00:0:131"),
+			DstIA: xtest.MustParseIA("1-ff00:0:111"),
+			Ups:   []*seg.PathSegment{up},
+			Cores: []*seg.PathSegment{core},
+			Downs: []*seg.PathSegment{plainDown},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			result := combinator.Combine(tc.SrcIA, tc.DstIA, tc.Ups, tc.Cores, tc.Downs, false)
+			require.Len(t, result, 1)
+			assert.Equal(t, tc.Expected, result[0].Metadata.EpicAuths)
+			assert.Equal(t, tc.Expected.AuthPHVF != nil,
+				result[0].Metadata.EpicAuths.SupportsEpic())
+		})
+	}
+}
+
 func TestFilterDuplicates(t *testing.T) {
 	// Define three different path interface sequences for the test cases below.
 	// These look somewhat valid, but that doesn't matter at all -- we only look
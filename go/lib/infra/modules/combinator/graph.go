@@ -24,6 +24,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/ctrl/seg/extensions/epic"
 	"github.com/scionproto/scion/go/lib/slayers/path"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -302,6 +303,7 @@ func (solution *pathSolution) Path() Path {
 		var hops []*path.HopField
 		var intfs []snet.PathInterface
 		var pathASEntries []seg.ASEntry // ASEntries that on the path, eventually in path order.
+		var epicAuths [][]byte          // EPIC authenticators of the hop fields, nil if missing.
 
 		// Go through each ASEntry, starting from the last one, until we
 		// find a shortcut (which can be 0, meaning the end of the segment).
@@ -313,6 +315,7 @@ func (solution *pathSolution) Path() Path {
 
 			var hopField *path.HopField
 			var forwardingLinkMtu int
+			var epicAuth []byte
 			if !isPeer {
 				// Regular hop field.
 				entry := asEntry.HopEntry
@@ -323,6 +326,7 @@ func (solution *pathSolution) Path() Path {
 					Mac:         append([]byte(nil), entry.HopField.MAC...),
 				}
 				forwardingLinkMtu = entry.IngressMTU
+				epicAuth = epicAuthenticator(hopField, asEntry.UnsignedExtensions.EpicDetached, 0)
 			} else {
 				// We've reached the ASEntry where we want to switch
 				// segments on a peering link.
@@ -334,6 +338,8 @@ func (solution *pathSolution) Path() Path {
 					Mac:         append([]byte(nil), peer.HopField.MAC...),
 				}
 				forwardingLinkMtu = peer.PeerMTU
+				epicAuth = epicAuthenticator(hopField, asEntry.UnsignedExtensions.EpicDetached,
+					solEdge.edge.Peer)
 			}
 
 			// Segment is traversed in reverse construction direction.
@@ -353,6 +359,7 @@ func (solution *pathSolution) Path() Path {
 			}
 			hops = append(hops, hopField)
 			pathASEntries = append(pathASEntries, asEntry)
+			epicAuths = append(epicAuths, epicAuth)
 
 			mtu = minUint16(mtu, uint16(asEntry.MTU))
 			if forwardingLinkMtu != 0 {
@@ -365,6 +372,7 @@ func (solution *pathSolution) Path() Path {
 			reverseHops(hops)
 			reverseIntfs(intfs)
 			reverseASEntries(pathASEntries)
+			reverseEpicAuths(epicAuths)
 		}
 
 		segments = append(segments, segment{
@@ -377,6 +385,7 @@ func (solution *pathSolution) Path() Path {
 			HopFields:  hops,
 			Interfaces: intfs,
 			ASEntries:  pathASEntries,
+			EpicAuths:  epicAuths,
 		})
 	}
 
@@ -396,6 +405,7 @@ func (solution *pathSolution) Path() Path {
 			LinkType:     staticInfo.LinkType,
 			InternalHops: staticInfo.InternalHops,
 			Notes:        staticInfo.Notes,
+			EpicAuths:    segments.EpicAuths(),
 		},
 		Weight: solution.cost,
 	}
@@ -419,6 +429,36 @@ func reverseASEntries(s []seg.ASEntry) {
 	}
 }
 
+func reverseEpicAuths(s [][]byte) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// epicAuthenticator returns the EPIC authenticator of the hop field, i.e., the
+// full MAC consisting of the MAC in the hop field and the remaining bytes in
+// the detached EPIC extension of the AS entry. Peer is the index + 1 of the
+// peer entry the hop field belongs to, or 0 for the hop entry. It returns nil
+// if the authenticator is not available.
+func epicAuthenticator(hopField *path.HopField, detached *epic.Detached, peer int) []byte {
+	if detached == nil {
+		return nil
+	}
+	remaining := detached.AuthHopEntry
+	if peer != 0 {
+		if peer > len(detached.AuthPeerEntries) {
+			return nil
+		}
+		remaining = detached.AuthPeerEntries[peer-1]
+	}
+	if len(hopField.Mac) != path.MacLen || len(remaining) != epic.AuthLen {
+		return nil
+	}
+	auth := make([]byte, 0, path.MacLen+epic.AuthLen)
+	auth = append(auth, hopField.Mac...)
+	return append(auth, remaining...)
+}
+
 func calculateBeta(se *solutionEdge) uint16 {
 	var index int
 	if se.segment.IsDownSeg() {
@@ -525,6 +565,9 @@ type segment struct {
 	HopFields  []*path.HopField
 	Interfaces []snet.PathInterface
 	ASEntries  []seg.ASEntry
+	// EpicAuths contains the EPIC authenticators of the hop fields. An entry
+	// is nil if the authenticator of the hop field is not available.
+	EpicAuths [][]byte
 }
 
 func (segment *segment) ComputeExpTime() time.Time {
@@ -568,6 +611,26 @@ func (s segmentList) ASEntries() []seg.ASEntry {
 	return asEntries
 }
 
+// EpicAuths returns the EPIC authenticators of the penultimate and the last hop
+// field of the path. They are empty unless both authenticators are available.
+func (s segmentList) EpicAuths() spath.EpicAuths {
+	var auths [][]byte
+	for _, seg := range s {
+		auths = append(auths, seg.EpicAuths...)
+	}
+	if len(auths) < 2 {
+		return spath.EpicAuths{}
+	}
+	phvf, lhvf := auths[len(auths)-2], auths[len(auths)-1]
+	if phvf == nil || lhvf == nil {
+		return spath.EpicAuths{}
+	}
+	return spath.EpicAuths{
+		AuthPHVF: phvf,
+		AuthLHVF: lhvf,
+	}
+}
+
 func (s segmentList) ComputeExpTime() time.Time {
 	minTimestamp := spath.MaxExpirationTime
 	for _, segment := range s {
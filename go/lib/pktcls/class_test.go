@@ -69,6 +69,28 @@ func TestClassMapMarshalUnMarshal(t *testing.T) {
 						pktcls.NewCondPorts(&pktcls.PortMatchSource{MinPort: 1, MaxPort: 10}),
 					),
 				),
+				"dual-stack": pktcls.NewClass(
+					"dual-stack",
+					pktcls.NewCondAnyOf(
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{
+							Net: &net.IPNet{
+								IP:   net.ParseIP("2001:db8::"),
+								Mask: net.CIDRMask(32, 128),
+							},
+						}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{
+							Net: &net.IPNet{
+								IP:   net.ParseIP("fd00::"),
+								Mask: net.CIDRMask(8, 128),
+							},
+						}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb8}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchDSCP{DSCP: 0x2e}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0x12345}),
+						pktcls.NewCondIPv6(&pktcls.IPv6MatchProtocol{Protocol: 58}),
+						pktcls.NewCondIPv4(&pktcls.IPv4MatchProtocol{Protocol: 253}),
+					),
+				),
 				"classC": pktcls.NewClass(
 					"classC",
 					pktcls.NewCondAllOf(),
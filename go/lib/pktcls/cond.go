@@ -226,6 +226,55 @@ func (c *CondIPv4) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+var _ Cond = (*CondIPv6)(nil)
+
+// CondIPv6 conditions return true if the embedded IPv6 predicate returns true.
+type CondIPv6 struct {
+	Predicate IPv6Predicate
+}
+
+func NewCondIPv6(p IPv6Predicate) *CondIPv6 {
+	return &CondIPv6{Predicate: p}
+}
+
+func (c *CondIPv6) Eval(v gopacket.Layer) bool {
+	if c.Predicate == nil || v == nil {
+		return false
+	}
+	t := v.LayerType()
+	if t != layers.LayerTypeIPv6 {
+		return false
+	}
+
+	p, ok := v.(*layers.IPv6)
+	if !ok {
+		return false
+	}
+
+	return c.Predicate.Eval(p)
+}
+
+func (c *CondIPv6) Type() string {
+	return TypeCondIPv6
+}
+
+func (c *CondIPv6) String() string {
+	if c.Predicate == nil {
+		return "<nil>"
+	}
+	return c.Predicate.String()
+}
+
+func (c *CondIPv6) MarshalJSON() ([]byte, error) {
+	return marshalInterface(c.Predicate)
+}
+
+func (c *CondIPv6) UnmarshalJSON(b []byte) error {
+	var err error
+	c.Predicate, err = unmarshalIPv6Predicate(b)
+	return err
+}
+
 var _ Cond = (*CondPorts)(nil)
 
 // CondPorts conditions return true if the embedded port predicate returns true.
@@ -243,19 +292,26 @@ func (c *CondPorts) Eval(v gopacket.Layer) bool {
 	}
 	// Port predicates are independent on particular L3 or L4 protocol.
 	// Here we extract the ports and pass them to the embedded predicate.
-	l3 := v.LayerType()
-	if l3 != layers.LayerTypeIPv4 {
-		return false
-	}
-	ipv4, ok := v.(*layers.IPv4)
-	if !ok {
+	var l4 gopacket.LayerType
+	var payload []byte
+	switch l3 := v.(type) {
+	case *layers.IPv4:
+		l4, payload = l3.NextLayerType(), l3.LayerPayload()
+	case *layers.IPv6:
+		var protocol layers.IPProtocol
+		protocol, payload = ipv6UpperLayer(l3)
+		if payload == nil {
+			return false
+		}
+		l4 = protocol.LayerType()
+	default:
 		return false
 	}
 
-	switch ipv4.NextLayerType() {
+	switch l4 {
 	case layers.LayerTypeUDP:
 		udp := &layers.UDP{}
-		err := udp.DecodeFromBytes(ipv4.LayerPayload(), gopacket.NilDecodeFeedback)
+		err := udp.DecodeFromBytes(payload, gopacket.NilDecodeFeedback)
 		if err != nil {
 			return false
 		}
@@ -265,7 +321,7 @@ func (c *CondPorts) Eval(v gopacket.Layer) bool {
 		})
 	case layers.LayerTypeTCP:
 		tcp := &layers.TCP{}
-		err := tcp.DecodeFromBytes(ipv4.LayerPayload(), gopacket.NilDecodeFeedback)
+		err := tcp.DecodeFromBytes(payload, gopacket.NilDecodeFeedback)
 		if err != nil {
 			return false
 		}
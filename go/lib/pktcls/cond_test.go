@@ -155,6 +155,117 @@ func TestIPCond(t *testing.T) {
 			},
 			ExpEval: false,
 		},
+		{
+			Name: "Do not match IPv4 predicate on IPv6 packet",
+			Cond: pktcls.NewCondIPv4(
+				&pktcls.IPv4MatchProtocol{
+					Protocol: 6,
+				},
+			),
+			Packet: &layers.IPv6{
+				NextHeader: 6,
+			},
+			ExpEval: false,
+		},
+		{
+			Name: "Match IPv6 source and destination",
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondIPv6(
+					&pktcls.IPv6MatchSource{
+						Net: &net.IPNet{
+							IP:   net.ParseIP("2001:db8::"),
+							Mask: net.CIDRMask(32, 128),
+						},
+					},
+				),
+				pktcls.NewCondIPv6(
+					&pktcls.IPv6MatchDestination{
+						Net: &net.IPNet{
+							IP:   net.ParseIP("fd00::"),
+							Mask: net.CIDRMask(8, 128),
+						},
+					},
+				),
+			),
+			Packet: &layers.IPv6{
+				SrcIP: net.ParseIP("2001:db8::1"),
+				DstIP: net.ParseIP("fd12::2"),
+			},
+			ExpEval: true,
+		},
+		{
+			Name: "Do not match IPv6 predicate on IPv4 packet",
+			Cond: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchSource{
+					Net: &net.IPNet{
+						IP:   net.IPv6zero,
+						Mask: net.CIDRMask(0, 128),
+					},
+				},
+			),
+			Packet: &layers.IPv4{
+				SrcIP: net.IP{192, 168, 1, 1},
+			},
+			ExpEval: false,
+		},
+		{
+			Name: "Match IPv6 DSCP and traffic class",
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchDSCP{DSCP: 0x2e}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: 0xb9}),
+			),
+			Packet: &layers.IPv6{
+				TrafficClass: 0xb9,
+			},
+			ExpEval: true,
+		},
+		{
+			Name: "Match IPv6 flow label",
+			Cond: pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0x12345}),
+			Packet: &layers.IPv6{
+				FlowLabel: 0x12345,
+			},
+			ExpEval: true,
+		},
+		{
+			Name: "Do not match IPv6 flow label",
+			Cond: pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0x12345}),
+			Packet: &layers.IPv6{
+				FlowLabel: 0x12346,
+			},
+			ExpEval: false,
+		},
+		{
+			Name: "Match IPv6 protocol",
+			Cond: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchProtocol{Protocol: uint8(layers.IPProtocolICMPv6)},
+			),
+			Packet: &layers.IPv6{
+				NextHeader: layers.IPProtocolICMPv6,
+			},
+			ExpEval: true,
+		},
+		{
+			Name: "Match IPv6 protocol behind extension headers",
+			Cond: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchProtocol{Protocol: uint8(layers.IPProtocolTCP)},
+			),
+			Packet: createIPv6Packet(layers.IPProtocolTCP, []byte{
+				// Destination options header, 8 bytes.
+				byte(layers.IPProtocolTCP), 0, 1, 4, 0, 0, 0, 0,
+			}),
+			ExpEval: true,
+		},
+		{
+			Name: "Do not match extension header as IPv6 protocol",
+			Cond: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchProtocol{Protocol: uint8(layers.IPProtocolIPv6Destination)},
+			),
+			Packet: createIPv6Packet(layers.IPProtocolTCP, []byte{
+				byte(layers.IPProtocolTCP), 0, 1, 4, 0, 0, 0, 0,
+			}),
+			ExpEval: false,
+		},
 	}
 
 	for _, test := range testCases {
@@ -169,6 +280,7 @@ func TestPortCond(t *testing.T) {
 		Cond    pktcls.Cond
 		SrcPort uint16
 		DstPort uint16
+		IPv6    bool
 		ExpEval bool
 	}{
 		"Match UDP src port": {
@@ -195,13 +307,44 @@ func TestPortCond(t *testing.T) {
 			DstPort: 200,
 			ExpEval: false,
 		},
+		"Match UDP dst port over IPv6": {
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondPorts(
+					&pktcls.PortMatchDestination{
+						MinPort: 100,
+						MaxPort: 199,
+					},
+				),
+			),
+			DstPort: 150,
+			IPv6:    true,
+			ExpEval: true,
+		},
+		"Do not match UDP src port over IPv6": {
+			Cond: pktcls.NewCondAllOf(
+				pktcls.NewCondPorts(
+					&pktcls.PortMatchSource{
+						MinPort: 100,
+						MaxPort: 199,
+					},
+				),
+			),
+			SrcPort: 99,
+			IPv6:    true,
+			ExpEval: false,
+		},
 	}
 
 	for name, tc := range testCases {
 		name, tc := name, tc
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			pkt := createUDPPacket(tc.SrcPort, tc.DstPort)
+			var pkt gopacket.Layer
+			if tc.IPv6 {
+				pkt = createUDPv6Packet(tc.SrcPort, tc.DstPort)
+			} else {
+				pkt = createUDPPacket(tc.SrcPort, tc.DstPort)
+			}
 			assert.Equal(t, tc.ExpEval, tc.Cond.Eval(pkt))
 		})
 	}
@@ -237,7 +380,61 @@ func createUDPPacket(src, dst uint16) gopacket.Layer {
 	return pkt
 }
 
+func createUDPv6Packet(src, dst uint16) gopacket.Layer {
+	ip := &layers.IPv6{
+		Version:    6,
+		HopLimit:   64,
+		SrcIP:      net.ParseIP("2001:db8::3"),
+		DstIP:      net.ParseIP("2001:db8::2"),
+		NextHeader: layers.IPProtocolUDP,
+	}
+	udp := &layers.UDP{
+		SrcPort: layers.UDPPort(src),
+		DstPort: layers.UDPPort(dst),
+	}
+	udp.SetNetworkLayerForChecksum(ip)
+	payload := []byte("payload")
+	input := gopacket.NewSerializeBuffer()
+	options := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+	if err := gopacket.SerializeLayers(input, options,
+		ip, udp, gopacket.Payload(payload)); err != nil {
+		panic(err)
+	}
+	pkt := &layers.IPv6{}
+	pkt.DecodeFromBytes(input.Bytes(), gopacket.NilDecodeFeedback)
+	return pkt
+}
+
+// createIPv6Packet creates an IPv6 packet with a destination options header
+// followed by the given upper-layer protocol.
+func createIPv6Packet(proto layers.IPProtocol, destOpts []byte) gopacket.Layer {
+	ip := &layers.IPv6{
+		Version:    6,
+		HopLimit:   64,
+		SrcIP:      net.ParseIP("2001:db8::3"),
+		DstIP:      net.ParseIP("2001:db8::2"),
+		NextHeader: layers.IPProtocolIPv6Destination,
+	}
+	input := gopacket.NewSerializeBuffer()
+	options := gopacket.SerializeOptions{
+		FixLengths: true,
+	}
+	payload := append(destOpts, make([]byte, 20)...)
+	if err := gopacket.SerializeLayers(input, options,
+		ip, gopacket.Payload(payload)); err != nil {
+		panic(err)
+	}
+	pkt := &layers.IPv6{}
+	pkt.DecodeFromBytes(input.Bytes(), gopacket.NilDecodeFeedback)
+	return pkt
+}
+
 func TestStringer(t *testing.T) {
+	_, net6Src, _ := net.ParseCIDR("2001:db8::/32")
+	_, net6Dst, _ := net.ParseCIDR("fd00::/8")
 	_, net, _ := net.ParseCIDR("12.12.12.0/26")
 	tests := map[string]struct {
 		Cond pktcls.Cond
@@ -264,6 +461,25 @@ func TestStringer(t *testing.T) {
 				},
 			},
 		},
+		"IPv6 src dst dscp6 tc flowlabel protocol6": {
+			Str: "any(src=2001:db8::/32,all(dst=fd00::/8,dscp6=0x2e,tc=0xb8)," +
+				"flowlabel=0x12345,protocol6=ICMPv6,protocol6=253)",
+			Cond: pktcls.CondAnyOf{
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchSource{Net: net6Src}),
+				pktcls.CondAllOf{
+					pktcls.NewCondIPv6(&pktcls.IPv6MatchDestination{Net: net6Dst}),
+					pktcls.NewCondIPv6(&pktcls.IPv6MatchDSCP{DSCP: uint8(0x2e)}),
+					pktcls.NewCondIPv6(&pktcls.IPv6MatchTrafficClass{TrafficClass: uint8(0xb8)}),
+				},
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchFlowLabel{FlowLabel: 0x12345}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchProtocol{Protocol: 58}),
+				pktcls.NewCondIPv6(&pktcls.IPv6MatchProtocol{Protocol: 253}),
+			},
+		},
+		"protocol number": {
+			Str:  "protocol=254",
+			Cond: pktcls.NewCondIPv4(&pktcls.IPv4MatchProtocol{Protocol: 254}),
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -20,14 +20,19 @@
 // true for a ClsPkt, that packet is considered to be part of that class.
 //
 // The following conditions are supported:
-// AnyOf, AllOf, Boolean true, Boolean false and IPv4. AnyOf returns true if at
-// least one subcondition returns true. AllOf returns true if all subconditions
-// return true.  AllOf or AnyOf without subconditions return true. Boolean
-// conditions always return their internal value. IPv4 conditions include
-// predicates that compare the analyzed packet to preset values. Supported IPv4
-// conditions currently include destination network match, source network match
-// and ToS/DSCP fields match. Multiple predicates can be checked by enumerating
-// them under AllOf or AnyOf.
+// AnyOf, AllOf, Boolean true, Boolean false, IPv4, IPv6 and Ports. AnyOf
+// returns true if at least one subcondition returns true. AllOf returns true if
+// all subconditions return true.  AllOf or AnyOf without subconditions return
+// true. Boolean conditions always return their internal value. IPv4 and IPv6
+// conditions include predicates that compare the analyzed packet to preset
+// values. Supported IPv4 conditions currently include destination network
+// match, source network match, ToS/DSCP fields match and protocol match.
+// Supported IPv6 conditions include destination network match, source network
+// match, traffic class/DSCP match, flow label match and upper-layer protocol
+// match; the latter skips over IPv6 extension headers. Protocols can be given
+// either by name or by number. Port conditions match the source or destination
+// port of UDP and TCP packets carried over either IPv4 or IPv6. Multiple
+// predicates can be checked by enumerating them under AllOf or AnyOf.
 //
 // The package contains support for JSON marshaling and unmarshaling of
 // classes. Due to the custom formatting of the JSON output, marshaling must be
@@ -51,6 +51,13 @@ const (
 	TypeIPv4MatchToS         = "MatchToS"
 	TypeIPv4MatchDSCP        = "MatchDSCP"
 	TypeIPv4MatchProtocol    = "MatchProtocol"
+	TypeCondIPv6             = "CondIPv6"
+	TypeIPv6MatchSource      = "MatchSourceIPv6"
+	TypeIPv6MatchDestination = "MatchDestinationIPv6"
+	TypeIPv6MatchTC          = "MatchTrafficClass"
+	TypeIPv6MatchDSCP        = "MatchDSCPIPv6"
+	TypeIPv6MatchFlowLabel   = "MatchFlowLabel"
+	TypeIPv6MatchProtocol    = "MatchProtocolIPv6"
 	TypeCondPorts            = "CondPorts"
 	TypePortMatchSource      = "MatchSourcePort"
 	TypePortMatchDestination = "MatchDestinationPort"
@@ -120,6 +127,34 @@ func unmarshalInterface(b []byte) (Typer, error) {
 			var p IPv4MatchProtocol
 			err := json.Unmarshal(*v, &p)
 			return &p, err
+		case TypeCondIPv6:
+			var c CondIPv6
+			err := json.Unmarshal(*v, &c)
+			return &c, err
+		case TypeIPv6MatchSource:
+			var p IPv6MatchSource
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchDestination:
+			var p IPv6MatchDestination
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchTC:
+			var p IPv6MatchTrafficClass
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchDSCP:
+			var p IPv6MatchDSCP
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchFlowLabel:
+			var p IPv6MatchFlowLabel
+			err := json.Unmarshal(*v, &p)
+			return &p, err
+		case TypeIPv6MatchProtocol:
+			var p IPv6MatchProtocol
+			err := json.Unmarshal(*v, &p)
+			return &p, err
 		case TypeCondPorts:
 			var c CondPorts
 			err := json.Unmarshal(*v, &c)
@@ -165,6 +200,19 @@ func unmarshalIPv4Predicate(b []byte) (IPv4Predicate, error) {
 	return p, nil
 }
 
+// unmarshalIPv6Predicate extracts an IPv6Predicate from a JSON encoding
+func unmarshalIPv6Predicate(b []byte) (IPv6Predicate, error) {
+	t, err := unmarshalInterface(b)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := t.(IPv6Predicate)
+	if !ok {
+		return nil, serrors.New("Unable to extract Cond from interface")
+	}
+	return p, nil
+}
+
 // unmarshalPortPredicate extracts an PortPredicate from a JSON encoding
 func unmarshalPortPredicate(b []byte) (PortPredicate, error) {
 	t, err := unmarshalInterface(b)
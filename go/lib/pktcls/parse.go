@@ -116,7 +116,7 @@ func (l *classListener) EnterMatchTOS(ctx *traffic_class.MatchTOSContext) {
 func (l *classListener) EnterMatchProtocol(ctx *traffic_class.MatchProtocolContext) {
 	// Push Selector as Predicate on stack and update the number of Conds on the stack
 	prot := &IPv4MatchProtocol{}
-	number, err := protocolNameToNumber(ctx.GetStop().GetText())
+	number, err := parseProtocol(ctx.GetStop().GetText())
 	if err != nil {
 		l.err = serrors.WrapStr("Protocol parsing failed!", err,
 			"protocol", ctx.GetStop().GetText())
@@ -125,6 +125,74 @@ func (l *classListener) EnterMatchProtocol(ctx *traffic_class.MatchProtocolConte
 	l.pushCond(NewCondIPv4(prot))
 }
 
+func (l *classListener) EnterMatchSrc6(ctx *traffic_class.MatchSrc6Context) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	var err error
+	msrc := &IPv6MatchSource{}
+	_, msrc.Net, err = net.ParseCIDR(ctx.GetStop().GetText())
+	if err != nil {
+		l.err = serrors.WrapStr("CIDR parsing failed!", err, "cidr", ctx.GetStop().GetText())
+	}
+	l.pushCond(NewCondIPv6(msrc))
+}
+
+func (l *classListener) EnterMatchDst6(ctx *traffic_class.MatchDst6Context) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	var err error
+	mdst := &IPv6MatchDestination{}
+	_, mdst.Net, err = net.ParseCIDR(ctx.GetStop().GetText())
+	if err != nil {
+		l.err = serrors.WrapStr("CIDR parsing failed!", err, "cidr", ctx.GetStop().GetText())
+	}
+	l.pushCond(NewCondIPv6(mdst))
+}
+
+func (l *classListener) EnterMatchDSCP6(ctx *traffic_class.MatchDSCP6Context) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mdscp := &IPv6MatchDSCP{}
+	dscp, err := strconv.ParseUint(ctx.GetStop().GetText(), 16, 6)
+	if err != nil {
+		l.err = serrors.WrapStr("DSCP6 parsing failed!", err, "dscp6", ctx.GetStop().GetText())
+	}
+	mdscp.DSCP = uint8(dscp)
+	l.pushCond(NewCondIPv6(mdscp))
+}
+
+func (l *classListener) EnterMatchTC(ctx *traffic_class.MatchTCContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mtc := &IPv6MatchTrafficClass{}
+	tc, err := strconv.ParseUint(ctx.GetStop().GetText(), 16, 8)
+	if err != nil {
+		l.err = serrors.WrapStr("TC parsing failed!", err, "tc", ctx.GetStop().GetText())
+	}
+	mtc.TrafficClass = uint8(tc)
+	l.pushCond(NewCondIPv6(mtc))
+}
+
+func (l *classListener) EnterMatchFlowLabel(ctx *traffic_class.MatchFlowLabelContext) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	mfl := &IPv6MatchFlowLabel{}
+	fl, err := strconv.ParseUint(ctx.GetStop().GetText(), 16, 20)
+	if err != nil {
+		l.err = serrors.WrapStr("FLOWLABEL parsing failed!", err,
+			"flowlabel", ctx.GetStop().GetText())
+	}
+	mfl.FlowLabel = uint32(fl)
+	l.pushCond(NewCondIPv6(mfl))
+}
+
+func (l *classListener) EnterMatchProtocol6(ctx *traffic_class.MatchProtocol6Context) {
+	// Push Selector as Predicate on stack and update the number of Conds on the stack
+	prot := &IPv6MatchProtocol{}
+	number, err := parseProtocol(ctx.GetStop().GetText())
+	if err != nil {
+		l.err = serrors.WrapStr("Protocol parsing failed!", err,
+			"protocol6", ctx.GetStop().GetText())
+	}
+	prot.Protocol = number
+	l.pushCond(NewCondIPv6(prot))
+}
+
 func (l *classListener) EnterMatchSrcPort(ctx *traffic_class.MatchSrcPortContext) {
 	// Push Selector as Predicate on stack and update the number of Conds on the stack
 	src := &PortMatchSource{}
@@ -292,3 +360,24 @@ func protocolNameToNumber(name string) (uint8, error) {
 	}
 	return 0, serrors.New("unknown IP protocol name", "name", name)
 }
+
+// parseProtocol converts either a decimal IP protocol number (e.g. "6") or a
+// protocol name (e.g. "TCP") to an IP protocol number.
+func parseProtocol(s string) (uint8, error) {
+	if number, err := strconv.ParseUint(s, 10, 8); err == nil {
+		return uint8(number), nil
+	}
+	return protocolNameToNumber(s)
+}
+
+// protocolString returns the name of the IP protocol if it is known and maps
+// back to the same number, and the decimal number otherwise.
+func protocolString(number uint8) string {
+	meta := layers.IPProtocolMetadata[number]
+	if meta.LayerType != 0 {
+		if n, err := protocolNameToNumber(meta.Name); err == nil && n == number {
+			return meta.Name
+		}
+	}
+	return strconv.Itoa(int(number))
+}
@@ -54,6 +54,41 @@ func TestTrafficClassValidation(t *testing.T) {
 			Class: "dscp=2",
 			Valid: false,
 		},
+		{
+			Name:  "src IPv6Cond",
+			Class: "src=2001:db8::/32",
+			Valid: true,
+		},
+		{
+			Name:  "dst IPv6Cond",
+			Class: "dst=::/0",
+			Valid: true,
+		},
+		{
+			Name:  "bad dst IPv6Cond",
+			Class: "dst=2001:db8::",
+			Valid: false,
+		},
+		{
+			Name:  "flowlabel IPv6Cond",
+			Class: "flowlabel=0x12345",
+			Valid: true,
+		},
+		{
+			Name:  "bad flowlabel IPv6Cond",
+			Class: "flowlabel=0x123456",
+			Valid: false,
+		},
+		{
+			Name:  "protocol number",
+			Class: "protocol=17",
+			Valid: true,
+		},
+		{
+			Name:  "bad protocol number",
+			Class: "protocol6=256",
+			Valid: false,
+		},
 		{
 			Name:  "NOT",
 			Class: "NOT(dscp=0x2)",
@@ -134,6 +169,7 @@ func TestTrafficClassValidation(t *testing.T) {
 }
 
 func TestTrafficClassTree(t *testing.T) {
+	_, net6, _ := net.ParseCIDR("2001:db8::/32")
 	_, net, _ := net.ParseCIDR("12.12.12.0/26")
 	testCases := []struct {
 		Name  string
@@ -161,6 +197,55 @@ func TestTrafficClassTree(t *testing.T) {
 				&pktcls.IPv4MatchDSCP{DSCP: uint8(0x2)},
 			),
 		},
+		{
+			Name:  "src IPv6Cond",
+			Class: "src=2001:db8::/32",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchSource{Net: net6},
+			),
+		},
+		{
+			Name:  "dst IPv6Cond",
+			Class: "dst=2001:db8::/32",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchDestination{Net: net6},
+			),
+		},
+		{
+			Name:  "dscp6 IPv6Cond",
+			Class: "dscp6=0x2e",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchDSCP{DSCP: uint8(0x2e)},
+			),
+		},
+		{
+			Name:  "tc IPv6Cond",
+			Class: "tc=0xb8",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchTrafficClass{TrafficClass: uint8(0xb8)},
+			),
+		},
+		{
+			Name:  "flowlabel IPv6Cond",
+			Class: "flowlabel=0x12345",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchFlowLabel{FlowLabel: 0x12345},
+			),
+		},
+		{
+			Name:  "protocol6 IPv6Cond",
+			Class: "protocol6=udp",
+			Tree: pktcls.NewCondIPv6(
+				&pktcls.IPv6MatchProtocol{Protocol: 17},
+			),
+		},
+		{
+			Name:  "protocol number IPv4Cond",
+			Class: "protocol=47",
+			Tree: pktcls.NewCondIPv4(
+				&pktcls.IPv4MatchProtocol{Protocol: 47},
+			),
+		},
 		{
 			Name:  "NOT",
 			Class: "NOT(dscp=0x2)",
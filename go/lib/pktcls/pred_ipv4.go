@@ -218,13 +218,13 @@ func (m *IPv4MatchProtocol) Eval(p *layers.IPv4) bool {
 }
 
 func (m *IPv4MatchProtocol) String() string {
-	return fmt.Sprintf("protocol=%s", layers.IPProtocolMetadata[m.Protocol].Name)
+	return fmt.Sprintf("protocol=%s", protocolString(m.Protocol))
 }
 
 func (m *IPv4MatchProtocol) MarshalJSON() ([]byte, error) {
 	return json.Marshal(
 		jsonContainer{
-			"Protocol": layers.IPProtocolMetadata[m.Protocol].Name,
+			"Protocol": protocolString(m.Protocol),
 		},
 	)
 }
@@ -234,7 +234,7 @@ func (m *IPv4MatchProtocol) UnmarshalJSON(b []byte) error {
 	if err != nil {
 		return err
 	}
-	n, err := protocolNameToNumber(s)
+	n, err := parseProtocol(s)
 	if err != nil {
 		return err
 	}
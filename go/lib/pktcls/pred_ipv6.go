@@ -0,0 +1,312 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pktcls
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// IPv6Predicate describes a single test on various IPv6 packet fields.
+type IPv6Predicate interface {
+	// Eval returns true if the IPv6 packet matched the predicate
+	Eval(*layers.IPv6) bool
+	Typer
+	fmt.Stringer
+}
+
+var _ IPv6Predicate = (*IPv6MatchSource)(nil)
+
+// IPv6MatchSource checks whether the source IPv6 address is contained in Net.
+type IPv6MatchSource struct {
+	Net *net.IPNet
+}
+
+func (m *IPv6MatchSource) Type() string {
+	return "MatchSourceIPv6"
+}
+
+func (m *IPv6MatchSource) Eval(p *layers.IPv6) bool {
+	return m.Net.Contains(p.SrcIP)
+}
+
+func (m *IPv6MatchSource) String() string {
+	if m.Net == nil {
+		return "src="
+	}
+	return fmt.Sprintf("src=%s", m.Net)
+}
+
+func (m *IPv6MatchSource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Net": m.Net.String(),
+		},
+	)
+}
+
+func (m *IPv6MatchSource) UnmarshalJSON(b []byte) error {
+	s, err := unmarshalStringField(b, "MatchSourceIPv6", "Net")
+	if err != nil {
+		return err
+	}
+	_, network, err := net.ParseCIDR(s)
+	if err != nil {
+		return serrors.WrapStr("Unable to parse MatchSourceIPv6 operand", err)
+	}
+	m.Net = network
+	return nil
+}
+
+var _ IPv6Predicate = (*IPv6MatchDestination)(nil)
+
+// IPv6MatchDestination checks whether the destination IPv6 address is contained
+// in Net.
+type IPv6MatchDestination struct {
+	Net *net.IPNet
+}
+
+func (m *IPv6MatchDestination) Type() string {
+	return "MatchDestinationIPv6"
+}
+
+func (m *IPv6MatchDestination) Eval(p *layers.IPv6) bool {
+	return m.Net.Contains(p.DstIP)
+}
+
+func (m *IPv6MatchDestination) String() string {
+	if m.Net == nil {
+		return "dst="
+	}
+	return fmt.Sprintf("dst=%s", m.Net)
+}
+
+func (m *IPv6MatchDestination) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Net": m.Net.String(),
+		},
+	)
+}
+
+func (m *IPv6MatchDestination) UnmarshalJSON(b []byte) error {
+	s, err := unmarshalStringField(b, "MatchDestinationIPv6", "Net")
+	if err != nil {
+		return err
+	}
+	_, network, err := net.ParseCIDR(s)
+	if err != nil {
+		return serrors.WrapStr("Unable to parse MatchDestinationIPv6 operand", err)
+	}
+	m.Net = network
+	return nil
+}
+
+var _ IPv6Predicate = (*IPv6MatchTrafficClass)(nil)
+
+// IPv6MatchTrafficClass checks whether the traffic class field matches.
+type IPv6MatchTrafficClass struct {
+	TrafficClass uint8
+}
+
+func (m *IPv6MatchTrafficClass) Type() string {
+	return "MatchTrafficClass"
+}
+
+func (m *IPv6MatchTrafficClass) Eval(p *layers.IPv6) bool {
+	return m.TrafficClass == p.TrafficClass
+}
+
+func (m *IPv6MatchTrafficClass) String() string {
+	return fmt.Sprintf("tc=%#x", m.TrafficClass)
+}
+
+func (m *IPv6MatchTrafficClass) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"TrafficClass": fmt.Sprintf("%#x", m.TrafficClass),
+		},
+	)
+}
+
+func (m *IPv6MatchTrafficClass) UnmarshalJSON(b []byte) error {
+	// Format is 0x hex number in quoted string
+	i, err := unmarshalUintField(b, "MatchTrafficClass", "TrafficClass", 8)
+	if err != nil {
+		return err
+	}
+	m.TrafficClass = uint8(i)
+	return nil
+}
+
+var _ IPv6Predicate = (*IPv6MatchDSCP)(nil)
+
+// IPv6MatchDSCP checks whether the DSCP subset of the traffic class field
+// matches.
+type IPv6MatchDSCP struct {
+	DSCP uint8
+}
+
+func (m *IPv6MatchDSCP) Type() string {
+	return "MatchDSCPIPv6"
+}
+
+func (m *IPv6MatchDSCP) Eval(p *layers.IPv6) bool {
+	return m.DSCP == p.TrafficClass>>2
+}
+
+func (m *IPv6MatchDSCP) String() string {
+	return fmt.Sprintf("dscp6=%#x", m.DSCP)
+}
+
+func (m *IPv6MatchDSCP) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"DSCP": fmt.Sprintf("%#x", m.DSCP),
+		},
+	)
+}
+
+func (m *IPv6MatchDSCP) UnmarshalJSON(b []byte) error {
+	// Format is 0x hex number in quoted string
+	i, err := unmarshalUintField(b, "MatchDSCPIPv6", "DSCP", 6)
+	if err != nil {
+		return err
+	}
+	m.DSCP = uint8(i)
+	return nil
+}
+
+var _ IPv6Predicate = (*IPv6MatchFlowLabel)(nil)
+
+// IPv6MatchFlowLabel checks whether the 20-bit flow label matches.
+type IPv6MatchFlowLabel struct {
+	FlowLabel uint32
+}
+
+func (m *IPv6MatchFlowLabel) Type() string {
+	return "MatchFlowLabel"
+}
+
+func (m *IPv6MatchFlowLabel) Eval(p *layers.IPv6) bool {
+	return m.FlowLabel == p.FlowLabel
+}
+
+func (m *IPv6MatchFlowLabel) String() string {
+	return fmt.Sprintf("flowlabel=%#x", m.FlowLabel)
+}
+
+func (m *IPv6MatchFlowLabel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"FlowLabel": fmt.Sprintf("%#x", m.FlowLabel),
+		},
+	)
+}
+
+func (m *IPv6MatchFlowLabel) UnmarshalJSON(b []byte) error {
+	// Format is 0x hex number in quoted string
+	i, err := unmarshalUintField(b, "MatchFlowLabel", "FlowLabel", 20)
+	if err != nil {
+		return err
+	}
+	m.FlowLabel = uint32(i)
+	return nil
+}
+
+var _ IPv6Predicate = (*IPv6MatchProtocol)(nil)
+
+// IPv6MatchProtocol checks whether the upper-layer protocol matches. Hop-by-hop,
+// routing, fragment and destination options extension headers are skipped.
+type IPv6MatchProtocol struct {
+	Protocol uint8
+}
+
+func (m *IPv6MatchProtocol) Type() string {
+	return "MatchProtocolIPv6"
+}
+
+func (m *IPv6MatchProtocol) Eval(p *layers.IPv6) bool {
+	protocol, _ := ipv6UpperLayer(p)
+	return m.Protocol == uint8(protocol)
+}
+
+func (m *IPv6MatchProtocol) String() string {
+	return fmt.Sprintf("protocol6=%s", protocolString(m.Protocol))
+}
+
+func (m *IPv6MatchProtocol) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		jsonContainer{
+			"Protocol": protocolString(m.Protocol),
+		},
+	)
+}
+
+func (m *IPv6MatchProtocol) UnmarshalJSON(b []byte) error {
+	s, err := unmarshalStringField(b, "MatchProtocolIPv6", "Protocol")
+	if err != nil {
+		return err
+	}
+	n, err := parseProtocol(s)
+	if err != nil {
+		return err
+	}
+	m.Protocol = n
+	return nil
+}
+
+// ipv6UpperLayer walks the extension header chain of the packet and returns the
+// upper-layer protocol together with its payload. The payload is nil if it
+// cannot be located, e.g., for truncated headers or non-initial fragments.
+func ipv6UpperLayer(p *layers.IPv6) (layers.IPProtocol, []byte) {
+	protocol, payload := p.NextHeader, p.Payload
+	// gopacket decodes the hop-by-hop options as part of the IPv6 layer.
+	if p.HopByHop != nil {
+		protocol = p.HopByHop.NextHeader
+	}
+	for {
+		switch protocol {
+		case layers.IPProtocolIPv6HopByHop, layers.IPProtocolIPv6Routing,
+			layers.IPProtocolIPv6Destination:
+
+			if len(payload) < 8 {
+				return protocol, nil
+			}
+			length := (int(payload[1]) + 1) * 8
+			if len(payload) < length {
+				return protocol, nil
+			}
+			protocol, payload = layers.IPProtocol(payload[0]), payload[length:]
+		case layers.IPProtocolIPv6Fragment:
+			if len(payload) < 8 {
+				return protocol, nil
+			}
+			protocol = layers.IPProtocol(payload[0])
+			if binary.BigEndian.Uint16(payload[2:4])>>3 != 0 {
+				return protocol, nil
+			}
+			payload = payload[8:]
+		default:
+			return protocol, payload
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revcache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+)
+
+// Notifier is implemented by revocation caches that can notify about inserted
+// revocations.
+type Notifier interface {
+	// Inserted returns a channel that is closed the next time a revocation is
+	// inserted into the cache.
+	Inserted() <-chan struct{}
+}
+
+// NotifyingRevCache is a revocation cache that notifies about inserted
+// revocations.
+type NotifyingRevCache struct {
+	RevCache
+
+	mtx      sync.Mutex
+	inserted chan struct{}
+}
+
+// NewNotifyingRevCache wraps the revocation cache so that it implements
+// Notifier.
+func NewNotifyingRevCache(revCache RevCache) *NotifyingRevCache {
+	return &NotifyingRevCache{
+		RevCache: revCache,
+		inserted: make(chan struct{}),
+	}
+}
+
+// Insert inserts the revocation into the wrapped cache. If it is inserted, the
+// channels returned by Inserted are closed.
+func (c *NotifyingRevCache) Insert(ctx context.Context, rev *path_mgmt.RevInfo) (bool, error) {
+	inserted, err := c.RevCache.Insert(ctx, rev)
+	if err != nil || !inserted {
+		return inserted, err
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	close(c.inserted)
+	c.inserted = make(chan struct{})
+	return inserted, err
+}
+
+// Inserted implements Notifier.
+func (c *NotifyingRevCache) Inserted() <-chan struct{} {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.inserted
+}
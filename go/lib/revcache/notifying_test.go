@@ -0,0 +1,72 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revcache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/revcache/mock_revcache"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+func TestNotifyingRevCacheInsert(t *testing.T) {
+	testCases := map[string]struct {
+		Inserted bool
+		Err      error
+		Notified bool
+	}{
+		"inserted": {
+			Inserted: true,
+			Notified: true,
+		},
+		"not inserted": {},
+		"error": {
+			Err: serrors.New("test"),
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rev := defaultRevInfo(ia110, ifid10, time.Now())
+			inner := mock_revcache.NewMockRevCache(ctrl)
+			inner.EXPECT().Insert(gomock.Any(), rev).Return(tc.Inserted, tc.Err)
+			revCache := revcache.NewNotifyingRevCache(inner)
+			inserted := revCache.Inserted()
+
+			ok, err := revCache.Insert(context.Background(), rev)
+			assert.Equal(t, tc.Inserted, ok)
+			assert.Equal(t, tc.Err, err)
+			select {
+			case <-inserted:
+				require.True(t, tc.Notified, "unexpected notification")
+				assert.NotEqual(t, inserted, revCache.Inserted())
+			default:
+				require.False(t, tc.Notified, "missing notification")
+				assert.Equal(t, inserted, revCache.Inserted())
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package colibri implements the Path interface for the COLIBRI path type.
+// A COLIBRI path carries the token of a bandwidth reservation, i.e., an info
+// field describing the reservation, and one hop field per AS on the
+// reservation path.
+package colibri
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+)
+
+const (
+	// PathType denotes the COLIBRI path type identifier.
+	PathType path.Type = 4
+	// InfoFieldLen is the length of the COLIBRI info field in bytes.
+	InfoFieldLen = 24
+	// HopFieldLen is the length of a COLIBRI hop field in bytes.
+	HopFieldLen = 8
+	// MacLen is the length of the MAC of a COLIBRI hop field in bytes.
+	MacLen = 4
+	// ResIDSuffixLen is the length of the reservation ID suffix in bytes. The
+	// AS part of the reservation ID is the source AS of the packet.
+	ResIDSuffixLen = 12
+	// MaxHops is the maximum number of hop fields in a COLIBRI path.
+	MaxHops = 64
+	// TickDuration is the unit of the expiration tick.
+	TickDuration = 4 * time.Second
+)
+
+// RegisterPath registers the COLIBRI path type globally.
+func RegisterPath() {
+	path.RegisterPath(path.Metadata{
+		Type: PathType,
+		Desc: "Colibri",
+		New: func() path.Path {
+			return &Path{}
+		},
+	})
+}
+
+// InfoField is the info field of the COLIBRI path type.
+//
+// The info field has the following format:
+//    0                   1                   2                   3
+//    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |C|R|S|r r r r r|      RSV      |    CurrHF     |    HFCount    |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                                                               |
+//   +                                                               +
+//   |                       Reservation ID Suffix                   |
+//   +                                                               +
+//   |                                                               |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                       Expiration Tick                         |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |     BWCls     |      RLC      |  Idx  |  RSV  |      RSV      |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+type InfoField struct {
+	// C is set for control plane traffic, i.e., traffic of the COLIBRI
+	// service itself.
+	C bool
+	// R is set if the packet travels against the direction of the reservation.
+	R bool
+	// S is set if the reservation is a segment reservation, and unset for
+	// end-to-end reservations.
+	S bool
+	// CurrHF is the index of the current hop field.
+	CurrHF uint8
+	// HFCount is the number of hop fields in the path.
+	HFCount uint8
+	// ResIDSuffix is the suffix of the reservation ID. Segment reservation
+	// IDs are shorter than the field, they are padded with zeros at the end.
+	ResIDSuffix [ResIDSuffixLen]byte
+	// ExpTick is the expiration time of the reservation, in units of
+	// TickDuration since the Unix epoch.
+	ExpTick uint32
+	// BWCls is the bandwidth class of the reservation.
+	BWCls uint8
+	// RLC is the request latency class of the reservation.
+	RLC uint8
+	// Idx is the index of the reservation, it only uses 4 bits.
+	Idx uint8
+}
+
+// DecodeFromBytes decodes the info field from the provided buffer.
+func (f *InfoField) DecodeFromBytes(raw []byte) error {
+	if len(raw) < InfoFieldLen {
+		return serrors.New("InfoField raw too short", "expected", InfoFieldLen,
+			"actual", len(raw))
+	}
+	f.C = raw[0]&0x80 != 0
+	f.R = raw[0]&0x40 != 0
+	f.S = raw[0]&0x20 != 0
+	f.CurrHF = raw[2]
+	f.HFCount = raw[3]
+	copy(f.ResIDSuffix[:], raw[4:16])
+	f.ExpTick = binary.BigEndian.Uint32(raw[16:20])
+	f.BWCls = raw[20]
+	f.RLC = raw[21]
+	f.Idx = raw[22] >> 4
+	return nil
+}
+
+// SerializeTo writes the info field to the provided buffer.
+func (f *InfoField) SerializeTo(b []byte) error {
+	if len(b) < InfoFieldLen {
+		return serrors.New("buffer for InfoField too short", "expected", InfoFieldLen,
+			"actual", len(b))
+	}
+	b[0] = 0
+	if f.C {
+		b[0] |= 0x80
+	}
+	if f.R {
+		b[0] |= 0x40
+	}
+	if f.S {
+		b[0] |= 0x20
+	}
+	b[1] = 0
+	b[2] = f.CurrHF
+	b[3] = f.HFCount
+	copy(b[4:16], f.ResIDSuffix[:])
+	binary.BigEndian.PutUint32(b[16:20], f.ExpTick)
+	b[20] = f.BWCls
+	b[21] = f.RLC
+	b[22] = f.Idx << 4
+	b[23] = 0
+	return nil
+}
+
+// Expiration returns the expiration time of the reservation.
+func (f *InfoField) Expiration() time.Time {
+	return time.Unix(int64(f.ExpTick)*int64(TickDuration/time.Second), 0)
+}
+
+// HopField is a hop field of the COLIBRI path type. The interface IDs are
+// always in the direction of the reservation, independent of the R flag.
+//
+// The hop field has the following format:
+//    0                   1                   2                   3
+//    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |           IngressID           |            EgressID           |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                              MAC                              |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+type HopField struct {
+	IngressID uint16
+	EgressID  uint16
+	Mac       [MacLen]byte
+}
+
+// DecodeFromBytes decodes the hop field from the provided buffer.
+func (h *HopField) DecodeFromBytes(raw []byte) error {
+	if len(raw) < HopFieldLen {
+		return serrors.New("HopField raw too short", "expected", HopFieldLen,
+			"actual", len(raw))
+	}
+	h.IngressID = binary.BigEndian.Uint16(raw[0:2])
+	h.EgressID = binary.BigEndian.Uint16(raw[2:4])
+	copy(h.Mac[:], raw[4:8])
+	return nil
+}
+
+// SerializeTo writes the hop field to the provided buffer.
+func (h *HopField) SerializeTo(b []byte) error {
+	if len(b) < HopFieldLen {
+		return serrors.New("buffer for HopField too short", "expected", HopFieldLen,
+			"actual", len(b))
+	}
+	binary.BigEndian.PutUint16(b[0:2], h.IngressID)
+	binary.BigEndian.PutUint16(b[2:4], h.EgressID)
+	copy(b[4:8], h.Mac[:])
+	return nil
+}
+
+// Path is the COLIBRI path type. The number of hop fields must match the
+// HFCount of the info field.
+type Path struct {
+	InfoField InfoField
+	HopFields []HopField
+}
+
+// DecodeFromBytes decodes the path from the provided buffer.
+func (p *Path) DecodeFromBytes(b []byte) error {
+	if err := p.InfoField.DecodeFromBytes(b); err != nil {
+		return err
+	}
+	if p.InfoField.HFCount == 0 || p.InfoField.HFCount > MaxHops {
+		return serrors.New("invalid number of hop fields", "hf_count", p.InfoField.HFCount)
+	}
+	if p.InfoField.CurrHF >= p.InfoField.HFCount {
+		return serrors.New("current hop field out of range", "curr_hf", p.InfoField.CurrHF,
+			"hf_count", p.InfoField.HFCount)
+	}
+	if minLen := p.Len(); len(b) < minLen {
+		return serrors.New("Colibri path raw too short", "expected", minLen, "actual", len(b))
+	}
+	p.HopFields = make([]HopField, p.InfoField.HFCount)
+	offset := InfoFieldLen
+	for i := range p.HopFields {
+		if err := p.HopFields[i].DecodeFromBytes(b[offset:]); err != nil {
+			return err
+		}
+		offset += HopFieldLen
+	}
+	return nil
+}
+
+// SerializeTo serializes the path into the provided buffer.
+func (p *Path) SerializeTo(b []byte) error {
+	if len(b) < p.Len() {
+		return serrors.New("buffer too small to serialize path.", "expected", p.Len(),
+			"actual", len(b))
+	}
+	if int(p.InfoField.HFCount) != len(p.HopFields) {
+		return serrors.New("inconsistent number of hop fields",
+			"hf_count", p.InfoField.HFCount, "hop_fields", len(p.HopFields))
+	}
+	if err := p.InfoField.SerializeTo(b); err != nil {
+		return err
+	}
+	offset := InfoFieldLen
+	for i := range p.HopFields {
+		if err := p.HopFields[i].SerializeTo(b[offset:]); err != nil {
+			return err
+		}
+		offset += HopFieldLen
+	}
+	return nil
+}
+
+// Reverse reverses the path such that it can be used in the direction
+// opposite to the current one. The order of the hop fields is reversed and
+// the R flag is toggled; the hop fields themselves are not modified, so that
+// their MACs stay valid.
+func (p *Path) Reverse() (path.Path, error) {
+	if int(p.InfoField.HFCount) != len(p.HopFields) || len(p.HopFields) == 0 {
+		return nil, serrors.New("cannot reverse inconsistent path",
+			"hf_count", p.InfoField.HFCount, "hop_fields", len(p.HopFields))
+	}
+	for i, j := 0, len(p.HopFields)-1; i < j; i, j = i+1, j-1 {
+		p.HopFields[i], p.HopFields[j] = p.HopFields[j], p.HopFields[i]
+	}
+	p.InfoField.R = !p.InfoField.R
+	p.InfoField.CurrHF = p.InfoField.HFCount - 1 - p.InfoField.CurrHF
+	return p, nil
+}
+
+// Len returns the length of the path in bytes.
+func (p *Path) Len() int {
+	return InfoFieldLen + int(p.InfoField.HFCount)*HopFieldLen
+}
+
+// Type returns the COLIBRI path type identifier.
+func (p *Path) Type() path.Type {
+	return PathType
+}
+
+// CurrentHopField returns the current hop field.
+func (p *Path) CurrentHopField() (*HopField, error) {
+	if int(p.InfoField.CurrHF) >= len(p.HopFields) {
+		return nil, serrors.New("current hop field out of range",
+			"curr_hf", p.InfoField.CurrHF, "hop_fields", len(p.HopFields))
+	}
+	return &p.HopFields[p.InfoField.CurrHF], nil
+}
+
+// IsLastHop returns whether the current hop field is the last one.
+func (p *Path) IsLastHop() bool {
+	return int(p.InfoField.CurrHF) == len(p.HopFields)-1
+}
+
+// IncPath increments the current hop field.
+func (p *Path) IncPath() error {
+	if p.IsLastHop() {
+		return serrors.New("path already at the last hop field",
+			"curr_hf", p.InfoField.CurrHF, "hop_fields", len(p.HopFields))
+	}
+	p.InfoField.CurrHF++
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+)
+
+func testPath() *colibri.Path {
+	return &colibri.Path{
+		InfoField: colibri.InfoField{
+			C:           true,
+			S:           true,
+			CurrHF:      1,
+			HFCount:     3,
+			ResIDSuffix: [colibri.ResIDSuffixLen]byte{1, 2, 3, 4},
+			ExpTick:     0x01020304,
+			BWCls:       13,
+			RLC:         4,
+			Idx:         7,
+		},
+		HopFields: []colibri.HopField{
+			{IngressID: 0, EgressID: 1, Mac: [colibri.MacLen]byte{1, 1, 1, 1}},
+			{IngressID: 2, EgressID: 3, Mac: [colibri.MacLen]byte{2, 2, 2, 2}},
+			{IngressID: 4, EgressID: 0, Mac: [colibri.MacLen]byte{3, 3, 3, 3}},
+		},
+	}
+}
+
+func TestSerializeDecode(t *testing.T) {
+	want := testPath()
+	b := make([]byte, want.Len())
+	require.NoError(t, want.SerializeTo(b))
+	assert.Equal(t, colibri.InfoFieldLen+3*colibri.HopFieldLen, len(b))
+
+	got := &colibri.Path{}
+	require.NoError(t, got.DecodeFromBytes(b))
+	assert.Equal(t, want, got)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	valid := testPath()
+	raw := make([]byte, valid.Len())
+	require.NoError(t, valid.SerializeTo(raw))
+
+	testCases := map[string]func() []byte{
+		"too short": func() []byte {
+			return raw[:colibri.InfoFieldLen+colibri.HopFieldLen]
+		},
+		"no hop fields": func() []byte {
+			b := append([]byte(nil), raw...)
+			b[2], b[3] = 0, 0
+			return b
+		},
+		"current hop out of range": func() []byte {
+			b := append([]byte(nil), raw...)
+			b[2] = 3
+			return b
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			p := &colibri.Path{}
+			assert.Error(t, p.DecodeFromBytes(tc()))
+		})
+	}
+}
+
+func TestSerializeInconsistent(t *testing.T) {
+	p := testPath()
+	p.HopFields = p.HopFields[:2]
+	assert.Error(t, p.SerializeTo(make([]byte, 128)))
+}
+
+func TestReverse(t *testing.T) {
+	p := testPath()
+	rev, err := p.Reverse()
+	require.NoError(t, err)
+	revPath := rev.(*colibri.Path)
+	assert.True(t, revPath.InfoField.R)
+	assert.Equal(t, uint8(1), revPath.InfoField.CurrHF)
+	assert.Equal(t, uint16(4), revPath.HopFields[0].IngressID)
+	assert.Equal(t, uint16(1), revPath.HopFields[2].EgressID)
+
+	rev, err = rev.Reverse()
+	require.NoError(t, err)
+	assert.Equal(t, testPath(), rev)
+}
+
+func TestIncPath(t *testing.T) {
+	p := testPath()
+	assert.False(t, p.IsLastHop())
+	require.NoError(t, p.IncPath())
+	assert.True(t, p.IsLastHop())
+	hf, err := p.CurrentHopField()
+	require.NoError(t, err)
+	assert.Equal(t, uint16(4), hf.IngressID)
+	assert.Error(t, p.IncPath())
+}
+
+func TestExpiration(t *testing.T) {
+	info := colibri.InfoField{ExpTick: 100}
+	assert.Equal(t, time.Unix(400, 0), info.Expiration())
+}
+
+func TestMAC(t *testing.T) {
+	mac, err := scrypto.InitMac([]byte("0123456789abcdef"))
+	require.NoError(t, err)
+	p := testPath()
+	hf := &p.HopFields[1]
+	as := addr.AS(0xff0000000110)
+
+	expected := colibri.MAC(mac, &p.InfoField, hf, as)
+	// The MAC is independent of the fields that change during forwarding.
+	p.InfoField.R = true
+	p.InfoField.CurrHF = 2
+	assert.Equal(t, expected, colibri.MAC(mac, &p.InfoField, hf, as))
+
+	// The MAC covers the reservation and the hop field.
+	p.InfoField.BWCls++
+	assert.NotEqual(t, expected, colibri.MAC(mac, &p.InfoField, hf, as))
+	p.InfoField.BWCls--
+	hf.EgressID++
+	assert.NotEqual(t, expected, colibri.MAC(mac, &p.InfoField, hf, as))
+	hf.EgressID--
+	assert.NotEqual(t, expected, colibri.MAC(mac, &p.InfoField, hf, as+1))
+}
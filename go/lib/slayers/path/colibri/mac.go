@@ -0,0 +1,84 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri
+
+import (
+	"encoding/binary"
+	"hash"
+
+	"github.com/scionproto/scion/go/lib/addr"
+)
+
+// MACInputLen is the length of the MAC input block.
+const MACInputLen = 32
+
+// MAC calculates the MAC of a COLIBRI hop field. The resAS is the AS that owns
+// the reservation, i.e., the source AS of packets that travel in the direction
+// of the reservation. This method does not modify info or hf.
+func MAC(h hash.Hash, info *InfoField, hf *HopField, resAS addr.AS) [MacLen]byte {
+	h.Reset()
+	// Write must not return an error: https://godoc.org/hash#Hash
+	if _, err := h.Write(MACInput(info, hf, resAS)); err != nil {
+		panic(err)
+	}
+	var mac [MacLen]byte
+	copy(mac[:], h.Sum(nil))
+	return mac
+}
+
+// MACInput returns the MAC input data block with the following layout:
+//
+//    0                   1                   2                   3
+//    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                                                               |
+//   +                                                               +
+//   |                       Reservation ID Suffix                   |
+//   +                                                               +
+//   |                                                               |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                       Expiration Tick                         |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |     BWCls     |      RLC      |  Idx  |   0   |C|0|S|    0    |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |           IngressID           |            EgressID           |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                       Reservation AS                          |
+//   +                               +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//   |                               |               0               |
+//   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// The R flag and the current hop field are not authenticated, they change
+// while the packet is forwarded.
+func MACInput(info *InfoField, hf *HopField, resAS addr.AS) []byte {
+	input := make([]byte, MACInputLen)
+	copy(input[0:12], info.ResIDSuffix[:])
+	binary.BigEndian.PutUint32(input[12:16], info.ExpTick)
+	input[16] = info.BWCls
+	input[17] = info.RLC
+	input[18] = info.Idx << 4
+	if info.C {
+		input[19] |= 0x80
+	}
+	if info.S {
+		input[19] |= 0x20
+	}
+	binary.BigEndian.PutUint16(input[20:22], hf.IngressID)
+	binary.BigEndian.PutUint16(input[22:24], hf.EgressID)
+	var as [8]byte
+	binary.BigEndian.PutUint64(as[:], uint64(resAS))
+	copy(input[24:30], as[2:])
+	return input
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/slayers/path/empty"
 	"github.com/scionproto/scion/go/lib/slayers/path/epic"
 	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
@@ -45,6 +46,7 @@ func init() {
 	scion.RegisterPath()
 	onehop.RegisterPath()
 	epic.RegisterPath()
+	colibri.RegisterPath()
 }
 
 // AddrLen indicates the length of a host address in the SCION header. The four possible lengths are
@@ -68,6 +68,7 @@ const (
 	SCMPCodeInvalidHopFieldMAC     SCMPCode = 51
 	SCMPCodePathExpired            SCMPCode = 52
 	SCMPCodeInvalidSegmentChange   SCMPCode = 53
+	SCMPCodeInvalidPacketTimestamp SCMPCode = 54
 
 	SCMPCodeInvalidExtensionHeader SCMPCode = 64
 	SCMPCodeUnknownHopByHopOption  SCMPCode = 65
@@ -158,6 +159,7 @@ var scmpTypeCodeInfo = map[SCMPType]struct {
 			SCMPCodeInvalidHopFieldMAC:        "InvalidHopFieldMAC",
 			SCMPCodePathExpired:               "PathExpired",
 			SCMPCodeInvalidSegmentChange:      "InvalidSegmentChange",
+			SCMPCodeInvalidPacketTimestamp:    "InvalidPacketTimestamp",
 			SCMPCodeInvalidExtensionHeader:    "InvalidExtensionHeader",
 			SCMPCodeUnknownHopByHopOption:     "UnknownHopByHopOption",
 			SCMPCodeUnknownEndToEndOption:     "UnknownEndToEndOption",
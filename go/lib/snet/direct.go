@@ -0,0 +1,112 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"syscall"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var _ PacketDispatcherService = (*DirectPacketDispatcherService)(nil)
+
+// DirectPacketDispatcherService opens connections on plain UDP sockets,
+// bypassing the dispatcher. The border routers deliver packets directly to
+// the sockets, given that their ports are in the dispatched port range of the
+// local AS (see daemon.Connector.PortRange). SCMP messages for a connection
+// are delivered to its socket and handled in the process by the SCMP handler.
+// SCMP echo and traceroute requests are still delivered to the dispatcher.
+type DirectPacketDispatcherService struct {
+	// StartPort and EndPort are the first and last port of the dispatched port
+	// range. Connections registered with port 0 are bound to a free port in
+	// the range.
+	StartPort uint16
+	EndPort   uint16
+	// SCMPHandler is invoked for packets that contain an SCMP L4. If the
+	// handler is nil, errors are returned back to applications every time an
+	// SCMP message is received.
+	SCMPHandler SCMPHandler
+	// Authenticator authenticates the packets sent and received on the
+	// registered connections. Received data packets that fail the verification
+	// are dropped. If it is nil, packets are not authenticated.
+	Authenticator PacketAuthenticator
+}
+
+// Register binds a UDP socket to the registration address. If the port is 0,
+// a free port in the dispatched port range is chosen. SVC addresses can only
+// be registered with the dispatcher.
+func (s *DirectPacketDispatcherService) Register(ctx context.Context, ia addr.IA,
+	registration *net.UDPAddr, svc addr.HostSVC) (PacketConn, uint16, error) {
+
+	if registration == nil {
+		return nil, 0, serrors.New("nil registration address")
+	}
+	if svc != addr.SvcNone {
+		return nil, 0, serrors.New("SVC addresses require the dispatcher", "svc", svc)
+	}
+	if s.StartPort == 0 || s.StartPort > s.EndPort {
+		return nil, 0, serrors.New("invalid dispatched port range",
+			"start", s.StartPort, "end", s.EndPort)
+	}
+	conn, err := s.listen(ctx, registration)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &SCIONPacketConn{
+		conn:          conn,
+		scmpHandler:   s.SCMPHandler,
+		authenticator: s.Authenticator,
+	}, uint16(conn.LocalAddr().(*net.UDPAddr).Port), nil
+}
+
+func (s *DirectPacketDispatcherService) listen(ctx context.Context,
+	registration *net.UDPAddr) (*net.UDPConn, error) {
+
+	if registration.Port != 0 {
+		if registration.Port < int(s.StartPort) || registration.Port > int(s.EndPort) {
+			return nil, serrors.New("port outside of the dispatched port range",
+				"port", registration.Port, "start", s.StartPort, "end", s.EndPort)
+		}
+		return net.ListenUDP("udp", registration)
+	}
+	// Start at a random port in the range, such that applications that start
+	// at the same time are unlikely to race for the same ports.
+	size := int(s.EndPort) - int(s.StartPort) + 1
+	offset := rand.Intn(size)
+	for i := 0; i < size; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		local := &net.UDPAddr{
+			IP:   registration.IP,
+			Port: int(s.StartPort) + (offset+i)%size,
+			Zone: registration.Zone,
+		}
+		conn, err := net.ListenUDP("udp", local)
+		if err == nil {
+			return conn, nil
+		}
+		if !errors.Is(err, syscall.EADDRINUSE) {
+			return nil, err
+		}
+	}
+	return nil, serrors.New("no free port in the dispatched port range",
+		"start", s.StartPort, "end", s.EndPort)
+}
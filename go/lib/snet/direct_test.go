@@ -0,0 +1,114 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestDirectPacketDispatcherServiceRegister(t *testing.T) {
+	const start, end = 41000, 41099
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	localhost := net.IP{127, 0, 0, 1}
+
+	testCases := map[string]struct {
+		StartPort    uint16
+		EndPort      uint16
+		Registration *net.UDPAddr
+		SVC          addr.HostSVC
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"free port in range": {
+			StartPort:    start,
+			EndPort:      end,
+			Registration: &net.UDPAddr{IP: localhost},
+			SVC:          addr.SvcNone,
+			ErrAssertion: assert.NoError,
+		},
+		"port outside of range": {
+			StartPort:    start,
+			EndPort:      end,
+			Registration: &net.UDPAddr{IP: localhost, Port: end + 1},
+			SVC:          addr.SvcNone,
+			ErrAssertion: assert.Error,
+		},
+		"SVC address": {
+			StartPort:    start,
+			EndPort:      end,
+			Registration: &net.UDPAddr{IP: localhost},
+			SVC:          addr.SvcCS,
+			ErrAssertion: assert.Error,
+		},
+		"no range": {
+			Registration: &net.UDPAddr{IP: localhost},
+			SVC:          addr.SvcNone,
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			s := &snet.DirectPacketDispatcherService{
+				StartPort: tc.StartPort,
+				EndPort:   tc.EndPort,
+			}
+			conn, port, err := s.Register(context.Background(), ia, tc.Registration, tc.SVC)
+			tc.ErrAssertion(t, err)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			assert.GreaterOrEqual(t, port, tc.StartPort)
+			assert.LessOrEqual(t, port, tc.EndPort)
+		})
+	}
+}
+
+func TestDirectNetworkLocalDelivery(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	localhost := &net.UDPAddr{IP: net.IP{127, 0, 0, 1}}
+	network := snet.NewDirectNetwork(ia, 41100, 41199, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	sender, err := network.Listen(ctx, "udp", localhost, addr.SvcNone)
+	require.NoError(t, err)
+	defer sender.Close()
+	receiver, err := network.Listen(ctx, "udp", localhost, addr.SvcNone)
+	require.NoError(t, err)
+	defer receiver.Close()
+
+	dst := receiver.LocalAddr().(*net.UDPAddr)
+	_, err = sender.WriteTo([]byte("hello"), &snet.UDPAddr{IA: ia, Host: dst})
+	require.NoError(t, err)
+
+	require.NoError(t, receiver.SetReadDeadline(time.Now().Add(time.Second)))
+	buf := make([]byte, 16)
+	n, src, err := receiver.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf[:n]))
+	assert.Equal(t, sender.LocalAddr().(*net.UDPAddr).Port, src.(*snet.UDPAddr).Host.Port)
+}
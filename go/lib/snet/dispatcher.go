@@ -48,6 +48,10 @@ type DefaultPacketDispatcherService struct {
 	// handler is nil, errors are returned back to applications every time an
 	// SCMP message is received.
 	SCMPHandler SCMPHandler
+	// Authenticator authenticates the packets sent and received on the
+	// registered connections. Received data packets that fail the verification
+	// are dropped. If it is nil, packets are not authenticated.
+	Authenticator PacketAuthenticator
 }
 
 func (s *DefaultPacketDispatcherService) Register(ctx context.Context, ia addr.IA,
@@ -58,8 +62,9 @@ func (s *DefaultPacketDispatcherService) Register(ctx context.Context, ia addr.I
 		return nil, 0, err
 	}
 	return &SCIONPacketConn{
-		conn:        rconn,
-		scmpHandler: s.SCMPHandler,
+		conn:          rconn,
+		scmpHandler:   s.SCMPHandler,
+		authenticator: s.Authenticator,
 	}, port, nil
 }
 
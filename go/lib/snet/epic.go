@@ -0,0 +1,97 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"sync/atomic"
+	"time"
+
+	libepic "github.com/scionproto/scion/go/lib/epic"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/epic"
+	"github.com/scionproto/scion/go/lib/spath"
+)
+
+// epicCounter is the counter of the EPIC packet identifiers. Together with the
+// timestamp, it makes the packet identifiers of a host unique.
+var epicCounter uint32
+
+// epicHVFs computes the hop validation fields of the packets with the EPIC
+// path type.
+type epicHVFs struct {
+	path  *epic.Path
+	auths spath.EpicAuths
+	// timestamp is the timestamp of the last info field, which the border
+	// routers use to verify the hop validation fields.
+	timestamp uint32
+}
+
+// newEpicHVFs sets the packet identifier of the EPIC path. The hop validation
+// fields are set to zero until they are computed by set.
+func newEpicHVFs(p *epic.Path, auths spath.EpicAuths, now time.Time) (*epicHVFs, error) {
+	if !auths.SupportsEpic() {
+		return nil, serrors.New("EPIC authenticators missing")
+	}
+	if p.ScionPath == nil {
+		return nil, serrors.New("SCION path is nil")
+	}
+	decoded, err := p.ScionPath.ToDecoded()
+	if err != nil {
+		return nil, serrors.WrapStr("decoding SCION path", err)
+	}
+	if decoded.NumINF == 0 {
+		return nil, serrors.New("SCION path has no info fields")
+	}
+	timestamp := decoded.InfoFields[decoded.NumINF-1].Timestamp
+	pktTS, err := libepic.CreateTimestamp(time.Unix(int64(timestamp), 0), now)
+	if err != nil {
+		return nil, serrors.WrapStr("creating EPIC timestamp", err)
+	}
+	p.PktID = epic.PktID{
+		Timestamp: pktTS,
+		Counter:   atomic.AddUint32(&epicCounter, 1),
+	}
+	p.PHVF = make([]byte, epic.HVFLen)
+	p.LHVF = make([]byte, epic.HVFLen)
+	return &epicHVFs{
+		path:      p,
+		auths:     auths,
+		timestamp: timestamp,
+	}, nil
+}
+
+// set computes the hop validation fields and writes them to the serialized
+// packet b. The SCION header s must be the header that was serialized to b,
+// such that the payload length is set.
+func (h *epicHVFs) set(b []byte, s *slayers.SCION) error {
+	phvf, err := libepic.CalcMac(h.auths.AuthPHVF, h.path.PktID, s, h.timestamp)
+	if err != nil {
+		return serrors.WrapStr("computing PHVF", err)
+	}
+	lhvf, err := libepic.CalcMac(h.auths.AuthLHVF, h.path.PktID, s, h.timestamp)
+	if err != nil {
+		return serrors.WrapStr("computing LHVF", err)
+	}
+	offset := slayers.CmnHdrLen + s.AddrHdrLen() + epic.PktIDLen
+	if len(b) < offset+2*epic.HVFLen {
+		return serrors.New("packet too short for EPIC path", "length", len(b))
+	}
+	copy(h.path.PHVF, phvf)
+	copy(h.path.LHVF, lhvf)
+	copy(b[offset:], phvf)
+	copy(b[offset+epic.HVFLen:], lhvf)
+	return nil
+}
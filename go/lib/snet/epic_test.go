@@ -0,0 +1,131 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	libepic "github.com/scionproto/scion/go/lib/epic"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/epic"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestPacketSerializeEpic(t *testing.T) {
+	timestamp := util.TimeToSecs(time.Now().Add(-time.Minute))
+	scionP := scion.Decoded{
+		Base: scion.Base{
+			PathMeta: scion.MetaHdr{
+				SegLen: [3]uint8{3, 0, 0},
+			},
+			NumINF:  1,
+			NumHops: 3,
+		},
+		InfoFields: []*path.InfoField{{ConsDir: true, Timestamp: timestamp}},
+		HopFields: []*path.HopField{
+			{ConsEgress: 4, Mac: make([]byte, path.MacLen)},
+			{ConsIngress: 1, ConsEgress: 2, Mac: make([]byte, path.MacLen)},
+			{ConsIngress: 3, Mac: make([]byte, path.MacLen)},
+		},
+	}
+	rawSP := make([]byte, scionP.Len())
+	require.NoError(t, scionP.SerializeTo(rawSP))
+	auths := spath.EpicAuths{
+		AuthPHVF: bytes.Repeat([]byte{1}, libepic.AuthLen),
+		AuthLHVF: bytes.Repeat([]byte{2}, libepic.AuthLen),
+	}
+
+	testCases := map[string]struct {
+		Meta         snet.PathMetadata
+		ErrAssertion assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Meta:         snet.PathMetadata{EpicAuths: auths},
+			ErrAssertion: assert.NoError,
+		},
+		"no authenticators": {
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			p, err := snetpath.EPIC(snetpath.Path{
+				Dst:   xtest.MustParseIA("1-ff00:0:110"),
+				SPath: spath.Path{Raw: rawSP, Type: scion.PathType},
+				Meta:  tc.Meta,
+			})
+			tc.ErrAssertion(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, epic.PathType, p.Path().Type)
+
+			pkt := &snet.Packet{
+				PacketInfo: snet.PacketInfo{
+					Destination: snet.SCIONAddress{
+						IA:   xtest.MustParseIA("1-ff00:0:110"),
+						Host: addr.HostIPv4(net.ParseIP("127.0.0.2").To4()),
+					},
+					Source: snet.SCIONAddress{
+						IA:   xtest.MustParseIA("1-ff00:0:112"),
+						Host: addr.HostIPv4(net.ParseIP("127.0.0.1").To4()),
+					},
+					Path: p.Path(),
+					Payload: snet.UDPPayload{
+						SrcPort: 25,
+						DstPort: 1925,
+						Payload: []byte("hello packet"),
+					},
+				},
+			}
+			require.NoError(t, pkt.Serialize())
+
+			var scn slayers.SCION
+			require.NoError(t, scn.DecodeFromBytes(pkt.Bytes, gopacket.NilDecodeFeedback))
+			ep, ok := scn.Path.(*epic.Path)
+			require.True(t, ok)
+			assert.NoError(t, libepic.VerifyTimestamp(time.Unix(int64(timestamp), 0),
+				ep.PktID.Timestamp, time.Now()))
+			assert.NoError(t, libepic.VerifyHVF(auths.AuthPHVF, ep.PktID, &scn,
+				timestamp, ep.PHVF))
+			assert.NoError(t, libepic.VerifyHVF(auths.AuthLHVF, ep.PktID, &scn,
+				timestamp, ep.LHVF))
+
+			// The reversed path is a SCION path.
+			decoded := snet.Packet{Bytes: pkt.Bytes}
+			require.NoError(t, decoded.Decode())
+			rev := decoded.Path.Copy()
+			require.NoError(t, rev.Reverse())
+			assert.Equal(t, scion.PathType, rev.Type)
+			assert.False(t, rev.EpicAuths.SupportsEpic())
+		})
+	}
+}
@@ -32,6 +32,7 @@ const (
 	subSCMPError       = "scmp_error"
 	subDispatcherError = "dispatcher_error"
 	subParseError      = "parse_error"
+	subAuthError       = "auth_error"
 )
 
 var (
@@ -50,6 +51,7 @@ type metrics struct {
 	parseErrors      prometheus.Counter
 	scmpErrors       prometheus.Counter
 	dispatcherErrors prometheus.Counter
+	authErrors       prometheus.Counter
 }
 
 func newMetrics() metrics {
@@ -74,6 +76,8 @@ func newMetrics() metrics {
 			"Total number of dispatcher errors"),
 		parseErrors: prom.NewCounter(Namespace, subParseError, "total",
 			"Total number of parse errors"),
+		authErrors: prom.NewCounter(Namespace, subAuthError, "total",
+			"Total number of packets dropped because of failed authentication"),
 	}
 }
 
@@ -126,3 +130,8 @@ func (m metrics) SCMPErrors() prometheus.Counter {
 func (m metrics) ParseErrors() prometheus.Counter {
 	return m.parseErrors
 }
+
+// AuthErrors returns the authentication errors counter.
+func (m metrics) AuthErrors() prometheus.Counter {
+	return m.authErrors
+}
@@ -16,6 +16,7 @@ package snet
 
 import (
 	"net"
+	"time"
 
 	"github.com/google/gopacket"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/epic"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	"github.com/scionproto/scion/go/lib/spath"
 )
@@ -293,7 +295,7 @@ func (p *Packet) Decode() error {
 	var (
 		scionLayer slayers.SCION
 		hbhLayer   slayers.HopByHopExtnSkipper
-		e2eLayer   slayers.EndToEndExtnSkipper
+		e2eLayer   slayers.EndToEndExtn
 		udpLayer   slayers.UDP
 		scmpLayer  slayers.SCMP
 	)
@@ -330,6 +332,12 @@ func (p *Packet) Decode() error {
 	}
 	p.Destination = SCIONAddress{IA: scionLayer.DstIA, Host: dstHost}
 	p.Source = SCIONAddress{IA: scionLayer.SrcIA, Host: srcHost}
+	p.E2EOptions = nil
+	for _, opt := range e2eLayer.Options {
+		if opt.OptType != slayers.OptTypePad1 && opt.OptType != slayers.OptTypePadN {
+			p.E2EOptions = append(p.E2EOptions, opt)
+		}
+	}
 	// A path of length 4 is an empty path, because it only contains the mandatory
 	// minimal header.
 	if l := scionLayer.Path.Len(); l > 4 {
@@ -524,9 +532,25 @@ func (p *Packet) Serialize() error {
 			return err
 		}
 	}
+	// The hop validation fields of EPIC paths depend on the packet, they are
+	// computed after the packet is serialized.
+	var hvfs *epicHVFs
+	if p.Path.Type == epic.PathType {
+		hvfs, err = newEpicHVFs(scionLayer.Path.(*epic.Path), p.Path.EpicAuths, time.Now())
+		if err != nil {
+			return serrors.WrapStr("preparing EPIC path", err)
+		}
+	}
 
 	packetLayers = append(packetLayers, &scionLayer)
-	packetLayers = append(packetLayers, p.Payload.toLayers(&scionLayer)...)
+	l4Layers := p.Payload.toLayers(&scionLayer)
+	if len(p.E2EOptions) > 0 {
+		e2e := &slayers.EndToEndExtn{Options: p.E2EOptions}
+		e2e.NextHdr = scionLayer.NextHdr
+		scionLayer.NextHdr = common.End2EndClass
+		packetLayers = append(packetLayers, e2e)
+	}
+	packetLayers = append(packetLayers, l4Layers...)
 
 	buffer := gopacket.NewSerializeBuffer()
 	options := gopacket.SerializeOptions{
@@ -536,6 +560,11 @@ func (p *Packet) Serialize() error {
 	if err := gopacket.SerializeLayers(buffer, options, packetLayers...); err != nil {
 		return err
 	}
+	if hvfs != nil {
+		if err := hvfs.set(buffer.Bytes(), &scionLayer); err != nil {
+			return serrors.WrapStr("setting EPIC hop validation fields", err)
+		}
+	}
 	copy(p.Bytes, buffer.Bytes())
 	p.Bytes = p.Bytes[:len(buffer.Bytes())]
 	return nil
@@ -558,6 +587,11 @@ type PacketInfo struct {
 	// If the source and destination are in different ASes but the path is
 	// nil or empty, an error is returned during serialization.
 	Path spath.Path
+	// E2EOptions contains the options of the end-to-end extension. If it is
+	// empty, the packet does not contain an end-to-end extension. For decoded
+	// packets, padding options are omitted and the option data points into the
+	// raw packet bytes.
+	E2EOptions []*slayers.EndToEndOption
 	// Payload is the Payload of the message.
 	Payload Payload
 }
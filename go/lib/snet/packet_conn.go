@@ -21,6 +21,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/lib/snet/internal/metrics"
@@ -100,6 +101,11 @@ type SCIONPacketConn struct {
 	// handler is nil, errors are returned back to applications every time an
 	// SCMP message is received.
 	scmpHandler SCMPHandler
+	// authenticator authenticates the packets sent and received on the
+	// connection. Received data packets that fail the verification are
+	// dropped. SCMP packets are not verified. If it is nil, packets are not
+	// authenticated.
+	authenticator PacketAuthenticator
 }
 
 // NewSCIONPacketConn creates a new conn with packet serialization/decoding
@@ -123,7 +129,11 @@ func (c *SCIONPacketConn) Close() error {
 }
 
 func (c *SCIONPacketConn) WriteTo(pkt *Packet, ov *net.UDPAddr) error {
-	if err := pkt.Serialize(); err != nil {
+	if c.authenticator != nil {
+		if err := c.authenticator.Sign(pkt); err != nil {
+			return serrors.WrapStr("authenticate SCION packet", err)
+		}
+	} else if err := pkt.Serialize(); err != nil {
 		return serrors.WrapStr("serialize SCION packet", err)
 	}
 
@@ -161,6 +171,14 @@ func (c *SCIONPacketConn) ReadFrom(pkt *Packet, ov *net.UDPAddr) error {
 			}
 			continue
 		}
+		if c.authenticator != nil {
+			if err := c.authenticator.Verify(pkt); err != nil {
+				metrics.M.AuthErrors().Inc()
+				log.Debug("Dropping packet that failed authentication", "err", err,
+					"src", pkt.Source)
+				continue
+			}
+		}
 		// non-SCMP L4s are assumed to be data and get passed back to the
 		// app.
 		return nil
@@ -114,6 +114,11 @@ type PathMetadata struct {
 	// Notes contains the notes added by ASes on the path, in the order of occurrence.
 	// Entry i is the note of AS i on the path.
 	Notes []string
+
+	// EpicAuths contains the EPIC authenticators of the penultimate and the
+	// last hop. They are only set if the ASes on the path added the EPIC
+	// extension to the path segments.
+	EpicAuths spath.EpicAuths
 }
 
 func (pm *PathMetadata) Copy() *PathMetadata {
@@ -130,6 +135,7 @@ func (pm *PathMetadata) Copy() *PathMetadata {
 		LinkType:     append(pm.LinkType[:0:0], pm.LinkType...),
 		InternalHops: append(pm.InternalHops[:0:0], pm.InternalHops...),
 		Notes:        append(pm.Notes[:0:0], pm.Notes...),
+		EpicAuths:    pm.EpicAuths.Copy(),
 	}
 }
 
@@ -0,0 +1,61 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package path
+
+import (
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path/epic"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spath"
+)
+
+// EPIC returns a copy of the path p that sends the traffic with the EPIC path
+// type. The hop validation fields are computed for every packet from the EPIC
+// authenticators in the path metadata. An error is returned if p is not a
+// SCION path or if its metadata does not contain the EPIC authenticators.
+func EPIC(p snet.Path) (Path, error) {
+	meta := p.Metadata()
+	if meta == nil || !meta.EpicAuths.SupportsEpic() {
+		return Path{}, serrors.New("path does not support EPIC")
+	}
+	sp := p.Path()
+	if sp.Type != scion.PathType {
+		return Path{}, serrors.New("unsupported path type", "type", sp.Type)
+	}
+	var raw scion.Raw
+	if err := raw.DecodeFromBytes(sp.Raw); err != nil {
+		return Path{}, serrors.WrapStr("decoding SCION path", err)
+	}
+	ep := &epic.Path{
+		PHVF:      make([]byte, epic.HVFLen),
+		LHVF:      make([]byte, epic.HVFLen),
+		ScionPath: &raw,
+	}
+	b := make([]byte, ep.Len())
+	if err := ep.SerializeTo(b); err != nil {
+		return Path{}, serrors.WrapStr("serializing EPIC path", err)
+	}
+	return Path{
+		Dst: p.Destination(),
+		SPath: spath.Path{
+			Raw:       b,
+			Type:      epic.PathType,
+			EpicAuths: meta.EpicAuths,
+		},
+		NextHop: p.UnderlayNextHop(),
+		Meta:    *meta,
+	}, nil
+}
@@ -51,6 +51,7 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet/internal/metrics"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
 )
 
 var _ Network = (*SCIONNetwork)(nil)
@@ -59,6 +60,14 @@ var _ Network = (*SCIONNetwork)(nil)
 type SCIONNetwork struct {
 	LocalIA    addr.IA
 	Dispatcher PacketDispatcherService
+	// DispatchedPortStart and DispatchedPortEnd are the first and last port
+	// of the range on which end hosts in the local AS receive packets
+	// directly. Packets to a host in the local AS are sent directly to the
+	// destination port if it is in the range, and to the dispatcher
+	// otherwise. If the start is zero, all packets are sent to the
+	// dispatcher.
+	DispatchedPortStart uint16
+	DispatchedPortEnd   uint16
 }
 
 // NewNetwork creates a new networking context.
@@ -76,6 +85,26 @@ func NewNetwork(ia addr.IA, dispatcher reliable.Dispatcher,
 	}
 }
 
+// NewDirectNetwork creates a new networking context that sends and receives
+// packets on plain UDP sockets in the dispatched port range [start, end],
+// without the dispatcher.
+func NewDirectNetwork(ia addr.IA, start, end uint16,
+	revHandler RevocationHandler) *SCIONNetwork {
+
+	return &SCIONNetwork{
+		LocalIA: ia,
+		Dispatcher: &DirectPacketDispatcherService{
+			StartPort: start,
+			EndPort:   end,
+			SCMPHandler: &DefaultSCMPHandler{
+				RevocationHandler: revHandler,
+			},
+		},
+		DispatchedPortStart: start,
+		DispatchedPortEnd:   end,
+	}
+}
+
 // Dial returns a SCION connection to remote. Nil values for listen are not
 // supported yet. Parameter network must be "udp". The returned connection's
 // Read and Write methods can be used to receive and send SCION packets.
@@ -147,3 +176,14 @@ func (n *SCIONNetwork) Listen(ctx context.Context, network string, listen *net.U
 	log.Debug("Registered with dispatcher", "addr", &UDPAddr{IA: n.LocalIA, Host: conn.listen})
 	return newConn(conn, packetConn), nil
 }
+
+// endhostPort returns the underlay port on which a host in the local AS
+// receives packets for the given L4 port.
+func (n *SCIONNetwork) endhostPort(port int) int {
+	if n.DispatchedPortStart != 0 && port >= int(n.DispatchedPortStart) &&
+		port <= int(n.DispatchedPortEnd) {
+
+		return port
+	}
+	return underlay.EndhostPort
+}
@@ -0,0 +1,199 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/subtle"
+	"encoding/binary"
+	"time"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+)
+
+// SPAOMACLen is the length of the authenticator computed with
+// slayers.PacketAuthCMAC.
+const SPAOMACLen = aes.BlockSize
+
+// DefaultSPAOKeyTimeout is the default timeout to get the key for a packet.
+const DefaultSPAOKeyTimeout = time.Second
+
+// PacketAuthenticator authenticates the packets sent and received on a
+// SCIONPacketConn.
+type PacketAuthenticator interface {
+	// Sign serializes the packet and adds an authenticator to it.
+	Sign(pkt *Packet) error
+	// Verify verifies the authenticator of a decoded packet.
+	Verify(pkt *Packet) error
+}
+
+// SPAOKeyProvider provides the symmetric keys used to authenticate packets with
+// the SCION packet authenticator option (SPAO).
+type SPAOKeyProvider interface {
+	// SPAOKey returns the key that authenticates the packets sent from src to
+	// dst. The sender and the receiver of a packet must obtain the same key.
+	SPAOKey(ctx context.Context, src, dst SCIONAddress) ([]byte, error)
+}
+
+var _ PacketAuthenticator = SPAOAuthenticator{}
+
+// SPAOAuthenticator authenticates packets with the SCION packet authenticator
+// option. The authenticator is an AES-CMAC over the addresses of the packet
+// and the upper layer, i.e., everything after the end-to-end extension. The
+// path is not authenticated, because it is modified on the way.
+type SPAOAuthenticator struct {
+	// KeyProvider provides the keys to compute the authenticators.
+	KeyProvider SPAOKeyProvider
+	// Timeout is the timeout to get the key for a packet. If it is zero,
+	// DefaultSPAOKeyTimeout is used.
+	Timeout time.Duration
+}
+
+// Sign serializes the packet with an authenticator option. Existing
+// authenticator options in the packet are replaced.
+func (a SPAOAuthenticator) Sign(pkt *Packet) error {
+	key, err := a.key(pkt.Source, pkt.Destination)
+	if err != nil {
+		return err
+	}
+	opt := slayers.NewPacketAuthenticatorOption(slayers.PacketAuthCMAC,
+		make([]byte, SPAOMACLen))
+	options := []*slayers.EndToEndOption{opt.EndToEndOption}
+	for _, o := range pkt.E2EOptions {
+		if o.OptType != slayers.OptTypeAuthenticator {
+			options = append(options, o)
+		}
+	}
+	pkt.E2EOptions = options
+	if err := pkt.Serialize(); err != nil {
+		return err
+	}
+	scn, auth, upper, err := decodeSPAO(pkt.Bytes)
+	if err != nil {
+		return serrors.WrapStr("decoding serialized packet", err)
+	}
+	mac, err := spaoMAC(key, scn, auth.Algorithm(), upper)
+	if err != nil {
+		return err
+	}
+	// The decoded authenticator points into the raw packet bytes.
+	copy(auth.Authenticator(), mac)
+	copy(opt.Authenticator(), mac)
+	return nil
+}
+
+// Verify verifies the authenticator option of a decoded packet. Packets
+// without authenticator option are rejected.
+func (a SPAOAuthenticator) Verify(pkt *Packet) error {
+	scn, auth, upper, err := decodeSPAO(pkt.Bytes)
+	if err != nil {
+		return err
+	}
+	if alg := auth.Algorithm(); alg != slayers.PacketAuthCMAC {
+		return serrors.New("unsupported authenticator algorithm", "alg", alg)
+	}
+	if len(auth.Authenticator()) != SPAOMACLen {
+		return serrors.New("invalid authenticator length", "expected", SPAOMACLen,
+			"actual", len(auth.Authenticator()))
+	}
+	key, err := a.key(pkt.Source, pkt.Destination)
+	if err != nil {
+		return err
+	}
+	mac, err := spaoMAC(key, scn, auth.Algorithm(), upper)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(mac, auth.Authenticator()) != 1 {
+		return serrors.New("invalid authenticator", "src", pkt.Source)
+	}
+	return nil
+}
+
+func (a SPAOAuthenticator) key(src, dst SCIONAddress) ([]byte, error) {
+	timeout := a.Timeout
+	if timeout == 0 {
+		timeout = DefaultSPAOKeyTimeout
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+	key, err := a.KeyProvider.SPAOKey(ctx, src, dst)
+	if err != nil {
+		return nil, serrors.WrapStr("getting authentication key", err,
+			"src", src, "dst", dst)
+	}
+	return key, nil
+}
+
+// decodeSPAO decodes the SCION header and the authenticator option of the raw
+// packet. It returns the upper layer bytes that follow the end-to-end
+// extension.
+func decodeSPAO(raw []byte) (*slayers.SCION, slayers.PacketAuthenticatorOption,
+	[]byte, error) {
+
+	var (
+		scn slayers.SCION
+		hbh slayers.HopByHopExtnSkipper
+		e2e slayers.EndToEndExtn
+	)
+	parser := gopacket.NewDecodingLayerParser(slayers.LayerTypeSCION, &scn, &hbh, &e2e)
+	parser.IgnoreUnsupported = true
+	decoded := make([]gopacket.LayerType, 0, 3)
+	if err := parser.DecodeLayers(raw, &decoded); err != nil {
+		return nil, slayers.PacketAuthenticatorOption{}, nil, err
+	}
+	if len(decoded) == 0 || decoded[len(decoded)-1] != slayers.LayerTypeEndToEndExtn {
+		return nil, slayers.PacketAuthenticatorOption{}, nil,
+			serrors.New("end-to-end extension missing")
+	}
+	opt, err := e2e.FindOption(slayers.OptTypeAuthenticator)
+	if err != nil {
+		return nil, slayers.PacketAuthenticatorOption{}, nil,
+			serrors.WrapStr("finding authenticator option", err)
+	}
+	auth, err := slayers.ParsePacketAuthenticatorOption(opt)
+	if err != nil {
+		return nil, slayers.PacketAuthenticatorOption{}, nil, err
+	}
+	return &scn, auth, e2e.Payload, nil
+}
+
+// spaoMAC computes the authenticator over the algorithm, the ISD-AS and host
+// addresses of the packet, and the upper layer.
+func spaoMAC(key []byte, scn *slayers.SCION, alg slayers.PacketAuthAlg,
+	upper []byte) ([]byte, error) {
+
+	mac, err := scrypto.InitMac(key)
+	if err != nil {
+		return nil, serrors.WrapStr("initializing MAC", err)
+	}
+	hdr := make([]byte, 2+2*addr.IABytes)
+	hdr[0] = byte(alg)
+	binary.BigEndian.PutUint64(hdr[1:], uint64(scn.DstIA.IAInt()))
+	binary.BigEndian.PutUint64(hdr[1+addr.IABytes:], uint64(scn.SrcIA.IAInt()))
+	hdr[1+2*addr.IABytes] = uint8(scn.DstAddrType&0x3)<<6 | uint8(scn.DstAddrLen&0x3)<<4 |
+		uint8(scn.SrcAddrType&0x3)<<2 | uint8(scn.SrcAddrLen&0x3)
+	mac.Write(hdr)
+	mac.Write(scn.RawDstAddr)
+	mac.Write(scn.RawSrcAddr)
+	mac.Write(upper)
+	return mac.Sum(nil), nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+type staticKeyProvider []byte
+
+func (p staticKeyProvider) SPAOKey(context.Context, snet.SCIONAddress,
+	snet.SCIONAddress) ([]byte, error) {
+
+	if p == nil {
+		return nil, serrors.New("no key")
+	}
+	return p, nil
+}
+
+func TestSPAOAuthenticator(t *testing.T) {
+	newPacket := func() *snet.Packet {
+		return &snet.Packet{
+			PacketInfo: snet.PacketInfo{
+				Destination: snet.SCIONAddress{
+					IA:   xtest.MustParseIA("1-ff00:0:110"),
+					Host: addr.HostIPv4(net.ParseIP("127.0.0.2").To4()),
+				},
+				Source: snet.SCIONAddress{
+					IA:   xtest.MustParseIA("1-ff00:0:110"),
+					Host: addr.HostIPv4(net.ParseIP("127.0.0.1").To4()),
+				},
+				Path: spath.Path{},
+				Payload: snet.UDPPayload{
+					SrcPort: 25,
+					DstPort: 1925,
+					Payload: []byte("hello packet"),
+				},
+			},
+		}
+	}
+	signer := snet.SPAOAuthenticator{KeyProvider: staticKeyProvider("0123456789abcdef")}
+	// receive decodes a copy of the signed packet.
+	receive := func(t *testing.T, pkt *snet.Packet) *snet.Packet {
+		received := &snet.Packet{Bytes: append(snet.Bytes(nil), pkt.Bytes...)}
+		require.NoError(t, received.Decode())
+		return received
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		pkt := newPacket()
+		require.NoError(t, signer.Sign(pkt))
+		received := receive(t, pkt)
+		assert.Equal(t, pkt.Payload, received.Payload)
+		require.Len(t, received.E2EOptions, 1)
+		opt, err := slayers.ParsePacketAuthenticatorOption(received.E2EOptions[0])
+		require.NoError(t, err)
+		assert.Equal(t, slayers.PacketAuthCMAC, opt.Algorithm())
+		assert.Len(t, opt.Authenticator(), snet.SPAOMACLen)
+		assert.NoError(t, signer.Verify(received))
+	})
+	t.Run("resign", func(t *testing.T) {
+		pkt := newPacket()
+		require.NoError(t, signer.Sign(pkt))
+		require.NoError(t, signer.Sign(pkt))
+		received := receive(t, pkt)
+		assert.Len(t, received.E2EOptions, 1)
+		assert.NoError(t, signer.Verify(received))
+	})
+	t.Run("modified payload", func(t *testing.T) {
+		pkt := newPacket()
+		require.NoError(t, signer.Sign(pkt))
+		pkt.Bytes[len(pkt.Bytes)-1] ^= 0xff
+		received := &snet.Packet{Bytes: pkt.Bytes}
+		assert.Error(t, signer.Verify(received))
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		pkt := newPacket()
+		require.NoError(t, signer.Sign(pkt))
+		verifier := snet.SPAOAuthenticator{
+			KeyProvider: staticKeyProvider("fedcba9876543210"),
+		}
+		assert.Error(t, verifier.Verify(receive(t, pkt)))
+	})
+	t.Run("no key", func(t *testing.T) {
+		pkt := newPacket()
+		assert.Error(t, snet.SPAOAuthenticator{KeyProvider: staticKeyProvider(nil)}.Sign(pkt))
+	})
+	t.Run("unauthenticated", func(t *testing.T) {
+		pkt := newPacket()
+		require.NoError(t, pkt.Serialize())
+		assert.Error(t, signer.Verify(receive(t, pkt)))
+	})
+}
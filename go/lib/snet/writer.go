@@ -25,7 +25,6 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/spath"
-	"github.com/scionproto/scion/go/lib/topology/underlay"
 )
 
 type scionConnWriter struct {
@@ -64,7 +63,7 @@ func (c *scionConnWriter) WriteTo(b []byte, raddr net.Addr) (int, error) {
 		if nextHop == nil && c.base.scionNet.LocalIA.Equal(a.IA) {
 			nextHop = &net.UDPAddr{
 				IP:   a.Host.IP,
-				Port: underlay.EndhostPort,
+				Port: c.base.scionNet.endhostPort(a.Host.Port),
 				Zone: a.Host.Zone,
 			}
 
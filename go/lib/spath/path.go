@@ -23,8 +23,10 @@ import (
 	"math/big"
 	"time"
 
+	libepic "github.com/scionproto/scion/go/lib/epic"
 	"github.com/scionproto/scion/go/lib/slayers/path"
 	"github.com/scionproto/scion/go/lib/slayers/path/empty"
+	"github.com/scionproto/scion/go/lib/slayers/path/epic"
 	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	"github.com/scionproto/scion/go/lib/util"
@@ -44,6 +46,35 @@ var (
 type Path struct {
 	Raw  []byte
 	Type path.Type
+	// EpicAuths contains the authenticators that are needed to compute the hop
+	// validation fields of the EPIC path type. It is ignored for other path
+	// types.
+	EpicAuths EpicAuths
+}
+
+// EpicAuths contains the EPIC authenticators of the penultimate and the last
+// hop of a path. An authenticator is the full 16 byte MAC of the hop field.
+type EpicAuths struct {
+	// AuthPHVF is the authenticator of the penultimate hop, which is used to
+	// compute the penultimate hop validation field (PHVF).
+	AuthPHVF []byte
+	// AuthLHVF is the authenticator of the last hop, which is used to compute
+	// the last hop validation field (LHVF).
+	AuthLHVF []byte
+}
+
+// SupportsEpic returns whether the authenticators are available, i.e., whether
+// the path can be used with the EPIC path type.
+func (ea EpicAuths) SupportsEpic() bool {
+	return len(ea.AuthPHVF) == libepic.AuthLen && len(ea.AuthLHVF) == libepic.AuthLen
+}
+
+// Copy returns a deep copy of the authenticators.
+func (ea EpicAuths) Copy() EpicAuths {
+	return EpicAuths{
+		AuthPHVF: append(ea.AuthPHVF[:0:0], ea.AuthPHVF...),
+		AuthLHVF: append(ea.AuthLHVF[:0:0], ea.AuthLHVF...),
+	}
 }
 
 // NewOneHop creates a onehop path that has the first hopfield initialized.
@@ -81,8 +112,9 @@ func (p Path) IsEmpty() bool {
 
 func (p Path) Copy() Path {
 	return Path{
-		Raw:  append(p.Raw[:0:0], p.Raw...),
-		Type: p.Type,
+		Raw:       append(p.Raw[:0:0], p.Raw...),
+		Type:      p.Type,
+		EpicAuths: p.EpicAuths.Copy(),
 	}
 }
 
@@ -102,6 +134,13 @@ func (p *Path) Reverse() error {
 	if err != nil {
 		return err
 	}
+	// The hop validation fields of the reversed EPIC path cannot be computed
+	// without the authenticators of the reverse direction. Hence, the reversed
+	// path is the underlying SCION path.
+	if ep, ok := po.(*epic.Path); ok {
+		po = ep.ScionPath
+		p.EpicAuths = EpicAuths{}
+	}
 	p.Type = po.Type()
 	l := po.Len()
 	if l > len(p.Raw) {
@@ -43,6 +43,10 @@ type Topology interface {
 	IA() addr.IA
 	// MTU returns the MTU of the local AS.
 	MTU() uint16
+	// PortRange returns the first and last port of the range on which end
+	// hosts receive packets directly from the border routers. Both are zero
+	// if the topology does not define a range.
+	PortRange() (uint16, uint16)
 	// Core returns whether the local AS is core.
 	Core() bool
 	// CA returns whether the local AS is a CA.
@@ -166,6 +170,10 @@ func (t *topologyS) MTU() uint16 {
 	return uint16(t.Topology.MTU)
 }
 
+func (t *topologyS) PortRange() (uint16, uint16) {
+	return t.Topology.DispatchedPortStart, t.Topology.DispatchedPortEnd
+}
+
 func (t *topologyS) InterfaceIDs() []common.IFIDType {
 	intfs := make([]common.IFIDType, 0, len(t.Topology.IFInfoMap))
 	for ifid := range t.Topology.IFInfoMap {
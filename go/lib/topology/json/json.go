@@ -75,6 +75,11 @@ type Topology struct {
 	HiddenSegmentLookup map[string]*ServerInfo  `json:"hidden_segment_lookup_service,omitempty"`
 	HiddenSegmentReg    map[string]*ServerInfo  `json:"hidden_segment_registration_service,omitempty"`
 	SIG                 map[string]*GatewayInfo `json:"sigs,omitempty"`
+	// DispatchedPorts is the range of underlay ports, in the form
+	// "<start>-<end>", on which end hosts receive SCION packets directly from
+	// the border routers. Packets for any other port are delivered to the
+	// dispatcher. If it is empty, all packets are delivered to the dispatcher.
+	DispatchedPorts string `json:"dispatched_ports,omitempty"`
 }
 
 // ServerInfo contains the information for a SCION application running in the local AS.
@@ -94,6 +99,7 @@ type GatewayInfo struct {
 	CtrlAddr   string   `json:"ctrl_addr"`
 	DataAddr   string   `json:"data_addr"`
 	Interfaces []uint64 `json:"allow_interfaces,omitempty"`
+	Priority   uint32   `json:"priority,omitempty"`
 }
 
 // BRInterface contains the information for an data-plane BR socket that is external (i.e., facing
@@ -43,6 +43,7 @@ func TestLoadRawFromFile(t *testing.T) {
 		MTU:            1472,
 		Attributes: []jsontopo.Attribute{jsontopo.Authoritative, jsontopo.AttrCore,
 			jsontopo.Issuing, jsontopo.Voting},
+		DispatchedPorts: "31000-32767",
 		BorderRouters: map[string]*jsontopo.BRInfo{
 			"borderrouter6-f00:0:362-1": {
 				InternalAddr: "10.1.0.1:0",
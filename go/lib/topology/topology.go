@@ -22,6 +22,8 @@ import (
 	"math/rand"
 	"net"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -62,6 +64,11 @@ type (
 		IA         addr.IA
 		Attributes []jsontopo.Attribute
 		MTU        int
+		// DispatchedPortStart and DispatchedPortEnd are the first and last
+		// port of the range on which end hosts receive packets directly from
+		// the border routers. Both are zero if the range is not set.
+		DispatchedPortStart uint16
+		DispatchedPortEnd   uint16
 
 		BR        map[string]BRInfo
 		BRNames   []string
@@ -79,6 +86,9 @@ type (
 		CtrlAddr        *TopoAddr
 		DataAddr        *net.UDPAddr
 		AllowInterfaces []uint64
+		// Priority of the gateway. Remote gateways send the traffic to the
+		// gateway with the highest priority that is reachable.
+		Priority uint32
 	}
 
 	// BRInfo is a list of AS-wide unique interface IDs for a router. These IDs are also used
@@ -204,9 +214,35 @@ func (t *RWTopology) populateMeta(raw *jsontopo.Topology) error {
 	}
 	t.MTU = raw.MTU
 	t.Attributes = raw.Attributes
+	if raw.DispatchedPorts != "" {
+		start, end, err := parsePortRange(raw.DispatchedPorts)
+		if err != nil {
+			return serrors.WrapStr("parsing dispatched ports", err)
+		}
+		t.DispatchedPortStart, t.DispatchedPortEnd = start, end
+	}
 	return nil
 }
 
+func parsePortRange(r string) (uint16, uint16, error) {
+	parts := strings.Split(r, "-")
+	if len(parts) != 2 {
+		return 0, 0, serrors.New("invalid port range, expected <start>-<end>", "range", r)
+	}
+	start, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return 0, 0, serrors.WrapStr("parsing start port", err, "range", r)
+	}
+	end, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil {
+		return 0, 0, serrors.WrapStr("parsing end port", err, "range", r)
+	}
+	if start == 0 || start > end {
+		return 0, 0, serrors.New("invalid port range", "start", start, "end", end)
+	}
+	return uint16(start), uint16(end), nil
+}
+
 func (t *RWTopology) populateBR(raw *jsontopo.Topology) error {
 	for name, rawBr := range raw.BorderRouters {
 		if rawBr.CtrlAddr == "" {
@@ -391,6 +427,9 @@ func (t *RWTopology) Copy() *RWTopology {
 		MTU:        t.MTU,
 		Attributes: append(t.Attributes[:0:0], t.Attributes...),
 
+		DispatchedPortStart: t.DispatchedPortStart,
+		DispatchedPortEnd:   t.DispatchedPortEnd,
+
 		BR:        copyBRMap(t.BR),
 		BRNames:   append(t.BRNames[:0:0], t.BRNames...),
 		IFInfoMap: t.IFInfoMap.copy(),
@@ -410,8 +449,10 @@ func copySIGMap(m map[string]GatewayInfo) map[string]GatewayInfo {
 	ret := make(map[string]GatewayInfo)
 	for k, v := range m {
 		e := GatewayInfo{
-			CtrlAddr: v.CtrlAddr.copy(),
-			DataAddr: copyUDPAddr(v.DataAddr),
+			CtrlAddr:        v.CtrlAddr.copy(),
+			DataAddr:        copyUDPAddr(v.DataAddr),
+			AllowInterfaces: append(v.AllowInterfaces[:0:0], v.AllowInterfaces...),
+			Priority:        v.Priority,
 		}
 		ret[k] = e
 	}
@@ -508,6 +549,7 @@ func gatewayMapFromRaw(ras map[string]*jsontopo.GatewayInfo) (map[string]Gateway
 			CtrlAddr:        c,
 			DataAddr:        d,
 			AllowInterfaces: svc.Interfaces,
+			Priority:        svc.Priority,
 		}
 	}
 	return ret, nil
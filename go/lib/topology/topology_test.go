@@ -36,6 +36,56 @@ func TestMeta(t *testing.T) {
 	assert.Equal(t, addr.IA{I: 1, A: 0xff0000000311}, c.IA, "Field 'ISD_AS'")
 	assert.Equal(t, 1472, c.MTU, "Field 'MTU'")
 	assert.Empty(t, c.Attributes, "Field 'Attributes'")
+	assert.Equal(t, uint16(31000), c.DispatchedPortStart, "Field 'DispatchedPortStart'")
+	assert.Equal(t, uint16(32767), c.DispatchedPortEnd, "Field 'DispatchedPortEnd'")
+}
+
+func TestParsePortRange(t *testing.T) {
+	testCases := map[string]struct {
+		Input         string
+		ExpectedStart uint16
+		ExpectedEnd   uint16
+		ErrAssertion  assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Input:         "31000-32767",
+			ExpectedStart: 31000,
+			ExpectedEnd:   32767,
+			ErrAssertion:  assert.NoError,
+		},
+		"single port": {
+			Input:         "31000-31000",
+			ExpectedStart: 31000,
+			ExpectedEnd:   31000,
+			ErrAssertion:  assert.NoError,
+		},
+		"missing end": {
+			Input:        "31000",
+			ErrAssertion: assert.Error,
+		},
+		"start after end": {
+			Input:        "32767-31000",
+			ErrAssertion: assert.Error,
+		},
+		"zero start": {
+			Input:        "0-31000",
+			ErrAssertion: assert.Error,
+		},
+		"out of range": {
+			Input:        "31000-65536",
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			start, end, err := parsePortRange(tc.Input)
+			tc.ErrAssertion(t, err)
+			assert.Equal(t, tc.ExpectedStart, start)
+			assert.Equal(t, tc.ExpectedEnd, end)
+		})
+	}
 }
 
 func Test_Active(t *testing.T) {
@@ -147,6 +197,7 @@ func TestServiceDetails(t *testing.T) {
 				Port: 30101,
 			},
 			AllowInterfaces: []uint64{1, 3, 5},
+			Priority:        100,
 		},
 		"sig2-ff00:0:311-1": {
 			CtrlAddr: &TopoAddr{
@@ -406,6 +457,17 @@ func TestCopy(t *testing.T) {
 	assert.Equal(t, topo, newTopo)
 }
 
+func TestCopyGateways(t *testing.T) {
+	topo, err := RWTopologyFromJSONFile("testdata/basic.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, topo.SIG["sig1-ff00:0:311-1"].AllowInterfaces)
+
+	newTopo := topo.Copy()
+	require.Equal(t, topo.SIG, newTopo.SIG)
+	newTopo.SIG["sig1-ff00:0:311-1"].AllowInterfaces[0] = 42
+	assert.Equal(t, []uint64{1, 3, 5}, topo.SIG["sig1-ff00:0:311-1"].AllowInterfaces)
+}
+
 func TestExternalDataPlanePort(t *testing.T) {
 	testCases := []struct {
 		Name            string
@@ -95,7 +95,11 @@ func (c *connUDPIPv4) ReadBatch(msgs Messages) (int, error) {
 	return n, err
 }
 
+// WriteBatch writes the packets in msgs and returns the number of packets
+// written, and an error if any. Similar to WriteTo, the destination addresses
+// are ignored if the connection is connected to a remote address.
 func (c *connUDPIPv4) WriteBatch(msgs Messages) (int, error) {
+	c.clearRemoteAddrs(msgs)
 	return c.pconn.WriteBatch(msgs, 0)
 }
 
@@ -133,7 +137,11 @@ func (c *connUDPIPv6) ReadBatch(msgs Messages) (int, error) {
 	return n, err
 }
 
+// WriteBatch writes the packets in msgs and returns the number of packets
+// written, and an error if any. Similar to WriteTo, the destination addresses
+// are ignored if the connection is connected to a remote address.
 func (c *connUDPIPv6) WriteBatch(msgs Messages) (int, error) {
+	c.clearRemoteAddrs(msgs)
 	return c.pconn.WriteBatch(msgs, 0)
 }
 
@@ -216,6 +224,17 @@ func (c *connUDPBase) WriteTo(b []byte, dst *net.UDPAddr) (int, error) {
 	return c.conn.WriteTo(b, dst)
 }
 
+// clearRemoteAddrs removes the destination addresses from msgs if the connection
+// is connected. This mirrors WriteTo, which ignores the destination in this case.
+func (c *connUDPBase) clearRemoteAddrs(msgs Messages) {
+	if c.Remote == nil {
+		return
+	}
+	for i := range msgs {
+		msgs[i].Addr = nil
+	}
+}
+
 func (c *connUDPBase) LocalAddr() *net.UDPAddr {
 	return c.Listen
 }
@@ -0,0 +1,39 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cs
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/keyconf"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/cs/drkey"
+)
+
+// NewSecretValueStore creates the DRKey secret value store. The secret values
+// are derived from the AS master key found in the config directory.
+func NewSecretValueStore(configDir string,
+	epochDuration time.Duration) (*drkey.SecretValueStore, error) {
+
+	mk, err := keyconf.LoadMaster(filepath.Join(configDir, "keys"))
+	if err != nil {
+		return nil, serrors.WrapStr("loading master key", err)
+	}
+	return &drkey.SecretValueStore{
+		MasterKey:     mk.Key0,
+		EpochDuration: epochDuration,
+	}, nil
+}
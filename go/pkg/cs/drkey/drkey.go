@@ -0,0 +1,196 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drkey implements the DRKey level 1 key management of the control
+// service.
+package drkey
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// DefaultEpochDuration is the default duration of a DRKey epoch.
+const DefaultEpochDuration = 24 * time.Hour
+
+// SecretValueStore derives the secret values of the local AS and keeps them in
+// memory until they expire.
+type SecretValueStore struct {
+	// MasterKey is the master key of the local AS.
+	MasterKey []byte
+	// EpochDuration is the duration of an epoch. If it is zero,
+	// DefaultEpochDuration is used.
+	EpochDuration time.Duration
+
+	mtx   sync.Mutex
+	cache map[time.Time]drkey.SV
+}
+
+// SecretValue returns the secret value that is valid at valTime.
+func (s *SecretValueStore) SecretValue(valTime time.Time) (drkey.SV, error) {
+	duration := s.EpochDuration
+	if duration == 0 {
+		duration = DefaultEpochDuration
+	}
+	epoch := drkey.NewEpoch(valTime, duration)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if sv, ok := s.cache[epoch.NotBefore]; ok {
+		return sv, nil
+	}
+	sv, err := drkey.DeriveSV(s.MasterKey, epoch)
+	if err != nil {
+		return drkey.SV{}, serrors.WrapStr("deriving secret value", err)
+	}
+	if s.cache == nil {
+		s.cache = make(map[time.Time]drkey.SV)
+	}
+	now := time.Now()
+	for k, cached := range s.cache {
+		if !now.Before(cached.Epoch.NotAfter) {
+			delete(s.cache, k)
+		}
+	}
+	s.cache[epoch.NotBefore] = sv
+	return sv, nil
+}
+
+// Lvl1Fetcher fetches level 1 keys from the control service of the fast side
+// AS.
+type Lvl1Fetcher interface {
+	// Lvl1 fetches the level 1 key K_{srcIA->local AS} that is valid at
+	// valTime.
+	Lvl1(ctx context.Context, srcIA addr.IA, valTime time.Time) (drkey.Lvl1Key, error)
+}
+
+// ServiceEngine manages the level 1 keys of the local AS. Keys with the local
+// AS as fast side are derived from the secret value. Keys with the local AS as
+// slow side are fetched from the fast side AS, and are kept in memory until
+// they expire.
+type ServiceEngine struct {
+	// LocalIA is the ISD-AS of the local AS.
+	LocalIA addr.IA
+	// SecretValues provides the secret values of the local AS.
+	SecretValues *SecretValueStore
+	// Fetcher fetches level 1 keys from remote ASes.
+	Fetcher Lvl1Fetcher
+
+	mtx    sync.Mutex
+	cache  map[addr.IA][]drkey.Lvl1Key
+	dedupe singleflight.Group
+}
+
+// DeriveLvl1 derives the level 1 key K_{local AS->dstIA} that is valid at
+// valTime.
+func (e *ServiceEngine) DeriveLvl1(dstIA addr.IA, valTime time.Time) (drkey.Lvl1Key, error) {
+	sv, err := e.SecretValues.SecretValue(valTime)
+	if err != nil {
+		return drkey.Lvl1Key{}, err
+	}
+	return drkey.DeriveLvl1(sv, drkey.Lvl1Meta{SrcIA: e.LocalIA, DstIA: dstIA})
+}
+
+// GetLvl1Key returns the level 1 key described by meta that is valid at
+// valTime. The local AS must either be the fast or the slow side of the key.
+func (e *ServiceEngine) GetLvl1Key(ctx context.Context, meta drkey.Lvl1Meta,
+	valTime time.Time) (drkey.Lvl1Key, error) {
+
+	switch {
+	case meta.SrcIA.Equal(e.LocalIA):
+		return e.DeriveLvl1(meta.DstIA, valTime)
+	case meta.DstIA.Equal(e.LocalIA):
+		return e.fetchLvl1(ctx, meta.SrcIA, valTime)
+	default:
+		return drkey.Lvl1Key{}, serrors.New("local AS is neither source nor destination",
+			"src", meta.SrcIA, "dst", meta.DstIA, "local", e.LocalIA)
+	}
+}
+
+func (e *ServiceEngine) fetchLvl1(ctx context.Context, srcIA addr.IA,
+	valTime time.Time) (drkey.Lvl1Key, error) {
+
+	if key, ok := e.cached(srcIA, valTime); ok {
+		return key, nil
+	}
+	fetch := func() (drkey.Lvl1Key, error) {
+		key, err := e.Fetcher.Lvl1(ctx, srcIA, valTime)
+		if err != nil {
+			return drkey.Lvl1Key{}, serrors.WrapStr("fetching level 1 key", err, "src", srcIA)
+		}
+		if !key.SrcIA.Equal(srcIA) || !key.DstIA.Equal(e.LocalIA) {
+			return drkey.Lvl1Key{}, serrors.New("fetched key does not match request",
+				"src", key.SrcIA, "dst", key.DstIA)
+		}
+		e.store(key)
+		return key, nil
+	}
+	// Requests are likely to arrive in bursts when a key expires, deduplicate
+	// them per AS.
+	r, err, _ := e.dedupe.Do(srcIA.String(), func() (interface{}, error) {
+		return fetch()
+	})
+	if err != nil {
+		log.FromCtx(ctx).Debug("Failed to fetch level 1 key", "src", srcIA, "err", err)
+		return drkey.Lvl1Key{}, err
+	}
+	key := r.(drkey.Lvl1Key)
+	if key.Epoch.Contains(valTime) {
+		return key, nil
+	}
+	// The deduplicated request was for a different epoch.
+	if key, err = fetch(); err != nil {
+		return drkey.Lvl1Key{}, err
+	}
+	if !key.Epoch.Contains(valTime) {
+		return drkey.Lvl1Key{}, serrors.New("fetched key not valid at requested time",
+			"src", srcIA, "epoch", key.Epoch, "val_time", valTime)
+	}
+	return key, nil
+}
+
+func (e *ServiceEngine) cached(srcIA addr.IA, valTime time.Time) (drkey.Lvl1Key, bool) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	for _, key := range e.cache[srcIA] {
+		if key.Epoch.Contains(valTime) {
+			return key, true
+		}
+	}
+	return drkey.Lvl1Key{}, false
+}
+
+func (e *ServiceEngine) store(key drkey.Lvl1Key) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.cache == nil {
+		e.cache = make(map[addr.IA][]drkey.Lvl1Key)
+	}
+	now := time.Now()
+	keys := []drkey.Lvl1Key{key}
+	for _, cached := range e.cache[key.SrcIA] {
+		if now.Before(cached.Epoch.NotAfter) && cached.Epoch != key.Epoch {
+			keys = append(keys, cached)
+		}
+	}
+	e.cache[key.SrcIA] = keys
+}
@@ -0,0 +1,156 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	csdrkey "github.com/scionproto/scion/go/pkg/cs/drkey"
+	"github.com/scionproto/scion/go/pkg/cs/drkey/mock_drkey"
+)
+
+var (
+	localIA  = xtest.MustParseIA("1-ff00:0:110")
+	remoteIA = xtest.MustParseIA("1-ff00:0:111")
+	otherIA  = xtest.MustParseIA("1-ff00:0:112")
+)
+
+func TestSecretValueStore(t *testing.T) {
+	store := &csdrkey.SecretValueStore{
+		MasterKey:     []byte("master key"),
+		EpochDuration: time.Hour,
+	}
+	now := time.Now()
+	sv, err := store.SecretValue(now)
+	require.NoError(t, err)
+	assert.True(t, sv.Epoch.Contains(now))
+	assert.Equal(t, time.Hour, sv.Epoch.NotAfter.Sub(sv.Epoch.NotBefore))
+
+	expected, err := drkey.DeriveSV([]byte("master key"), sv.Epoch)
+	require.NoError(t, err)
+	assert.Equal(t, expected, sv)
+
+	next, err := store.SecretValue(now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, sv.Epoch.NotAfter, next.Epoch.NotBefore)
+	assert.NotEqual(t, sv.Key, next.Key)
+
+	_, err = (&csdrkey.SecretValueStore{}).SecretValue(now)
+	assert.Error(t, err)
+}
+
+func TestServiceEngineGetLvl1Key(t *testing.T) {
+	now := time.Now()
+	newEngine := func(fetcher csdrkey.Lvl1Fetcher) *csdrkey.ServiceEngine {
+		return &csdrkey.ServiceEngine{
+			LocalIA: localIA,
+			SecretValues: &csdrkey.SecretValueStore{
+				MasterKey:     []byte("master key"),
+				EpochDuration: time.Hour,
+			},
+			Fetcher: fetcher,
+		}
+	}
+	remoteKey := func(valTime time.Time) drkey.Lvl1Key {
+		return drkey.Lvl1Key{
+			Lvl1Meta: drkey.Lvl1Meta{SrcIA: remoteIA, DstIA: localIA},
+			Epoch:    drkey.NewEpoch(valTime, time.Hour),
+			Key:      drkey.Key{1, 2, 3},
+		}
+	}
+
+	t.Run("local AS as fast side is derived", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		engine := newEngine(mock_drkey.NewMockLvl1Fetcher(ctrl))
+		meta := drkey.Lvl1Meta{SrcIA: localIA, DstIA: remoteIA}
+		key, err := engine.GetLvl1Key(context.Background(), meta, now)
+		require.NoError(t, err)
+		assert.Equal(t, meta, key.Lvl1Meta)
+		assert.True(t, key.Epoch.Contains(now))
+
+		derived, err := engine.DeriveLvl1(remoteIA, now)
+		require.NoError(t, err)
+		assert.Equal(t, key, derived)
+	})
+	t.Run("local AS as slow side is fetched and cached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		fetcher := mock_drkey.NewMockLvl1Fetcher(ctrl)
+		fetcher.EXPECT().Lvl1(gomock.Any(), remoteIA, now).Return(remoteKey(now), nil)
+		engine := newEngine(fetcher)
+		meta := drkey.Lvl1Meta{SrcIA: remoteIA, DstIA: localIA}
+		for i := 0; i < 2; i++ {
+			key, err := engine.GetLvl1Key(context.Background(), meta, now)
+			require.NoError(t, err)
+			assert.Equal(t, remoteKey(now), key)
+		}
+	})
+	t.Run("key of the next epoch is fetched", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		next := now.Add(time.Hour)
+		fetcher := mock_drkey.NewMockLvl1Fetcher(ctrl)
+		fetcher.EXPECT().Lvl1(gomock.Any(), remoteIA, now).Return(remoteKey(now), nil)
+		fetcher.EXPECT().Lvl1(gomock.Any(), remoteIA, next).Return(remoteKey(next), nil)
+		engine := newEngine(fetcher)
+		meta := drkey.Lvl1Meta{SrcIA: remoteIA, DstIA: localIA}
+		_, err := engine.GetLvl1Key(context.Background(), meta, now)
+		require.NoError(t, err)
+		key, err := engine.GetLvl1Key(context.Background(), meta, next)
+		require.NoError(t, err)
+		assert.Equal(t, remoteKey(next), key)
+	})
+	t.Run("fetch error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		fetcher := mock_drkey.NewMockLvl1Fetcher(ctrl)
+		fetcher.EXPECT().Lvl1(gomock.Any(), remoteIA, now).Return(drkey.Lvl1Key{},
+			serrors.New("internal"))
+		engine := newEngine(fetcher)
+		meta := drkey.Lvl1Meta{SrcIA: remoteIA, DstIA: localIA}
+		_, err := engine.GetLvl1Key(context.Background(), meta, now)
+		assert.Error(t, err)
+	})
+	t.Run("mismatching fetched key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		key := remoteKey(now)
+		key.DstIA = otherIA
+		fetcher := mock_drkey.NewMockLvl1Fetcher(ctrl)
+		fetcher.EXPECT().Lvl1(gomock.Any(), remoteIA, now).Return(key, nil)
+		engine := newEngine(fetcher)
+		meta := drkey.Lvl1Meta{SrcIA: remoteIA, DstIA: localIA}
+		_, err := engine.GetLvl1Key(context.Background(), meta, now)
+		assert.Error(t, err)
+	})
+	t.Run("local AS not involved", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		engine := newEngine(mock_drkey.NewMockLvl1Fetcher(ctrl))
+		meta := drkey.Lvl1Meta{SrcIA: remoteIA, DstIA: otherIA}
+		_, err := engine.GetLvl1Key(context.Background(), meta, now)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	csdrkey "github.com/scionproto/scion/go/pkg/cs/drkey"
+	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
+	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
+)
+
+var _ csdrkey.Lvl1Fetcher = (*Lvl1Fetcher)(nil)
+
+// Lvl1Fetcher fetches level 1 keys from the control service of the fast side
+// AS.
+type Lvl1Fetcher struct {
+	// LocalIA is the ISD-AS of the local AS.
+	LocalIA addr.IA
+	// Dialer dials a new gRPC connection.
+	Dialer libgrpc.Dialer
+	// Router finds paths to the remote AS.
+	Router snet.Router
+}
+
+// Lvl1 fetches the level 1 key K_{srcIA->local AS} that is valid at valTime.
+func (f Lvl1Fetcher) Lvl1(ctx context.Context, srcIA addr.IA,
+	valTime time.Time) (drkey.Lvl1Key, error) {
+
+	path, err := f.Router.Route(ctx, srcIA)
+	if err != nil || path == nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("unable to find path to remote AS", err,
+			"isd_as", srcIA)
+	}
+	remote := &snet.SVCAddr{
+		IA:      path.Destination(),
+		Path:    path.Path(),
+		NextHop: path.UnderlayNextHop(),
+		SVC:     addr.SvcCS,
+	}
+	conn, err := f.Dialer.Dial(ctx, remote)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("dialing", err)
+	}
+	defer conn.Close()
+	client := cppb.NewDRKeyInterServiceClient(conn)
+	ts, err := ptypes.TimestampProto(valTime)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("converting validity time", err)
+	}
+	rep, err := client.DRKeyLvl1(ctx, &cppb.DRKeyLvl1Request{ValTime: ts},
+		libgrpc.RetryProfile...)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("requesting level 1 key", err)
+	}
+	meta := drkey.Lvl1Meta{SrcIA: srcIA, DstIA: f.LocalIA}
+	return lvl1KeyFromPB(meta, rep.EpochBegin, rep.EpochEnd, rep.Key)
+}
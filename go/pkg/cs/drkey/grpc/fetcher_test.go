@@ -0,0 +1,130 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/xtest"
+	dkgrpc "github.com/scionproto/scion/go/pkg/cs/drkey/grpc"
+	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
+	mock_cp "github.com/scionproto/scion/go/pkg/proto/control_plane/mock_control_plane"
+)
+
+func TestLvl1FetcherLvl1(t *testing.T) {
+	now := time.Now()
+	epoch := drkey.NewEpoch(now, time.Hour)
+	begin, err := ptypes.TimestampProto(epoch.NotBefore)
+	require.NoError(t, err)
+	end, err := ptypes.TimestampProto(epoch.NotAfter)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		Server    func(*gomock.Controller) *mock_cp.MockDRKeyInterServiceServer
+		Assertion assert.ErrorAssertionFunc
+		Expected  drkey.Lvl1Key
+	}{
+		"valid": {
+			Server: func(mctrl *gomock.Controller) *mock_cp.MockDRKeyInterServiceServer {
+				srv := mock_cp.NewMockDRKeyInterServiceServer(mctrl)
+				srv.EXPECT().DRKeyLvl1(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context,
+						req *cppb.DRKeyLvl1Request) (*cppb.DRKeyLvl1Response, error) {
+
+						valTime, err := ptypes.Timestamp(req.ValTime)
+						require.NoError(t, err)
+						assert.True(t, valTime.Equal(now))
+						return &cppb.DRKeyLvl1Response{
+							EpochBegin: begin,
+							EpochEnd:   end,
+							Key:        []byte("0123456789abcdef"),
+						}, nil
+					},
+				)
+				return srv
+			},
+			Assertion: assert.NoError,
+			Expected: drkey.Lvl1Key{
+				Lvl1Meta: drkey.Lvl1Meta{SrcIA: remoteIA, DstIA: localIA},
+				Epoch:    epoch,
+				Key: drkey.Key{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9',
+					'a', 'b', 'c', 'd', 'e', 'f'},
+			},
+		},
+		"RPC fail": {
+			Server: func(mctrl *gomock.Controller) *mock_cp.MockDRKeyInterServiceServer {
+				srv := mock_cp.NewMockDRKeyInterServiceServer(mctrl)
+				srv.EXPECT().DRKeyLvl1(gomock.Any(), gomock.Any()).Return(nil,
+					serrors.New("internal"))
+				return srv
+			},
+			Assertion: assert.Error,
+		},
+		"invalid key length": {
+			Server: func(mctrl *gomock.Controller) *mock_cp.MockDRKeyInterServiceServer {
+				srv := mock_cp.NewMockDRKeyInterServiceServer(mctrl)
+				srv.EXPECT().DRKeyLvl1(gomock.Any(), gomock.Any()).Return(
+					&cppb.DRKeyLvl1Response{
+						EpochBegin: begin,
+						EpochEnd:   end,
+						Key:        []byte("short"),
+					}, nil,
+				)
+				return srv
+			},
+			Assertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+
+			svc := xtest.NewGRPCService()
+			cppb.RegisterDRKeyInterServiceServer(svc.Server(), tc.Server(mctrl))
+			svc.Start(t)
+
+			path := mock_snet.NewMockPath(mctrl)
+			path.EXPECT().Destination().Return(remoteIA).AnyTimes()
+			path.EXPECT().Path().Return(spath.Path{}).AnyTimes()
+			path.EXPECT().UnderlayNextHop().Return(&net.UDPAddr{}).AnyTimes()
+			router := mock_snet.NewMockRouter(mctrl)
+			router.EXPECT().Route(gomock.Any(), remoteIA).Return(path, nil)
+
+			f := dkgrpc.Lvl1Fetcher{
+				LocalIA: localIA,
+				Dialer:  svc,
+				Router:  router,
+			}
+			key, err := f.Lvl1(context.Background(), remoteIA, now)
+			tc.Assertion(t, err)
+			assert.Equal(t, tc.Expected, key)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	timestamppb "github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
+)
+
+// Engine provides the level 1 keys of the local AS.
+type Engine interface {
+	// DeriveLvl1 derives the level 1 key K_{local AS->dstIA}.
+	DeriveLvl1(dstIA addr.IA, valTime time.Time) (drkey.Lvl1Key, error)
+	// GetLvl1Key returns a level 1 key with the local AS as fast or slow side.
+	GetLvl1Key(ctx context.Context, meta drkey.Lvl1Meta,
+		valTime time.Time) (drkey.Lvl1Key, error)
+}
+
+// ChainVerifier verifies a certificate chain presented by a peer against the
+// TRCs of the ISD of the peer.
+type ChainVerifier interface {
+	VerifyPeerCertificate(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error
+}
+
+// Server serves the DRKey level 1 keys of the local AS. It implements both the
+// inter-AS and the intra-AS service.
+type Server struct {
+	Engine Engine
+	// ClientVerifier verifies the client certificate chains of the requesters
+	// of the inter-AS service. If it is nil, no level 1 key is served to
+	// remote ASes.
+	ClientVerifier ChainVerifier
+	// AllowedHosts are the hosts in the local AS that may fetch level 1 keys
+	// through the intra-AS service, e.g., the SCION daemon or the border
+	// routers. Requests from all other hosts are rejected.
+	AllowedHosts []net.IP
+}
+
+// DRKeyLvl1 serves the level 1 key the local AS derives for the AS of the
+// requester. The requester is identified by the ISD-AS of the client
+// certificate it presented in the TLS handshake, which is verified against
+// the TRCs of its ISD. Requests without verified client certificate are
+// rejected.
+func (s Server) DRKeyLvl1(ctx context.Context,
+	req *cppb.DRKeyLvl1Request) (*cppb.DRKeyLvl1Response, error) {
+
+	logger := log.FromCtx(ctx)
+	dstIA, err := s.authenticatedPeerIA(ctx)
+	if err != nil {
+		logger.Debug("Rejecting level 1 key request", "err", err)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	valTime, err := ptypes.Timestamp(req.ValTime)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	key, err := s.Engine.DeriveLvl1(dstIA, valTime)
+	if err != nil {
+		logger.Info("Failed to derive level 1 key", "dst", dstIA, "err", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	begin, end, err := epochToPB(key.Epoch)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &cppb.DRKeyLvl1Response{
+		EpochBegin: begin,
+		EpochEnd:   end,
+		Key:        append([]byte(nil), key.Key[:]...),
+	}, nil
+}
+
+// DRKeyIntraLvl1 serves a level 1 key with the local AS as fast or slow side
+// to one of the allowed hosts in the local AS.
+func (s Server) DRKeyIntraLvl1(ctx context.Context,
+	req *cppb.DRKeyIntraLvl1Request) (*cppb.DRKeyIntraLvl1Response, error) {
+
+	if err := s.checkAllowedHost(ctx); err != nil {
+		log.FromCtx(ctx).Debug("Rejecting intra-AS level 1 key request", "err", err)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	valTime, err := ptypes.Timestamp(req.ValTime)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	meta := drkey.Lvl1Meta{
+		SrcIA: addr.IAInt(req.SrcIsdAs).IA(),
+		DstIA: addr.IAInt(req.DstIsdAs).IA(),
+	}
+	key, err := s.Engine.GetLvl1Key(ctx, meta, valTime)
+	if err != nil {
+		log.FromCtx(ctx).Info("Failed to get level 1 key",
+			"src", meta.SrcIA, "dst", meta.DstIA, "err", err)
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	begin, end, err := epochToPB(key.Epoch)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &cppb.DRKeyIntraLvl1Response{
+		EpochBegin: begin,
+		EpochEnd:   end,
+		Key:        append([]byte(nil), key.Key[:]...),
+	}, nil
+}
+
+// authenticatedPeerIA returns the ISD-AS of the verified client certificate of
+// the peer. The ISD-AS must match the one of the peer's SCION address.
+func (s Server) authenticatedPeerIA(ctx context.Context) (addr.IA, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return addr.IA{}, serrors.New("peer not present")
+	}
+	if s.ClientVerifier == nil {
+		return addr.IA{}, serrors.New("client certificate verification not configured")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return addr.IA{}, serrors.New("peer not authenticated",
+			"auth_info", fmt.Sprintf("%T", p.AuthInfo))
+	}
+	certs := tlsInfo.State.PeerCertificates
+	if len(certs) == 0 {
+		return addr.IA{}, serrors.New("no client certificate")
+	}
+	rawCerts := make([][]byte, 0, len(certs))
+	for _, cert := range certs {
+		rawCerts = append(rawCerts, cert.Raw)
+	}
+	if err := s.ClientVerifier.VerifyPeerCertificate(rawCerts, nil); err != nil {
+		return addr.IA{}, serrors.WrapStr("verifying client certificate", err)
+	}
+	ia, err := cppki.ExtractIA(certs[0].Subject)
+	if err != nil {
+		return addr.IA{}, serrors.WrapStr("extracting ISD-AS from client certificate", err)
+	}
+	a, ok := p.Addr.(*snet.UDPAddr)
+	if !ok {
+		return addr.IA{}, serrors.New("invalid peer address type, expected snet.UDPAddr",
+			"type", fmt.Sprintf("%T", p.Addr))
+	}
+	if !a.IA.Equal(ia) {
+		return addr.IA{}, serrors.New("client certificate does not match peer address",
+			"certificate", ia, "address", a.IA)
+	}
+	return ia, nil
+}
+
+// checkAllowedHost checks that the peer is one of the allowed hosts.
+func (s Server) checkAllowedHost(ctx context.Context) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return serrors.New("peer not present")
+	}
+	var ip net.IP
+	switch a := p.Addr.(type) {
+	case *net.TCPAddr:
+		ip = a.IP
+	case *net.UDPAddr:
+		ip = a.IP
+	default:
+		return serrors.New("unsupported peer address type", "type", fmt.Sprintf("%T", p.Addr))
+	}
+	for _, allowed := range s.AllowedHosts {
+		if allowed.Equal(ip) {
+			return nil
+		}
+	}
+	return serrors.New("host not allowed", "host", ip)
+}
+
+func epochToPB(epoch drkey.Epoch) (*timestamppb.Timestamp, *timestamppb.Timestamp, error) {
+	begin, err := ptypes.TimestampProto(epoch.NotBefore)
+	if err != nil {
+		return nil, nil, err
+	}
+	end, err := ptypes.TimestampProto(epoch.NotAfter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return begin, end, nil
+}
+
+func lvl1KeyFromPB(meta drkey.Lvl1Meta, begin, end *timestamppb.Timestamp,
+	raw []byte) (drkey.Lvl1Key, error) {
+
+	notBefore, err := ptypes.Timestamp(begin)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("parsing epoch begin", err)
+	}
+	notAfter, err := ptypes.Timestamp(end)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("parsing epoch end", err)
+	}
+	if len(raw) != drkey.KeyLen {
+		return drkey.Lvl1Key{}, serrors.New("invalid key length", "expected", drkey.KeyLen,
+			"actual", len(raw))
+	}
+	key := drkey.Lvl1Key{
+		Lvl1Meta: meta,
+		Epoch:    drkey.Epoch{NotBefore: notBefore, NotAfter: notAfter},
+	}
+	copy(key.Key[:], raw)
+	return key, nil
+}
@@ -0,0 +1,261 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+	csdrkey "github.com/scionproto/scion/go/pkg/cs/drkey"
+	dkgrpc "github.com/scionproto/scion/go/pkg/cs/drkey/grpc"
+	"github.com/scionproto/scion/go/pkg/cs/drkey/mock_drkey"
+	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
+)
+
+var (
+	localIA  = xtest.MustParseIA("1-ff00:0:110")
+	remoteIA = xtest.MustParseIA("1-ff00:0:111")
+)
+
+func newEngine(fetcher csdrkey.Lvl1Fetcher) *csdrkey.ServiceEngine {
+	return &csdrkey.ServiceEngine{
+		LocalIA: localIA,
+		SecretValues: &csdrkey.SecretValueStore{
+			MasterKey:     []byte("master key"),
+			EpochDuration: time.Hour,
+		},
+		Fetcher: fetcher,
+	}
+}
+
+var allowedHost = net.ParseIP("127.0.0.42")
+
+// verifierFunc is a dkgrpc.ChainVerifier backed by a function.
+type verifierFunc func(rawCerts [][]byte) error
+
+func (f verifierFunc) VerifyPeerCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	return f(rawCerts)
+}
+
+// authenticatedPeer returns a context with a peer that presented a client
+// certificate for the given ISD-AS.
+func authenticatedPeer(certIA, addrIA addr.IA) context.Context {
+	cert := &x509.Certificate{
+		Raw: []byte("raw certificate"),
+		Subject: pkix.Name{
+			Names: []pkix.AttributeTypeAndValue{
+				{Type: cppki.OIDNameIA, Value: certIA.String()},
+			},
+		},
+	}
+	info := credentials.TLSInfo{}
+	info.State.PeerCertificates = []*x509.Certificate{cert}
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr:     &snet.UDPAddr{IA: addrIA, Host: &net.UDPAddr{}},
+		AuthInfo: info,
+	})
+}
+
+func TestServerDRKeyLvl1(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	engine := newEngine(mock_drkey.NewMockLvl1Fetcher(mctrl))
+	acceptAll := verifierFunc(func([][]byte) error { return nil })
+	s := dkgrpc.Server{Engine: engine, ClientVerifier: acceptAll}
+
+	now := time.Now()
+	valTime, err := ptypes.TimestampProto(now)
+	require.NoError(t, err)
+	req := &cppb.DRKeyLvl1Request{ValTime: valTime}
+
+	t.Run("valid", func(t *testing.T) {
+		var verified [][]byte
+		s := dkgrpc.Server{
+			Engine: engine,
+			ClientVerifier: verifierFunc(func(rawCerts [][]byte) error {
+				verified = rawCerts
+				return nil
+			}),
+		}
+		rep, err := s.DRKeyLvl1(authenticatedPeer(remoteIA, remoteIA), req)
+		require.NoError(t, err)
+		expected, err := engine.DeriveLvl1(remoteIA, now)
+		require.NoError(t, err)
+		assert.Equal(t, expected.Key[:], rep.Key)
+		begin, err := ptypes.Timestamp(rep.EpochBegin)
+		require.NoError(t, err)
+		assert.True(t, expected.Epoch.NotBefore.Equal(begin))
+		assert.Equal(t, [][]byte{[]byte("raw certificate")}, verified)
+	})
+	t.Run("unauthenticated peer", func(t *testing.T) {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &snet.UDPAddr{IA: remoteIA, Host: &net.UDPAddr{}},
+		})
+		_, err := s.DRKeyLvl1(ctx, req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("no verifier", func(t *testing.T) {
+		s := dkgrpc.Server{Engine: engine}
+		_, err := s.DRKeyLvl1(authenticatedPeer(remoteIA, remoteIA), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("verification fails", func(t *testing.T) {
+		s := dkgrpc.Server{
+			Engine: engine,
+			ClientVerifier: verifierFunc(func([][]byte) error {
+				return serrors.New("untrusted chain")
+			}),
+		}
+		_, err := s.DRKeyLvl1(authenticatedPeer(remoteIA, remoteIA), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("certificate does not match address", func(t *testing.T) {
+		_, err := s.DRKeyLvl1(authenticatedPeer(remoteIA, localIA), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("unknown peer", func(t *testing.T) {
+		_, err := s.DRKeyLvl1(context.Background(), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("non SCION peer", func(t *testing.T) {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{},
+		})
+		_, err := s.DRKeyLvl1(ctx, req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestServerDRKeyIntraLvl1(t *testing.T) {
+	now := time.Now()
+	valTime, err := ptypes.TimestampProto(now)
+	require.NoError(t, err)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: allowedHost, Port: 30255},
+	})
+	allowed := []net.IP{allowedHost}
+
+	t.Run("local AS as fast side", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		engine := newEngine(mock_drkey.NewMockLvl1Fetcher(mctrl))
+		s := dkgrpc.Server{Engine: engine, AllowedHosts: allowed}
+		rep, err := s.DRKeyIntraLvl1(ctx, &cppb.DRKeyIntraLvl1Request{
+			ValTime:  valTime,
+			SrcIsdAs: uint64(localIA.IAInt()),
+			DstIsdAs: uint64(remoteIA.IAInt()),
+		})
+		require.NoError(t, err)
+		expected, err := engine.DeriveLvl1(remoteIA, now)
+		require.NoError(t, err)
+		assert.Equal(t, expected.Key[:], rep.Key)
+	})
+	t.Run("local AS as slow side", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		key := drkey.Lvl1Key{
+			Lvl1Meta: drkey.Lvl1Meta{SrcIA: remoteIA, DstIA: localIA},
+			Epoch:    drkey.NewEpoch(now, time.Hour),
+			Key:      drkey.Key{1, 2, 3},
+		}
+		fetcher := mock_drkey.NewMockLvl1Fetcher(mctrl)
+		fetcher.EXPECT().Lvl1(gomock.Any(), remoteIA, gomock.Any()).Return(key, nil)
+		s := dkgrpc.Server{Engine: newEngine(fetcher), AllowedHosts: allowed}
+		rep, err := s.DRKeyIntraLvl1(ctx, &cppb.DRKeyIntraLvl1Request{
+			ValTime:  valTime,
+			SrcIsdAs: uint64(remoteIA.IAInt()),
+			DstIsdAs: uint64(localIA.IAInt()),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, key.Key[:], rep.Key)
+	})
+	t.Run("local AS not involved", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		s := dkgrpc.Server{
+			Engine:       newEngine(mock_drkey.NewMockLvl1Fetcher(mctrl)),
+			AllowedHosts: allowed,
+		}
+		_, err := s.DRKeyIntraLvl1(ctx, &cppb.DRKeyIntraLvl1Request{
+			ValTime:  valTime,
+			SrcIsdAs: uint64(remoteIA.IAInt()),
+			DstIsdAs: uint64(remoteIA.IAInt()),
+		})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+	t.Run("missing validity time", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		s := dkgrpc.Server{
+			Engine:       newEngine(mock_drkey.NewMockLvl1Fetcher(mctrl)),
+			AllowedHosts: allowed,
+		}
+		_, err := s.DRKeyIntraLvl1(ctx, &cppb.DRKeyIntraLvl1Request{
+			SrcIsdAs: uint64(localIA.IAInt()),
+			DstIsdAs: uint64(remoteIA.IAInt()),
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("host not allowed", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		s := dkgrpc.Server{
+			Engine:       newEngine(mock_drkey.NewMockLvl1Fetcher(mctrl)),
+			AllowedHosts: allowed,
+		}
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.43"), Port: 30255},
+		})
+		_, err := s.DRKeyIntraLvl1(ctx, &cppb.DRKeyIntraLvl1Request{
+			ValTime:  valTime,
+			SrcIsdAs: uint64(localIA.IAInt()),
+			DstIsdAs: uint64(remoteIA.IAInt()),
+		})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("unknown peer", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		s := dkgrpc.Server{
+			Engine:       newEngine(mock_drkey.NewMockLvl1Fetcher(mctrl)),
+			AllowedHosts: allowed,
+		}
+		_, err := s.DRKeyIntraLvl1(context.Background(), &cppb.DRKeyIntraLvl1Request{
+			ValTime:  valTime,
+			SrcIsdAs: uint64(localIA.IAInt()),
+			DstIsdAs: uint64(remoteIA.IAInt()),
+		})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
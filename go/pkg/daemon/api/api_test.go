@@ -0,0 +1,225 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/daemon/api/mock_api"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var update = xtest.UpdateGoldenFiles()
+
+// TestAPI tests the API response generation of the endpoints implemented in the
+// api package.
+func TestAPI(t *testing.T) {
+	testCases := map[string]struct {
+		Handler      func(t *testing.T, ctrl *gomock.Controller) http.Handler
+		RequestURL   string
+		ResponseFile string
+		Status       int
+	}{
+		"segments empty": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				segs := mock_api.NewMockSegmentsStore(ctrl)
+				segs.EXPECT().Get(gomock.Any(), &query.Params{}).Return(query.Results{}, nil)
+				return Handler(&Server{Segments: segs})
+			},
+			RequestURL:   "/segments",
+			ResponseFile: "testdata/segments-empty.json",
+			Status:       200,
+		},
+		"paths": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				fetcher := mock_api.NewMockPathFetcher(ctrl)
+				fetcher.EXPECT().GetPaths(gomock.Any(), addr.IA{},
+					xtest.MustParseIA("1-ff00:0:112"), false,
+				).Return(createPaths(), nil)
+				return Handler(&Server{Paths: fetcher})
+			},
+			RequestURL:   "/paths/1-ff00:0:112",
+			ResponseFile: "testdata/paths.json",
+			Status:       200,
+		},
+		"paths malformed destination": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				return Handler(&Server{Paths: mock_api.NewMockPathFetcher(ctrl)})
+			},
+			RequestURL:   "/paths/1-ff00:0:11x",
+			ResponseFile: "testdata/paths-malformed.json",
+			Status:       400,
+		},
+		"paths error": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				fetcher := mock_api.NewMockPathFetcher(ctrl)
+				fetcher.EXPECT().GetPaths(gomock.Any(), gomock.Any(), gomock.Any(),
+					gomock.Any()).Return(nil, serrors.New("internal"))
+				return Handler(&Server{Paths: fetcher})
+			},
+			RequestURL:   "/paths/1-ff00:0:112",
+			ResponseFile: "testdata/paths-error.json",
+			Status:       500,
+		},
+		"revocations": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				revs := mock_api.NewMockRevocationStore(ctrl)
+				revs.EXPECT().GetAll(gomock.Any()).Return(revResults(
+					revcache.RevOrErr{Rev: newRevInfo("1-ff00:0:112", 3)},
+					revcache.RevOrErr{Rev: newRevInfo("1-ff00:0:111", 5)},
+					revcache.RevOrErr{Rev: newRevInfo("1-ff00:0:111", 2)},
+				), nil)
+				return Handler(&Server{Revocations: revs})
+			},
+			RequestURL:   "/revocations",
+			ResponseFile: "testdata/revocations.json",
+			Status:       200,
+		},
+		"revocations error": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				revs := mock_api.NewMockRevocationStore(ctrl)
+				revs.EXPECT().GetAll(gomock.Any()).Return(revResults(
+					revcache.RevOrErr{Rev: newRevInfo("1-ff00:0:112", 3)},
+					revcache.RevOrErr{Err: serrors.New("corrupt entry")},
+				), nil)
+				return Handler(&Server{Revocations: revs})
+			},
+			RequestURL:   "/revocations",
+			ResponseFile: "testdata/revocations-error.json",
+			Status:       500,
+		},
+		"interfaces": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				return Handler(&Server{TopoProvider: loadTopo(t)})
+			},
+			RequestURL:   "/interfaces",
+			ResponseFile: "testdata/interfaces.json",
+			Status:       200,
+		},
+		"services": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				return Handler(&Server{TopoProvider: loadTopo(t)})
+			},
+			RequestURL:   "/services",
+			ResponseFile: "testdata/services.json",
+			Status:       200,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req, err := http.NewRequest("GET", tc.RequestURL, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			tc.Handler(t, ctrl).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+
+			if *update {
+				require.NoError(t, ioutil.WriteFile(tc.ResponseFile, rr.Body.Bytes(), 0666))
+			}
+			golden, err := ioutil.ReadFile(tc.ResponseFile)
+			require.NoError(t, err)
+			assert.Equal(t, string(golden), rr.Body.String())
+		})
+	}
+}
+
+type staticProvider struct {
+	topo topology.Topology
+}
+
+func (p staticProvider) Get() topology.Topology { return p.topo }
+
+func loadTopo(t *testing.T) topology.Provider {
+	topo, err := topology.FromJSONFile("testdata/topology.json")
+	require.NoError(t, err)
+	return staticProvider{topo: topo}
+}
+
+func createPaths() []snet.Path {
+	expiry := time.Unix(1611051121, 0).UTC()
+	return []snet.Path{
+		snetpath.Path{
+			Dst:     xtest.MustParseIA("1-ff00:0:112"),
+			NextHop: &net.UDPAddr{IP: net.IP{127, 0, 0, 9}, Port: 31002},
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: xtest.MustParseIA("1-ff00:0:110"), ID: 2},
+					{IA: xtest.MustParseIA("1-ff00:0:112"), ID: 1},
+				},
+				MTU:    1280,
+				Expiry: expiry,
+			},
+		},
+		snetpath.Path{
+			Dst:     xtest.MustParseIA("1-ff00:0:112"),
+			NextHop: &net.UDPAddr{IP: net.IP{127, 0, 0, 9}, Port: 31002},
+			Meta: snet.PathMetadata{
+				Interfaces: []snet.PathInterface{
+					{IA: xtest.MustParseIA("1-ff00:0:110"), ID: 1},
+					{IA: xtest.MustParseIA("1-ff00:0:111"), ID: 4},
+					{IA: xtest.MustParseIA("1-ff00:0:111"), ID: 5},
+					{IA: xtest.MustParseIA("1-ff00:0:112"), ID: 3},
+				},
+				MTU:    1472,
+				Expiry: expiry.Add(time.Hour),
+			},
+		},
+	}
+}
+
+func newRevInfo(ia string, ifID common.IFIDType) *path_mgmt.RevInfo {
+	return &path_mgmt.RevInfo{
+		IfID:         ifID,
+		RawIsdas:     xtest.MustParseIA(ia).IAInt(),
+		LinkType:     proto.LinkType_child,
+		RawTimestamp: 1611051121,
+		RawTTL:       10,
+	}
+}
+
+func revResults(results ...revcache.RevOrErr) revcache.ResultChan {
+	ch := make(chan revcache.RevOrErr, len(results))
+	for _, res := range results {
+		ch <- res
+	}
+	close(ch)
+	return ch
+}
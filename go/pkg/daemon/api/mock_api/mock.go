@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/scionproto/scion/go/pkg/daemon/api (interfaces: PathFetcher,RevocationStore,SegmentsStore)
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	addr "github.com/scionproto/scion/go/lib/addr"
+	query "github.com/scionproto/scion/go/lib/pathdb/query"
+	revcache "github.com/scionproto/scion/go/lib/revcache"
+	snet "github.com/scionproto/scion/go/lib/snet"
+)
+
+// MockPathFetcher is a mock of PathFetcher interface.
+type MockPathFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPathFetcherMockRecorder
+}
+
+// MockPathFetcherMockRecorder is the mock recorder for MockPathFetcher.
+type MockPathFetcherMockRecorder struct {
+	mock *MockPathFetcher
+}
+
+// NewMockPathFetcher creates a new mock instance.
+func NewMockPathFetcher(ctrl *gomock.Controller) *MockPathFetcher {
+	mock := &MockPathFetcher{ctrl: ctrl}
+	mock.recorder = &MockPathFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPathFetcher) EXPECT() *MockPathFetcherMockRecorder {
+	return m.recorder
+}
+
+// GetPaths mocks base method.
+func (m *MockPathFetcher) GetPaths(arg0 context.Context, arg1, arg2 addr.IA, arg3 bool) ([]snet.Path, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaths", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]snet.Path)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaths indicates an expected call of GetPaths.
+func (mr *MockPathFetcherMockRecorder) GetPaths(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaths", reflect.TypeOf((*MockPathFetcher)(nil).GetPaths), arg0, arg1, arg2, arg3)
+}
+
+// MockRevocationStore is a mock of RevocationStore interface.
+type MockRevocationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevocationStoreMockRecorder
+}
+
+// MockRevocationStoreMockRecorder is the mock recorder for MockRevocationStore.
+type MockRevocationStoreMockRecorder struct {
+	mock *MockRevocationStore
+}
+
+// NewMockRevocationStore creates a new mock instance.
+func NewMockRevocationStore(ctrl *gomock.Controller) *MockRevocationStore {
+	mock := &MockRevocationStore{ctrl: ctrl}
+	mock.recorder = &MockRevocationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevocationStore) EXPECT() *MockRevocationStoreMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockRevocationStore) GetAll(arg0 context.Context) (revcache.ResultChan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret0, _ := ret[0].(revcache.ResultChan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRevocationStoreMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRevocationStore)(nil).GetAll), arg0)
+}
+
+// MockSegmentsStore is a mock of SegmentsStore interface.
+type MockSegmentsStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSegmentsStoreMockRecorder
+}
+
+// MockSegmentsStoreMockRecorder is the mock recorder for MockSegmentsStore.
+type MockSegmentsStoreMockRecorder struct {
+	mock *MockSegmentsStore
+}
+
+// NewMockSegmentsStore creates a new mock instance.
+func NewMockSegmentsStore(ctrl *gomock.Controller) *MockSegmentsStore {
+	mock := &MockSegmentsStore{ctrl: ctrl}
+	mock.recorder = &MockSegmentsStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSegmentsStore) EXPECT() *MockSegmentsStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockSegmentsStore) Get(arg0 context.Context, arg1 *query.Params) (query.Results, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(query.Results)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSegmentsStoreMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSegmentsStore)(nil).Get), arg0, arg1)
+}
@@ -0,0 +1,648 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen DO NOT EDIT.
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"github.com/go-chi/chi/v5"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List the certificate chains
+	// (GET /certificates)
+	GetCertificates(w http.ResponseWriter, r *http.Request, params GetCertificatesParams)
+	// Get the certificate chain
+	// (GET /certificates/{chain-id})
+	GetCertificate(w http.ResponseWriter, r *http.Request, chainId ChainID)
+	// Get the certificate chain blob
+	// (GET /certificates/{chain-id}/blob)
+	GetCertificateBlob(w http.ResponseWriter, r *http.Request, chainId ChainID)
+	// Prints the TOML configuration file.
+	// (GET /config)
+	GetConfig(w http.ResponseWriter, r *http.Request)
+	// Basic information page about the control service process.
+	// (GET /info)
+	GetInfo(w http.ResponseWriter, r *http.Request)
+	// List the SCION interfaces of the local AS
+	// (GET /interfaces)
+	GetInterfaces(w http.ResponseWriter, r *http.Request)
+	// Get logging level
+	// (GET /log/level)
+	GetLogLevel(w http.ResponseWriter, r *http.Request)
+	// Set logging level
+	// (PUT /log/level)
+	SetLogLevel(w http.ResponseWriter, r *http.Request)
+	// List the SCION paths to a destination
+	// (GET /paths/{isd-as})
+	GetPaths(w http.ResponseWriter, r *http.Request, isdAs IsdAs)
+	// List the cached revocations
+	// (GET /revocations)
+	GetRevocations(w http.ResponseWriter, r *http.Request)
+	// List the SCION path segments
+	// (GET /segments)
+	GetSegments(w http.ResponseWriter, r *http.Request, params GetSegmentsParams)
+	// Get the SCION path segment description
+	// (GET /segments/{segment-id})
+	GetSegment(w http.ResponseWriter, r *http.Request, segmentId SegmentIDs)
+	// Get the SCION path segment blob
+	// (GET /segments/{segment-id}/blob)
+	GetSegmentBlob(w http.ResponseWriter, r *http.Request, segmentId SegmentIDs)
+	// List the services of the local AS
+	// (GET /services)
+	GetServices(w http.ResponseWriter, r *http.Request)
+	// Prints the contents of the AS topology file.
+	// (GET /topology)
+	GetTopology(w http.ResponseWriter, r *http.Request)
+	// List the TRCs
+	// (GET /trcs)
+	GetTrcs(w http.ResponseWriter, r *http.Request, params GetTrcsParams)
+	// Get the TRC
+	// (GET /trcs/isd{isd}-b{base}-s{serial})
+	GetTrc(w http.ResponseWriter, r *http.Request, isd int, base int, serial int)
+	// Get the TRC blob
+	// (GET /trcs/isd{isd}-b{base}-s{serial}/blob)
+	GetTrcBlob(w http.ResponseWriter, r *http.Request, isd int, base int, serial int)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+}
+
+type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
+
+// GetCertificates operation middleware
+func (siw *ServerInterfaceWrapper) GetCertificates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCertificatesParams
+
+	// ------------- Optional query parameter "isd_as" -------------
+	if paramValue := r.URL.Query().Get("isd_as"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "isd_as", r.URL.Query(), &params.IsdAs)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter isd_as: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "valid_at" -------------
+	if paramValue := r.URL.Query().Get("valid_at"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "valid_at", r.URL.Query(), &params.ValidAt)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter valid_at: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "all" -------------
+	if paramValue := r.URL.Query().Get("all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "all", r.URL.Query(), &params.All)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter all: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCertificates(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetCertificate operation middleware
+func (siw *ServerInterfaceWrapper) GetCertificate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "chain-id" -------------
+	var chainId ChainID
+
+	err = runtime.BindStyledParameter("simple", false, "chain-id", chi.URLParam(r, "chain-id"), &chainId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter chain-id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCertificate(w, r, chainId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetCertificateBlob operation middleware
+func (siw *ServerInterfaceWrapper) GetCertificateBlob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "chain-id" -------------
+	var chainId ChainID
+
+	err = runtime.BindStyledParameter("simple", false, "chain-id", chi.URLParam(r, "chain-id"), &chainId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter chain-id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCertificateBlob(w, r, chainId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetConfig operation middleware
+func (siw *ServerInterfaceWrapper) GetConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConfig(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetInfo operation middleware
+func (siw *ServerInterfaceWrapper) GetInfo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInfo(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetInterfaces operation middleware
+func (siw *ServerInterfaceWrapper) GetInterfaces(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInterfaces(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetLogLevel operation middleware
+func (siw *ServerInterfaceWrapper) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLogLevel(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// SetLogLevel operation middleware
+func (siw *ServerInterfaceWrapper) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetLogLevel(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetPaths operation middleware
+func (siw *ServerInterfaceWrapper) GetPaths(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "isd-as" -------------
+	var isdAs IsdAs
+
+	err = runtime.BindStyledParameter("simple", false, "isd-as", chi.URLParam(r, "isd-as"), &isdAs)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter isd-as: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPaths(w, r, isdAs)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRevocations operation middleware
+func (siw *ServerInterfaceWrapper) GetRevocations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRevocations(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetSegments operation middleware
+func (siw *ServerInterfaceWrapper) GetSegments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSegmentsParams
+
+	// ------------- Optional query parameter "start_isd_as" -------------
+	if paramValue := r.URL.Query().Get("start_isd_as"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "start_isd_as", r.URL.Query(), &params.StartIsdAs)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter start_isd_as: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "end_isd_as" -------------
+	if paramValue := r.URL.Query().Get("end_isd_as"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "end_isd_as", r.URL.Query(), &params.EndIsdAs)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter end_isd_as: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSegments(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetSegment operation middleware
+func (siw *ServerInterfaceWrapper) GetSegment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "segment-id" -------------
+	var segmentId SegmentIDs
+
+	err = runtime.BindStyledParameter("simple", false, "segment-id", chi.URLParam(r, "segment-id"), &segmentId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter segment-id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSegment(w, r, segmentId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetSegmentBlob operation middleware
+func (siw *ServerInterfaceWrapper) GetSegmentBlob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "segment-id" -------------
+	var segmentId SegmentIDs
+
+	err = runtime.BindStyledParameter("simple", false, "segment-id", chi.URLParam(r, "segment-id"), &segmentId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter segment-id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSegmentBlob(w, r, segmentId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetServices operation middleware
+func (siw *ServerInterfaceWrapper) GetServices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetServices(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTopology operation middleware
+func (siw *ServerInterfaceWrapper) GetTopology(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTopology(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTrcs operation middleware
+func (siw *ServerInterfaceWrapper) GetTrcs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTrcsParams
+
+	// ------------- Optional query parameter "isd" -------------
+	if paramValue := r.URL.Query().Get("isd"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", false, false, "isd", r.URL.Query(), &params.Isd)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter isd: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "all" -------------
+	if paramValue := r.URL.Query().Get("all"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "all", r.URL.Query(), &params.All)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter all: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTrcs(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTrc operation middleware
+func (siw *ServerInterfaceWrapper) GetTrc(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "isd" -------------
+	var isd int
+
+	err = runtime.BindStyledParameter("simple", false, "isd", chi.URLParam(r, "isd"), &isd)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter isd: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "base" -------------
+	var base int
+
+	err = runtime.BindStyledParameter("simple", false, "base", chi.URLParam(r, "base"), &base)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter base: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "serial" -------------
+	var serial int
+
+	err = runtime.BindStyledParameter("simple", false, "serial", chi.URLParam(r, "serial"), &serial)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter serial: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTrc(w, r, isd, base, serial)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTrcBlob operation middleware
+func (siw *ServerInterfaceWrapper) GetTrcBlob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "isd" -------------
+	var isd int
+
+	err = runtime.BindStyledParameter("simple", false, "isd", chi.URLParam(r, "isd"), &isd)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter isd: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "base" -------------
+	var base int
+
+	err = runtime.BindStyledParameter("simple", false, "base", chi.URLParam(r, "base"), &base)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter base: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "serial" -------------
+	var serial int
+
+	err = runtime.BindStyledParameter("simple", false, "serial", chi.URLParam(r, "serial"), &serial)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter serial: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTrcBlob(w, r, isd, base, serial)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL     string
+	BaseRouter  chi.Router
+	Middlewares []MiddlewareFunc
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/certificates", wrapper.GetCertificates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/certificates/{chain-id}", wrapper.GetCertificate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/certificates/{chain-id}/blob", wrapper.GetCertificateBlob)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/config", wrapper.GetConfig)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/info", wrapper.GetInfo)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/interfaces", wrapper.GetInterfaces)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/log/level", wrapper.GetLogLevel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/log/level", wrapper.SetLogLevel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/paths/{isd-as}", wrapper.GetPaths)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/revocations", wrapper.GetRevocations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/segments", wrapper.GetSegments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/segments/{segment-id}", wrapper.GetSegment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/segments/{segment-id}/blob", wrapper.GetSegmentBlob)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/services", wrapper.GetServices)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/topology", wrapper.GetTopology)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/trcs", wrapper.GetTrcs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/trcs/isd{isd}-b{base}-s{serial}", wrapper.GetTrc)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/trcs/isd{isd}-b{base}-s{serial}/blob", wrapper.GetTrcBlob)
+	})
+
+	return r
+}
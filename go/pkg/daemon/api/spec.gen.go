@@ -0,0 +1,160 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen DO NOT EDIT.
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+xbW3PbuJL+Kyie87CnRnfbSaw3RXZmVJuLy9acU7XjrAsiWyImJMABQDtar/77VgO8",
+	"gBdZlDOT8WydVB4sEpfG1183Go3mo+eLOBEcuFbe9NGToBLBFZgfb2lwDb+loDT+8gXXwM2fNEki5lPN",
+	"BB/+qgTHZ8oPIab4198lrL2p97dhOfTQvlXDG015QGVwKaWQ3m6363kBKF+yBAfzpjgnkdmk+DbriOPO",
+	"QWq2xnkBfyZSJPjEyhowpRnfpEyFENxxGps2epuAN/WUloxvvF3PYyq4o+qQlAsVzBQ2V+nqV/D13RfY",
+	"3tFoI7AjfKVxEuGwl/OLm5nXa87idmPBQUxs6/+E7eICe9/TiAVMbw/1+2feDnFCzJiEwJv+0oZFsXJn",
+	"+JblNUT/3PM002a1DvzE1VmxfmF64grmIWW8qSOmVAry0LJcNZdYHtWrhkc+RC+XYM+qfBS709reSgbr",
+	"lgUe1LXpbdXcDY06FTu3/2YWscDrNaFzBnZQNHgQ/1lYLi6qVrWmZyd0dEq9nrcWMqbam3ohfO1n5vWU",
+	"6hYBcHwEspyttMqfRNKiMq5BrqkPFSFOJ0V/bLABebTzqKOZm185oYPfFdUhUbCJgWsSiqQNrIUr6Z5F",
+	"ZN6m6lIXF0SsiQ6BFO0GXq9c7FnbWiPGv9zZx/XxltsE8hGxGdHC/M2BbcKVQLjJ7KYyheeHLAralJJ3",
+	"ujvSM3P4qu9CkTTFMzhxGpGUByAjuiU0CCQolcu8EjIASaRINUiiQ6qJeODqCYS88flkMBpMBuPpyWh0",
+	"OmkupK5tVyEumI7gjvpv5otPH8u5D5mNBaFiNOP+ej0aTUfT8Xjk9byEagTBm3r/fXsb/ND/j19ofz3q",
+	"n39+HPdOd9N/PE521Uf/+F9s93fHuhY3F/3ZzQGTei827+EeoiYlo/xxVTnvxWaD/LCvex7wNDZbFqzS",
+	"jbGOtcDHJjz47Koge/M07nbYzy2YoYU1pYSE+U0h/xWCDg01gCRomT6VkoFlCE11iJD4VAupkPsKeEC0",
+	"pOs188kD06Fpd3m1mNveKEuFTmsaKShkXAkRAeUoJHxNmKRWirpQV4JxTRgnmsVAHkLgpXymH6iB6zID",
+	"qqGPbdv0tmZ8AzKRjOvmTO/Kl7nJ4Cw98hAyPyRMkQAku4eArKWIzXuFERv3jVsoaKyqNnQSjFev6Kv1",
+	"ydkqOKXBOHi1euO/DsarN6uT9TmcwevV6/VkNaGvgtPgxJ/4r+m5fwJjik/f+Kdt6whFovZ4ACMBEdyR",
+	"n9kf1vxxZZLeg1Q0QkGZhvig+8EtZFfIQaWkW/wd67QpxQf6lcVpjLNwFTOlmOAk5awGKuNktdU1sMan",
+	"r1t3oP1e7+dOzi5DIBI+jcjsxjq/tZAPVAaW3jmPHeC+zRW6VMsUZgGr0N1ZWs9aZcM9GqYf8IxXUqwi",
+	"iFuOB6Apa/FHMxKmMeVEAg3oKgI0pYhyIxRRCfgYYNgNjikifD+VMie6wcdOaIFkioQQJes0wh4IsoZK",
+	"K8oDsmH3QGhwz3AQTkLxgI0TKXyAYED+JZnWwFFTl3wTMRWaXoV8ayEJ8A3jAFL1SKpSGkVbwoUmKmUa",
+	"jVJIwlF94IecoaKVpl8gFFEAUpnRsLXhAPsfCKrqnQvOwTfL14IEVNMVVWCcTkBEqtuskHGlKfehDd6f",
+	"rxdEwhosahamfEuxjCtQ3otuj8BgMyArQ27cPShZS2qDpWIwSYQkKl31rc8VVfWgCyYf6JasgKQKgpqC",
+	"pBCZWTJVdMqMRYlU+kB8EdSCgmHWcOgXmPXNxvU3Lb4A7+OO1UfFGTcc9C16hYNOJesXyLSeIjXVaYt7",
+	"W4ZAflour4htYCQjG+AgKep/tc28HNswThTIe5CGFE9TuBoSjk7wlx+lit1D5sm8qZYp9Lw4//lqNOp5",
+	"MeP213g0KhbhuKzMiJvMUKGQSNo4pnLbsCejsD/bGG5AGjv9mdN7yiKcs01RZZi8pmmEuqUrkerpKqL8",
+	"i9frYhMpZ7+lEG3rxuHiQQSPtjkrTTbmq3Zwu2cBBGR2tRiQT0kiMpK7Fma9GuPk+t28//rN6HWPMOO1",
+	"ODAT8EjwRRwDD2zfFeBOnwlqAEe8EhOIaEGo9Z39Qh2B8FM0SjsPF5JsIrEyKrHry2hYU3M3ozrCdOrH",
+	"f2tHORXbosNruBd+EXjVYsTjgzJZDHd8aNb1LIdzfIFgz4nldzjAHncG7CTV/mMgoqE0jZPnYPxAFTHp",
+	"iaArzAcP581zWylhJXBxwpQi7HRlOxCw3Ngz/yHWdaNOHg1/SyjbIV1pRbZJrIgqfZcmKFbQXdCKtp+j",
+	"r2C/QmoyZai0R5N5xqWbkvYk/YAHxyYvjgUZ+MaeXmuHafM8N71sMdVzRJsXUJpKffdNyazAqw3Tc2Eo",
+	"JG5kCJ+NfSNJuDo9C05Pg4NJwqz/gTxGMUt3+6loKGZ8YTuNn5paeU2Dy2KMJq+ygxy0RICz/FWZ2LMR",
+	"uCrJYEatHGzbz3CTs8n+mKYUU5ViVmX5SGNombWcDCMVKaLD23Q2Q89ZuWu29vVBvlQumJrGmj+uLsK0",
+	"JjEoRTeH3U+RnWrO7l7lVCB/c07enpPTczKfkMk7/H8+JxcXZHRBJjNy9prMzsnFJXlzaV6dkXcnZHRO",
+	"xiNyMXY5rhLqQ9CvUr2uu+X1vIVOqQ6FZJpqdg93NONVJ6oXfqtOCl/I32uoij7aLu4Ouszl9fx3uj8z",
+	"7s25JiuX2WuDsSq8Q9nl9fwQXZfX82ffJWULbgrfcLvdBFlcNKXAw/8dT+MVyAqfx3vCyw63KAoko1Hb",
+	"oCfN5s1AzetVhKqPV4O/ze07ixaJiMRmm7lbhuDQ6MpBwJ54Gx3/6VCsChgX+o6udW1l3mQ0mfRH4/7o",
+	"lIzOp2fn05OTHzBfP+p8LsCBV7AWEhojj79l5BrCzjQ9ZzEOrPnaSQKSiaCJ626X5fGb+9bVojgC2lDg",
+	"gkJsCZmNbh/gMRZtEKSyPUeD0WCMMIgEOE0Y5pNxC7N3HaGBfujcP5oHG2jJbr9nStsTtMmD6GhLqI/G",
+	"3Ly+VPZsTiWQL1w88Ow8fcuzLa3Y78jSHEhUGmniU44H5zWLNEibjrFXKQPyLpV4zI6FhN4tFxxM44Qq",
+	"RShJqNTMTyMqSVI58VCdJd51WJHxlmdConzGWxE8B/Ek1QMyI9m1Qi5PkSDQgkjQqeSERtEtdzHrEQkb",
+	"KoOozB8zmakZf2MOxKh+cIsqQ9qbmHsReFPvR9BzF39UjKQxaJDKm/7y6DFE/7cUJLpUW6BRHry6VY8U",
+	"0Wj7aAaEO6or43WzgfYBaRRVxqrf2Ow+96oVM5PR6KhSmU57plNx0Ng4mwU0ht+i5TLebLunTwqY5V5+",
+	"OK6mJ0+6twiz4JaYlYoem/GrmGJT1p6n6QaJ42mZKu19xq4VCx8+mqZ9Fuz2GvuPsGcC44aoScZzkpUh",
+	"HGb1HlKjBypJk0vluY7V7iPd8CxqRL6ZXgdnadNZo6zixfFmr1aPY81wFYnVM6gDHDPuxtteXX6wt3cE",
+	"x3oeqd6iFC+aWF/7CcT9NYtqoUcf/729/HHxkcwvr5eLd4v5bHlpnt7y2Y1LpMFgcMvNm8uPFy2tnxxq",
+	"PjtmKK8DpY26/jq8tuLuIbfga7ZxaNzkmm1xUOV4nzBMoqx0r7HrFZtlY1U3qe+DUnjv+Smf3AG3DatC",
+	"lKFTZFpF40oyru0tyPLTh/fELjS1w2N8BQMHEry4wNOXwSSPPvchsuCmluSvhcdbqphPGLcBDWKQ0A0Q",
+	"c9VUXAk5Uam9U1bqCZTyMonDwXKtQqnIMxWVBHgzhA/qRVfQXomgirorfBcPyKw0O5VV1FSrEShxL3af",
+	"quFaM6l09QaihQHF2r9HCFdMd0wEV8d88HIDuEP0cH1Xfua2JIzEZljUiu2z16LM7A+Mh4o5vptBo7uP",
+	"avVwDUPteUnaAspNDRQz/lsRbL8LHnkVnzt/GY7s/l9p6aaLlpDJJg8xfGQq6FO16+pSTa+ykK6szLJ+",
+	"LgClWVaOhCmEZQgKzIk/r87KPKlpbHMn9nhvShMdoJXpvDV9fRGvGHcL+NwrGSft4VM/NLe5ZaO8JGhA",
+	"PjClEBUhsTDIXLeVQ2BvLjRZg/ZDd6Z67qTNO2OxpuoUE1u0nx0R5wmF73KOx1Ud7/8zHTfo8OJ3g0Jw",
+	"6ortWA82yGynvDDvEIs4jdu2yQZ7V1vHOgbkqjQaxA3Da9qsWygIbK3JJs5ccyIp1yx6otyjweprZ5Hf",
+	"g2/lfEfljSxoDsgvmGlNYR1+lU8zluXO6Rjf3OIU3VwweSIVrNpywdjH3JybiBnj3NlN/QafLLhKwNfZ",
+	"1W7A7lmQ0ih/r7I8QywkEFvYCgG5Z/DQSrubfNUNf1rbPo1UWdm/WLfWE9S/M2hLmtbqAo7O7NYKjUDG",
+	"jNs9cZ9Qk1yoyV6hKtUJL3BvqJSYHGGtMdV+iJtwC2P/EjtEIa1juNmjmtUOH7O/OmV8naeIEy3LjJtz",
+	"P2U2TavBCnERlJ9xtEQlpaDPjkycihSEUG8N0xUzlP9DE8P5wtsi7AZ0bj5i8GITaofLjrozr1vWuGXG",
+	"vWnjp+jXnhz+61GwQwr5arb8idxc/vjh8uMyS+UaFDFey0Sp5X5benidSPuis7/75N3PUhN5dIhqGim5",
+	"rKtTPtb6gVAj6MlC6XbeZtJ8n13TTHbMhpmj9YI3x1zE7kk07dSx7MuhFbUuf+DWUczxZ1wSZCsoYDNJ",
+	"FCvP07cFWvodjCeiGpTOrHOJFzDkWghN5u61hA3NgfohBtLHHxn2VI/gFx22Nina9rBuAyu6iuNFRidz",
+	"elUaqKnVMN+KOHILvudUusTVd9thmsUbXq/NZFs+AqpXk2abCcYO3suuvigq9I5wMtm0+E0MKmrw7Twv",
+	"aIjj7bkJRB4PmQowBbnrrx4xS7frq0dbILfrGLPso/aei+yl9Lsm6p4MRJ4sGtz1WsfEBXYbdNx5TAtW",
+	"t1Hb6hX/yMgc63pbWLe8ng9+n9uIjGDP49cxgfE+kuXBcR4rY5BsY+S97OtcPvFvBj4zMF9ez7Po+r9+",
+	"nT18+nX26sPy8mFRC8bLVl4rRetB97fTdG9VRPa5Qs6FVEbe1Au1TqbD4WMolN5NHxMh9c6UeUuGjtpA",
+	"he+qH3Ka2Ms8xps4IWuvT0anZ2dok58LMRqfFtyD3GqTo5EQmUsSLdrTNfXDm7frHTuaO0pi7xu6DdEh",
+	"se4MLd3U8jEyGh1h1sqQ3BnRvDhK2lIwk0rdFzW7cxThaWOauYizsmNM4ZuPZ1fbTObyyFCMlMWPu8+7",
+	"/xsAhWdGC0FNAAA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %s", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	var res = make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.Swagger, err error) {
+	var resolvePath = PathToRawSpec("")
+
+	loader := openapi3.NewSwaggerLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.SwaggerLoader, url *url.URL) ([]byte, error) {
+		var pathToFile = url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadSwaggerFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
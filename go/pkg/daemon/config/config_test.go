@@ -24,6 +24,7 @@ import (
 	"github.com/scionproto/scion/go/lib/daemon"
 	"github.com/scionproto/scion/go/lib/env/envtest"
 	"github.com/scionproto/scion/go/lib/log/logtest"
+	"github.com/scionproto/scion/go/pkg/api/apitest"
 	storagetest "github.com/scionproto/scion/go/pkg/storage/test"
 )
 
@@ -39,6 +40,7 @@ func TestConfigSample(t *testing.T) {
 }
 
 func InitTestConfig(cfg *Config) {
+	apitest.InitConfig(&cfg.API)
 	envtest.InitTest(&cfg.General, &cfg.Metrics, &cfg.Tracing, nil)
 	logtest.InitTestLogging(&cfg.Logging)
 	InitTestSDConfig(&cfg.SD)
@@ -50,6 +52,7 @@ func InitTestSDConfig(cfg *SDConfig) {
 }
 
 func CheckTestConfig(t *testing.T, cfg *Config, id string) {
+	apitest.CheckConfig(t, &cfg.API)
 	envtest.CheckTest(t, &cfg.General, &cfg.Metrics, &cfg.Tracing, nil, id)
 	logtest.CheckTestLogging(t, &cfg.Logging, id)
 	storagetest.CheckTestTrustDBConfig(t, &cfg.TrustDB, id)
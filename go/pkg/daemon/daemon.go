@@ -34,6 +34,7 @@ import (
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/pkg/daemon/drkey"
 	"github.com/scionproto/scion/go/pkg/daemon/fetcher"
 	"github.com/scionproto/scion/go/pkg/daemon/internal/servers"
 	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
@@ -106,6 +107,7 @@ type ServerConfig struct {
 	RevCache     revcache.RevCache
 	Engine       trust.Engine
 	TopoProvider topology.Provider
+	DRKeyClient  *drkey.ClientEngine
 }
 
 // NewServer constructs a daemon API server.
@@ -115,6 +117,7 @@ func NewServer(cfg ServerConfig) *servers.DaemonServer {
 		ASInspector:  cfg.Engine.Inspector,
 		RevCache:     cfg.RevCache,
 		TopoProvider: cfg.TopoProvider,
+		DRKeyClient:  cfg.DRKeyClient,
 		Metrics: servers.Metrics{
 			PathsRequests: servers.RequestMetrics{
 				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
@@ -131,6 +134,14 @@ func NewServer(cfg ServerConfig) *servers.DaemonServer {
 					Buckets:   prom.DefaultLatencyBuckets,
 				}, servers.LatencyLabels),
 			},
+			WatchPathsRequests: servers.RequestMetrics{
+				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+					Namespace: "sd",
+					Subsystem: "path",
+					Name:      "watch_requests_total",
+					Help:      "The amount of path watch requests that were served.",
+				}, servers.WatchPathsRequestsLabels),
+			},
 			ASRequests: servers.RequestMetrics{
 				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
 					Namespace: "sd",
@@ -161,6 +172,21 @@ func NewServer(cfg ServerConfig) *servers.DaemonServer {
 					Buckets:   prom.DefaultLatencyBuckets,
 				}, servers.LatencyLabels),
 			},
+			PortRangeRequests: servers.RequestMetrics{
+				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+					Namespace: "sd",
+					Subsystem: "port_range",
+					Name:      "requests_total",
+					Help:      "The amount of port range requests received.",
+				}, servers.PortRangeRequestsLabels),
+				Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+					Namespace: "sd",
+					Subsystem: "port_range",
+					Name:      "request_duration_seconds",
+					Help:      "Time to handle port range requests.",
+					Buckets:   prom.DefaultLatencyBuckets,
+				}, servers.LatencyLabels),
+			},
 			ServicesRequests: servers.RequestMetrics{
 				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
 					Namespace: "sd",
@@ -192,6 +218,21 @@ func NewServer(cfg ServerConfig) *servers.DaemonServer {
 					Buckets:   prom.DefaultLatencyBuckets,
 				}, servers.LatencyLabels),
 			},
+			DRKeyRequests: servers.RequestMetrics{
+				Requests: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+					Namespace: "sd",
+					Subsystem: "drkey",
+					Name:      "requests_total",
+					Help:      "The amount of DRKey level 2 requests received.",
+				}, servers.DRKeyRequestsLabels),
+				Latency: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+					Namespace: "sd",
+					Subsystem: "drkey",
+					Name:      "request_duration_seconds",
+					Help:      "Time to handle DRKey level 2 requests.",
+					Buckets:   prom.DefaultLatencyBuckets,
+				}, servers.LatencyLabels),
+			},
 		},
 	}
 }
@@ -0,0 +1,131 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drkey implements the DRKey level 2 key derivation of the SCION
+// daemon.
+package drkey
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Lvl1Fetcher fetches level 1 keys from the local control service.
+type Lvl1Fetcher interface {
+	// Lvl1 fetches the level 1 key described by meta that is valid at
+	// valTime.
+	Lvl1(ctx context.Context, meta drkey.Lvl1Meta, valTime time.Time) (drkey.Lvl1Key, error)
+}
+
+// ClientEngine derives level 2 keys from the level 1 keys provided by the
+// local control service. The level 1 keys are kept in memory until they
+// expire.
+type ClientEngine struct {
+	// Fetcher fetches level 1 keys from the local control service.
+	Fetcher Lvl1Fetcher
+
+	mtx    sync.Mutex
+	cache  map[drkey.Lvl1Meta][]drkey.Lvl1Key
+	dedupe singleflight.Group
+}
+
+// GetLvl2Key returns the level 2 key described by meta that is valid at
+// valTime.
+func (e *ClientEngine) GetLvl2Key(ctx context.Context, meta drkey.Lvl2Meta,
+	valTime time.Time) (drkey.Lvl2Key, error) {
+
+	lvl1, err := e.getLvl1Key(ctx, meta.Lvl1(), valTime)
+	if err != nil {
+		return drkey.Lvl2Key{}, err
+	}
+	return drkey.DeriveLvl2(lvl1, meta)
+}
+
+func (e *ClientEngine) getLvl1Key(ctx context.Context, meta drkey.Lvl1Meta,
+	valTime time.Time) (drkey.Lvl1Key, error) {
+
+	if key, ok := e.cached(meta, valTime); ok {
+		return key, nil
+	}
+	r, err, _ := e.dedupe.Do(meta.SrcIA.String()+meta.DstIA.String(),
+		func() (interface{}, error) {
+			return e.fetch(ctx, meta, valTime)
+		},
+	)
+	if err != nil {
+		return drkey.Lvl1Key{}, err
+	}
+	key := r.(drkey.Lvl1Key)
+	if key.Epoch.Contains(valTime) {
+		return key, nil
+	}
+	// The deduplicated request was for a different epoch.
+	if key, err = e.fetch(ctx, meta, valTime); err != nil {
+		return drkey.Lvl1Key{}, err
+	}
+	if !key.Epoch.Contains(valTime) {
+		return drkey.Lvl1Key{}, serrors.New("fetched key not valid at requested time",
+			"epoch", key.Epoch, "val_time", valTime)
+	}
+	return key, nil
+}
+
+func (e *ClientEngine) fetch(ctx context.Context, meta drkey.Lvl1Meta,
+	valTime time.Time) (drkey.Lvl1Key, error) {
+
+	key, err := e.Fetcher.Lvl1(ctx, meta, valTime)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("fetching level 1 key", err,
+			"src", meta.SrcIA, "dst", meta.DstIA)
+	}
+	if key.Lvl1Meta != meta {
+		return drkey.Lvl1Key{}, serrors.New("fetched key does not match request",
+			"src", key.SrcIA, "dst", key.DstIA)
+	}
+	e.store(key)
+	return key, nil
+}
+
+func (e *ClientEngine) cached(meta drkey.Lvl1Meta, valTime time.Time) (drkey.Lvl1Key, bool) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	for _, key := range e.cache[meta] {
+		if key.Epoch.Contains(valTime) {
+			return key, true
+		}
+	}
+	return drkey.Lvl1Key{}, false
+}
+
+func (e *ClientEngine) store(key drkey.Lvl1Key) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.cache == nil {
+		e.cache = make(map[drkey.Lvl1Meta][]drkey.Lvl1Key)
+	}
+	now := time.Now()
+	keys := []drkey.Lvl1Key{key}
+	for _, cached := range e.cache[key.Lvl1Meta] {
+		if now.Before(cached.Epoch.NotAfter) && cached.Epoch != key.Epoch {
+			keys = append(keys, cached)
+		}
+	}
+	e.cache[key.Lvl1Meta] = keys
+}
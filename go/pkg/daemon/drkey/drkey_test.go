@@ -0,0 +1,115 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drkey_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	sddrkey "github.com/scionproto/scion/go/pkg/daemon/drkey"
+	"github.com/scionproto/scion/go/pkg/daemon/drkey/mock_drkey"
+)
+
+func TestClientEngineGetLvl2Key(t *testing.T) {
+	now := time.Now()
+	lvl1Meta := drkey.Lvl1Meta{
+		SrcIA: xtest.MustParseIA("1-ff00:0:110"),
+		DstIA: xtest.MustParseIA("1-ff00:0:111"),
+	}
+	lvl1Key := func(valTime time.Time) drkey.Lvl1Key {
+		return drkey.Lvl1Key{
+			Lvl1Meta: lvl1Meta,
+			Epoch:    drkey.NewEpoch(valTime, time.Hour),
+			Key:      drkey.Key{1, 2, 3},
+		}
+	}
+	meta := drkey.Lvl2Meta{
+		KeyType:  drkey.Host2Host,
+		Protocol: "scmp",
+		SrcIA:    lvl1Meta.SrcIA,
+		DstIA:    lvl1Meta.DstIA,
+		SrcHost:  addr.HostFromIP(net.IP{127, 0, 0, 1}),
+		DstHost:  addr.HostFromIP(net.IP{127, 0, 0, 2}),
+	}
+
+	t.Run("cached", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		fetcher := mock_drkey.NewMockLvl1Fetcher(mctrl)
+		fetcher.EXPECT().Lvl1(gomock.Any(), lvl1Meta, gomock.Any()).Return(lvl1Key(now), nil)
+		engine := &sddrkey.ClientEngine{Fetcher: fetcher}
+
+		key, err := engine.GetLvl2Key(context.Background(), meta, now)
+		require.NoError(t, err)
+		expected, err := drkey.DeriveLvl2(lvl1Key(now), meta)
+		require.NoError(t, err)
+		assert.Equal(t, expected, key)
+
+		// The level 1 key is cached.
+		again, err := engine.GetLvl2Key(context.Background(), meta, now)
+		require.NoError(t, err)
+		assert.Equal(t, key, again)
+	})
+	t.Run("next epoch", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		next := now.Add(time.Hour)
+		fetcher := mock_drkey.NewMockLvl1Fetcher(mctrl)
+		gomock.InOrder(
+			fetcher.EXPECT().Lvl1(gomock.Any(), lvl1Meta, now).Return(lvl1Key(now), nil),
+			fetcher.EXPECT().Lvl1(gomock.Any(), lvl1Meta, next).Return(lvl1Key(next), nil),
+		)
+		engine := &sddrkey.ClientEngine{Fetcher: fetcher}
+
+		key, err := engine.GetLvl2Key(context.Background(), meta, now)
+		require.NoError(t, err)
+		nextKey, err := engine.GetLvl2Key(context.Background(), meta, next)
+		require.NoError(t, err)
+		assert.Equal(t, key.Epoch.NotAfter, nextKey.Epoch.NotBefore)
+	})
+	t.Run("fetch error", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		fetcher := mock_drkey.NewMockLvl1Fetcher(mctrl)
+		fetcher.EXPECT().Lvl1(gomock.Any(), lvl1Meta, now).Return(
+			drkey.Lvl1Key{}, serrors.New("internal"))
+		engine := &sddrkey.ClientEngine{Fetcher: fetcher}
+
+		_, err := engine.GetLvl2Key(context.Background(), meta, now)
+		assert.Error(t, err)
+	})
+	t.Run("mismatching key", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		fetcher := mock_drkey.NewMockLvl1Fetcher(mctrl)
+		wrong := lvl1Key(now)
+		wrong.SrcIA = xtest.MustParseIA("1-ff00:0:112")
+		fetcher.EXPECT().Lvl1(gomock.Any(), lvl1Meta, now).Return(wrong, nil)
+		engine := &sddrkey.ClientEngine{Fetcher: fetcher}
+
+		_, err := engine.GetLvl2Key(context.Background(), meta, now)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	sddrkey "github.com/scionproto/scion/go/pkg/daemon/drkey"
+	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
+	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
+)
+
+var _ sddrkey.Lvl1Fetcher = (*Lvl1Fetcher)(nil)
+
+// Lvl1Fetcher fetches level 1 keys from the local control service.
+type Lvl1Fetcher struct {
+	// Dialer dials a new gRPC connection.
+	Dialer libgrpc.Dialer
+}
+
+// Lvl1 fetches the level 1 key described by meta that is valid at valTime.
+func (f Lvl1Fetcher) Lvl1(ctx context.Context, meta drkey.Lvl1Meta,
+	valTime time.Time) (drkey.Lvl1Key, error) {
+
+	conn, err := f.Dialer.Dial(ctx, &snet.SVCAddr{SVC: addr.SvcCS})
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("dialing", err)
+	}
+	defer conn.Close()
+	client := cppb.NewDRKeyIntraServiceClient(conn)
+	ts, err := ptypes.TimestampProto(valTime)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("converting validity time", err)
+	}
+	rep, err := client.DRKeyIntraLvl1(ctx, &cppb.DRKeyIntraLvl1Request{
+		ValTime:  ts,
+		SrcIsdAs: uint64(meta.SrcIA.IAInt()),
+		DstIsdAs: uint64(meta.DstIA.IAInt()),
+	}, libgrpc.RetryProfile...)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("requesting level 1 key", err)
+	}
+	notBefore, err := ptypes.Timestamp(rep.EpochBegin)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("parsing epoch begin", err)
+	}
+	notAfter, err := ptypes.Timestamp(rep.EpochEnd)
+	if err != nil {
+		return drkey.Lvl1Key{}, serrors.WrapStr("parsing epoch end", err)
+	}
+	if len(rep.Key) != drkey.KeyLen {
+		return drkey.Lvl1Key{}, serrors.New("invalid key length", "expected", drkey.KeyLen,
+			"actual", len(rep.Key))
+	}
+	key := drkey.Lvl1Key{
+		Lvl1Meta: meta,
+		Epoch:    drkey.Epoch{NotBefore: notBefore, NotAfter: notAfter},
+	}
+	copy(key.Key[:], rep.Key)
+	return key, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+	dkgrpc "github.com/scionproto/scion/go/pkg/daemon/drkey/grpc"
+	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
+	mock_cp "github.com/scionproto/scion/go/pkg/proto/control_plane/mock_control_plane"
+)
+
+func TestLvl1FetcherLvl1(t *testing.T) {
+	now := time.Now()
+	epoch := drkey.NewEpoch(now, time.Hour)
+	begin, err := ptypes.TimestampProto(epoch.NotBefore)
+	require.NoError(t, err)
+	end, err := ptypes.TimestampProto(epoch.NotAfter)
+	require.NoError(t, err)
+	meta := drkey.Lvl1Meta{
+		SrcIA: xtest.MustParseIA("1-ff00:0:110"),
+		DstIA: xtest.MustParseIA("1-ff00:0:111"),
+	}
+
+	testCases := map[string]struct {
+		Server    func(*gomock.Controller) *mock_cp.MockDRKeyIntraServiceServer
+		Assertion assert.ErrorAssertionFunc
+		Expected  drkey.Lvl1Key
+	}{
+		"valid": {
+			Server: func(mctrl *gomock.Controller) *mock_cp.MockDRKeyIntraServiceServer {
+				srv := mock_cp.NewMockDRKeyIntraServiceServer(mctrl)
+				srv.EXPECT().DRKeyIntraLvl1(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context,
+						req *cppb.DRKeyIntraLvl1Request) (*cppb.DRKeyIntraLvl1Response, error) {
+
+						valTime, err := ptypes.Timestamp(req.ValTime)
+						require.NoError(t, err)
+						assert.True(t, valTime.Equal(now))
+						assert.Equal(t, meta.SrcIA, addr.IAInt(req.SrcIsdAs).IA())
+						assert.Equal(t, meta.DstIA, addr.IAInt(req.DstIsdAs).IA())
+						return &cppb.DRKeyIntraLvl1Response{
+							EpochBegin: begin,
+							EpochEnd:   end,
+							Key:        []byte("0123456789abcdef"),
+						}, nil
+					},
+				)
+				return srv
+			},
+			Assertion: assert.NoError,
+			Expected: drkey.Lvl1Key{
+				Lvl1Meta: meta,
+				Epoch:    epoch,
+				Key: drkey.Key{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9',
+					'a', 'b', 'c', 'd', 'e', 'f'},
+			},
+		},
+		"RPC fail": {
+			Server: func(mctrl *gomock.Controller) *mock_cp.MockDRKeyIntraServiceServer {
+				srv := mock_cp.NewMockDRKeyIntraServiceServer(mctrl)
+				srv.EXPECT().DRKeyIntraLvl1(gomock.Any(), gomock.Any()).Return(nil,
+					serrors.New("internal"))
+				return srv
+			},
+			Assertion: assert.Error,
+		},
+		"invalid key length": {
+			Server: func(mctrl *gomock.Controller) *mock_cp.MockDRKeyIntraServiceServer {
+				srv := mock_cp.NewMockDRKeyIntraServiceServer(mctrl)
+				srv.EXPECT().DRKeyIntraLvl1(gomock.Any(), gomock.Any()).Return(
+					&cppb.DRKeyIntraLvl1Response{
+						EpochBegin: begin,
+						EpochEnd:   end,
+						Key:        []byte("short"),
+					}, nil,
+				)
+				return srv
+			},
+			Assertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+
+			svc := xtest.NewGRPCService()
+			cppb.RegisterDRKeyIntraServiceServer(svc.Server(), tc.Server(mctrl))
+			svc.Start(t)
+
+			f := dkgrpc.Lvl1Fetcher{Dialer: svc}
+			key, err := f.Lvl1(context.Background(), meta, now)
+			tc.Assertion(t, err)
+			assert.Equal(t, tc.Expected, key)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/scionproto/scion/go/pkg/daemon/drkey (interfaces: Lvl1Fetcher)
+
+// Package mock_drkey is a generated GoMock package.
+package mock_drkey
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	drkey "github.com/scionproto/scion/go/lib/drkey"
+)
+
+// MockLvl1Fetcher is a mock of Lvl1Fetcher interface.
+type MockLvl1Fetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockLvl1FetcherMockRecorder
+}
+
+// MockLvl1FetcherMockRecorder is the mock recorder for MockLvl1Fetcher.
+type MockLvl1FetcherMockRecorder struct {
+	mock *MockLvl1Fetcher
+}
+
+// NewMockLvl1Fetcher creates a new mock instance.
+func NewMockLvl1Fetcher(ctrl *gomock.Controller) *MockLvl1Fetcher {
+	mock := &MockLvl1Fetcher{ctrl: ctrl}
+	mock.recorder = &MockLvl1FetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLvl1Fetcher) EXPECT() *MockLvl1FetcherMockRecorder {
+	return m.recorder
+}
+
+// Lvl1 mocks base method.
+func (m *MockLvl1Fetcher) Lvl1(arg0 context.Context, arg1 drkey.Lvl1Meta, arg2 time.Time) (drkey.Lvl1Key, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lvl1", arg0, arg1, arg2)
+	ret0, _ := ret[0].(drkey.Lvl1Key)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lvl1 indicates an expected call of Lvl1.
+func (mr *MockLvl1FetcherMockRecorder) Lvl1(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lvl1", reflect.TypeOf((*MockLvl1Fetcher)(nil).Lvl1), arg0, arg1, arg2)
+}
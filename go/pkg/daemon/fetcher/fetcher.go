@@ -89,16 +89,44 @@ func NewFetcher(cfg FetcherConfig) Fetcher {
 				},
 				Metrics: segfetcher.NewFetcherMetrics("sd"),
 			},
-			Splitter: &segfetcher.MultiSegmentSplitter{
-				LocalIA:   cfg.TopoProvider.Get().IA(),
-				Core:      cfg.TopoProvider.Get().Core(),
-				Inspector: cfg.Inspector,
+			Splitter: newSplitter(cfg),
+		},
+		config: cfg.Cfg,
+	}
+}
+
+// NewCachedFetcher creates a fetcher that only combines the segments that are
+// already stored in the path database. Segments that are missing or outdated
+// are not requested from the control service. The RPC and Verifier of the
+// configuration are not used.
+func NewCachedFetcher(cfg FetcherConfig) Fetcher {
+	return &fetcher{
+		pather: segfetcher.Pather{
+			RevCache:     cfg.RevCache,
+			TopoProvider: cfg.TopoProvider,
+			Fetcher: &segfetcher.Fetcher{
+				PathDB: cfg.PathDB,
+				Resolver: segfetcher.NewResolver(
+					cfg.PathDB,
+					cfg.RevCache,
+					alwaysLocal{},
+				),
+				Requester: noRequester{},
 			},
+			Splitter: newSplitter(cfg),
 		},
 		config: cfg.Cfg,
 	}
 }
 
+func newSplitter(cfg FetcherConfig) segfetcher.Splitter {
+	return &segfetcher.MultiSegmentSplitter{
+		LocalIA:   cfg.TopoProvider.Get().IA(),
+		Core:      cfg.TopoProvider.Get().Core(),
+		Inspector: cfg.Inspector,
+	}
+}
+
 // GetPaths uses the pather to get paths from src to dst.
 // src may be either zero or the local IA (nothing else).
 func (f *fetcher) GetPaths(ctx context.Context, src, dst addr.IA,
@@ -128,3 +156,20 @@ type neverLocal struct{}
 func (neverLocal) IsSegLocal(_ segfetcher.Request) bool {
 	return false
 }
+
+// alwaysLocal treats all segments as locally stored, such that the resolver
+// always loads them from the path database.
+type alwaysLocal struct{}
+
+func (alwaysLocal) IsSegLocal(_ segfetcher.Request) bool {
+	return true
+}
+
+// noRequester never requests any segments.
+type noRequester struct{}
+
+func (noRequester) Request(_ context.Context, _ segfetcher.Requests) <-chan segfetcher.ReplyOrErr {
+	replies := make(chan segfetcher.ReplyOrErr)
+	close(replies)
+	return replies
+}
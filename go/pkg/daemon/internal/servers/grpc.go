@@ -18,16 +18,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	durationpb "github.com/golang/protobuf/ptypes/duration"
 	timestamppb "github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/opentracing/opentracing-go"
 	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/prom"
 	"github.com/scionproto/scion/go/lib/revcache"
@@ -35,6 +41,7 @@ import (
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/lib/util"
+	sddrkey "github.com/scionproto/scion/go/pkg/daemon/drkey"
 	"github.com/scionproto/scion/go/pkg/daemon/fetcher"
 	sdpb "github.com/scionproto/scion/go/pkg/proto/daemon"
 	"github.com/scionproto/scion/go/pkg/trust"
@@ -47,6 +54,10 @@ type DaemonServer struct {
 	TopoProvider topology.Provider
 	RevCache     revcache.RevCache
 	ASInspector  trust.Inspector
+	DRKeyClient  *sddrkey.ClientEngine
+	// WatchInterval is the interval in which the paths of a WatchPaths
+	// subscription are fetched again. If zero, DefaultWatchInterval is used.
+	WatchInterval time.Duration
 
 	Metrics Metrics
 
@@ -77,6 +88,13 @@ func (s *DaemonServer) paths(ctx context.Context,
 		defer cancelF()
 	}
 	srcIA, dstIA := addr.IAInt(req.SourceIsdAs).IA(), addr.IAInt(req.DestinationIsdAs).IA()
+	selection, err := newPathSelection(req)
+	if err != nil {
+		return nil, metricsError{
+			err:    status.Error(codes.InvalidArgument, err.Error()),
+			result: prom.ErrInvalidReq,
+		}
+	}
 	go func() {
 		defer log.HandlePanic()
 		s.backgroundPaths(ctx, srcIA, dstIA, req.Refresh)
@@ -88,7 +106,7 @@ func (s *DaemonServer) paths(ctx context.Context,
 		return nil, err
 	}
 	reply := &sdpb.PathsResponse{}
-	for _, p := range paths {
+	for _, p := range selection.apply(paths) {
 		reply.Paths = append(reply.Paths, pathToPB(p))
 	}
 	return reply, nil
@@ -142,6 +160,13 @@ func pathToPB(path snet.Path) *sdpb.Path {
 	if nextHop := path.UnderlayNextHop(); nextHop != nil {
 		nextHopStr = nextHop.String()
 	}
+	var epicAuths *sdpb.EpicAuths
+	if meta.EpicAuths.SupportsEpic() {
+		epicAuths = &sdpb.EpicAuths{
+			AuthPhvf: append([]byte(nil), meta.EpicAuths.AuthPHVF...),
+			AuthLhvf: append([]byte(nil), meta.EpicAuths.AuthLHVF...),
+		}
+	}
 	return &sdpb.Path{
 		Raw: raw,
 		Interface: &sdpb.Interface{
@@ -156,6 +181,7 @@ func pathToPB(path snet.Path) *sdpb.Path {
 		LinkType:     linkType,
 		InternalHops: meta.InternalHops,
 		Notes:        meta.Notes,
+		EpicAuths:    epicAuths,
 	}
 
 }
@@ -262,6 +288,29 @@ func (s *DaemonServer) interfaces(ctx context.Context,
 	return reply, nil
 }
 
+// PortRange serves the port range request.
+func (s *DaemonServer) PortRange(ctx context.Context,
+	req *sdpb.PortRangeRequest) (*sdpb.PortRangeResponse, error) {
+
+	start := time.Now()
+	response, err := s.portRange(ctx, req)
+	s.Metrics.PortRangeRequests.inc(
+		reqLabels{Result: errToMetricResult(err)},
+		time.Since(start).Seconds(),
+	)
+	return response, unwrapMetricsError(err)
+}
+
+func (s *DaemonServer) portRange(ctx context.Context,
+	_ *sdpb.PortRangeRequest) (*sdpb.PortRangeResponse, error) {
+
+	start, end := s.TopoProvider.Get().PortRange()
+	return &sdpb.PortRangeResponse{
+		DispatchedPortStart: uint32(start),
+		DispatchedPortEnd:   uint32(end),
+	}, nil
+}
+
 // Services serves the services request.
 func (s *DaemonServer) Services(ctx context.Context,
 	req *sdpb.ServicesRequest) (*sdpb.ServicesResponse, error) {
@@ -331,3 +380,125 @@ func (s *DaemonServer) notifyInterfaceDown(ctx context.Context,
 	}
 	return &sdpb.NotifyInterfaceDownResponse{}, nil
 }
+
+// DRKeyLvl2 serves the DRKey level 2 key request.
+func (s *DaemonServer) DRKeyLvl2(ctx context.Context,
+	req *sdpb.DRKeyLvl2Request) (*sdpb.DRKeyLvl2Response, error) {
+
+	start := time.Now()
+	response, err := s.drkeyLvl2(ctx, req)
+	s.Metrics.DRKeyRequests.inc(
+		reqLabels{Result: errToMetricResult(err)},
+		time.Since(start).Seconds(),
+	)
+	return response, unwrapMetricsError(err)
+}
+
+func (s *DaemonServer) drkeyLvl2(ctx context.Context,
+	req *sdpb.DRKeyLvl2Request) (*sdpb.DRKeyLvl2Response, error) {
+
+	if s.DRKeyClient == nil {
+		return nil, serrors.New("DRKey is not available")
+	}
+	meta, err := lvl2MetaFromPB(req)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing request", err)
+	}
+	if err := s.checkLvl2Host(ctx, meta); err != nil {
+		log.FromCtx(ctx).Info("Rejecting level 2 key request", "err", err)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	valTime, err := ptypes.Timestamp(req.ValTime)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing validity time", err)
+	}
+	key, err := s.DRKeyClient.GetLvl2Key(ctx, meta, valTime)
+	if err != nil {
+		log.FromCtx(ctx).Debug("Getting level 2 key", "err", err, "type", meta.KeyType,
+			"protocol", meta.Protocol, "src", meta.SrcIA, "dst", meta.DstIA)
+		return nil, serrors.WrapStr("getting level 2 key", err)
+	}
+	begin, err := ptypes.TimestampProto(key.Epoch.NotBefore)
+	if err != nil {
+		return nil, err
+	}
+	end, err := ptypes.TimestampProto(key.Epoch.NotAfter)
+	if err != nil {
+		return nil, err
+	}
+	return &sdpb.DRKeyLvl2Response{
+		EpochBegin: begin,
+		EpochEnd:   end,
+		Key:        append([]byte(nil), key.Key[:]...),
+	}, nil
+}
+
+// checkLvl2Host checks that the peer of the request is the host in the local AS
+// that the level 2 key is derived for. Keys without a host in the local AS are
+// never served, because they would allow any local process to impersonate
+// other hosts.
+func (s *DaemonServer) checkLvl2Host(ctx context.Context, meta drkey.Lvl2Meta) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return serrors.New("peer not present")
+	}
+	var host addr.HostAddr
+	switch a := p.Addr.(type) {
+	case *net.TCPAddr:
+		host = addr.HostFromIP(a.IP)
+	case *net.UDPAddr:
+		host = addr.HostFromIP(a.IP)
+	default:
+		return serrors.New("unsupported peer address type", "type", fmt.Sprintf("%T", p.Addr))
+	}
+	local := s.TopoProvider.Get().IA()
+	switch meta.KeyType {
+	case drkey.Host2Host:
+		if meta.SrcIA.Equal(local) && meta.SrcHost.Equal(host) {
+			return nil
+		}
+		if meta.DstIA.Equal(local) && meta.DstHost.Equal(host) {
+			return nil
+		}
+	case drkey.AS2Host:
+		if meta.DstIA.Equal(local) && meta.DstHost.Equal(host) {
+			return nil
+		}
+	}
+	return serrors.New("peer is not the host of the requested key", "peer", host,
+		"type", meta.KeyType, "src", meta.SrcIA, "dst", meta.DstIA)
+}
+
+func lvl2MetaFromPB(req *sdpb.DRKeyLvl2Request) (drkey.Lvl2Meta, error) {
+	meta := drkey.Lvl2Meta{
+		Protocol: req.Protocol,
+		SrcIA:    addr.IAInt(req.SrcIsdAs).IA(),
+		DstIA:    addr.IAInt(req.DstIsdAs).IA(),
+	}
+	switch req.KeyType {
+	case sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_AS_AS:
+		meta.KeyType = drkey.AS2AS
+	case sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_AS_HOST:
+		meta.KeyType = drkey.AS2Host
+	case sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_HOST_HOST:
+		meta.KeyType = drkey.Host2Host
+	default:
+		return drkey.Lvl2Meta{}, serrors.New("unsupported key type", "type", req.KeyType)
+	}
+	if meta.KeyType == drkey.AS2Host || meta.KeyType == drkey.Host2Host {
+		ip := net.ParseIP(req.DstHost)
+		if ip == nil {
+			return drkey.Lvl2Meta{}, serrors.New("invalid destination host",
+				"host", req.DstHost)
+		}
+		meta.DstHost = addr.HostFromIP(ip)
+	}
+	if meta.KeyType == drkey.Host2Host {
+		ip := net.ParseIP(req.SrcHost)
+		if ip == nil {
+			return drkey.Lvl2Meta{}, serrors.New("invalid source host", "host", req.SrcHost)
+		}
+		meta.SrcHost = addr.HostFromIP(ip)
+	}
+	return meta, nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/topology/mock_topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+	sddrkey "github.com/scionproto/scion/go/pkg/daemon/drkey"
+	"github.com/scionproto/scion/go/pkg/daemon/drkey/mock_drkey"
+	sdpb "github.com/scionproto/scion/go/pkg/proto/daemon"
+)
+
+func TestDRKeyLvl2(t *testing.T) {
+	local := xtest.MustParseIA("1-ff00:0:110")
+	remote := xtest.MustParseIA("1-ff00:0:111")
+	now := time.Now()
+	valTime, err := ptypes.TimestampProto(now)
+	require.NoError(t, err)
+
+	peerCtx := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000},
+		})
+	}
+
+	testCases := map[string]struct {
+		Ctx     context.Context
+		Request *sdpb.DRKeyLvl2Request
+		Code    codes.Code
+	}{
+		"host to host as source": {
+			Ctx: peerCtx("127.0.0.1"),
+			Request: &sdpb.DRKeyLvl2Request{
+				KeyType:  sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_HOST_HOST,
+				SrcIsdAs: uint64(local.IAInt()),
+				DstIsdAs: uint64(remote.IAInt()),
+				SrcHost:  "127.0.0.1",
+				DstHost:  "127.0.0.2",
+			},
+			Code: codes.OK,
+		},
+		"host to host as destination": {
+			Ctx: peerCtx("127.0.0.2"),
+			Request: &sdpb.DRKeyLvl2Request{
+				KeyType:  sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_HOST_HOST,
+				SrcIsdAs: uint64(remote.IAInt()),
+				DstIsdAs: uint64(local.IAInt()),
+				SrcHost:  "127.0.0.1",
+				DstHost:  "127.0.0.2",
+			},
+			Code: codes.OK,
+		},
+		"as to host as destination": {
+			Ctx: peerCtx("127.0.0.2"),
+			Request: &sdpb.DRKeyLvl2Request{
+				KeyType:  sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_AS_HOST,
+				SrcIsdAs: uint64(remote.IAInt()),
+				DstIsdAs: uint64(local.IAInt()),
+				DstHost:  "127.0.0.2",
+			},
+			Code: codes.OK,
+		},
+		"host to host mismatched host": {
+			Ctx: peerCtx("127.0.0.3"),
+			Request: &sdpb.DRKeyLvl2Request{
+				KeyType:  sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_HOST_HOST,
+				SrcIsdAs: uint64(local.IAInt()),
+				DstIsdAs: uint64(remote.IAInt()),
+				SrcHost:  "127.0.0.1",
+				DstHost:  "127.0.0.2",
+			},
+			Code: codes.PermissionDenied,
+		},
+		"host to host host in remote AS": {
+			Ctx: peerCtx("127.0.0.2"),
+			Request: &sdpb.DRKeyLvl2Request{
+				KeyType:  sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_HOST_HOST,
+				SrcIsdAs: uint64(local.IAInt()),
+				DstIsdAs: uint64(remote.IAInt()),
+				SrcHost:  "127.0.0.1",
+				DstHost:  "127.0.0.2",
+			},
+			Code: codes.PermissionDenied,
+		},
+		"as to host mismatched host": {
+			Ctx: peerCtx("127.0.0.3"),
+			Request: &sdpb.DRKeyLvl2Request{
+				KeyType:  sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_AS_HOST,
+				SrcIsdAs: uint64(remote.IAInt()),
+				DstIsdAs: uint64(local.IAInt()),
+				DstHost:  "127.0.0.2",
+			},
+			Code: codes.PermissionDenied,
+		},
+		"as to host host in remote AS": {
+			Ctx: peerCtx("127.0.0.2"),
+			Request: &sdpb.DRKeyLvl2Request{
+				KeyType:  sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_AS_HOST,
+				SrcIsdAs: uint64(local.IAInt()),
+				DstIsdAs: uint64(remote.IAInt()),
+				DstHost:  "127.0.0.2",
+			},
+			Code: codes.PermissionDenied,
+		},
+		"as to as": {
+			Ctx: peerCtx("127.0.0.1"),
+			Request: &sdpb.DRKeyLvl2Request{
+				KeyType:  sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_AS_AS,
+				SrcIsdAs: uint64(local.IAInt()),
+				DstIsdAs: uint64(remote.IAInt()),
+			},
+			Code: codes.PermissionDenied,
+		},
+		"unknown peer": {
+			Ctx: context.Background(),
+			Request: &sdpb.DRKeyLvl2Request{
+				KeyType:  sdpb.DRKeyLvl2Type_DRKEY_LVL2_TYPE_HOST_HOST,
+				SrcIsdAs: uint64(local.IAInt()),
+				DstIsdAs: uint64(remote.IAInt()),
+				SrcHost:  "127.0.0.1",
+				DstHost:  "127.0.0.2",
+			},
+			Code: codes.PermissionDenied,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			topo := mock_topology.NewMockTopology(ctrl)
+			topo.EXPECT().IA().Return(local).AnyTimes()
+			fetcher := mock_drkey.NewMockLvl1Fetcher(ctrl)
+			fetcher.EXPECT().Lvl1(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, meta drkey.Lvl1Meta,
+					valTime time.Time) (drkey.Lvl1Key, error) {
+
+					return drkey.Lvl1Key{
+						Lvl1Meta: meta,
+						Epoch:    drkey.NewEpoch(valTime, time.Hour),
+						Key:      drkey.Key{1, 2, 3},
+					}, nil
+				},
+			).AnyTimes()
+			s := &DaemonServer{
+				TopoProvider: topoProvider{topo: topo},
+				DRKeyClient:  &sddrkey.ClientEngine{Fetcher: fetcher},
+				Metrics:      Metrics{},
+			}
+			tc.Request.Protocol = "scmp"
+			tc.Request.ValTime = valTime
+			rep, err := s.DRKeyLvl2(tc.Ctx, tc.Request)
+			assert.Equal(t, tc.Code, status.Code(err))
+			if tc.Code != codes.OK {
+				assert.Nil(t, rep)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, rep.Key, 16)
+		})
+	}
+}
+
+type topoProvider struct {
+	topo topology.Topology
+}
+
+func (p topoProvider) Get() topology.Topology {
+	return p.topo
+}
@@ -24,10 +24,13 @@ import (
 // for initialization.
 var (
 	PathsRequestsLabels              = []string{prom.LabelResult, prom.LabelDst}
+	WatchPathsRequestsLabels         = []string{prom.LabelResult}
 	ASRequestsLabels                 = []string{prom.LabelResult}
 	InterfacesRequestsLabels         = []string{prom.LabelResult}
+	PortRangeRequestsLabels          = []string{prom.LabelResult}
 	ServicesRequestsLabels           = []string{prom.LabelResult}
 	InterfaceDownNotificationsLabels = []string{prom.LabelResult, prom.LabelSrc}
+	DRKeyRequestsLabels              = []string{prom.LabelResult}
 	LatencyLabels                    = []string{prom.LabelResult}
 )
 
@@ -35,10 +38,13 @@ var (
 // field may be set individually.
 type Metrics struct {
 	PathsRequests              RequestMetrics
+	WatchPathsRequests         RequestMetrics
 	ASRequests                 RequestMetrics
 	InterfacesRequests         RequestMetrics
+	PortRangeRequests          RequestMetrics
 	ServicesRequests           RequestMetrics
 	InterfaceDownNotifications RequestMetrics
+	DRKeyRequests              RequestMetrics
 }
 
 // RequestMetrics contains the metrics for a given request.
@@ -0,0 +1,169 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	sdpb "github.com/scionproto/scion/go/pkg/proto/daemon"
+)
+
+// pathSelection filters and orders the paths of a paths request.
+type pathSelection struct {
+	// policy filters the paths. If nil, all paths are accepted.
+	policy *pathpol.Policy
+	// less orders the paths. If nil, the order is not changed.
+	less func(a, b *snet.PathMetadata) bool
+	// max is the maximum number of paths. If 0, the number is not limited.
+	max int
+}
+
+// newPathSelection parses the path policy and the ordering of the request.
+func newPathSelection(req *sdpb.PathsRequest) (pathSelection, error) {
+	s := pathSelection{max: int(req.MaxPaths)}
+	if req.Policy != "" {
+		policy, err := parsePolicy(req.Policy)
+		if err != nil {
+			return pathSelection{}, serrors.WrapStr("parsing path policy", err)
+		}
+		s.policy = policy
+	}
+	switch req.Ordering {
+	case sdpb.PathOrdering_PATH_ORDERING_UNSPECIFIED:
+	case sdpb.PathOrdering_PATH_ORDERING_LATENCY:
+		s.less = lessLatency
+	case sdpb.PathOrdering_PATH_ORDERING_HOPS:
+		s.less = func(a, b *snet.PathMetadata) bool {
+			return len(a.Interfaces) < len(b.Interfaces)
+		}
+	case sdpb.PathOrdering_PATH_ORDERING_BANDWIDTH:
+		s.less = lessBandwidth
+	case sdpb.PathOrdering_PATH_ORDERING_MTU:
+		s.less = func(a, b *snet.PathMetadata) bool {
+			return a.MTU > b.MTU
+		}
+	default:
+		return pathSelection{}, serrors.New("unsupported path ordering",
+			"ordering", req.Ordering)
+	}
+	return s, nil
+}
+
+// apply returns the selected paths. The paths are not modified in place,
+// because they are shared with the other requests for the same destination.
+func (s pathSelection) apply(paths []snet.Path) []snet.Path {
+	if s.policy != nil {
+		paths = s.policy.Filter(paths)
+	}
+	paths = append([]snet.Path(nil), paths...)
+	if s.less != nil {
+		sort.SliceStable(paths, func(i, j int) bool {
+			return s.less(metadata(paths[i]), metadata(paths[j]))
+		})
+	}
+	if s.max > 0 && len(paths) > s.max {
+		paths = paths[:s.max]
+	}
+	return paths
+}
+
+// parsePolicy parses a path policy in the JSON format of the path policy
+// language.
+func parsePolicy(raw string) (*pathpol.Policy, error) {
+	var ext pathpol.ExtPolicy
+	if err := json.Unmarshal([]byte(raw), &ext); err != nil {
+		return nil, err
+	}
+	if len(ext.Extends) != 0 {
+		return nil, serrors.New("extending policies is not supported", "extends", ext.Extends)
+	}
+	if ext.Policy == nil {
+		return &pathpol.Policy{}, nil
+	}
+	return pathpol.NewPolicy("", ext.ACL, ext.Sequence, ext.Options), nil
+}
+
+// lessLatency orders the paths by their total announced latency. Paths on
+// which not all latencies are announced come last.
+func lessLatency(a, b *snet.PathMetadata) bool {
+	latencyA, okA := totalLatency(a)
+	latencyB, okB := totalLatency(b)
+	if okA != okB {
+		return okA
+	}
+	return latencyA < latencyB
+}
+
+// lessBandwidth orders the paths by their announced bottleneck bandwidth,
+// highest first. Paths on which not all bandwidths are announced come last.
+func lessBandwidth(a, b *snet.PathMetadata) bool {
+	bandwidthA, okA := bottleneckBandwidth(a)
+	bandwidthB, okB := bottleneckBandwidth(b)
+	if okA != okB {
+		return okA
+	}
+	return bandwidthA > bandwidthB
+}
+
+func totalLatency(meta *snet.PathMetadata) (int64, bool) {
+	if len(meta.Latency) != hops(meta) {
+		return 0, false
+	}
+	var total int64
+	for _, latency := range meta.Latency {
+		if latency < 0 {
+			return 0, false
+		}
+		total += int64(latency)
+	}
+	return total, true
+}
+
+func bottleneckBandwidth(meta *snet.PathMetadata) (uint64, bool) {
+	if len(meta.Bandwidth) != hops(meta) {
+		return 0, false
+	}
+	bottleneck := uint64(math.MaxUint64)
+	for _, bandwidth := range meta.Bandwidth {
+		if bandwidth == 0 {
+			return 0, false
+		}
+		if bandwidth < bottleneck {
+			bottleneck = bandwidth
+		}
+	}
+	return bottleneck, true
+}
+
+// hops returns the number of links between consecutive interfaces of the
+// path, which is the number of latency and bandwidth entries.
+func hops(meta *snet.PathMetadata) int {
+	if len(meta.Interfaces) == 0 {
+		return 0
+	}
+	return len(meta.Interfaces) - 1
+}
+
+func metadata(path snet.Path) *snet.PathMetadata {
+	if meta := path.Metadata(); meta != nil {
+		return meta
+	}
+	return &snet.PathMetadata{}
+}
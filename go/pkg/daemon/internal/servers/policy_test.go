@@ -0,0 +1,168 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/daemon/fetcher/mock_fetcher"
+	sdpb "github.com/scionproto/scion/go/pkg/proto/daemon"
+)
+
+func TestPathsSelection(t *testing.T) {
+	ia112 := xtest.MustParseIA("1-ff00:0:112")
+	// Direct path with high bandwidth and MTU.
+	pA := policyTestPath(1500, []snet.PathInterface{
+		{IA: ia110, ID: 1}, {IA: ia111, ID: 1},
+	}, []time.Duration{10 * time.Millisecond}, []uint64{1000})
+	// Path through 1-ff00:0:112 with low latency.
+	pB := policyTestPath(1400, []snet.PathInterface{
+		{IA: ia110, ID: 2}, {IA: ia112, ID: 1}, {IA: ia112, ID: 2}, {IA: ia111, ID: 2},
+	}, []time.Duration{5 * time.Millisecond, time.Millisecond, 2 * time.Millisecond},
+		[]uint64{1000, 2000, 500})
+	// Direct path without announced latency and bandwidth.
+	pC := policyTestPath(1300, []snet.PathInterface{
+		{IA: ia110, ID: 3}, {IA: ia111, ID: 3},
+	}, []time.Duration{snet.LatencyUnset}, []uint64{0})
+
+	testCases := map[string]struct {
+		Request   *sdpb.PathsRequest
+		Expected  []snet.Path
+		ErrorCode codes.Code
+	}{
+		"no selection": {
+			Request:  &sdpb.PathsRequest{},
+			Expected: []snet.Path{pA, pB, pC},
+		},
+		"ACL": {
+			Request: &sdpb.PathsRequest{
+				Policy: `{"acl": ["- 1-ff00:0:112", "+"]}`,
+			},
+			Expected: []snet.Path{pA, pC},
+		},
+		"sequence": {
+			Request: &sdpb.PathsRequest{
+				Policy: `{"sequence": "1-ff00:0:110 1-ff00:0:112 1-ff00:0:111"}`,
+			},
+			Expected: []snet.Path{pB},
+		},
+		"options": {
+			Request: &sdpb.PathsRequest{
+				Policy: `{"options": [
+					{"weight": 1, "policy": {"acl": ["+"]}},
+					{"weight": 3, "policy": {"sequence": "1-ff00:0:110 1-ff00:0:113 1-ff00:0:111"}},
+					{"weight": 2, "policy": {"sequence": "1-ff00:0:110 1-ff00:0:112 1-ff00:0:111"}}
+				]}`,
+			},
+			Expected: []snet.Path{pB},
+		},
+		"latency": {
+			Request: &sdpb.PathsRequest{
+				Ordering: sdpb.PathOrdering_PATH_ORDERING_LATENCY,
+			},
+			Expected: []snet.Path{pB, pA, pC},
+		},
+		"hops": {
+			Request: &sdpb.PathsRequest{
+				Ordering: sdpb.PathOrdering_PATH_ORDERING_HOPS,
+			},
+			Expected: []snet.Path{pA, pC, pB},
+		},
+		"bandwidth": {
+			Request: &sdpb.PathsRequest{
+				Ordering: sdpb.PathOrdering_PATH_ORDERING_BANDWIDTH,
+			},
+			Expected: []snet.Path{pA, pB, pC},
+		},
+		"MTU with max paths": {
+			Request: &sdpb.PathsRequest{
+				Policy:   `{"acl": ["- 1-ff00:0:111#1", "+"]}`,
+				Ordering: sdpb.PathOrdering_PATH_ORDERING_MTU,
+				MaxPaths: 1,
+			},
+			Expected: []snet.Path{pB},
+		},
+		"invalid policy": {
+			Request: &sdpb.PathsRequest{
+				Policy: `{"acl": ["* 1-ff00:0:112"]}`,
+			},
+			ErrorCode: codes.InvalidArgument,
+		},
+		"extending policy": {
+			Request: &sdpb.PathsRequest{
+				Policy: `{"extends": ["other"]}`,
+			},
+			ErrorCode: codes.InvalidArgument,
+		},
+		"unsupported ordering": {
+			Request: &sdpb.PathsRequest{
+				Ordering: 42,
+			},
+			ErrorCode: codes.InvalidArgument,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fetcher := mock_fetcher.NewMockFetcher(ctrl)
+			fetcher.EXPECT().GetPaths(gomock.Any(), gomock.Any(), ia111, false).
+				Return([]snet.Path{pA, pB, pC}, nil).AnyTimes()
+			s := &DaemonServer{Fetcher: fetcher}
+
+			tc.Request.SourceIsdAs = uint64(ia110.IAInt())
+			tc.Request.DestinationIsdAs = uint64(ia111.IAInt())
+			reply, err := s.Paths(context.Background(), tc.Request)
+			if tc.ErrorCode != codes.OK {
+				assert.Equal(t, tc.ErrorCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, reply.Paths, len(tc.Expected))
+			for i, p := range tc.Expected {
+				assert.Equal(t, pathToPB(p), reply.Paths[i], "path %d", i)
+			}
+		})
+	}
+}
+
+func policyTestPath(mtu uint16, intfs []snet.PathInterface, latency []time.Duration,
+	bandwidth []uint64) path.Path {
+
+	return path.Path{
+		Dst: ia111,
+		Meta: snet.PathMetadata{
+			Interfaces: intfs,
+			MTU:        mtu,
+			Latency:    latency,
+			Bandwidth:  bandwidth,
+			Expiry:     time.Now().Add(time.Hour),
+		},
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/snet"
+	sdpb "github.com/scionproto/scion/go/pkg/proto/daemon"
+)
+
+// DefaultWatchInterval is the default interval in which the paths of a
+// subscription are fetched again.
+const DefaultWatchInterval = 30 * time.Second
+
+// WatchPaths serves the paths subscription. The paths are fetched again
+// periodically, when the first path expires, and when a revocation is inserted
+// into the revocation cache. They are only sent to the client if they differ
+// from the paths that were last sent.
+func (s *DaemonServer) WatchPaths(req *sdpb.WatchPathsRequest,
+	stream sdpb.DaemonService_WatchPathsServer) error {
+
+	start := time.Now()
+	err := s.watchPaths(req, stream)
+	s.Metrics.WatchPathsRequests.inc(
+		reqLabels{Result: errToMetricResult(err)},
+		time.Since(start).Seconds(),
+	)
+	return err
+}
+
+func (s *DaemonServer) watchPaths(req *sdpb.WatchPathsRequest,
+	stream sdpb.DaemonService_WatchPathsServer) error {
+
+	ctx := stream.Context()
+	srcIA, dstIA := addr.IAInt(req.SourceIsdAs).IA(), addr.IAInt(req.DestinationIsdAs).IA()
+	var sent map[snet.PathFingerprint]time.Time
+	for {
+		// Get the notification channel before fetching the paths, such that
+		// revocations that are inserted during the fetch are not missed.
+		revoked := s.revocations()
+		paths, err := s.watchedPaths(ctx, srcIA, dstIA)
+		switch {
+		case err != nil && sent == nil:
+			log.FromCtx(ctx).Debug("Fetching watched paths", "err", err,
+				"src", srcIA, "dst", dstIA)
+			return err
+		case err != nil:
+			log.FromCtx(ctx).Debug("Fetching watched paths", "err", err,
+				"src", srcIA, "dst", dstIA)
+		default:
+			state := pathsState(paths)
+			if sent == nil || !sameState(sent, state) {
+				reply := &sdpb.WatchPathsResponse{}
+				for _, p := range paths {
+					reply.Paths = append(reply.Paths, pathToPB(p))
+				}
+				if err := stream.Send(reply); err != nil {
+					return err
+				}
+				sent = state
+			}
+		}
+
+		timer := time.NewTimer(s.nextWatch(paths))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-revoked:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// watchedPaths fetches the paths from src to dst and drops the expired ones.
+func (s *DaemonServer) watchedPaths(ctx context.Context, src,
+	dst addr.IA) ([]snet.Path, error) {
+
+	ctx, cancelF := context.WithTimeout(ctx, 10*time.Second)
+	defer cancelF()
+	paths, err := s.fetchPaths(ctx, &s.foregroundPathDedupe, src, dst, false)
+	if err != nil {
+		return nil, err
+	}
+	// The paths are shared with the other callers of fetchPaths, so they are
+	// copied instead of filtered in place.
+	now := time.Now()
+	valid := make([]snet.Path, 0, len(paths))
+	for _, p := range paths {
+		if meta := p.Metadata(); meta != nil && !meta.Expiry.IsZero() &&
+			!meta.Expiry.After(now) {
+
+			continue
+		}
+		valid = append(valid, p)
+	}
+	return valid, nil
+}
+
+// nextWatch returns the time until the paths are fetched again, which is at
+// the latest when the first of the paths expires.
+func (s *DaemonServer) nextWatch(paths []snet.Path) time.Duration {
+	next := s.WatchInterval
+	if next == 0 {
+		next = DefaultWatchInterval
+	}
+	for _, p := range paths {
+		meta := p.Metadata()
+		if meta == nil || meta.Expiry.IsZero() {
+			continue
+		}
+		if untilExpiry := time.Until(meta.Expiry); untilExpiry < next {
+			next = untilExpiry
+		}
+	}
+	if next < 0 {
+		next = 0
+	}
+	return next
+}
+
+// revocations returns a channel that is closed when a revocation is inserted
+// into the revocation cache. If the revocation cache does not implement
+// revcache.Notifier, the returned channel is nil.
+func (s *DaemonServer) revocations() <-chan struct{} {
+	if notifier, ok := s.RevCache.(revcache.Notifier); ok {
+		return notifier.Inserted()
+	}
+	return nil
+}
+
+// pathsState maps the fingerprints of the paths to their expiration time.
+func pathsState(paths []snet.Path) map[snet.PathFingerprint]time.Time {
+	state := make(map[snet.PathFingerprint]time.Time, len(paths))
+	for _, p := range paths {
+		var expiry time.Time
+		if meta := p.Metadata(); meta != nil {
+			expiry = meta.Expiry
+		}
+		state[snet.Fingerprint(p)] = expiry
+	}
+	return state
+}
+
+func sameState(a, b map[snet.PathFingerprint]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for fingerprint, expiry := range a {
+		other, ok := b[fingerprint]
+		if !ok || !other.Equal(expiry) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,210 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/revcache/mock_revcache"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/daemon/fetcher/mock_fetcher"
+	sdpb "github.com/scionproto/scion/go/pkg/proto/daemon"
+)
+
+var (
+	ia110 = xtest.MustParseIA("1-ff00:0:110")
+	ia111 = xtest.MustParseIA("1-ff00:0:111")
+)
+
+func TestWatchPaths(t *testing.T) {
+	expiry := time.Now().Add(time.Hour)
+	req := &sdpb.WatchPathsRequest{
+		SourceIsdAs:      uint64(ia110.IAInt()),
+		DestinationIsdAs: uint64(ia111.IAInt()),
+	}
+
+	t.Run("revocation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		p1, p2 := watchTestPath(1, expiry), watchTestPath(2, expiry)
+		fetcher := mock_fetcher.NewMockFetcher(ctrl)
+		gomock.InOrder(
+			fetcher.EXPECT().GetPaths(gomock.Any(), ia110, ia111, false).
+				Return([]snet.Path{p1, p2}, nil),
+			fetcher.EXPECT().GetPaths(gomock.Any(), ia110, ia111, false).
+				Return([]snet.Path{p1}, nil).AnyTimes(),
+		)
+		rc := mock_revcache.NewMockRevCache(ctrl)
+		rc.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(true, nil)
+		s := &DaemonServer{
+			Fetcher:  fetcher,
+			RevCache: revcache.NewNotifyingRevCache(rc),
+		}
+
+		stream := newWatchTestStream()
+		defer stream.cancel()
+		errs := make(chan error, 1)
+		go func() { errs <- s.WatchPaths(req, stream) }()
+
+		reply := <-stream.replies
+		assert.Len(t, reply.Paths, 2)
+		_, err := s.NotifyInterfaceDown(context.Background(), &sdpb.NotifyInterfaceDownRequest{
+			IsdAs: uint64(ia111.IAInt()),
+			Id:    2,
+		})
+		require.NoError(t, err)
+		reply = <-stream.replies
+		require.Len(t, reply.Paths, 1)
+		assert.Equal(t, p1.Meta.Interfaces[1].ID, interfaceIDFromPB(reply.Paths[0]))
+
+		stream.cancel()
+		assert.NoError(t, <-errs)
+	})
+	t.Run("unchanged paths are not sent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fetched := make(chan struct{}, 10)
+		fetcher := mock_fetcher.NewMockFetcher(ctrl)
+		fetcher.EXPECT().GetPaths(gomock.Any(), ia110, ia111, false).
+			DoAndReturn(func(context.Context, addr.IA, addr.IA, bool) ([]snet.Path, error) {
+				select {
+				case fetched <- struct{}{}:
+				default:
+				}
+				return []snet.Path{watchTestPath(1, expiry)}, nil
+			}).MinTimes(3)
+		s := &DaemonServer{
+			Fetcher:       fetcher,
+			WatchInterval: time.Millisecond,
+		}
+
+		stream := newWatchTestStream()
+		defer stream.cancel()
+		errs := make(chan error, 1)
+		go func() { errs <- s.WatchPaths(req, stream) }()
+
+		<-stream.replies
+		for i := 0; i < 3; i++ {
+			<-fetched
+		}
+		stream.cancel()
+		assert.NoError(t, <-errs)
+		assert.Empty(t, stream.replies)
+	})
+	t.Run("expiration", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		p1 := watchTestPath(1, expiry)
+		p2 := watchTestPath(2, time.Now().Add(50*time.Millisecond))
+		fetcher := mock_fetcher.NewMockFetcher(ctrl)
+		fetcher.EXPECT().GetPaths(gomock.Any(), ia110, ia111, false).
+			Return([]snet.Path{p1, p2}, nil).MinTimes(2)
+		s := &DaemonServer{
+			Fetcher: fetcher,
+		}
+
+		stream := newWatchTestStream()
+		defer stream.cancel()
+		errs := make(chan error, 1)
+		go func() { errs <- s.WatchPaths(req, stream) }()
+
+		reply := <-stream.replies
+		assert.Len(t, reply.Paths, 2)
+		select {
+		case reply = <-stream.replies:
+			assert.Len(t, reply.Paths, 1)
+		case <-time.After(5 * time.Second):
+			t.Fatal("expired path not removed")
+		}
+
+		stream.cancel()
+		assert.NoError(t, <-errs)
+	})
+	t.Run("initial error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		fetcher := mock_fetcher.NewMockFetcher(ctrl)
+		fetcher.EXPECT().GetPaths(gomock.Any(), ia110, ia111, false).
+			Return(nil, serrors.New("test"))
+		s := &DaemonServer{
+			Fetcher: fetcher,
+		}
+
+		stream := newWatchTestStream()
+		defer stream.cancel()
+		assert.Error(t, s.WatchPaths(req, stream))
+		assert.Empty(t, stream.replies)
+	})
+}
+
+func watchTestPath(ifID uint64, expiry time.Time) path.Path {
+	return path.Path{
+		Dst: ia111,
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: ia110, ID: 1},
+				{IA: ia111, ID: common.IFIDType(ifID)},
+			},
+			Expiry: expiry,
+		},
+	}
+}
+
+func interfaceIDFromPB(p *sdpb.Path) common.IFIDType {
+	return common.IFIDType(p.Interfaces[1].Id)
+}
+
+type watchTestStream struct {
+	grpc.ServerStream
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	replies chan *sdpb.WatchPathsResponse
+}
+
+func newWatchTestStream() *watchTestStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &watchTestStream{
+		ctx:     ctx,
+		cancel:  cancel,
+		replies: make(chan *sdpb.WatchPathsResponse, 10),
+	}
+}
+
+func (s *watchTestStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchTestStream) Send(reply *sdpb.WatchPathsResponse) error {
+	s.replies <- reply
+	return nil
+}
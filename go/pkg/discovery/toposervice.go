@@ -83,6 +83,7 @@ func (t Topology) Gateways(ctx context.Context,
 			DataAddress:     info.DataAddr.String(),
 			ProbeAddress:    probeAddr.String(),
 			AllowInterfaces: info.AllowInterfaces,
+			Priority:        info.Priority,
 		})
 	}
 	logger.Debug("Replied with gateways", "gateways", gateways)
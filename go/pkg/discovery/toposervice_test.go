@@ -48,6 +48,7 @@ func TestGateways(t *testing.T) {
 							},
 							DataAddr:        xtest.MustParseUDPAddr(t, "127.0.0.82:30101"),
 							AllowInterfaces: []uint64{1, 3, 5},
+							Priority:        100,
 						},
 						{
 							CtrlAddr: &topology.TopoAddr{
@@ -69,6 +70,7 @@ func TestGateways(t *testing.T) {
 						DataAddress:     "127.0.0.82:30101",
 						ProbeAddress:    "127.0.0.82:30856",
 						AllowInterfaces: []uint64{1, 3, 5},
+						Priority:        100,
 					},
 					{
 						ControlAddress: "[2001:db8:f00:b43::1%some-zone]:23425",
@@ -0,0 +1,438 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/pkg/api"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
+)
+
+// RemoteMonitor provides the remote gateways that are known to the gateway.
+type RemoteMonitor interface {
+	// Remotes returns the discovered remote gateways, by remote ISD-AS.
+	Remotes() map[addr.IA][]control.RemoteGatewayInfo
+}
+
+// Engine provides the state of the sessions and of the routing table of the
+// gateway.
+type Engine interface {
+	// Sessions returns the state of the sessions, sorted by ID.
+	Sessions() []control.SessionInfo
+	// Routes returns the routing table entries in evaluation order.
+	Routes() []control.RouteInfo
+	// Capture records the traffic of the session to w in pcapng format until
+	// the context is done or the maximum number of packets is written.
+	Capture(ctx context.Context, id uint8, w io.Writer, opts control.CaptureOptions) error
+}
+
+const (
+	defaultCaptureDuration = 10 * time.Second
+	maxCaptureDuration     = 5 * time.Minute
+)
+
+// Advertiser computes the IP prefixes the gateway advertises to remote ASes.
+type Advertiser interface {
+	AdvertiseList(from, to addr.IA) []*net.IPNet
+}
+
+// Server implements the Gateway API.
+type Server struct {
+	LocalIA    addr.IA
+	Remotes    RemoteMonitor
+	Engine     Engine
+	Advertiser Advertiser
+	Config     http.HandlerFunc
+	Info       http.HandlerFunc
+	LogLevel   http.HandlerFunc
+}
+
+// GetRemotes lists the remote ASes with their gateways and prefixes.
+func (s *Server) GetRemotes(w http.ResponseWriter, r *http.Request) {
+	remotes := s.Remotes.Remotes()
+	ias := make([]addr.IA, 0, len(remotes))
+	for ia := range remotes {
+		ias = append(ias, ia)
+	}
+	sort.Slice(ias, func(i, j int) bool { return ias[i].IAInt() < ias[j].IAInt() })
+
+	rep := make([]RemoteAS, 0, len(ias))
+	for _, ia := range ias {
+		rep = append(rep, s.makeRemoteAS(ia, remotes[ia]))
+	}
+	writeJSON(w, rep)
+}
+
+// GetRemote gets the gateways and prefixes of the remote AS.
+func (s *Server) GetRemote(w http.ResponseWriter, r *http.Request, isdAs IsdAs) {
+	ia, err := addr.IAFromString(string(isdAs))
+	if err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "malformed ISD-AS",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	gateways, ok := s.Remotes.Remotes()[ia]
+	if !ok {
+		Error(w, Problem{
+			Detail: api.StringRef(fmt.Sprintf("remote AS %s is not monitored", ia)),
+			Status: http.StatusNotFound,
+			Title:  "remote AS not found",
+			Type:   api.StringRef(api.NotFound),
+		})
+		return
+	}
+	writeJSON(w, s.makeRemoteAS(ia, gateways))
+}
+
+// GetSessions lists the sessions to the remote gateways.
+func (s *Server) GetSessions(w http.ResponseWriter, r *http.Request) {
+	infos := s.Engine.Sessions()
+	rep := make([]Session, 0, len(infos))
+	for _, info := range infos {
+		rep = append(rep, makeSession(info))
+	}
+	writeJSON(w, rep)
+}
+
+// GetSession gets the description of the session specified by its ID.
+func (s *Server) GetSession(w http.ResponseWriter, r *http.Request, sessionId SessionID) {
+	for _, info := range s.Engine.Sessions() {
+		if int(info.ID) != int(sessionId) {
+			continue
+		}
+		writeJSON(w, makeSession(info))
+		return
+	}
+	Error(w, Problem{
+		Detail: api.StringRef(fmt.Sprintf("session %d is not known", sessionId)),
+		Status: http.StatusNotFound,
+		Title:  "session not found",
+		Type:   api.StringRef(api.NotFound),
+	})
+}
+
+// GetSessionCapture records the traffic of the session specified by its ID and
+// returns it in pcapng format.
+func (s *Server) GetSessionCapture(w http.ResponseWriter, r *http.Request,
+	sessionId SessionID, params GetSessionCaptureParams) {
+
+	duration, opts, err := captureOptions(params)
+	if err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusBadRequest,
+			Title:  "invalid capture parameters",
+			Type:   api.StringRef(api.BadRequest),
+		})
+		return
+	}
+	if sessionId < 0 || sessionId > 255 {
+		err = serrors.WithCtx(control.ErrSessionNotFound, "id", sessionId)
+	} else {
+		ctx, cancel := context.WithTimeout(r.Context(), duration)
+		defer cancel()
+		cw := &captureWriter{w: w, id: sessionId}
+		err = s.Engine.Capture(ctx, uint8(sessionId), cw, opts)
+		if cw.started {
+			// The status is already sent, the client sees a truncated capture.
+			if err != nil {
+				log.FromCtx(r.Context()).Info("Session capture failed", "id", sessionId,
+					"err", err)
+			}
+			return
+		}
+		if err == nil {
+			cw.start()
+			return
+		}
+	}
+	switch {
+	case errors.Is(err, control.ErrSessionNotFound):
+		Error(w, Problem{
+			Detail: api.StringRef(fmt.Sprintf("session %d is not known", sessionId)),
+			Status: http.StatusNotFound,
+			Title:  "session not found",
+			Type:   api.StringRef(api.NotFound),
+		})
+	case errors.Is(err, control.ErrCaptureNotSupported):
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusNotImplemented,
+			Title:  "capture not supported",
+			Type:   api.StringRef(api.InternalError),
+		})
+	default:
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to capture traffic",
+			Type:   api.StringRef(api.InternalError),
+		})
+	}
+}
+
+// captureWriter streams a capture to the client. The response header is only
+// sent with the first write, so that failures before the capture starts can
+// still be reported as problems.
+type captureWriter struct {
+	w       http.ResponseWriter
+	id      SessionID
+	started bool
+}
+
+func (c *captureWriter) start() {
+	c.started = true
+	c.w.Header().Set("Content-Type", "application/x-pcapng")
+	c.w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=\"session-%d.pcapng\"", c.id))
+	c.w.WriteHeader(http.StatusOK)
+}
+
+func (c *captureWriter) Write(b []byte) (int, error) {
+	if !c.started {
+		c.start()
+	}
+	n, err := c.w.Write(b)
+	if f, ok := c.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return n, err
+}
+
+// GetRoutes lists the routing table entries.
+func (s *Server) GetRoutes(w http.ResponseWriter, r *http.Request) {
+	routes := s.Engine.Routes()
+	rep := make([]Route, 0, len(routes))
+	for _, route := range routes {
+		entry := Route{
+			RemoteIsdAs:  IsdAs(route.RemoteIA.String()),
+			Prefixes:     makePrefixes(route.Prefixes),
+			TrafficClass: pktcls.CondTrue.String(),
+			Sessions:     make([]SessionID, 0, len(route.Sessions)),
+		}
+		if route.TrafficClass != nil {
+			entry.TrafficClass = route.TrafficClass.String()
+		}
+		for _, id := range route.Sessions {
+			entry.Sessions = append(entry.Sessions, SessionID(id))
+		}
+		if route.Active {
+			id := SessionID(route.ActiveSession)
+			entry.ActiveSession = &id
+		}
+		rep = append(rep, entry)
+	}
+	writeJSON(w, rep)
+}
+
+// GetConfig is an indirection to the http handler.
+func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
+	s.Config(w, r)
+}
+
+// GetInfo is an indirection to the http handler.
+func (s *Server) GetInfo(w http.ResponseWriter, r *http.Request) {
+	s.Info(w, r)
+}
+
+// GetLogLevel is an indirection to the http handler.
+func (s *Server) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	s.LogLevel(w, r)
+}
+
+// SetLogLevel is an indirection to the http handler.
+func (s *Server) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	s.LogLevel(w, r)
+}
+
+// Error creates an detailed error response.
+func Error(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	// no point in catching error here, there is nothing we can do about it anymore.
+	enc.Encode(p)
+}
+
+// writeJSON writes the indented JSON encoding of the response.
+func writeJSON(w http.ResponseWriter, rep interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+func (s *Server) makeRemoteAS(ia addr.IA, gateways []control.RemoteGatewayInfo) RemoteAS {
+	remote := RemoteAS{
+		IsdAs:      IsdAs(ia.String()),
+		Gateways:   make([]RemoteGateway, 0, len(gateways)),
+		Advertised: []Prefix{},
+	}
+	for _, gw := range gateways {
+		remote.Gateways = append(remote.Gateways, makeRemoteGateway(gw))
+	}
+	if s.Advertiser != nil {
+		remote.Advertised = makePrefixes(s.Advertiser.AdvertiseList(s.LocalIA, ia))
+	}
+	return remote
+}
+
+// captureOptions parses the parameters of a capture request.
+func captureOptions(params GetSessionCaptureParams) (time.Duration,
+	control.CaptureOptions, error) {
+
+	duration := defaultCaptureDuration
+	if params.Duration != nil {
+		var err error
+		if duration, err = time.ParseDuration(*params.Duration); err != nil {
+			return 0, control.CaptureOptions{}, serrors.WrapStr("parsing duration", err)
+		}
+		if duration <= 0 || duration > maxCaptureDuration {
+			return 0, control.CaptureOptions{}, serrors.New("duration out of range",
+				"duration", duration, "max", maxCaptureDuration)
+		}
+	}
+	opts := control.CaptureOptions{Frames: true}
+	if params.MaxPackets != nil {
+		if *params.MaxPackets < 0 {
+			return 0, control.CaptureOptions{}, serrors.New("max_packets must not be negative",
+				"max_packets", *params.MaxPackets)
+		}
+		opts.MaxPackets = *params.MaxPackets
+	}
+	if params.Filter != nil && *params.Filter != "" {
+		filter, err := pktcls.BuildClassTree(*params.Filter)
+		if err != nil {
+			return 0, control.CaptureOptions{}, serrors.WrapStr("parsing filter", err)
+		}
+		opts.Filter = filter
+	}
+	if params.Frames != nil {
+		opts.Frames = *params.Frames
+	}
+	return duration, opts, nil
+}
+
+func makeRemoteGateway(info control.RemoteGatewayInfo) RemoteGateway {
+	gw := RemoteGateway{
+		ControlAddress: info.Gateway.Control.String(),
+		ProbeAddress:   info.Gateway.Probe.String(),
+		DataAddress:    info.Gateway.Data.String(),
+		Interfaces:     make([]int, 0, len(info.Gateway.Interfaces)),
+		Prefixes:       makePrefixes(info.Prefixes),
+	}
+	for _, intf := range info.Gateway.Interfaces {
+		gw.Interfaces = append(gw.Interfaces, int(intf))
+	}
+	if !info.Timestamp.IsZero() {
+		ts := info.Timestamp.UTC()
+		gw.LastUpdate = &ts
+	}
+	return gw
+}
+
+func makeSession(info control.SessionInfo) Session {
+	sess := Session{
+		SessionId:          SessionID(info.ID),
+		PolicyId:           info.PolicyID,
+		RemoteIsdAs:        IsdAs(info.RemoteIA.String()),
+		RemoteDataAddress:  info.Gateway.Data.String(),
+		RemoteProbeAddress: info.Gateway.Probe.String(),
+		Healthy:            info.Healthy,
+		Paths:              make([]SessionPath, 0, len(info.Paths.Paths)),
+		PathsAlive:         info.Paths.PathsAlive,
+		PathsDead:          info.Paths.PathsDead,
+		PathsRejected:      info.Paths.PathsRejected,
+	}
+	for i, path := range info.Paths.Paths {
+		stats := policies.Stats{Fingerprint: snet.Fingerprint(path)}
+		if i < len(info.Paths.Stats) {
+			stats = info.Paths.Stats[i]
+		}
+		sess.Paths = append(sess.Paths, makeSessionPath(path, stats))
+	}
+	return sess
+}
+
+func makeSessionPath(path snet.Path, stats policies.Stats) SessionPath {
+	p := SessionPath{
+		Fingerprint: stats.Fingerprint.String(),
+		Hops:        []Hop{},
+		Stats: PathStats{
+			LatencyMs: milliseconds(stats.Latency),
+			JitterMs:  milliseconds(stats.Jitter),
+			DropRate:  float32(stats.DropRate),
+			Alive:     stats.IsAlive,
+			Current:   stats.IsCurrent,
+			Revoked:   stats.IsRevoked,
+		},
+	}
+	if nextHop := path.UnderlayNextHop(); nextHop != nil {
+		p.NextHop = api.StringRef(nextHop.String())
+	}
+	if meta := path.Metadata(); meta != nil {
+		for _, intf := range meta.Interfaces {
+			p.Hops = append(p.Hops, Hop{
+				IsdAs:     IsdAs(intf.IA.String()),
+				Interface: int(intf.ID),
+			})
+		}
+		expiration := meta.Expiry.UTC()
+		mtu := int(meta.MTU)
+		p.Expiration = &expiration
+		p.Mtu = &mtu
+	}
+	return p
+}
+
+func makePrefixes(prefixes []*net.IPNet) []Prefix {
+	ret := make([]Prefix, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		ret = append(ret, Prefix(prefix.String()))
+	}
+	return ret
+}
+
+func milliseconds(d time.Duration) float32 {
+	return float32(float64(d) / float64(time.Millisecond))
+}
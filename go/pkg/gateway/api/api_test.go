@@ -0,0 +1,398 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/gateway/api/mock_api"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth"
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
+)
+
+var update = xtest.UpdateGoldenFiles()
+
+var (
+	localIA  = xtest.MustParseIA("1-ff00:0:110")
+	remoteIA = xtest.MustParseIA("1-ff00:0:111")
+	otherIA  = xtest.MustParseIA("1-ff00:0:112")
+)
+
+// TestAPI tests the API response generation of the endpoints implemented in the
+// api package
+func TestAPI(t *testing.T) {
+	testCases := map[string]struct {
+		Handler      func(t *testing.T, ctrl *gomock.Controller) http.Handler
+		RequestURL   string
+		ResponseFile string
+		Status       int
+	}{
+		"remotes": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				rm := mock_api.NewMockRemoteMonitor(ctrl)
+				rm.EXPECT().Remotes().Return(remotes())
+				adv := mock_api.NewMockAdvertiser(ctrl)
+				adv.EXPECT().AdvertiseList(localIA, remoteIA).Return(
+					[]*net.IPNet{xtest.MustParseCIDR(t, "10.1.0.0/16")})
+				adv.EXPECT().AdvertiseList(localIA, otherIA).Return(nil)
+				return Handler(&Server{LocalIA: localIA, Remotes: rm, Advertiser: adv})
+			},
+			RequestURL:   "/remotes",
+			ResponseFile: "testdata/remotes.json",
+			Status:       200,
+		},
+		"remotes empty": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				rm := mock_api.NewMockRemoteMonitor(ctrl)
+				rm.EXPECT().Remotes().Return(nil)
+				return Handler(&Server{LocalIA: localIA, Remotes: rm})
+			},
+			RequestURL:   "/remotes",
+			ResponseFile: "testdata/remotes-empty.json",
+			Status:       200,
+		},
+		"remote": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				rm := mock_api.NewMockRemoteMonitor(ctrl)
+				rm.EXPECT().Remotes().Return(remotes())
+				adv := mock_api.NewMockAdvertiser(ctrl)
+				adv.EXPECT().AdvertiseList(localIA, remoteIA).Return(
+					[]*net.IPNet{xtest.MustParseCIDR(t, "10.1.0.0/16")})
+				return Handler(&Server{LocalIA: localIA, Remotes: rm, Advertiser: adv})
+			},
+			RequestURL:   "/remotes/1-ff00:0:111",
+			ResponseFile: "testdata/remote.json",
+			Status:       200,
+		},
+		"remote not found": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				rm := mock_api.NewMockRemoteMonitor(ctrl)
+				rm.EXPECT().Remotes().Return(remotes())
+				return Handler(&Server{LocalIA: localIA, Remotes: rm})
+			},
+			RequestURL:   "/remotes/1-ff00:0:113",
+			ResponseFile: "testdata/remote-not-found.json",
+			Status:       404,
+		},
+		"remote malformed": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				rm := mock_api.NewMockRemoteMonitor(ctrl)
+				return Handler(&Server{LocalIA: localIA, Remotes: rm})
+			},
+			RequestURL:   "/remotes/garbage",
+			ResponseFile: "testdata/remote-malformed.json",
+			Status:       400,
+		},
+		"sessions": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				e := mock_api.NewMockEngine(ctrl)
+				e.EXPECT().Sessions().Return(sessions(t))
+				return Handler(&Server{Engine: e})
+			},
+			RequestURL:   "/sessions",
+			ResponseFile: "testdata/sessions.json",
+			Status:       200,
+		},
+		"session": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				e := mock_api.NewMockEngine(ctrl)
+				e.EXPECT().Sessions().Return(sessions(t))
+				return Handler(&Server{Engine: e})
+			},
+			RequestURL:   "/sessions/2",
+			ResponseFile: "testdata/session.json",
+			Status:       200,
+		},
+		"session not found": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				e := mock_api.NewMockEngine(ctrl)
+				e.EXPECT().Sessions().Return(sessions(t))
+				return Handler(&Server{Engine: e})
+			},
+			RequestURL:   "/sessions/42",
+			ResponseFile: "testdata/session-not-found.json",
+			Status:       404,
+		},
+		"session malformed": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				e := mock_api.NewMockEngine(ctrl)
+				return Handler(&Server{Engine: e})
+			},
+			RequestURL:   "/sessions/one",
+			ResponseFile: "testdata/session-malformed.txt",
+			Status:       400,
+		},
+		"session capture": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				e := mock_api.NewMockEngine(ctrl)
+				e.EXPECT().Capture(gomock.Any(), uint8(2), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ uint8, w io.Writer,
+						opts control.CaptureOptions) error {
+
+						assert.Equal(t, "dst=192.0.2.0/24", opts.Filter.String())
+						assert.Equal(t, 100, opts.MaxPackets)
+						assert.False(t, opts.Frames)
+						_, err := w.Write([]byte("pcapng"))
+						return err
+					},
+				)
+				return Handler(&Server{Engine: e})
+			},
+			RequestURL: "/sessions/2/capture?duration=1s&max_packets=100&" +
+				"filter=dst%3D192.0.2.0%2F24&frames=false",
+			ResponseFile: "testdata/session-capture.txt",
+			Status:       200,
+		},
+		"session capture fails after start": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				e := mock_api.NewMockEngine(ctrl)
+				e.EXPECT().Capture(gomock.Any(), uint8(2), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ uint8, w io.Writer,
+						opts control.CaptureOptions) error {
+
+						if _, err := w.Write([]byte("pcapng")); err != nil {
+							return err
+						}
+						return serrors.New("test error")
+					},
+				)
+				return Handler(&Server{Engine: e})
+			},
+			RequestURL:   "/sessions/2/capture",
+			ResponseFile: "testdata/session-capture.txt",
+			Status:       200,
+		},
+		"session capture not found": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				e := mock_api.NewMockEngine(ctrl)
+				e.EXPECT().Capture(gomock.Any(), uint8(42), gomock.Any(), gomock.Any()).Return(
+					serrors.WithCtx(control.ErrSessionNotFound, "id", 42))
+				return Handler(&Server{Engine: e})
+			},
+			RequestURL:   "/sessions/42/capture",
+			ResponseFile: "testdata/session-capture-not-found.json",
+			Status:       404,
+		},
+		"session capture not supported": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				e := mock_api.NewMockEngine(ctrl)
+				e.EXPECT().Capture(gomock.Any(), uint8(2), gomock.Any(), gomock.Any()).Return(
+					control.ErrCaptureNotSupported)
+				return Handler(&Server{Engine: e})
+			},
+			RequestURL:   "/sessions/2/capture",
+			ResponseFile: "testdata/session-capture-not-supported.json",
+			Status:       501,
+		},
+		"session capture invalid filter": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				e := mock_api.NewMockEngine(ctrl)
+				return Handler(&Server{Engine: e})
+			},
+			RequestURL:   "/sessions/2/capture?filter=garbage",
+			ResponseFile: "testdata/session-capture-invalid-filter.json",
+			Status:       400,
+		},
+		"session capture invalid duration": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				e := mock_api.NewMockEngine(ctrl)
+				return Handler(&Server{Engine: e})
+			},
+			RequestURL:   "/sessions/2/capture?duration=1h",
+			ResponseFile: "testdata/session-capture-invalid-duration.json",
+			Status:       400,
+		},
+		"routes": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				e := mock_api.NewMockEngine(ctrl)
+				e.EXPECT().Routes().Return([]control.RouteInfo{
+					{
+						RemoteIA: remoteIA,
+						Prefixes: []*net.IPNet{
+							xtest.MustParseCIDR(t, "192.0.2.0/24"),
+							xtest.MustParseCIDR(t, "198.51.100.0/24"),
+						},
+						TrafficClass: pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x2e}),
+						Sessions:     []uint8{1, 2},
+						// The active session must be ignored if the route is
+						// not active.
+						ActiveSession: 1,
+					},
+					{
+						RemoteIA:      remoteIA,
+						Prefixes:      []*net.IPNet{xtest.MustParseCIDR(t, "192.0.2.0/24")},
+						TrafficClass:  pktcls.CondTrue,
+						Sessions:      []uint8{2, 1},
+						ActiveSession: 2,
+						Active:        true,
+					},
+				})
+				return Handler(&Server{Engine: e})
+			},
+			RequestURL:   "/routes",
+			ResponseFile: "testdata/routes.json",
+			Status:       200,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req, err := http.NewRequest("GET", tc.RequestURL, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			tc.Handler(t, ctrl).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+
+			if *update {
+				require.NoError(t, ioutil.WriteFile(tc.ResponseFile, rr.Body.Bytes(), 0666))
+			}
+			golden, err := ioutil.ReadFile(tc.ResponseFile)
+			require.NoError(t, err)
+			assert.Equal(t, string(golden), rr.Body.String())
+		})
+	}
+}
+
+func remotes() map[addr.IA][]control.RemoteGatewayInfo {
+	return map[addr.IA][]control.RemoteGatewayInfo{
+		otherIA: {},
+		remoteIA: {
+			{
+				Gateway: control.Gateway{
+					Control:    &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 30256},
+					Probe:      &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 30856},
+					Data:       &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 30056},
+					Interfaces: []uint64{1, 2},
+				},
+				Prefixes: []*net.IPNet{
+					{IP: net.IP{198, 51, 100, 0}, Mask: net.CIDRMask(24, 32)},
+				},
+				Timestamp: time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC),
+			},
+			{
+				Gateway: control.Gateway{
+					Control: &net.UDPAddr{IP: net.ParseIP("192.0.2.2"), Port: 30256},
+					Probe:   &net.UDPAddr{IP: net.ParseIP("192.0.2.2"), Port: 30856},
+					Data:    &net.UDPAddr{IP: net.ParseIP("192.0.2.2"), Port: 30056},
+				},
+			},
+		},
+	}
+}
+
+func sessions(t *testing.T) []control.SessionInfo {
+	path := snetpath.Path{
+		Dst:     remoteIA,
+		NextHop: &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 30041},
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: localIA, ID: 1},
+				{IA: remoteIA, ID: 3},
+			},
+			MTU:    1472,
+			Expiry: time.Date(2021, 5, 1, 18, 0, 0, 0, time.UTC),
+		},
+	}
+	gateway := control.Gateway{
+		Probe: &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 30856},
+		Data:  &net.UDPAddr{IP: net.ParseIP("192.0.2.1"), Port: 30056},
+	}
+	return []control.SessionInfo{
+		{
+			ID:       1,
+			PolicyID: 1,
+			RemoteIA: remoteIA,
+			Gateway:  gateway,
+			Paths: pathhealth.Selection{
+				PathsDead:     1,
+				PathsRejected: 2,
+			},
+		},
+		{
+			ID:       2,
+			PolicyID: 2,
+			RemoteIA: remoteIA,
+			Gateway:  gateway,
+			Healthy:  true,
+			Paths: pathhealth.Selection{
+				Paths: []snet.Path{path},
+				Stats: []policies.Stats{
+					{
+						Fingerprint: snet.Fingerprint(path),
+						Latency:     12500 * time.Microsecond,
+						Jitter:      time.Millisecond,
+						DropRate:    0.125,
+						IsAlive:     true,
+						IsCurrent:   true,
+					},
+				},
+				PathsAlive: 1,
+			},
+		},
+	}
+}
+
+func TestGetSessionCaptureStreams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rr := httptest.NewRecorder()
+	e := mock_api.NewMockEngine(ctrl)
+	e.EXPECT().Capture(gomock.Any(), uint8(2), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ uint8, w io.Writer, _ control.CaptureOptions) error {
+			for _, chunk := range []string{"header", "packet"} {
+				_, err := w.Write([]byte(chunk))
+				require.NoError(t, err)
+				// The data is sent to the client before the capture ends.
+				assert.True(t, rr.Flushed)
+				assert.True(t, strings.HasSuffix(rr.Body.String(), chunk))
+			}
+			return nil
+		},
+	)
+	req, err := http.NewRequest("GET", "/sessions/2/capture", nil)
+	require.NoError(t, err)
+	Handler(&Server{Engine: e}).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/x-pcapng", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "headerpacket", rr.Body.String())
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/scionproto/scion/go/pkg/gateway/api (interfaces: Advertiser,Engine,RemoteMonitor)
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	context "context"
+	io "io"
+	net "net"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	addr "github.com/scionproto/scion/go/lib/addr"
+	control "github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+// MockAdvertiser is a mock of Advertiser interface.
+type MockAdvertiser struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdvertiserMockRecorder
+}
+
+// MockAdvertiserMockRecorder is the mock recorder for MockAdvertiser.
+type MockAdvertiserMockRecorder struct {
+	mock *MockAdvertiser
+}
+
+// NewMockAdvertiser creates a new mock instance.
+func NewMockAdvertiser(ctrl *gomock.Controller) *MockAdvertiser {
+	mock := &MockAdvertiser{ctrl: ctrl}
+	mock.recorder = &MockAdvertiserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdvertiser) EXPECT() *MockAdvertiserMockRecorder {
+	return m.recorder
+}
+
+// AdvertiseList mocks base method.
+func (m *MockAdvertiser) AdvertiseList(arg0, arg1 addr.IA) []*net.IPNet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvertiseList", arg0, arg1)
+	ret0, _ := ret[0].([]*net.IPNet)
+	return ret0
+}
+
+// AdvertiseList indicates an expected call of AdvertiseList.
+func (mr *MockAdvertiserMockRecorder) AdvertiseList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvertiseList", reflect.TypeOf((*MockAdvertiser)(nil).AdvertiseList), arg0, arg1)
+}
+
+// MockEngine is a mock of Engine interface.
+type MockEngine struct {
+	ctrl     *gomock.Controller
+	recorder *MockEngineMockRecorder
+}
+
+// MockEngineMockRecorder is the mock recorder for MockEngine.
+type MockEngineMockRecorder struct {
+	mock *MockEngine
+}
+
+// NewMockEngine creates a new mock instance.
+func NewMockEngine(ctrl *gomock.Controller) *MockEngine {
+	mock := &MockEngine{ctrl: ctrl}
+	mock.recorder = &MockEngineMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEngine) EXPECT() *MockEngineMockRecorder {
+	return m.recorder
+}
+
+// Capture mocks base method.
+func (m *MockEngine) Capture(arg0 context.Context, arg1 byte, arg2 io.Writer, arg3 control.CaptureOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockEngineMockRecorder) Capture(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockEngine)(nil).Capture), arg0, arg1, arg2, arg3)
+}
+
+// Routes mocks base method.
+func (m *MockEngine) Routes() []control.RouteInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Routes")
+	ret0, _ := ret[0].([]control.RouteInfo)
+	return ret0
+}
+
+// Routes indicates an expected call of Routes.
+func (mr *MockEngineMockRecorder) Routes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Routes", reflect.TypeOf((*MockEngine)(nil).Routes))
+}
+
+// Sessions mocks base method.
+func (m *MockEngine) Sessions() []control.SessionInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sessions")
+	ret0, _ := ret[0].([]control.SessionInfo)
+	return ret0
+}
+
+// Sessions indicates an expected call of Sessions.
+func (mr *MockEngineMockRecorder) Sessions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sessions", reflect.TypeOf((*MockEngine)(nil).Sessions))
+}
+
+// MockRemoteMonitor is a mock of RemoteMonitor interface.
+type MockRemoteMonitor struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemoteMonitorMockRecorder
+}
+
+// MockRemoteMonitorMockRecorder is the mock recorder for MockRemoteMonitor.
+type MockRemoteMonitorMockRecorder struct {
+	mock *MockRemoteMonitor
+}
+
+// NewMockRemoteMonitor creates a new mock instance.
+func NewMockRemoteMonitor(ctrl *gomock.Controller) *MockRemoteMonitor {
+	mock := &MockRemoteMonitor{ctrl: ctrl}
+	mock.recorder = &MockRemoteMonitorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRemoteMonitor) EXPECT() *MockRemoteMonitorMockRecorder {
+	return m.recorder
+}
+
+// Remotes mocks base method.
+func (m *MockRemoteMonitor) Remotes() map[addr.IA][]control.RemoteGatewayInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remotes")
+	ret0, _ := ret[0].(map[addr.IA][]control.RemoteGatewayInfo)
+	return ret0
+}
+
+// Remotes indicates an expected call of Remotes.
+func (mr *MockRemoteMonitorMockRecorder) Remotes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remotes", reflect.TypeOf((*MockRemoteMonitor)(nil).Remotes))
+}
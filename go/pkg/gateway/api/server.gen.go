@@ -0,0 +1,355 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen DO NOT EDIT.
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"github.com/go-chi/chi/v5"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Prints the TOML configuration file.
+	// (GET /config)
+	GetConfig(w http.ResponseWriter, r *http.Request)
+	// Basic information page about the control service process.
+	// (GET /info)
+	GetInfo(w http.ResponseWriter, r *http.Request)
+	// Get logging level
+	// (GET /log/level)
+	GetLogLevel(w http.ResponseWriter, r *http.Request)
+	// Set logging level
+	// (PUT /log/level)
+	SetLogLevel(w http.ResponseWriter, r *http.Request)
+	// List the remote ASes
+	// (GET /remotes)
+	GetRemotes(w http.ResponseWriter, r *http.Request)
+	// Get the remote AS description
+	// (GET /remotes/{isd-as})
+	GetRemote(w http.ResponseWriter, r *http.Request, isdAs IsdAs)
+	// List the routing table entries
+	// (GET /routes)
+	GetRoutes(w http.ResponseWriter, r *http.Request)
+	// List the sessions
+	// (GET /sessions)
+	GetSessions(w http.ResponseWriter, r *http.Request)
+	// Get the session description
+	// (GET /sessions/{session-id})
+	GetSession(w http.ResponseWriter, r *http.Request, sessionId SessionID)
+	// Capture the traffic of the session
+	// (GET /sessions/{session-id}/capture)
+	GetSessionCapture(w http.ResponseWriter, r *http.Request, sessionId SessionID, params GetSessionCaptureParams)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+}
+
+type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
+
+// GetConfig operation middleware
+func (siw *ServerInterfaceWrapper) GetConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConfig(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetInfo operation middleware
+func (siw *ServerInterfaceWrapper) GetInfo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInfo(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetLogLevel operation middleware
+func (siw *ServerInterfaceWrapper) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLogLevel(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// SetLogLevel operation middleware
+func (siw *ServerInterfaceWrapper) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetLogLevel(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRemotes operation middleware
+func (siw *ServerInterfaceWrapper) GetRemotes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRemotes(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRemote operation middleware
+func (siw *ServerInterfaceWrapper) GetRemote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "isd-as" -------------
+	var isdAs IsdAs
+
+	err = runtime.BindStyledParameter("simple", false, "isd-as", chi.URLParam(r, "isd-as"), &isdAs)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter isd-as: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRemote(w, r, isdAs)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRoutes operation middleware
+func (siw *ServerInterfaceWrapper) GetRoutes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRoutes(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSessions(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetSession operation middleware
+func (siw *ServerInterfaceWrapper) GetSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "session-id" -------------
+	var sessionId SessionID
+
+	err = runtime.BindStyledParameter("simple", false, "session-id", chi.URLParam(r, "session-id"), &sessionId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter session-id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSession(w, r, sessionId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetSessionCapture operation middleware
+func (siw *ServerInterfaceWrapper) GetSessionCapture(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "session-id" -------------
+	var sessionId SessionID
+
+	err = runtime.BindStyledParameter("simple", false, "session-id", chi.URLParam(r, "session-id"), &sessionId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter session-id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSessionCaptureParams
+
+	// ------------- Optional query parameter "duration" -------------
+	if paramValue := r.URL.Query().Get("duration"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "duration", r.URL.Query(), &params.Duration)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter duration: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "max_packets" -------------
+	if paramValue := r.URL.Query().Get("max_packets"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "max_packets", r.URL.Query(), &params.MaxPackets)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter max_packets: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "filter" -------------
+	if paramValue := r.URL.Query().Get("filter"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter filter: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "frames" -------------
+	if paramValue := r.URL.Query().Get("frames"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "frames", r.URL.Query(), &params.Frames)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter frames: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSessionCapture(w, r, sessionId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL     string
+	BaseRouter  chi.Router
+	Middlewares []MiddlewareFunc
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/config", wrapper.GetConfig)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/info", wrapper.GetInfo)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/log/level", wrapper.GetLogLevel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/log/level", wrapper.SetLogLevel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/remotes", wrapper.GetRemotes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/remotes/{isd-as}", wrapper.GetRemote)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/routes", wrapper.GetRoutes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sessions", wrapper.GetSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sessions/{session-id}", wrapper.GetSession)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sessions/{session-id}/capture", wrapper.GetSessionCapture)
+	})
+
+	return r
+}
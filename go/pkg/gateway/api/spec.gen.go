@@ -0,0 +1,153 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen DO NOT EDIT.
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+xb3XPbNrb/VzDcfbg7pSTKsdNGM/vgJv3wTLrJRO7sw8bXA5GHIhoSYAFQtq6v//c7",
+	"BwBJkIQsOdvN9u50+hCLBHG+fucD56APUSqqWnDgWkWrh0iCqgVXYH58S7MP8GsDSuOvVHAN3PxJ67pk",
+	"KdVM8MUvSnB8ptICKop//VlCHq2iPy36rRf2rVqsNeUZldl3UgoZPT4+xlEGKpWsxs2iFdIk0hHFt+5D",
+	"3PdHUeM/tRQ1SM0sj4xrkDlNAX/APa3qEqLV+Vkc6X0N0cos2IKMHuOIqeyWqmMsXqnsUhnayAeTkEWr",
+	"f7Tfxh7BmzjSTCO56D3VBSlEHXVkxeYXSDVStfv53EXLWZ4nySpZLZdJFEc11RokSv/fHz9mX83+6x90",
+	"liezVzcPy/j8cfWXh7PH4aO//C+u+3PUM3C1fjO7XJOrDLhmOQPZc6K0ZHyLnLwV27ewg3KqxbJ9PDTG",
+	"W7HdMr4l9nUcAW8qVEYGm2ZrdJELfGyMeRN7Ero3IxZGOrXb3gR0hvpca6rVlCd8zJRmqSIiJ7oAUqPy",
+	"dUE1oRJIBVQ1EjJyx3RBaik2oOZRPBKYlmwH083/XoAuQLrPSNpICVyXe1JTpYgupGi2RUd0Hnkia9lA",
+	"J8lGiBIoR1HcHodpdSLcUUVoKYFme8I4aRSQDeRCgllSUqXtOgUlpLjJSfQzKepbSXVA2u8lNfu0ipSQ",
+	"Atet8EajGcsIF5psQRNKJNTlfkA1mScXcVTRe1YhNJZxVDFu/046bnhTbawH/sIQ6bdVwK6XO5B0CyRj",
+	"eQ4SeIrS6zsAbngTHGZ3dE9KqoGnDIz1U8EVpI1mO2i5ZpxUrCyZglTwTI2YPQ/wZHfcB5n6CTJG+Yj4",
+	"Pqyvpygvz+YXAdISduITZFO6LTYoJ128IYL3WGGKuI8HdHJaqgAKxn7XS+ybxMdK7Dykx2/P7Tjuqc4j",
+	"Q+HvvYSc3Y/iXzI3/y2WL/0Y9p7UdnEgdr2XYlNCNQ1dGWjKArHrkhRNRTlBf6KbEgjc1yXlJmkRVUPK",
+	"cpYSLYgumCIitXKm0Bq3tgStGzBFCijrvCnxi1KkVMNgFeUZ2SIMabZj1laFuMPFtRQpQDYnf5eoaTQo",
+	"+Y5vS6YK81XHXy4kAb5lHECqmDSqoWW5N96nGqYhMys4ogDSgrOUlqj6T1CIMgOpzG64Gtkr2f+MoBG9",
+	"FpzbuIFsZVTTDVVANKsgI6LRIa0zrjTlKYTU+/OHKyKh9VWrpjb9KKOcTssHtRsTmG/nZLMnNMsw01CS",
+	"S7qtgHubSSIkUc1mZgO9GJpnX8Oc/ET3ZAMYMrORgaQQ2hJlqvuIWU9SopEpkFRkMFTVwi1cpJ3OZibJ",
+	"/UmLT8BnmN1maLiZ0d7Mai8XsqI6WkWNZLNOMyG1osc0gXBzXQD58fr6PbELDGdkCxzQJzNUkwmFkm0Z",
+	"JwrkDqQBxdMQHsh2kbzAX2nZKLaDn9rAbbNHF8dfJokXyZdJEiqnnN9OkaEKIRG0VUXlfuJPxmD/bmdY",
+	"gzR++jOnO8pKpBkylH2AEua0KdG2dCMavdqUlH+K4lN8ouHs1wbK/dg5fH0Qwct9i0pTZ99rT287lkFG",
+	"Lt9fzcm7uhYO5L6H2ajGOPnw/evZ198kX8eEmajFgZlEIiEVVQU8s99ugGTQMmoUjvqqBeMaX1MbO2ed",
+	"OTKRNuiUlg4XkmxLsTEmsfI5GI7MfJpTPcN1RonM+VELxVAl+QEqoeFyPU0cNNvhDxXKv10qcsYyKCJb",
+	"qgHLgO5L1dpBGirkco0iMw3V0VOGS4sdxiIqJd3jb0clEB5+cG9IxlQqdoBVLuOfyYBVjNsyxMdvclrq",
+	"hIl9fXsVxIeWceLLetCQLb8Ta6LXSFHe0iyToEL1pX1htNUa0oRQRZhWxLf4qLKOlq/O5sn8bL5cvUjO",
+	"Ll6GAgWm0+fRlpACQ+r4KdGS5iZTPkE6CZPuCsQA4bdU6VkharJ+ffXub30tqfrzUm3cTdq4IIGmhc/m",
+	"AE5HjtdjAOGJ5baps+DZ45pVNkH7ir8DCSQHnRaQmQPPnFzZMCY0UaAJa2Oi/wG+bD/agx4q8Cw5W86S",
+	"5Sw5J8mr1cWr1YsXX+HRO/GjDrJoUnlIvy2x41Giiw+ci4anrQz/fEgwJ4znwqsumQ1Q7nzyFLS+CUFr",
+	"5NNjDxuzNXKCATA9LU59v2X5WAAQjYap4+MRdge3CpRigh/T8douu3rzPMsC13JPTNsLn4jfwKY2Yt8+",
+	"K8rGkRMzxPKbriHSLuqdHEq2ZW1dhUtcvImJEtKVlrVkQjK9P1m2gS7H4jkCt2lJQ5B9LXjGNHNHWreY",
+	"VI3SpKI6LVyRIkXjuOusMATxt+/evf0rFq9H4TvUt2f8Ma+ekn2sikbjAUXbsyRyEgLpuofhEKYF0FIX",
+	"+6fbQI6wayyUXZLIrA93Tp1LUflp3wvWR/tBeIgKGARP8mrARN/8ahSoDimG9gaUKRTvhHxGgHPKQVLB",
+	"KIcs3B7ozP3NNE0Q4bXj1EGblqW46zGCb0ktSpbuTU1rthso5kUod1naGdDstyG9aTTJhElNpnXY9yH7",
+	"ntBhPiQgnuA5vLSfBJgZ9sCCVM26WxaqhN+Mokorof8IO5epBOrOZccJOmc8rWrqum2DbIGPBqUTU0SB",
+	"Ob88u4T6vFjsvjoxOR+WwuVntOJRAb4JC+AM4Sx4YtAeH6b6PXxExJPIGTLeAW3EXdxrA8/QzQeON0G/",
+	"F34d28dqhF46v1xdep2Ns4uLUY96RCE0QvGQ68ewSZCH+5pJql0CGOLgu+6dabn5w4tDVS66cAWaGpi7",
+	"91274mCZu7z4rDI3Z3wLspYsNKv4Ee4J8FRg+8BbOBBiwM8FPducp0lOX6UJvNx8HaJYiDo035meVJwq",
+	"tKQ7kArUyQkHJ4aBRFPpJtDqv/75t7XJ8vzr4DGJw72+LUQ9ZWEUKnImlbYlkPSb/0+xlgmwL0qgO/B6",
+	"F5frAXNdUDnDqHh+dqhLebzA7SZ143jiA8oZu91zPEEQOaFtMgm69WBqPHW79vHI4/AxqUApuj1eH3ZD",
+	"zBH1x0c355ya6/1VV09b1F69J22DopfQPcH+XRRHiGD7OU5AliidqIHTmkWr6AWaJPJqNOyV5WyLf27B",
+	"+CXKbaLIVYZ7g35tV8TD0f1Zkoxm9thTXNQlZaNp/Vgrk4n8uklTUApnH+9a4sj2eZIcAkfHysK7QoA7",
+	"u54w2h1xYZ37+t1Pb4kVtLHbk5xZZ9J0q+zZs6oEj25wj0VrjUMaubKz5/9f+viWKqxhuA3Wps7Caahp",
+	"N3dtYSlKolzn2syVlDqopVJsF91Y/5CquhsBR9X1+Vc+OhpfTJc/gCbl6OrCREdxVDcBpaxHSjH7fyuy",
+	"/RfRR3vhwqdvAxQe5x7/o6y0PsVKiGRbWSoPxyOlMaWHTXC/v1kJzrSQ/cmoPSyT74Ukpt/ZfRebBV53",
+	"fdt23PEoOe5W6gL6Xp+hVTKlIYsJxc5kWeK/04+ofv5IYeK4H5xO/klEPGNScLmellIBAKMtRO6bYu5B",
+	"5QBjbjL01fMg214JCLBxxXe0ZKObZB3yQoDxwGefDsG3eGAqm1H1eBCFP4Dd81T02JqnnZCfYm1TGEha",
+	"gQaJjD5EmLtMsRDFEaeVKTQNm5PQEZ+o03acc/MvDDU9nqaG68dBXir8XSAIOTj/khz0qjADDtHwLJDn",
+	"BjAeH4+DgMbzxAnBFLiW7pqVoTHof7qH01CqwLj/BOjDECuBMPQK11evQXZdHNODnZNrj4M+srbTTiEz",
+	"kPjjrmB2XrU3q2BHy4ZqyMJeZCX/IiETST0rXk7ay+z3HTlD/PqQs+8d5vypxdOo60cXItAuUzFhPC0b",
+	"c1VIF8Aksf2lLsK2XVHYD/vXQUCsW7a+BCQcseeAolXG7xgHqldha3r3aGT6xYP7a8ay40k0aNVhxnTb",
+	"PWXYkxJmz9ZnJ02/p/qvTJwdhAIlun31R9ZsFXEsZ6pwQ/l0DC9SWutGwkEsv7bvu8KPpp9A97VgjsAc",
+	"TtwU8MxEPsqzdvR3eNhncqRjwgw/tARa2SRZp7TmW2KxgFmyBMI0kQ1Xc3Jlk3f7aU5ZqQjNNaZUTZSm",
+	"0hxiLEkLZWI4AypLR9cXyEyfUszJWdum9Nq3HyPYSlBqxuqPkZHsY8R4/yj2tWHu7NvAbjtquL7hGciS",
+	"7jEqmLt9TxBRKRN8Qsc9fSpWOGv9O0JGPEbOm7YNJgaWspdlY/IxWibqYzQEQGcghmbMSUXvb1sLhSw1",
+	"J5eaVEJpcmEuiBoxf21A7ns5M8dH5EvVX4VcJmrQUn6RqFCX9akRpuPLYK+r5QYyGbgmFiR88qXrgpes",
+	"Yq7qC8nh6SIsSuK37ZMkGU2IRi38qUjXfvE6dvjppQZngri7h7znmt631h5UwqDGj91Ml3xvPYa2F6BY",
+	"qUEe1oF9PxC/t1ym9F/bgUCyODs/xYz+vYW856WLBIybO93tffOhUg5yaTYKGyl8oeGZCfd+ZkPjMNd0",
+	"E7IN41TuA+JP8oyLF1lrlz+yrcu2cXSRLL8kD9YSeCJoZ2JNXXe3mrz8OqoE/PzcOtfwqkO4IMBNzNV7",
+	"mx8aWUarqNC6Xi0WD4VQ+nH1gPQfcdpDJcMTkr0CJJQeRlDTAzSPzSUMOXr9Ijm/eIki33SMTGZcO5B7",
+	"XaD0EkpzASN8cmrrDia7k/m89zu7OHqMT9/fP6mNaM0nqfJZOz/ZavB4tssCW7823WMcuOH/9GPuy2/2",
+	"rqBw4xOfR9dsfrx5/L8BABJFAYQOOwAA",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %s", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	var res = make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.Swagger, err error) {
+	var resolvePath = PathToRawSpec("")
+
+	loader := openapi3.NewSwaggerLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.SwaggerLoader, url *url.URL) ([]byte, error) {
+		var pathToFile = url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadSwaggerFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,215 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen DO NOT EDIT.
+package api
+
+import (
+	"time"
+)
+
+// Defines values for LogLevelLevel.
+const (
+	LogLevelLevelDebug LogLevelLevel = "debug"
+
+	LogLevelLevelError LogLevelLevel = "error"
+
+	LogLevelLevelInfo LogLevelLevel = "info"
+)
+
+// Hop defines model for Hop.
+type Hop struct {
+	Interface int   `json:"interface"`
+	IsdAs     IsdAs `json:"isd_as"`
+}
+
+// IsdAs defines model for IsdAs.
+type IsdAs string
+
+// LogLevel defines model for LogLevel.
+type LogLevel struct {
+
+	// Logging level
+	Level LogLevelLevel `json:"level"`
+}
+
+// Logging level
+type LogLevelLevel string
+
+// Statistics of the path that are measured with probes.
+type PathStats struct {
+
+	// Whether probes currently pass through the path.
+	Alive bool `json:"alive"`
+
+	// Whether the path was already in use before the last path selection.
+	Current bool `json:"current"`
+
+	// Fraction of the recent probes that did not get a reply.
+	DropRate float32 `json:"drop_rate"`
+
+	// Average difference between the one-way latencies of consecutive probes in milliseconds.
+	JitterMs float32 `json:"jitter_ms"`
+
+	// Median one-way latency of the recent probes in milliseconds.
+	LatencyMs float32 `json:"latency_ms"`
+
+	// Whether an interface on the path is revoked.
+	Revoked bool `json:"revoked"`
+}
+
+// Prefix defines model for Prefix.
+type Prefix string
+
+// Problem defines model for Problem.
+type Problem struct {
+
+	// A human readable explanation specific to this occurrence of the problem that is helpful to locate the problem and give advice on how to proceed. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
+	Detail *string `json:"detail,omitempty"`
+
+	// A URI reference that identifies the specific occurrence of the problem, e.g. by adding a fragment identifier or sub-path to the problem type. May be used to locate the root of this problem in the source code.
+	Instance *string `json:"instance,omitempty"`
+
+	// The HTTP status code generated by the origin server for this occurrence of the problem.
+	Status int `json:"status"`
+
+	// A short summary of the problem type. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
+	Title string `json:"title"`
+
+	// A URI reference that uniquely identifies the problem type only in the context of the provided API. Opposed to the specification in RFC-7807, it is neither recommended to be dereferencable and point to a human-readable documentation nor globally unique for the problem type.
+	Type *string `json:"type,omitempty"`
+}
+
+// RemoteAS defines model for RemoteAS.
+type RemoteAS struct {
+
+	// IP prefixes the local gateway advertises to the remote AS.
+	Advertised []Prefix `json:"advertised"`
+
+	// Gateways discovered in the remote AS.
+	Gateways []RemoteGateway `json:"gateways"`
+	IsdAs    IsdAs           `json:"isd_as"`
+}
+
+// RemoteGateway defines model for RemoteGateway.
+type RemoteGateway struct {
+
+	// Address the gateway serves its IP prefixes on.
+	ControlAddress string `json:"control_address"`
+
+	// Address the gateway receives data traffic on.
+	DataAddress string `json:"data_address"`
+
+	// Last-hop SCION interfaces that are preferred to reach the gateway.
+	Interfaces []int `json:"interfaces"`
+
+	// Time the IP prefixes were fetched last. It is not set if the prefixes were not fetched yet.
+	LastUpdate *time.Time `json:"last_update,omitempty"`
+
+	// IP prefixes the gateway announced last.
+	Prefixes []Prefix `json:"prefixes"`
+
+	// Address the gateway replies to probes on.
+	ProbeAddress string `json:"probe_address"`
+}
+
+// Route defines model for Route.
+type Route struct {
+	ActiveSession *SessionID `json:"active_session,omitempty"`
+
+	// IP prefixes the entry applies to.
+	Prefixes    []Prefix `json:"prefixes"`
+	RemoteIsdAs IsdAs    `json:"remote_isd_as"`
+
+	// IDs of the sessions that are eligible for the traffic, sorted by priority.
+	Sessions []SessionID `json:"sessions"`
+
+	// Condition the traffic must match to be routed by the entry.
+	TrafficClass string `json:"traffic_class"`
+}
+
+// Session defines model for Session.
+type Session struct {
+
+	// Whether the session recently received probe replies from the remote gateway.
+	Healthy bool `json:"healthy"`
+
+	// Paths the session currently uses, sorted from best to worst.
+	Paths []SessionPath `json:"paths"`
+
+	// Number of paths that are allowed by the path policy and alive.
+	PathsAlive int `json:"paths_alive"`
+
+	// Number of paths that are allowed by the path policy but do not pass probes.
+	PathsDead int `json:"paths_dead"`
+
+	// Number of paths that are rejected by the path policy.
+	PathsRejected int `json:"paths_rejected"`
+
+	// ID of the session policy the session was created for.
+	PolicyId int `json:"policy_id"`
+
+	// Address of the remote gateway the data traffic is sent to.
+	RemoteDataAddress string `json:"remote_data_address"`
+	RemoteIsdAs       IsdAs  `json:"remote_isd_as"`
+
+	// Address of the remote gateway the probes are sent to.
+	RemoteProbeAddress string    `json:"remote_probe_address"`
+	SessionId          SessionID `json:"session_id"`
+}
+
+// SessionID defines model for SessionID.
+type SessionID int
+
+// SessionPath defines model for SessionPath.
+type SessionPath struct {
+
+	// Expiration time of the path. It is not set if the path metadata is not available.
+	Expiration *time.Time `json:"expiration,omitempty"`
+
+	// Hex encoded fingerprint of the path.
+	Fingerprint string `json:"fingerprint"`
+
+	// SCION interfaces the path traverses.
+	Hops []Hop `json:"hops"`
+
+	// MTU of the path. It is not set if the path metadata is not available.
+	Mtu *int `json:"mtu,omitempty"`
+
+	// Address of the first router on the path. It is not set if the path does not leave the local AS.
+	NextHop *string `json:"next_hop,omitempty"`
+
+	// Statistics of the path that are measured with probes.
+	Stats PathStats `json:"stats"`
+}
+
+// StandardError defines model for StandardError.
+type StandardError struct {
+
+	// Error message
+	Error string `json:"error"`
+}
+
+// BadRequest defines model for BadRequest.
+type BadRequest StandardError
+
+// SetLogLevelJSONBody defines parameters for SetLogLevel.
+type SetLogLevelJSONBody LogLevel
+
+// GetSessionCaptureParams defines parameters for GetSessionCapture.
+type GetSessionCaptureParams struct {
+
+	// Duration of the capture, e.g., "10s". The capture ends earlier if max_packets packets are recorded. At most 5m.
+	Duration *string `json:"duration,omitempty"`
+
+	// Number of packets after which the capture ends. If 0, the number of packets is not limited.
+	MaxPackets *int `json:"max_packets,omitempty"`
+
+	// Traffic class the IP packets must match to be recorded, in the syntax of the traffic classes of the traffic policy. Frames are not filtered.
+	Filter *string `json:"filter,omitempty"`
+
+	// Whether the frames are recorded in addition to the IP packets.
+	Frames *bool `json:"frames,omitempty"`
+}
+
+// SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
+type SetLogLevelJSONRequestBody SetLogLevelJSONBody
@@ -18,8 +18,11 @@ import (
 	"io"
 	"net"
 	"strconv"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/config"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
 )
 
 // Defaults.
@@ -31,6 +34,11 @@ const (
 
 	DefaultTunnelName           = "sig"
 	DefaultTunnelRoutingTableID = 11
+
+	DefaultFailoverTimeout = 2 * time.Second
+
+	// mainTableID is the ID of the main Linux routing table.
+	mainTableID = 254
 )
 
 // Gateway holds the gateway specific configuration.
@@ -47,6 +55,17 @@ type Gateway struct {
 	CtrlAddr string `toml:"ctrl_addr,omitempty"`
 	// Data plane address, for frames.
 	DataAddr string `toml:"data_addr,omitempty"`
+	// EncryptionKeyFile is the file path of the pre-shared key that
+	// authenticates the key exchange for encrypted frames. If empty, frames are
+	// sent and accepted unencrypted.
+	EncryptionKeyFile string `toml:"encryption_key_file,omitempty"`
+	// FailoverTimeout is the time after the last successful probe of a remote
+	// gateway after which the traffic fails over to another remote gateway.
+	FailoverTimeout util.DurWrap `toml:"failover_timeout,omitempty"`
+	// PathMTUDiscovery makes the gateway drop the IP packets that exceed the
+	// MTU of the paths to the remote gateway and must not be fragmented, and
+	// answer them with ICMP Packet Too Big messages.
+	PathMTUDiscovery bool `toml:"path_mtu_discovery,omitempty"`
 }
 
 func (cfg *Gateway) Validate() error {
@@ -58,6 +77,13 @@ func (cfg *Gateway) Validate() error {
 	}
 	cfg.CtrlAddr = DefaultAddress(cfg.CtrlAddr, defaultCtrlPort)
 	cfg.DataAddr = DefaultAddress(cfg.DataAddr, defaultDataPort)
+	if cfg.FailoverTimeout.Duration < 0 {
+		return serrors.New("failover_timeout must not be negative",
+			"value", cfg.FailoverTimeout)
+	}
+	if cfg.FailoverTimeout.Duration == 0 {
+		cfg.FailoverTimeout.Duration = DefaultFailoverTimeout
+	}
 	return nil
 }
 
@@ -79,15 +105,48 @@ type Tunnel struct {
 	SrcIPv4 net.IP `toml:"src_ipv4,omitempty"`
 	// SrcIPv6 is the source address to put into the routing table.
 	SrcIPv6 net.IP `toml:"src_ipv6,omitempty"`
+	// RouteTableID is the ID of the Linux routing table the prefixes of remote
+	// gateways are exported to. If zero, the main table is used.
+	RouteTableID int `toml:"route_table_id,omitempty"`
+	// RouteMetric is the metric the prefixes of remote gateways are exported
+	// with. If zero, a metric of 15 is used.
+	RouteMetric int `toml:"route_metric,omitempty"`
+	// ImportTableID is the ID of the Linux routing table the gateway learns
+	// prefixes from. The learned prefixes are redistributed to remote
+	// gateways according to the redistribute-bgp rules of the routing policy.
+	// If zero, no prefixes are learned.
+	ImportTableID int `toml:"import_table_id,omitempty"`
 }
 
 func (cfg *Tunnel) Validate() error {
 	if cfg.Name == "" {
 		cfg.Name = DefaultTunnelName
 	}
+	if cfg.RouteTableID < 0 {
+		return serrors.New("route_table_id must not be negative", "value", cfg.RouteTableID)
+	}
+	if cfg.RouteMetric < 0 {
+		return serrors.New("route_metric must not be negative", "value", cfg.RouteMetric)
+	}
+	if cfg.ImportTableID < 0 {
+		return serrors.New("import_table_id must not be negative", "value", cfg.ImportTableID)
+	}
+	if cfg.ImportTableID != 0 && cfg.ImportTableID == exportTableID(cfg.RouteTableID) {
+		return serrors.New("import_table_id must differ from the export table",
+			"import_table_id", cfg.ImportTableID, "route_table_id", cfg.RouteTableID)
+	}
 	return nil
 }
 
+// exportTableID returns the ID of the table routes are exported to. The zero
+// value refers to the main table.
+func exportTableID(id int) int {
+	if id == 0 {
+		return mainTableID
+	}
+	return id
+}
+
 func (cfg *Tunnel) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, tunnelSample)
 }
@@ -47,6 +47,52 @@ func TestTunnelSample(t *testing.T) {
 	configtest.CheckTunnel(t, &cfg)
 }
 
+func TestTunnelValidate(t *testing.T) {
+	testCases := map[string]struct {
+		Input     config.Tunnel
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"empty": {
+			Assertion: assert.NoError,
+		},
+		"import and export table": {
+			Input:     config.Tunnel{RouteTableID: 11, RouteMetric: 20, ImportTableID: 12},
+			Assertion: assert.NoError,
+		},
+		"import from main table": {
+			Input:     config.Tunnel{RouteTableID: 11, ImportTableID: 254},
+			Assertion: assert.NoError,
+		},
+		"import from export table": {
+			Input:     config.Tunnel{RouteTableID: 11, ImportTableID: 11},
+			Assertion: assert.Error,
+		},
+		"import from implicit main export table": {
+			Input:     config.Tunnel{ImportTableID: 254},
+			Assertion: assert.Error,
+		},
+		"negative table": {
+			Input:     config.Tunnel{RouteTableID: -1},
+			Assertion: assert.Error,
+		},
+		"negative metric": {
+			Input:     config.Tunnel{RouteMetric: -1},
+			Assertion: assert.Error,
+		},
+		"negative import table": {
+			Input:     config.Tunnel{ImportTableID: -1},
+			Assertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tc.Assertion(t, tc.Input.Validate())
+		})
+	}
+}
+
 func TestDefaultAddress(t *testing.T) {
 	testCases := map[string]struct {
 		Input    string
@@ -30,10 +30,16 @@ func CheckGateway(t *testing.T, cfg *config.Gateway) {
 	assert.Empty(t, cfg.IPRoutingPolicy)
 	assert.Equal(t, config.DefaultCtrlAddr, cfg.CtrlAddr)
 	assert.Equal(t, config.DefaultDataAddr, cfg.DataAddr)
+	assert.Empty(t, cfg.EncryptionKeyFile)
+	assert.Equal(t, config.DefaultFailoverTimeout, cfg.FailoverTimeout.Duration)
+	assert.False(t, cfg.PathMTUDiscovery)
 }
 
 func InitTunnel(cfg *config.Tunnel) {}
 
 func CheckTunnel(t *testing.T, cfg *config.Tunnel) {
 	assert.Equal(t, config.DefaultTunnelName, cfg.Name)
+	assert.Zero(t, cfg.RouteTableID)
+	assert.Zero(t, cfg.RouteMetric)
+	assert.Zero(t, cfg.ImportTableID)
 }
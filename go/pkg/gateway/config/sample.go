@@ -50,6 +50,28 @@ ctrl_addr = ":30256"
 #
 # (default ":30056")
 data_addr = ":30056"
+
+# The file containing the pre-shared key that authenticates the key exchange
+# with remote gateways. If set, all frames sent to remote gateways are
+# encrypted and authenticated, and unencrypted frames from remote gateways are
+# dropped. All gateways that communicate with each other must use the same key.
+# If not set, frames are sent and accepted unencrypted.
+# (default "")
+encryption_key_file = ""
+
+# The time after the last successful probe of a remote gateway after which the
+# traffic fails over to the remote gateway with the next lower priority. Remote
+# gateways are probed every 500ms.
+# (default "2s")
+failover_timeout = "2s"
+
+# Whether to drop the IP packets that do not fit into a single frame on the
+# paths to the remote gateway and either have the Don't Fragment bit set or are
+# IPv6 packets, and answer them with ICMP Packet Too Big messages. This lets the
+# hosts discover the path MTU. If not set, such packets are split across several
+# frames.
+# (default false)
+path_mtu_discovery = false
 `
 
 const tunnelSample = `
@@ -61,4 +83,21 @@ src_ipv4 = "192.0.2.100"
 # Source hint to put to put into the routing table for IPv6 routes.
 # (default "")
 src_ipv6 = "2001:db8::2:1"
+
+# ID of the Linux routing table the prefixes of remote gateways are exported
+# to. If 0, the main table is used.
+# (default 0)
+route_table_id = 0
+
+# Metric the prefixes of remote gateways are exported with. If 0, a metric of 15
+# is used.
+# (default 0)
+route_metric = 0
+
+# ID of the Linux routing table the gateway learns prefixes from, e.g., the
+# table a BGP daemon on the same host installs its routes in. The learned
+# prefixes are advertised to remote gateways according to the redistribute-bgp
+# rules of the routing policy. If 0, no prefixes are learned.
+# (default 0)
+import_table_id = 0
 `
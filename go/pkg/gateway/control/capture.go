@@ -0,0 +1,91 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"context"
+	"io"
+
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var (
+	// ErrSessionNotFound indicates that there is no session with the given ID.
+	ErrSessionNotFound = serrors.New("session not found")
+	// ErrCaptureNotSupported indicates that the dataplane session cannot
+	// record its traffic.
+	ErrCaptureNotSupported = serrors.New("packet capture not supported")
+)
+
+// CaptureOptions describe which packets are recorded by a packet capture.
+type CaptureOptions struct {
+	// Filter selects the IP packets that are recorded. If nil, all IP packets
+	// are recorded. Frames are not filtered.
+	Filter pktcls.Cond
+	// Frames indicates whether the frames sent to and received from the remote
+	// gateway are recorded, including their SCION headers.
+	Frames bool
+	// MaxPackets is the number of packets after which the capture stops. If
+	// zero, the capture runs until the context is done.
+	MaxPackets int
+}
+
+// PacketCapturer is implemented by dataplane sessions that can record their
+// traffic.
+type PacketCapturer interface {
+	// Capture writes the packets of the session to w in pcapng format. It
+	// blocks until the context is done or the maximum number of packets is
+	// written.
+	Capture(ctx context.Context, w io.Writer, opts CaptureOptions) error
+}
+
+// Capture records the traffic of the session with the given ID to w in pcapng
+// format. It blocks until the context is done or the maximum number of packets
+// is written.
+func (e *Engine) Capture(ctx context.Context, id uint8, w io.Writer,
+	opts CaptureOptions) error {
+
+	e.stateMtx.RLock()
+	session, ok := e.dataplaneSessions[id]
+	e.stateMtx.RUnlock()
+	if !ok {
+		return serrors.WithCtx(ErrSessionNotFound, "id", id)
+	}
+	capturer, ok := session.(PacketCapturer)
+	if !ok {
+		return ErrCaptureNotSupported
+	}
+	return capturer.Capture(ctx, w, opts)
+}
+
+// Capture records the traffic of the session with the given ID of the engine
+// currently in use. See Engine.Capture.
+func (c *EngineController) Capture(ctx context.Context, id uint8, w io.Writer,
+	opts CaptureOptions) error {
+
+	c.stateMtx.RLock()
+	engine := c.engine
+	c.stateMtx.RUnlock()
+
+	type capturer interface {
+		Capture(context.Context, uint8, io.Writer, CaptureOptions) error
+	}
+	e, ok := engine.(capturer)
+	if !ok {
+		return serrors.WithCtx(ErrSessionNotFound, "id", id)
+	}
+	return e.Capture(ctx, id, w, opts)
+}
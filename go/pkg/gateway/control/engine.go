@@ -28,9 +28,11 @@ import (
 	"github.com/google/gopacket"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth"
 	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
 	"github.com/scionproto/scion/go/pkg/worker"
 )
@@ -69,6 +71,22 @@ type Engine struct {
 	// DataplaneSessionFactory is used to construct dataplane sessions.
 	DataplaneSessionFactory DataplaneSessionFactory
 
+	// KeyExchange is used to establish the keys that encrypt the frames of the
+	// sessions. If set, the dataplane sessions must implement FrameKeySetter.
+	// If nil, frames are not encrypted.
+	KeyExchange *KeyExchange
+
+	// HealthExpiration is the duration after the last successful probe after
+	// which a session is considered down, and the traffic fails over to the
+	// next session. If zero, a default value is used.
+	HealthExpiration time.Duration
+
+	// PathMTUDiscovery indicates whether the sessions send ICMP Packet Too Big
+	// messages for IP packets that exceed the MTU of their paths. If set, the
+	// dataplane sessions must implement PathMTUEnforcer. Otherwise, such
+	// packets are split across multiple frames.
+	PathMTUDiscovery bool
+
 	// Logger to be passed down to worker goroutines. If nil, logging is disabled.
 	Logger log.Logger
 
@@ -154,6 +172,67 @@ func (e *Engine) DiagnosticsWrite(w io.Writer) {
 	}
 }
 
+// SessionInfo describes the state of a session of the engine.
+type SessionInfo struct {
+	// ID is the ID of the session.
+	ID uint8
+	// PolicyID is the ID of the session policy the session was created for.
+	PolicyID int
+	// RemoteIA is the ISD-AS of the remote gateway.
+	RemoteIA addr.IA
+	// Gateway is the remote gateway of the session.
+	Gateway Gateway
+	// Healthy indicates whether the session recently received probe replies
+	// from the remote gateway.
+	Healthy bool
+	// Paths is the last path selection of the session.
+	Paths pathhealth.Selection
+}
+
+// Sessions returns the state of the sessions of the engine, sorted by ID.
+func (e *Engine) Sessions() []SessionInfo {
+	e.stateMtx.RLock()
+	defer e.stateMtx.RUnlock()
+
+	sessions := make(map[uint8]*SessionInfo, len(e.SessionConfigs))
+	for _, sc := range e.SessionConfigs {
+		sessions[sc.ID] = &SessionInfo{
+			ID:       sc.ID,
+			PolicyID: sc.PolicyID,
+			RemoteIA: sc.IA,
+			Gateway:  sc.Gateway,
+		}
+	}
+	for _, sm := range e.sessionMonitors {
+		if info, ok := sessions[sm.ID]; ok {
+			info.Healthy = sm.sessionState().Healthy
+		}
+	}
+	for _, s := range e.sessions {
+		if info, ok := sessions[s.ID]; ok {
+			info.Paths = s.selection()
+		}
+	}
+	infos := make([]SessionInfo, 0, len(sessions))
+	for _, info := range sessions {
+		infos = append(infos, *info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+// activeSessions returns the sessions currently in use, by routing table
+// index.
+func (e *Engine) activeSessions() map[int]uint8 {
+	e.stateMtx.RLock()
+	defer e.stateMtx.RUnlock()
+
+	if e.router == nil {
+		return map[int]uint8{}
+	}
+	return e.router.activeSessions()
+}
+
 // Status prints the status page to the writer.
 func (e *Engine) Status(w io.Writer) {
 	e.stateMtx.RLock()
@@ -293,11 +372,30 @@ func (e *Engine) initWorkers() error {
 	for _, config := range e.SessionConfigs {
 		dataplaneSession := e.DataplaneSessionFactory.New(config.ID, config.PolicyID,
 			config.IA, config.Gateway.Data)
+		var frameKeys FrameKeySetter
+		if e.KeyExchange != nil {
+			var ok bool
+			if frameKeys, ok = dataplaneSession.(FrameKeySetter); !ok {
+				return serrors.New("dataplane session does not support encryption",
+					"type", common.TypeOf(dataplaneSession))
+			}
+		}
+		if config.QoS != nil {
+			qos, ok := dataplaneSession.(QoSSetter)
+			if !ok {
+				return serrors.New("dataplane session does not support QoS",
+					"type", common.TypeOf(dataplaneSession))
+			}
+			if err := qos.SetQoS(*config.QoS); err != nil {
+				return serrors.WrapStr("setting QoS policy", err)
+			}
+		}
 		remoteIA := config.IA
 		pathMonitorRegistration := e.PathMonitor.Register(remoteIA, &policies.Policies{
 			PathPolicy: config.PathPolicy,
 			PerfPolicy: config.PerfPolicy,
 			PathCount:  config.PathCount,
+			Multipath:  config.Multipath,
 		}, config.PolicyID)
 		probeConn, err := e.ProbeConnFactory.New()
 		if err != nil {
@@ -309,6 +407,16 @@ func (e *Engine) initWorkers() error {
 			return serrors.WrapStr("getting tun device handle", err)
 		}
 		e.deviceHandles = append(e.deviceHandles, deviceHandle)
+		if e.PathMTUDiscovery {
+			enforcer, ok := dataplaneSession.(PathMTUEnforcer)
+			if !ok {
+				return serrors.New("dataplane session does not support path MTU discovery",
+					"type", common.TypeOf(dataplaneSession))
+			}
+			// The ICMP messages are sent back to the hosts through the tunnel
+			// device.
+			enforcer.EnforcePathMTU(deviceHandle)
+		}
 
 		sessionMonitorEvents := make(chan SessionEvent, 1)
 		labels := []string{
@@ -332,7 +440,10 @@ func (e *Engine) initWorkers() error {
 				IsHealthy: metrics.GaugeWith(
 					e.Metrics.SessionMonitorMetrics.IsHealthy, labels...),
 			},
-			Logger: e.Logger,
+			Logger:           e.Logger,
+			KeyExchange:      e.KeyExchange,
+			FrameKeys:        frameKeys,
+			HealthExpiration: e.HealthExpiration,
 		}
 		e.workerBase.WG.Add(1)
 		go func() {
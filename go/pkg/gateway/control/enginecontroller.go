@@ -107,6 +107,11 @@ type EngineController struct {
 	// startup before the first configuration update arrives), it means no forwarding is currently
 	// in effect.
 	engine Worker
+	// routingChains are the routing chains of the engine currently in use.
+	routingChains []*RoutingChain
+	// routingTableIndices maps the routing table indices of the engine
+	// currently in use to the list of eligible sessions, sorted by priority.
+	routingTableIndices map[int][]uint8
 
 	workerBase worker.Base
 }
@@ -135,6 +140,64 @@ func (c *EngineController) Status(w io.Writer) {
 	}
 }
 
+// Sessions returns the state of the sessions of the engine currently in use,
+// sorted by ID.
+func (c *EngineController) Sessions() []SessionInfo {
+	c.stateMtx.RLock()
+	defer c.stateMtx.RUnlock()
+
+	if e, ok := c.engine.(interface{ Sessions() []SessionInfo }); ok {
+		return e.Sessions()
+	}
+	return nil
+}
+
+// RouteInfo describes an entry of the routing table.
+type RouteInfo struct {
+	// RemoteIA is the ISD-AS the traffic is routed to.
+	RemoteIA addr.IA
+	// Prefixes are the IP prefixes the entry applies to.
+	Prefixes []*net.IPNet
+	// TrafficClass is the condition the traffic must match to be routed by the
+	// entry.
+	TrafficClass pktcls.Cond
+	// Sessions are the IDs of the sessions eligible for the traffic, sorted by
+	// priority.
+	Sessions []uint8
+	// ActiveSession is the ID of the session the traffic is currently routed
+	// on. It is only valid if Active is set.
+	ActiveSession uint8
+	// Active indicates whether there is a session the traffic is routed on.
+	Active bool
+}
+
+// Routes returns the routing table of the engine currently in use. The entries
+// are in the order they are evaluated for a prefix.
+func (c *EngineController) Routes() []RouteInfo {
+	c.stateMtx.RLock()
+	defer c.stateMtx.RUnlock()
+
+	var active map[int]uint8
+	if e, ok := c.engine.(interface{ activeSessions() map[int]uint8 }); ok {
+		active = e.activeSessions()
+	}
+	var routes []RouteInfo
+	for _, rc := range c.routingChains {
+		for _, tm := range rc.TrafficMatchers {
+			sessID, ok := active[tm.ID]
+			routes = append(routes, RouteInfo{
+				RemoteIA:      rc.RemoteIA,
+				Prefixes:      rc.Prefixes,
+				TrafficClass:  tm.Matcher,
+				Sessions:      c.routingTableIndices[tm.ID],
+				ActiveSession: sessID,
+				Active:        ok,
+			})
+		}
+	}
+	return routes
+}
+
 func (c *EngineController) validate() error {
 	if c.ConfigurationUpdates == nil {
 		return serrors.New("configuration update channel must not be nil")
@@ -191,6 +254,8 @@ func (c *EngineController) run() error {
 
 		c.stateMtx.Lock()
 		c.engine = newEngine
+		c.routingChains = rcs
+		c.routingTableIndices = rcMapping
 		c.stateMtx.Unlock()
 	}
 	return nil
@@ -217,6 +282,18 @@ type DefaultEngineFactory struct {
 	// DataplaneSessionFactory is used to construct dataplane sessions.
 	DataplaneSessionFactory DataplaneSessionFactory
 
+	// KeyExchange is used by engines to establish the keys that encrypt the
+	// frames of the sessions. If nil, frames are not encrypted.
+	KeyExchange *KeyExchange
+
+	// HealthExpiration is the duration after the last successful probe after
+	// which a session is considered down. If zero, a default value is used.
+	HealthExpiration time.Duration
+
+	// PathMTUDiscovery indicates whether the sessions of the engines send ICMP
+	// Packet Too Big messages for IP packets that exceed the path MTU.
+	PathMTUDiscovery bool
+
 	// Logger is used by engines to write messages about internal operation. If nil,
 	// no logging messages are printed. Child engines will inherit this logger.
 	Logger log.Logger
@@ -240,6 +317,9 @@ func (f *DefaultEngineFactory) New(table RoutingTable,
 		ProbeConnFactory:        f.ProbeConnFactory,
 		DeviceManager:           f.DeviceManager,
 		DataplaneSessionFactory: f.DataplaneSessionFactory,
+		KeyExchange:             f.KeyExchange,
+		HealthExpiration:        f.HealthExpiration,
+		PathMTUDiscovery:        f.PathMTUDiscovery,
 		Logger:                  f.Logger,
 		Metrics:                 f.Metrics,
 	}
@@ -312,6 +392,19 @@ func buildRoutingChains(sessionConfigs []*SessionConfig) ([]*RoutingChain, map[i
 			}
 		}
 	}
+	// Prefer the sessions to the remote gateways with the highest priority.
+	// The other sessions are standbys that are used if the preferred ones are
+	// down.
+	priorities := make(map[uint8]uint32, len(sessionConfigs))
+	for _, sc := range sessionConfigs {
+		priorities[sc.ID] = sc.Gateway.Priority
+	}
+	for _, ids := range sessionMap {
+		ids := ids
+		sort.SliceStable(ids, func(i, j int) bool {
+			return priorities[ids[i]] > priorities[ids[j]]
+		})
+	}
 	return routingChains, sessionMap
 }
 
@@ -131,6 +131,77 @@ func TestEngineControllerRun(t *testing.T) {
 	})
 }
 
+func TestEngineControllerRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	configurationUpdates := make(chan []*control.SessionConfig)
+	routingTableSwapper := mock_control.NewMockRoutingTableSwapper(ctrl)
+	routingTableFactory := mock_control.NewMockRoutingTableFactory(ctrl)
+	engineFactory := mock_control.NewMockEngineFactory(ctrl)
+	publisherFactory := mock_control.NewMockPublisherFactory(ctrl)
+	engine := mock_control.NewMockWorker(ctrl)
+
+	routingTableFactory.EXPECT().New(gomock.Any()).Return(
+		mock_control.NewMockRoutingTable(ctrl), nil)
+	publisherFactory.EXPECT().NewPublisher().Return(mock_control.NewMockPublisher(ctrl))
+	engineFactory.EXPECT().New(gomock.Any(), gomock.Any(), gomock.Any()).Return(engine)
+	engine.EXPECT().Run().Return(nil).AnyTimes()
+	swapped := make(chan struct{})
+	routingTableSwapper.EXPECT().SetRoutingTable(gomock.Any()).Do(
+		func(control.RoutingTable) { close(swapped) })
+
+	engineController := &control.EngineController{
+		ConfigurationUpdates:  configurationUpdates,
+		RoutingTableSwapper:   routingTableSwapper,
+		RoutingTableFactory:   routingTableFactory,
+		EngineFactory:         engineFactory,
+		RoutePublisherFactory: publisherFactory,
+	}
+	assert.Empty(t, engineController.Routes())
+	assert.Empty(t, engineController.Sessions())
+
+	go func() {
+		engineController.Run()
+	}()
+	configurationUpdates <- []*control.SessionConfig{
+		{
+			ID:             1,
+			IA:             xtest.MustParseIA("1-ff00:0:110"),
+			TrafficMatcher: pktcls.CondTrue,
+			Prefixes:       xtest.MustParseCIDRs(t, "10.1.0.0/16"),
+		},
+		{
+			ID:             2,
+			IA:             xtest.MustParseIA("1-ff00:0:110"),
+			TrafficMatcher: pktcls.CondFalse,
+			Prefixes:       xtest.MustParseCIDRs(t, "10.1.0.0/16"),
+		},
+	}
+	<-swapped
+	close(configurationUpdates)
+
+	assert.Eventually(t, func() bool {
+		return len(engineController.Routes()) != 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []control.RouteInfo{
+		{
+			RemoteIA:     xtest.MustParseIA("1-ff00:0:110"),
+			Prefixes:     xtest.MustParseCIDRs(t, "10.1.0.0/16"),
+			TrafficClass: pktcls.CondTrue,
+			Sessions:     []uint8{1},
+		},
+		{
+			RemoteIA:     xtest.MustParseIA("1-ff00:0:110"),
+			Prefixes:     xtest.MustParseCIDRs(t, "10.1.0.0/16"),
+			TrafficClass: pktcls.CondFalse,
+			Sessions:     []uint8{2},
+		},
+	}, engineController.Routes())
+	// The mocked engine does not expose any sessions.
+	assert.Empty(t, engineController.Sessions())
+}
+
 func TestBuildRoutingChains(t *testing.T) {
 	testCases := map[string]struct {
 		Input          []*control.SessionConfig
@@ -536,6 +607,59 @@ func TestBuildRoutingChains(t *testing.T) {
 				2: {42},
 			},
 		},
+		"active standby": {
+			Input: []*control.SessionConfig{
+				{
+					ID:             10,
+					PolicyID:       0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					Gateway: control.Gateway{
+						Control:  xtest.MustParseUDPAddr(t, "10.1.0.1:30256"),
+						Priority: 10,
+					},
+					Prefixes: xtest.MustParseCIDRs(t, "10.1.0.0/16"),
+				},
+				{
+					ID:             11,
+					PolicyID:       0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					Gateway: control.Gateway{
+						Control:  xtest.MustParseUDPAddr(t, "10.1.0.2:30256"),
+						Priority: 100,
+					},
+					Prefixes: xtest.MustParseCIDRs(t, "10.1.0.0/16"),
+				},
+				{
+					ID:             12,
+					PolicyID:       0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					Gateway: control.Gateway{
+						Control:  xtest.MustParseUDPAddr(t, "10.1.0.3:30256"),
+						Priority: 10,
+					},
+					Prefixes: xtest.MustParseCIDRs(t, "10.1.0.0/16"),
+				},
+			},
+			Chains: []*control.RoutingChain{
+				{
+					RemoteIA:        xtest.MustParseIA("1-ff00:0:110"),
+					Prefixes:        xtest.MustParseCIDRs(t, "10.1.0.0/16"),
+					TrafficMatchers: []control.TrafficMatcher{{ID: 1, Matcher: pktcls.CondTrue}},
+				},
+			},
+			SessionMapping: map[int][]uint8{
+				1: {11, 10, 12},
+			},
+		},
 	}
 	for name, tc := range testCases {
 		name, tc := name, tc
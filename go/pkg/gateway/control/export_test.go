@@ -14,6 +14,10 @@
 
 package control
 
+import (
+	gatewaypb "github.com/scionproto/scion/go/pkg/proto/gateway"
+)
+
 var (
 	BuildSessionConfigs     = buildSessionConfigs
 	ComputeDiff             = computeDiff
@@ -26,3 +30,16 @@ var (
 
 type ConjunctionPathPol = conjuctionPathPol
 type Diff = diff
+type KeyExchangeHandshake = keyExchangeHandshake
+
+func (k *KeyExchange) Initiate(sessID uint8) (*KeyExchangeHandshake, error) {
+	return k.initiate(sessID)
+}
+
+func (h *KeyExchangeHandshake) Request() *gatewaypb.KeyExchangeRequest {
+	return h.request
+}
+
+func (h *KeyExchangeHandshake) Finish(resp *gatewaypb.KeyExchangeResponse) ([]byte, error) {
+	return h.finish(resp)
+}
@@ -73,6 +73,7 @@ func (d Discoverer) Gateways(ctx context.Context) ([]control.Gateway, error) {
 			Probe:      probe,
 			Data:       data,
 			Interfaces: pb.AllowInterfaces,
+			Priority:   pb.Priority,
 		})
 	}
 	return gateways, nil
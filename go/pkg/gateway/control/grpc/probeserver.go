@@ -23,14 +23,23 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
 	gpb "github.com/scionproto/scion/go/pkg/proto/gateway"
 )
 
 // ProbeDispatcher handles incoming gateway protocol messages.
-// Currently, it only supports probe requests, and immediately replies to them.
+// It immediately replies to probe requests and, if encryption is enabled, to
+// key exchange requests.
 type ProbeDispatcher struct {
-	Logger log.Logger
+	// KeyExchange is used to answer key exchange requests of remote gateways.
+	// If nil, key exchange requests are rejected.
+	KeyExchange *control.KeyExchange
+	// IngressKeys receives the keys established with remote gateways. It must
+	// be set if KeyExchange is set.
+	IngressKeys control.IngressKeySetter
+	Logger      log.Logger
 }
 
 // Listen handles the received control requests.
@@ -83,11 +92,49 @@ func (d *ProbeDispatcher) dispatch(conn net.PacketConn, raw []byte, addr net.Add
 		}
 		_, err = conn.WriteTo(packed, addr)
 		return err
+	case *gpb.ControlRequest_KeyExchange:
+		return d.handleKeyExchange(conn, c.KeyExchange, addr)
 	default:
 		return serrors.New("unexpected control request", "type", common.TypeOf(ctrl.Request))
 	}
 }
 
+func (d *ProbeDispatcher) handleKeyExchange(conn net.PacketConn,
+	req *gpb.KeyExchangeRequest, addr net.Addr) error {
+
+	if d.KeyExchange == nil || d.IngressKeys == nil {
+		return serrors.New("key exchange not supported", "session_id", req.SessionId)
+	}
+	src, ok := addr.(*snet.UDPAddr)
+	if !ok {
+		return serrors.New("unexpected address type", "type", common.TypeOf(addr))
+	}
+	resp, key, err := d.KeyExchange.Respond(req)
+	if err != nil {
+		return serrors.WrapStr("verifying key exchange request", err,
+			"session_id", req.SessionId)
+	}
+	// The key must be installed before the response is sent, because the
+	// remote gateway encrypts its frames with it as soon as it receives the
+	// response.
+	if err := d.IngressKeys.Set(src.IA, net.IP(req.DataIp), uint8(req.SessionId),
+		key); err != nil {
+
+		return serrors.WrapStr("installing ingress key", err, "session_id", req.SessionId)
+	}
+	packed, err := proto.Marshal(&gpb.ControlResponse{
+		Response: &gpb.ControlResponse_KeyExchange{
+			KeyExchange: resp,
+		},
+	})
+	if err != nil {
+		return serrors.WrapStr("packing key exchange response", err,
+			"session_id", req.SessionId)
+	}
+	_, err = conn.WriteTo(packed, addr)
+	return err
+}
+
 func (d *ProbeDispatcher) logInfo(msg string, ctx ...interface{}) {
 	if d.Logger != nil {
 		d.Logger.Info(msg, ctx...)
@@ -19,6 +19,7 @@ import (
 	"net"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -29,7 +30,9 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
 	"github.com/scionproto/scion/go/pkg/gateway/control/grpc"
+	"github.com/scionproto/scion/go/pkg/gateway/control/mock_control"
 	gpb "github.com/scionproto/scion/go/pkg/proto/gateway"
 )
 
@@ -98,3 +101,56 @@ func TestControlDispatcher(t *testing.T) {
 	<-done
 
 }
+
+func TestControlDispatcherKeyExchange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	src := &snet.UDPAddr{IA: xtest.MustParseIA("1-ff00:0:110")}
+	// The request is not authenticated with the pre-shared key and must
+	// neither be answered nor result in an installed key.
+	raw, err := proto.Marshal(&gpb.ControlRequest{
+		Request: &gpb.ControlRequest_KeyExchange{
+			KeyExchange: &gpb.KeyExchangeRequest{
+				SessionId: 3,
+				DataIp:    net.IP{192, 0, 2, 1},
+				Timestamp: uint64(time.Now().Unix()),
+				PublicKey: make([]byte, 32),
+				Mac:       make([]byte, 32),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	conn := mock_net.NewMockPacketConn(ctrl)
+	conn.EXPECT().ReadFrom(gomock.Any()).DoAndReturn(
+		func(buf []byte) (int, net.Addr, error) {
+			copy(buf, raw)
+			return len(raw), src, nil
+		},
+	)
+	allReceived := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	conn.EXPECT().ReadFrom(gomock.Any()).DoAndReturn(
+		func(buf []byte) (int, net.Addr, error) {
+			close(allReceived)
+			<-ctx.Done()
+			return 0, nil, serrors.New("closed")
+		},
+	)
+
+	keys := mock_control.NewMockIngressKeySetter(ctrl)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d := &grpc.ProbeDispatcher{
+			KeyExchange: &control.KeyExchange{PSK: []byte("pre-shared key")},
+			IngressKeys: keys,
+		}
+		err := d.Listen(ctx, conn)
+		assert.NoError(t, err)
+	}()
+	<-allReceived
+	cancel()
+	<-done
+}
@@ -0,0 +1,267 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	gatewaypb "github.com/scionproto/scion/go/pkg/proto/gateway"
+)
+
+const (
+	defaultKeyExchangeMaxAge = time.Minute
+	// frameKeyLen is the length of the derived frame keys. It must match the
+	// key length expected by the dataplane.
+	frameKeyLen = 32
+
+	requestMACLabel  = "scion gateway key exchange request"
+	responseMACLabel = "scion gateway key exchange response"
+	frameKeyLabel    = "scion gateway frame key"
+)
+
+// FrameKeySetter is implemented by dataplane sessions that encrypt their
+// frames.
+type FrameKeySetter interface {
+	// SetKey sets the key that is used to encrypt the frames of the session.
+	SetKey(key []byte) error
+}
+
+// IngressKeySetter installs the keys that are used to decrypt the frames
+// received from remote gateways.
+type IngressKeySetter interface {
+	// Set installs the key for the session sessID of the remote gateway that
+	// sends its frames from ip in ia.
+	Set(ia addr.IA, ip net.IP, sessID uint8, key []byte) error
+}
+
+// KeyExchange establishes the keys that encrypt the data frames of sessions.
+//
+// The gateway that sends the frames of a session initiates the exchange with
+// an ephemeral X25519 public key. The remote gateway replies with its own
+// ephemeral public key and installs the derived key for the frames it
+// receives. Both messages are authenticated with HMAC-SHA256 keyed with a
+// pre-shared key. The frame key is derived with HKDF-SHA256 from the X25519
+// shared secret, salted with the pre-shared key.
+//
+// A KeyExchange must not be copied after first use.
+type KeyExchange struct {
+	// PSK is the pre-shared key that authenticates the key exchange messages.
+	// All gateways that communicate with each other must use the same key.
+	PSK []byte
+	// DataIP is the IP address from which the local gateway sends the data
+	// frames. The remote gateway uses it to associate the key with the frames.
+	DataIP net.IP
+	// MaxAge is the maximum age of accepted requests. If zero, a default of
+	// one minute is used.
+	MaxAge time.Duration
+
+	mtx sync.Mutex
+	// seen contains the public keys of recently accepted requests. It is used
+	// to reject replayed requests.
+	seen map[string]time.Time
+}
+
+// keyExchangeHandshake is the state of the initiator during a key exchange.
+type keyExchangeHandshake struct {
+	k       *KeyExchange
+	request *gatewaypb.KeyExchangeRequest
+	private []byte
+}
+
+// initiate starts a key exchange for the session sessID.
+func (k *KeyExchange) initiate(sessID uint8) (*keyExchangeHandshake, error) {
+	dataIP := k.DataIP.To16()
+	if dataIP == nil {
+		return nil, serrors.New("invalid data IP", "ip", k.DataIP)
+	}
+	private, public, err := newKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	req := &gatewaypb.KeyExchangeRequest{
+		SessionId: uint32(sessID),
+		DataIp:    dataIP,
+		Timestamp: uint64(time.Now().Unix()),
+		PublicKey: public,
+	}
+	req.Mac = k.requestMAC(req)
+	return &keyExchangeHandshake{k: k, request: req, private: private}, nil
+}
+
+// finish verifies the response of the remote gateway and returns the frame
+// key.
+func (h *keyExchangeHandshake) finish(resp *gatewaypb.KeyExchangeResponse) ([]byte, error) {
+	if resp.SessionId != h.request.SessionId {
+		return nil, serrors.New("unexpected session ID in response",
+			"response_id", resp.SessionId, "expected_id", h.request.SessionId)
+	}
+	if len(resp.PublicKey) != curve25519.PointSize {
+		return nil, serrors.New("invalid public key length", "expected", curve25519.PointSize,
+			"actual", len(resp.PublicKey))
+	}
+	if !hmac.Equal(resp.Mac, h.k.responseMAC(h.request, resp.PublicKey)) {
+		return nil, serrors.New("invalid response MAC")
+	}
+	shared, err := curve25519.X25519(h.private, resp.PublicKey)
+	if err != nil {
+		return nil, serrors.WrapStr("computing shared secret", err)
+	}
+	return h.k.frameKey(shared, h.request, resp.PublicKey)
+}
+
+// Respond verifies the key exchange request of a remote gateway. It returns the
+// response and the key that the remote gateway uses to encrypt the frames of
+// the session.
+func (k *KeyExchange) Respond(
+	req *gatewaypb.KeyExchangeRequest) (*gatewaypb.KeyExchangeResponse, []byte, error) {
+
+	if err := validateKeyExchangeRequest(req); err != nil {
+		return nil, nil, err
+	}
+	if !hmac.Equal(req.Mac, k.requestMAC(req)) {
+		return nil, nil, serrors.New("invalid request MAC")
+	}
+	if err := k.checkFresh(req, time.Now()); err != nil {
+		return nil, nil, err
+	}
+	private, public, err := newKeyPair()
+	if err != nil {
+		return nil, nil, err
+	}
+	shared, err := curve25519.X25519(private, req.PublicKey)
+	if err != nil {
+		return nil, nil, serrors.WrapStr("computing shared secret", err)
+	}
+	key, err := k.frameKey(shared, req, public)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp := &gatewaypb.KeyExchangeResponse{
+		SessionId: req.SessionId,
+		PublicKey: public,
+		Mac:       k.responseMAC(req, public),
+	}
+	return resp, key, nil
+}
+
+// checkFresh checks that the request is recent and was not accepted before.
+func (k *KeyExchange) checkFresh(req *gatewaypb.KeyExchangeRequest, now time.Time) error {
+	maxAge := k.MaxAge
+	if maxAge == 0 {
+		maxAge = defaultKeyExchangeMaxAge
+	}
+	created := time.Unix(int64(req.Timestamp), 0)
+	if age := now.Sub(created); age > maxAge || age < -maxAge {
+		return serrors.New("request expired", "timestamp", created, "max_age", maxAge)
+	}
+
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	if k.seen == nil {
+		k.seen = make(map[string]time.Time)
+	}
+	for public, t := range k.seen {
+		// Entries older than twice the maximum age can no longer match a
+		// request that passes the timestamp check.
+		if now.Sub(t) > 2*maxAge {
+			delete(k.seen, public)
+		}
+	}
+	if _, ok := k.seen[string(req.PublicKey)]; ok {
+		return serrors.New("replayed request")
+	}
+	k.seen[string(req.PublicKey)] = now
+	return nil
+}
+
+func (k *KeyExchange) requestMAC(req *gatewaypb.KeyExchangeRequest) []byte {
+	mac := hmac.New(sha256.New, k.PSK)
+	mac.Write([]byte(requestMACLabel))
+	writeKeyExchangeRequest(mac, req)
+	return mac.Sum(nil)
+}
+
+func (k *KeyExchange) responseMAC(req *gatewaypb.KeyExchangeRequest, public []byte) []byte {
+	mac := hmac.New(sha256.New, k.PSK)
+	mac.Write([]byte(responseMACLabel))
+	writeKeyExchangeRequest(mac, req)
+	mac.Write(public)
+	return mac.Sum(nil)
+}
+
+func (k *KeyExchange) frameKey(shared []byte, req *gatewaypb.KeyExchangeRequest,
+	public []byte) ([]byte, error) {
+
+	info := sha256.New()
+	info.Write([]byte(frameKeyLabel))
+	writeKeyExchangeRequest(info, req)
+	info.Write(public)
+
+	key := make([]byte, frameKeyLen)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, k.PSK, info.Sum(nil)), key); err != nil {
+		return nil, serrors.WrapStr("deriving frame key", err)
+	}
+	return key, nil
+}
+
+// writeKeyExchangeRequest writes the authenticated fields of the request to h.
+// The request must be valid.
+func writeKeyExchangeRequest(h hash.Hash, req *gatewaypb.KeyExchangeRequest) {
+	var buf [12]byte
+	binary.BigEndian.PutUint32(buf[:4], req.SessionId)
+	binary.BigEndian.PutUint64(buf[4:], req.Timestamp)
+	h.Write(buf[:])
+	h.Write(net.IP(req.DataIp).To16())
+	h.Write(req.PublicKey)
+}
+
+func validateKeyExchangeRequest(req *gatewaypb.KeyExchangeRequest) error {
+	if req.SessionId > 0xff {
+		return serrors.New("invalid session ID", "session_id", req.SessionId)
+	}
+	if ip := net.IP(req.DataIp); len(ip) != net.IPv4len && len(ip) != net.IPv6len {
+		return serrors.New("invalid data IP length", "length", len(ip))
+	}
+	if len(req.PublicKey) != curve25519.PointSize {
+		return serrors.New("invalid public key length", "expected", curve25519.PointSize,
+			"actual", len(req.PublicKey))
+	}
+	return nil
+}
+
+func newKeyPair() ([]byte, []byte, error) {
+	private := make([]byte, curve25519.ScalarSize)
+	if _, err := rand.Read(private); err != nil {
+		return nil, nil, serrors.WrapStr("generating private key", err)
+	}
+	public, err := curve25519.X25519(private, curve25519.Basepoint)
+	if err != nil {
+		return nil, nil, serrors.WrapStr("computing public key", err)
+	}
+	return private, public, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+	gatewaypb "github.com/scionproto/scion/go/pkg/proto/gateway"
+)
+
+func TestKeyExchange(t *testing.T) {
+	psk := []byte("pre-shared key")
+	newInitiator := func() *control.KeyExchange {
+		return &control.KeyExchange{PSK: psk, DataIP: net.IP{192, 0, 2, 1}}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		responder := &control.KeyExchange{PSK: psk}
+		handshake, err := newInitiator().Initiate(3)
+		require.NoError(t, err)
+		req := handshake.Request()
+		assert.Equal(t, uint32(3), req.SessionId)
+		assert.Equal(t, net.IP{192, 0, 2, 1}.To16(), net.IP(req.DataIp))
+
+		resp, respKey, err := responder.Respond(req)
+		require.NoError(t, err)
+		initKey, err := handshake.Finish(resp)
+		require.NoError(t, err)
+		assert.Len(t, initKey, 32)
+		assert.Equal(t, respKey, initKey)
+
+		// Subsequent exchanges result in different keys.
+		handshake, err = newInitiator().Initiate(3)
+		require.NoError(t, err)
+		_, otherKey, err := responder.Respond(handshake.Request())
+		require.NoError(t, err)
+		assert.NotEqual(t, respKey, otherKey)
+	})
+	t.Run("replayed request", func(t *testing.T) {
+		responder := &control.KeyExchange{PSK: psk}
+		handshake, err := newInitiator().Initiate(3)
+		require.NoError(t, err)
+		_, _, err = responder.Respond(handshake.Request())
+		require.NoError(t, err)
+		_, _, err = responder.Respond(handshake.Request())
+		assert.Error(t, err)
+	})
+	t.Run("wrong PSK", func(t *testing.T) {
+		responder := &control.KeyExchange{PSK: []byte("other key")}
+		handshake, err := newInitiator().Initiate(3)
+		require.NoError(t, err)
+		_, _, err = responder.Respond(handshake.Request())
+		assert.Error(t, err)
+	})
+
+	modifyRequest := map[string]func(req *gatewaypb.KeyExchangeRequest){
+		"session ID": func(req *gatewaypb.KeyExchangeRequest) {
+			req.SessionId = 4
+		},
+		"data IP": func(req *gatewaypb.KeyExchangeRequest) {
+			req.DataIp = net.IP{192, 0, 2, 2}.To16()
+		},
+		"timestamp": func(req *gatewaypb.KeyExchangeRequest) {
+			req.Timestamp--
+		},
+		"public key": func(req *gatewaypb.KeyExchangeRequest) {
+			req.PublicKey[0] ^= 0xff
+		},
+		"short public key": func(req *gatewaypb.KeyExchangeRequest) {
+			req.PublicKey = req.PublicKey[:16]
+		},
+		"expired": func(req *gatewaypb.KeyExchangeRequest) {
+			req.Timestamp -= 3600
+		},
+	}
+	for name, modify := range modifyRequest {
+		name, modify := name, modify
+		t.Run("modified request "+name, func(t *testing.T) {
+			responder := &control.KeyExchange{PSK: psk}
+			handshake, err := newInitiator().Initiate(3)
+			require.NoError(t, err)
+			req := proto.Clone(handshake.Request()).(*gatewaypb.KeyExchangeRequest)
+			modify(req)
+			_, _, err = responder.Respond(req)
+			assert.Error(t, err)
+		})
+	}
+
+	modifyResponse := map[string]func(resp *gatewaypb.KeyExchangeResponse){
+		"session ID": func(resp *gatewaypb.KeyExchangeResponse) {
+			resp.SessionId = 4
+		},
+		"public key": func(resp *gatewaypb.KeyExchangeResponse) {
+			resp.PublicKey[0] ^= 0xff
+		},
+		"MAC": func(resp *gatewaypb.KeyExchangeResponse) {
+			resp.Mac[0] ^= 0xff
+		},
+	}
+	for name, modify := range modifyResponse {
+		name, modify := name, modify
+		t.Run("modified response "+name, func(t *testing.T) {
+			responder := &control.KeyExchange{PSK: psk}
+			handshake, err := newInitiator().Initiate(3)
+			require.NoError(t, err)
+			resp, _, err := responder.Respond(handshake.Request())
+			require.NoError(t, err)
+			modify(resp)
+			_, err = handshake.Finish(resp)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/go/pkg/gateway/control (interfaces: DataplaneSession,Discoverer,RoutingTable,RoutingTableSwapper,RoutingTableFactory,EngineFactory,PathMonitor,PathMonitorRegistration,PacketConnFactory,PrefixConsumer,PrefixFetcher,DataplaneSessionFactory,PktWriter,Worker,SessionPolicyParser,RoutingPolicyProvider,Runner,GatewayWatcherFactory,Publisher,PublisherFactory,DeviceOpener,DeviceHandle)
+// Source: github.com/scionproto/scion/go/pkg/gateway/control (interfaces: DataplaneSession,Discoverer,RoutingTable,RoutingTableSwapper,RoutingTableFactory,EngineFactory,PathMonitor,PathMonitorRegistration,PacketConnFactory,PrefixConsumer,PrefixFetcher,DataplaneSessionFactory,PktWriter,Worker,SessionPolicyParser,RoutingPolicyProvider,Runner,GatewayWatcherFactory,Publisher,PublisherFactory,DeviceOpener,DeviceHandle,IngressKeySetter,WeightedPathSetter)
 
 // Package mock_control is a generated GoMock package.
 package mock_control
@@ -1029,3 +1029,77 @@ func (mr *MockDeviceHandleMockRecorder) Write(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockDeviceHandle)(nil).Write), arg0)
 }
+
+// MockIngressKeySetter is a mock of IngressKeySetter interface.
+type MockIngressKeySetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockIngressKeySetterMockRecorder
+}
+
+// MockIngressKeySetterMockRecorder is the mock recorder for MockIngressKeySetter.
+type MockIngressKeySetterMockRecorder struct {
+	mock *MockIngressKeySetter
+}
+
+// NewMockIngressKeySetter creates a new mock instance.
+func NewMockIngressKeySetter(ctrl *gomock.Controller) *MockIngressKeySetter {
+	mock := &MockIngressKeySetter{ctrl: ctrl}
+	mock.recorder = &MockIngressKeySetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIngressKeySetter) EXPECT() *MockIngressKeySetterMockRecorder {
+	return m.recorder
+}
+
+// Set mocks base method.
+func (m *MockIngressKeySetter) Set(arg0 addr.IA, arg1 net.IP, arg2 byte, arg3 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockIngressKeySetterMockRecorder) Set(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockIngressKeySetter)(nil).Set), arg0, arg1, arg2, arg3)
+}
+
+// MockWeightedPathSetter is a mock of WeightedPathSetter interface.
+type MockWeightedPathSetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWeightedPathSetterMockRecorder
+}
+
+// MockWeightedPathSetterMockRecorder is the mock recorder for MockWeightedPathSetter.
+type MockWeightedPathSetterMockRecorder struct {
+	mock *MockWeightedPathSetter
+}
+
+// NewMockWeightedPathSetter creates a new mock instance.
+func NewMockWeightedPathSetter(ctrl *gomock.Controller) *MockWeightedPathSetter {
+	mock := &MockWeightedPathSetter{ctrl: ctrl}
+	mock.recorder = &MockWeightedPathSetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWeightedPathSetter) EXPECT() *MockWeightedPathSetterMockRecorder {
+	return m.recorder
+}
+
+// SetWeightedPaths mocks base method.
+func (m *MockWeightedPathSetter) SetWeightedPaths(arg0 []snet.Path, arg1 []float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWeightedPaths", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWeightedPaths indicates an expected call of SetWeightedPaths.
+func (mr *MockWeightedPathSetterMockRecorder) SetWeightedPaths(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWeightedPaths", reflect.TypeOf((*MockWeightedPathSetter)(nil).SetWeightedPaths), arg0, arg1)
+}
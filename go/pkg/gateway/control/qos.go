@@ -0,0 +1,154 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// DefaultQueueName is the name of the queue that holds the packets that do not
+// match any of the configured queues.
+const DefaultQueueName = "default"
+
+// QoSSetter is implemented by dataplane sessions that can shape and prioritize
+// their traffic.
+type QoSSetter interface {
+	// SetQoS sets the rate limit and the queues of the session.
+	SetQoS(QoSPolicy) error
+}
+
+// QoSPolicy specifies how the traffic of a session is shaped and prioritized.
+type QoSPolicy struct {
+	// RateLimit is the maximum rate in bits per second of the IP packets that
+	// are sent on the session. If zero, the rate is not limited.
+	RateLimit uint64
+	// Burst is the number of bytes that can be sent in excess of the rate
+	// limit after the session was idle. If zero, a default value is used.
+	Burst int
+	// Queues are the queues the IP packets are put in before they are sent.
+	// A packet is put in the first queue whose matcher it satisfies. Packets
+	// that do not match any queue are put in a queue named DefaultQueueName
+	// with priority 0 and weight 1.
+	Queues []QueuePolicy
+}
+
+// QueuePolicy specifies one of the queues of a session.
+type QueuePolicy struct {
+	// Name identifies the queue in the metrics. It must be unique within the
+	// session.
+	Name string
+	// TrafficMatcher contains the conditions the IP packets must satisfy to be
+	// put in the queue.
+	TrafficMatcher pktcls.Cond
+	// Priority of the queue. Queues with a higher priority are served first,
+	// i.e., a queue is only served if all the queues with a higher priority
+	// are empty.
+	Priority int
+	// Weight is the share of the bandwidth the queue gets among the queues
+	// with the same priority. If zero, 1 is used.
+	Weight int
+	// Length is the maximum number of packets in the queue. Packets that
+	// exceed it are dropped. If zero, a default value is used.
+	Length int
+}
+
+// Copy creates a deep copy.
+func (p *QoSPolicy) Copy() *QoSPolicy {
+	if p == nil {
+		return nil
+	}
+	queues := make([]QueuePolicy, 0, len(p.Queues))
+	for _, q := range p.Queues {
+		q.TrafficMatcher = copyTrafficMatcher(q.TrafficMatcher)
+		queues = append(queues, q)
+	}
+	return &QoSPolicy{
+		RateLimit: p.RateLimit,
+		Burst:     p.Burst,
+		Queues:    queues,
+	}
+}
+
+func (p *QoSPolicy) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	queues := make([]string, 0, len(p.Queues))
+	for _, q := range p.Queues {
+		queues = append(queues, fmt.Sprintf("%s(%s,prio=%d,weight=%d,len=%d)",
+			q.Name, q.TrafficMatcher, q.Priority, q.Weight, q.Length))
+	}
+	return fmt.Sprintf("rate=%d burst=%d queues=[%s]", p.RateLimit, p.Burst,
+		strings.Join(queues, " "))
+}
+
+// qosConfig is the JSON representation of a QoS policy.
+type qosConfig struct {
+	// RateLimit is the maximum rate in bits per second.
+	RateLimit uint64
+	// Burst is the burst size in bytes.
+	Burst int
+	// Queues are the queues in the order in which packets are matched.
+	Queues []struct {
+		Name string
+		// Class is the traffic class of the queue in the traffic
+		// classification language, e.g., "dscp=0x2e".
+		Class    string
+		Priority int
+		Weight   int
+		Length   int
+	}
+}
+
+func (c *qosConfig) build() (*QoSPolicy, error) {
+	if c.Burst < 0 {
+		return nil, serrors.New("burst must not be negative", "burst", c.Burst)
+	}
+	p := &QoSPolicy{
+		RateLimit: c.RateLimit,
+		Burst:     c.Burst,
+		Queues:    make([]QueuePolicy, 0, len(c.Queues)),
+	}
+	names := map[string]struct{}{DefaultQueueName: {}}
+	for _, q := range c.Queues {
+		if q.Name == "" {
+			return nil, serrors.New("queue name must not be empty")
+		}
+		if _, ok := names[q.Name]; ok {
+			return nil, serrors.New("duplicate queue name", "name", q.Name)
+		}
+		names[q.Name] = struct{}{}
+		if q.Weight < 0 || q.Length < 0 {
+			return nil, serrors.New("queue weight and length must not be negative",
+				"name", q.Name, "weight", q.Weight, "length", q.Length)
+		}
+		matcher, err := pktcls.BuildClassTree(q.Class)
+		if err != nil {
+			return nil, serrors.WrapStr("parsing queue class", err, "name", q.Name)
+		}
+		p.Queues = append(p.Queues, QueuePolicy{
+			Name:           q.Name,
+			TrafficMatcher: matcher,
+			Priority:       q.Priority,
+			Weight:         q.Weight,
+			Length:         q.Length,
+		})
+	}
+	return p, nil
+}
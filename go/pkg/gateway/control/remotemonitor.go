@@ -145,6 +145,25 @@ func (rm *RemoteMonitor) process(ias []addr.IA) {
 	rm.currentWatchers = newWatchers
 }
 
+// Remotes returns the remote gateways that are currently known, by remote
+// ISD-AS.
+func (rm *RemoteMonitor) Remotes() map[addr.IA][]RemoteGatewayInfo {
+	rm.stateMtx.RLock()
+	defer rm.stateMtx.RUnlock()
+
+	remotes := make(map[addr.IA][]RemoteGatewayInfo, len(rm.currentWatchers))
+	for ia, watcher := range rm.currentWatchers {
+		gatewayWatcher, ok := watcher.runner.(interface {
+			remoteGateways() []RemoteGatewayInfo
+		})
+		if !ok {
+			continue
+		}
+		remotes[ia] = gatewayWatcher.remoteGateways()
+	}
+	return remotes
+}
+
 // DiagnosticsWrite writes diagnostics to the writer.
 func (rm *RemoteMonitor) DiagnosticsWrite(w io.Writer) {
 	rm.stateMtx.RLock()
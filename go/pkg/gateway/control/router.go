@@ -222,6 +222,19 @@ func (r *Router) findSession(rtID int) (uint8, int) {
 	return 0, -1
 }
 
+// activeSessions returns the sessions currently in use, by routing table
+// index.
+func (r *Router) activeSessions() map[int]uint8 {
+	r.stateMtx.RLock()
+	defer r.stateMtx.RUnlock()
+
+	active := make(map[int]uint8, len(r.currentSessions))
+	for rtID, sessID := range r.currentSessions {
+		active[rtID] = sessID
+	}
+	return active
+}
+
 // DiagnosticsWrite writes diagnostics for the router to the writer.
 func (r *Router) DiagnosticsWrite(w io.Writer) {
 	r.stateMtx.RLock()
@@ -0,0 +1,28 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control
+
+import (
+	"net"
+)
+
+// RouteSource provides the IP prefixes that are learned from a source outside
+// of the gateway, e.g., a BGP daemon running on the same host. The prefixes are
+// redistributed to remote gateways according to the redistribute-bgp rules of
+// the routing policy.
+type RouteSource interface {
+	// Prefixes returns the currently learned prefixes.
+	Prefixes() []*net.IPNet
+}
@@ -15,6 +15,7 @@
 package control
 
 import (
+	"io"
 	"sync"
 	"time"
 
@@ -35,6 +36,23 @@ type DataplaneSession interface {
 	Close()
 }
 
+// WeightedPathSetter is implemented by dataplane sessions that can split the
+// traffic across their paths in proportion to per-path weights.
+type WeightedPathSetter interface {
+	// SetWeightedPaths sets the paths and the weights of the paths. The weights
+	// are in the same order as the paths.
+	SetWeightedPaths(paths []snet.Path, weights []float64) error
+}
+
+// PathMTUEnforcer is implemented by dataplane sessions that can reject IP
+// packets that exceed the MTU of their paths.
+type PathMTUEnforcer interface {
+	// EnforcePathMTU makes the session drop the IP packets that exceed the MTU
+	// of its paths and must not be fragmented. For each of them, an ICMP
+	// Packet Too Big message is written to w.
+	EnforcePathMTU(w io.Writer)
+}
+
 // Session represents a point-to-point association with a remote gateway that is subject to
 // a path policy.
 //
@@ -73,6 +91,8 @@ type Session struct {
 	PathMonitorPollInterval time.Duration
 
 	// DataplaneSession points to the data-plane session managed by this control-plane session.
+	// If the path monitor returns weighted paths and the session implements
+	// WeightedPathSetter, the traffic is split according to the weights.
 	//
 	// Run will return an error if DataplaneSession is nil.
 	DataplaneSession DataplaneSession
@@ -124,18 +144,30 @@ func (s *Session) Run() error {
 			if s.PathMonitorPollInterval == 0 && sessionMonitorEvent.Event == EventUp {
 				s.pathResultMtx.Lock()
 				s.pathResult = s.PathMonitorRegistration.Get()
-				s.DataplaneSession.SetPaths(s.pathResult.Paths)
+				s.setPaths()
 				s.pathResultMtx.Unlock()
 			}
 		case <-pathChan:
 			s.pathResultMtx.Lock()
 			s.pathResult = s.PathMonitorRegistration.Get()
-			s.DataplaneSession.SetPaths(s.pathResult.Paths)
+			s.setPaths()
 			s.pathResultMtx.Unlock()
 		}
 	}
 }
 
+// setPaths passes the last path result to the dataplane session. The caller
+// must hold pathResultMtx.
+func (s *Session) setPaths() {
+	if setter, ok := s.DataplaneSession.(WeightedPathSetter); ok &&
+		len(s.pathResult.Weights) != 0 {
+
+		setter.SetWeightedPaths(s.pathResult.Paths, s.pathResult.Weights)
+		return
+	}
+	s.DataplaneSession.SetPaths(s.pathResult.Paths)
+}
+
 func (s *Session) runCalledCheck() error {
 	s.runCalledMutex.Lock()
 	defer s.runCalledMutex.Unlock()
@@ -173,6 +205,13 @@ type sessionPaths struct {
 	Paths []snet.Path
 }
 
+// selection returns the last path selection of the session.
+func (s *Session) selection() pathhealth.Selection {
+	s.pathResultMtx.RLock()
+	defer s.pathResultMtx.RUnlock()
+	return s.pathResult
+}
+
 func (s *Session) sessionPaths() sessionPaths {
 	s.pathResultMtx.RLock()
 	defer s.pathResultMtx.RUnlock()
@@ -134,4 +134,55 @@ func TestSessionRun(t *testing.T) {
 		close(sessionMonitorEvents)
 		xtest.AssertReadReturnsBefore(t, done, time.Second)
 	})
+
+	t.Run("weighted paths", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		path := mock_snet.NewMockPath(ctrl)
+		pathMonitorRegistration := mock_control.NewMockPathMonitorRegistration(ctrl)
+		pathMonitorRegistration.EXPECT().Get().Return(pathhealth.Selection{
+			Paths:   []snet.Path{path},
+			Weights: []float64{1},
+		})
+
+		// The weights are passed to dataplane sessions that support them.
+		dataplaneSession := mock_control.NewMockDataplaneSession(ctrl)
+		weightedSetter := mock_control.NewMockWeightedPathSetter(ctrl)
+		weightedSetter.EXPECT().SetWeightedPaths([]snet.Path{path}, []float64{1})
+
+		events := make(chan control.SessionEvent)
+		sessionMonitorEvents := make(chan control.SessionEvent)
+
+		session := &control.Session{
+			Events:                  events,
+			SessionMonitorEvents:    sessionMonitorEvents,
+			PathMonitorRegistration: pathMonitorRegistration,
+			DataplaneSession: struct {
+				*mock_control.MockDataplaneSession
+				*mock_control.MockWeightedPathSetter
+			}{dataplaneSession, weightedSetter},
+		}
+
+		done := make(chan struct{})
+		go func() {
+			err := session.Run()
+			assert.NoError(t, err)
+			close(done)
+		}()
+
+		select {
+		case sessionMonitorEvents <- control.SessionEvent{Event: control.EventUp, SessionID: 1}:
+		case <-time.After(time.Second):
+			t.Fatal("test deadline exceeded while sending Up event notification")
+		}
+		select {
+		case <-events:
+		case <-time.After(time.Second):
+			t.Fatal("test deadline exceeded while waiting for Up event notification")
+		}
+
+		close(sessionMonitorEvents)
+		xtest.AssertReadReturnsBefore(t, done, time.Second)
+	})
 }
@@ -47,14 +47,18 @@ type SessionConfig struct {
 	// this session.
 	TrafficMatcher pktcls.Cond
 	// PerfPolicy specifies which paths should be preferred (e.g., the path with
-	// the lowest latency). If unset, paths with the lowest latency are
-	// preferred.
+	// the lowest latency). If unset, the shortest paths are preferred.
 	PerfPolicy policies.PerfPolicy
 	// PathPolicy specifies the path properties that paths used for this session
 	// must satisfy.
 	PathPolicy policies.PathPolicy
 	// PathCount is the max number of paths to use.
 	PathCount int
+	// Multipath indicates that the traffic is split across the paths.
+	Multipath bool
+	// QoS specifies how the traffic is shaped and prioritized. If nil, the
+	// traffic is neither shaped nor prioritized.
+	QoS *QoSPolicy
 	// Gateway describes a discovered remote gateway instance.
 	Gateway Gateway
 	// Prefixes contains the network prefixes that are reachable through this
@@ -222,6 +226,8 @@ func diffSessionPolicies(a, b SessionPolicies) bool {
 func diffSessionPolicy(a, b SessionPolicy) bool {
 	if a.TrafficMatcher.String() != b.TrafficMatcher.String() ||
 		a.PathCount != b.PathCount ||
+		a.Multipath != b.Multipath ||
+		a.QoS.String() != b.QoS.String() ||
 		// no better way than comparing pointers here:
 		a.PerfPolicy != b.PerfPolicy ||
 		prefixesKey(a.Prefixes) != prefixesKey(b.Prefixes) {
@@ -326,6 +332,8 @@ func buildSessionConfigs(sessionPolicies SessionPolicies,
 				PerfPolicy:     sessionPolicy.PerfPolicy,
 				PathPolicy:     pathPol,
 				PathCount:      sessionPolicy.PathCount,
+				Multipath:      sessionPolicy.Multipath,
+				QoS:            sessionPolicy.QoS,
 				Gateway:        entry.Gateway,
 				Prefixes:       mergePrefixes(sessionPolicy.Prefixes, entry.Prefixes),
 			})
@@ -35,6 +35,10 @@ import (
 const (
 	defaultProbeInterval    = 500 * time.Millisecond
 	defaultHealthExpiration = 2 * time.Second
+	defaultKeyRefresh       = 5 * time.Minute
+	// keyExchangeRetryInterval is the time after which an unanswered key
+	// exchange is started anew.
+	keyExchangeRetryInterval = 2 * time.Second
 )
 
 // Event describes a health check event.
@@ -97,6 +101,16 @@ type SessionMonitor struct {
 	Metrics SessionMonitorMetrics
 	// Logger is the logger to use. If nil no logs are written.
 	Logger log.Logger
+	// KeyExchange, if set, is used to establish the key that encrypts the
+	// data frames of the session. The key exchange is repeated every
+	// KeyRefreshInterval and after the remote gateway was unreachable.
+	KeyExchange *KeyExchange
+	// FrameKeys receives the keys established with KeyExchange. It must be
+	// set if KeyExchange is set.
+	FrameKeys FrameKeySetter
+	// KeyRefreshInterval is the interval at which the frame key is renewed.
+	// Can be left zero and a default value will be used.
+	KeyRefreshInterval time.Duration
 
 	// stateMtx protects the state from concurrent access.
 	stateMtx sync.RWMutex
@@ -110,6 +124,17 @@ type SessionMonitor struct {
 	// rawProbe is the raw probe to send.
 	rawProbe []byte
 
+	// keyMtx protects the key exchange state from concurrent access.
+	keyMtx sync.Mutex
+	// handshake is the pending key exchange. It is nil if no key exchange is
+	// in progress.
+	handshake *keyExchangeHandshake
+	// handshakeStarted is the time the pending key exchange was started.
+	handshakeStarted time.Time
+	// keyEstablished is the time the current frame key was established. It is
+	// zero if the key must be (re-)established.
+	keyEstablished time.Time
+
 	workerBase worker.Base
 }
 
@@ -120,6 +145,9 @@ func (m *SessionMonitor) initDefaults() {
 	if m.HealthExpiration == 0 {
 		m.HealthExpiration = defaultHealthExpiration
 	}
+	if m.KeyRefreshInterval == 0 {
+		m.KeyRefreshInterval = defaultKeyRefresh
+	}
 }
 
 // Run runs the session monitor. It blocks until Close is called..
@@ -135,10 +163,12 @@ func (m *SessionMonitor) run() error {
 	}()
 	probeTicker := time.NewTicker(m.ProbeInterval)
 	m.sendProbe()
+	m.exchangeKey()
 	for {
 		select {
 		case <-probeTicker.C:
 			m.sendProbe()
+			m.exchangeKey()
 		case <-m.receivedProbe:
 			m.handleProbeReply()
 		case <-m.expirationTimer.C:
@@ -177,6 +207,9 @@ func (m *SessionMonitor) sessionState() sessionState {
 }
 
 func (m *SessionMonitor) setupInternalState() error {
+	if m.KeyExchange != nil && m.FrameKeys == nil {
+		return serrors.New("frame key setter must be set for key exchange")
+	}
 	m.initDefaults()
 	m.state = EventDown
 	probe := &gatewaypb.ControlRequest{
@@ -197,10 +230,25 @@ func (m *SessionMonitor) setupInternalState() error {
 }
 
 func (m *SessionMonitor) sendProbe() {
+	sent, err := m.sendControl(m.rawProbe)
+	if err != nil {
+		if m.Logger != nil {
+			m.Logger.Error("Error sending probe", "err", err)
+		}
+		return
+	}
+	if sent {
+		safeInc(m.Metrics.Probes)
+	}
+}
+
+// sendControl sends the raw control request to the remote gateway. It reports
+// whether the request was sent.
+func (m *SessionMonitor) sendControl(raw []byte) (bool, error) {
 	paths := m.Paths.Get().Paths
 	if len(paths) == 0 {
 		// no path nothing we can do.
-		return
+		return false, nil
 	}
 	remote := &snet.UDPAddr{
 		IA:      m.RemoteIA,
@@ -210,14 +258,51 @@ func (m *SessionMonitor) sendProbe() {
 	}
 	// TODO(sustrik): This should not block. Use SetWriteDeadline.
 	// Do so when creating the connection.
-	_, err := m.ProbeConn.WriteTo(m.rawProbe, remote)
+	if _, err := m.ProbeConn.WriteTo(raw, remote); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// exchangeKey starts a key exchange with the remote gateway if no frame key is
+// established, the key is due for renewal, or a pending key exchange was not
+// answered in time.
+func (m *SessionMonitor) exchangeKey() {
+	if m.KeyExchange == nil {
+		return
+	}
+	m.keyMtx.Lock()
+	now := time.Now()
+	if !m.keyEstablished.IsZero() && now.Sub(m.keyEstablished) < m.KeyRefreshInterval {
+		m.keyMtx.Unlock()
+		return
+	}
+	if m.handshake != nil && now.Sub(m.handshakeStarted) < keyExchangeRetryInterval {
+		m.keyMtx.Unlock()
+		return
+	}
+	handshake, err := m.KeyExchange.initiate(m.ID)
 	if err != nil {
-		if m.Logger != nil {
-			m.Logger.Error("Error sending probe", "err", err)
-		}
+		m.keyMtx.Unlock()
+		log.SafeError(m.Logger, "Starting key exchange", "err", err)
 		return
 	}
-	safeInc(m.Metrics.Probes)
+	// Only the response to the most recent request is accepted.
+	m.handshake, m.handshakeStarted = handshake, now
+	m.keyMtx.Unlock()
+
+	raw, err := proto.Marshal(&gatewaypb.ControlRequest{
+		Request: &gatewaypb.ControlRequest_KeyExchange{
+			KeyExchange: handshake.request,
+		},
+	})
+	if err != nil {
+		log.SafeError(m.Logger, "Marshaling key exchange request", "err", err)
+		return
+	}
+	if _, err := m.sendControl(raw); err != nil {
+		log.SafeError(m.Logger, "Error sending key exchange request", "err", err)
+	}
 }
 
 func (m *SessionMonitor) handleProbeReply() {
@@ -249,6 +334,12 @@ func (m *SessionMonitor) handleExpiration() {
 	m.state = EventDown
 	metrics.GaugeSet(m.Metrics.IsHealthy, 0)
 
+	// The remote gateway might have lost the frame key, e.g., because it
+	// restarted. Establish a new one once it is reachable again.
+	m.keyMtx.Lock()
+	m.keyEstablished = time.Time{}
+	m.keyMtx.Unlock()
+
 	select {
 	case <-m.workerBase.GetDoneChan():
 	case m.Events <- m.notification(m.state):
@@ -283,15 +374,44 @@ func (m *SessionMonitor) handlePkt(raw []byte) error {
 	if err := proto.Unmarshal(raw, &ctrl); err != nil {
 		return serrors.WrapStr("parsing control response", err)
 	}
-	probe, ok := ctrl.Response.(*gatewaypb.ControlResponse_Probe)
-	if !ok {
+	switch resp := ctrl.Response.(type) {
+	case *gatewaypb.ControlResponse_Probe:
+		return m.handleProbe(resp.Probe)
+	case *gatewaypb.ControlResponse_KeyExchange:
+		return m.handleKeyExchange(resp.KeyExchange)
+	default:
 		return serrors.New("unexpected control response", "type", common.TypeOf(ctrl.Response))
 	}
-	if probe.Probe.SessionId != uint32(m.ID) {
+}
+
+func (m *SessionMonitor) handleProbe(probe *gatewaypb.ProbeResponse) error {
+	if probe.SessionId != uint32(m.ID) {
 		return serrors.New("unexpected session ID in response",
-			"response_id", probe.Probe.SessionId, "expected_id", m.ID)
+			"response_id", probe.SessionId, "expected_id", m.ID)
 	}
 	safeInc(m.Metrics.ProbeReplies)
 	m.receivedProbe <- struct{}{}
 	return nil
 }
+
+func (m *SessionMonitor) handleKeyExchange(resp *gatewaypb.KeyExchangeResponse) error {
+	if m.KeyExchange == nil {
+		return serrors.New("unexpected key exchange response, encryption disabled")
+	}
+	m.keyMtx.Lock()
+	defer m.keyMtx.Unlock()
+	if m.handshake == nil {
+		return serrors.New("unexpected key exchange response, no key exchange pending")
+	}
+	key, err := m.handshake.finish(resp)
+	if err != nil {
+		return serrors.WrapStr("verifying key exchange response", err)
+	}
+	if err := m.FrameKeys.SetKey(key); err != nil {
+		return serrors.WrapStr("setting frame key", err)
+	}
+	m.handshake = nil
+	m.keyEstablished = time.Now()
+	log.SafeDebug(m.Logger, "Frame key established", "session_id", m.ID)
+	return nil
+}
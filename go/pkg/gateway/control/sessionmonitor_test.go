@@ -219,3 +219,84 @@ func TestSessionMonitorTestEvents(t *testing.T) {
 	}
 
 }
+
+func TestSessionMonitorKeyExchange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	psk := []byte("pre-shared key")
+	responder := &control.KeyExchange{PSK: psk}
+	conn := mock_net.NewMockPacketConn(ctrl)
+	pathReg := mock_control.NewMockPathMonitorRegistration(ctrl)
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Path().Return(spath.Path{Raw: []byte("dummy")}).AnyTimes()
+	path.EXPECT().UnderlayNextHop().AnyTimes()
+	pathReg.EXPECT().Get().Return(pathhealth.Selection{Paths: []snet.Path{path}}).AnyTimes()
+
+	keys := make(frameKeys, 1)
+	sessMon := control.SessionMonitor{
+		ID:               25,
+		RemoteIA:         xtest.MustParseIA("1-ff00:0:110"),
+		ProbeAddr:        &net.UDPAddr{IP: net.IP{10, 0, 01}, Port: 42},
+		Events:           make(chan control.SessionEvent, 50),
+		ProbeConn:        conn,
+		HealthExpiration: time.Hour,
+		Paths:            pathReg,
+		ProbeInterval:    time.Hour,
+		KeyExchange:      &control.KeyExchange{PSK: psk, DataIP: net.IP{192, 0, 2, 1}},
+		FrameKeys:        keys,
+	}
+
+	responses := make(chan []byte, 10)
+	var expectedKey []byte
+	conn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(raw []byte, _ net.Addr) (int, error) {
+			var req gatewaypb.ControlRequest
+			require.NoError(t, proto.Unmarshal(raw, &req))
+			if kex := req.GetKeyExchange(); kex != nil {
+				resp, key, err := responder.Respond(kex)
+				require.NoError(t, err)
+				expectedKey = key
+				raw, err := proto.Marshal(&gatewaypb.ControlResponse{
+					Response: &gatewaypb.ControlResponse_KeyExchange{KeyExchange: resp},
+				})
+				require.NoError(t, err)
+				responses <- raw
+			}
+			return len(raw), nil
+		},
+	).AnyTimes()
+	conn.EXPECT().ReadFrom(gomock.Any()).DoAndReturn(func(buf []byte) (int, net.Addr, error) {
+		raw := <-responses
+		return copy(buf, raw), nil, nil
+	}).AnyTimes()
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- sessMon.Run()
+	}()
+
+	select {
+	case <-time.After(time.Second):
+		t.Fatalf("Test timed out")
+	case key := <-keys:
+		assert.Equal(t, expectedKey, key)
+	}
+
+	err := sessMon.Close()
+	assert.NoError(t, err)
+	// Unblock the reader.
+	responses <- nil
+	select {
+	case <-time.After(time.Second):
+		t.Fatalf("Test timed out")
+	case err := <-errChan:
+		assert.NoError(t, err)
+	}
+}
+
+type frameKeys chan []byte
+
+func (k frameKeys) SetKey(key []byte) error {
+	k <- key
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/pktcls"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
 )
 
@@ -33,8 +34,9 @@ var (
 			Entries: []*pathpol.ACLEntry{{Action: pathpol.Allow}},
 		},
 	}
-	DefaultPerfPolicy = fingerPrintOrder{}
-	DefaultPathCount  = 1
+	DefaultPathCount = 1
+	// DefaultPerfPolicy is not set, i.e., the shortest paths are preferred.
+	DefaultPerfPolicy policies.PerfPolicy
 )
 
 // LegacySessionPolicyAdapter parses the legacy gateway JSON configuration and
@@ -45,8 +47,11 @@ type LegacySessionPolicyAdapter struct{}
 func (LegacySessionPolicyAdapter) Parse(raw []byte) (SessionPolicies, error) {
 	type JSONFormat struct {
 		ASes map[addr.IA]struct {
-			Nets      []string
-			PathCount int
+			Nets       []string
+			PathCount  int
+			Multipath  bool
+			PerfPolicy *perfPolicyConfig
+			QoS        *qosConfig
 		}
 		ConfigVersion uint64
 	}
@@ -64,19 +69,72 @@ func (LegacySessionPolicyAdapter) Parse(raw []byte) (SessionPolicies, error) {
 		if asEntry.PathCount != 0 {
 			pathCount = asEntry.PathCount
 		}
+		perfPolicy := DefaultPerfPolicy
+		if asEntry.PerfPolicy != nil {
+			if perfPolicy, err = asEntry.PerfPolicy.build(); err != nil {
+				return nil, serrors.WithCtx(err, "isd_as", ia)
+			}
+		}
+		var qos *QoSPolicy
+		if asEntry.QoS != nil {
+			if qos, err = asEntry.QoS.build(); err != nil {
+				return nil, serrors.WithCtx(err, "isd_as", ia)
+			}
+		}
 		policies = append(policies, SessionPolicy{
 			ID:             0,
 			IA:             ia,
 			TrafficMatcher: pktcls.CondTrue,
-			PerfPolicy:     DefaultPerfPolicy,
+			PerfPolicy:     perfPolicy,
 			PathPolicy:     DefaultPathPolicy,
 			PathCount:      pathCount,
+			Multipath:      asEntry.Multipath,
+			QoS:            qos,
 			Prefixes:       prefixes,
 		})
 	}
 	return policies, nil
 }
 
+// perfPolicyConfig is the JSON representation of a perf policy.
+type perfPolicyConfig struct {
+	// Type is the type of the policy. It is one of "latency", "loss" or "slo".
+	Type string
+	// MaxLatency, MaxJitter and MaxDropRate are the bounds of the "slo" policy.
+	MaxLatency  util.DurWrap
+	MaxJitter   util.DurWrap
+	MaxDropRate float64
+	// Hysteresis is the relative margin by which a path must be better than
+	// the current path to replace it.
+	Hysteresis float64
+}
+
+func (c *perfPolicyConfig) build() (policies.PerfPolicy, error) {
+	if c.MaxDropRate < 0 || c.MaxDropRate > 1 {
+		return nil, serrors.New("max drop rate must be in [0, 1]", "value", c.MaxDropRate)
+	}
+	if c.Type != "slo" &&
+		(c.MaxLatency.Duration != 0 || c.MaxJitter.Duration != 0 || c.MaxDropRate != 0) {
+		return nil, serrors.New("bounds are only supported by the slo perf policy",
+			"type", c.Type)
+	}
+	switch c.Type {
+	case "latency":
+		return policies.LowestLatency{Hysteresis: c.Hysteresis}, nil
+	case "loss":
+		return policies.LowestLoss{Hysteresis: c.Hysteresis}, nil
+	case "slo":
+		return policies.SLO{
+			MaxLatency:  c.MaxLatency.Duration,
+			MaxJitter:   c.MaxJitter.Duration,
+			MaxDropRate: c.MaxDropRate,
+			Hysteresis:  c.Hysteresis,
+		}, nil
+	default:
+		return nil, serrors.New("unknown perf policy type", "type", c.Type)
+	}
+}
+
 func parsePrefixes(rawNets []string) ([]*net.IPNet, error) {
 	nets := make([]*net.IPNet, 0, len(rawNets))
 	for _, s := range rawNets {
@@ -147,6 +205,8 @@ func (p SessionPolicies) Copy() SessionPolicies {
 // - a path class defined by a path policy,
 // - a performance policy,
 // - a path count,
+// - a multipath flag,
+// - a QoS policy,
 // - a remote IA,
 // - a set of prefixes.
 type SessionPolicy struct {
@@ -158,8 +218,7 @@ type SessionPolicy struct {
 	// this session.
 	TrafficMatcher pktcls.Cond
 	// PerfPolicy specifies which paths should be preferred (e.g., the path with
-	// the lowest latency). If unset, paths with the lowest latency are
-	// preferred.
+	// the lowest latency). If unset, the shortest paths are preferred.
 	PerfPolicy policies.PerfPolicy
 	// PathPolicy specifies the path properties that paths used for this session
 	// must satisfy.
@@ -167,6 +226,13 @@ type SessionPolicy struct {
 	// PathCount  defines the number of paths that can be simultaneously used
 	// within a session.
 	PathCount int
+	// Multipath indicates that the traffic is split across the paths of a
+	// session in proportion to their measured performance. Otherwise, each
+	// flow is hashed onto one of the paths.
+	Multipath bool
+	// QoS specifies how the traffic of the session is shaped and prioritized.
+	// If nil, the traffic is sent as fast as possible in arrival order.
+	QoS *QoSPolicy
 	// Prefixes contains the network prefixes that are reachable through this
 	// session.
 	Prefixes []*net.IPNet
@@ -182,6 +248,8 @@ func (sp SessionPolicy) Copy() SessionPolicy {
 		PerfPolicy: sp.PerfPolicy,
 		PathPolicy: copyPathPolicy(sp.PathPolicy),
 		PathCount:  sp.PathCount,
+		Multipath:  sp.Multipath,
+		QoS:        sp.QoS.Copy(),
 		Prefixes:   copyPrefixes(sp.Prefixes),
 	}
 }
@@ -221,7 +289,3 @@ func copyPrefixes(prefixes []*net.IPNet) []*net.IPNet {
 	}
 	return copy
 }
-
-type fingerPrintOrder struct{}
-
-func (fingerPrintOrder) Better(x, y *policies.Stats) bool { return x.Fingerprint < y.Fingerprint }
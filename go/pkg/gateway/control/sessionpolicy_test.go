@@ -19,6 +19,7 @@ import (
 	"net"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -31,6 +32,7 @@ import (
 	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/pkg/gateway/control"
 	"github.com/scionproto/scion/go/pkg/gateway/control/mock_control"
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
 )
 
 func TestLegacySessionPolicyAdapterParse(t *testing.T) {
@@ -107,6 +109,199 @@ func TestLegacySessionPolicyAdapterParse(t *testing.T) {
 			},
 			AssertErr: assert.NoError,
 		},
+		"multipath": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"PathCount": 3,
+					"Multipath": true
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected: control.SessionPolicies{
+				control.SessionPolicy{
+					ID:             0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      3,
+					Multipath:      true,
+					Prefixes:       []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+				},
+			},
+			AssertErr: assert.NoError,
+		},
+		"perf policy": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"PerfPolicy": {
+					  "Type": "slo",
+					  "MaxLatency": "50ms",
+					  "MaxJitter": "5ms",
+					  "MaxDropRate": 0.01,
+					  "Hysteresis": 0.2
+					}
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected: control.SessionPolicies{
+				control.SessionPolicy{
+					ID:             0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy: policies.SLO{
+						MaxLatency:  50 * time.Millisecond,
+						MaxJitter:   5 * time.Millisecond,
+						MaxDropRate: 0.01,
+						Hysteresis:  0.2,
+					},
+					PathPolicy: control.DefaultPathPolicy,
+					PathCount:  1,
+					Prefixes:   []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+				},
+			},
+			AssertErr: assert.NoError,
+		},
+		"unknown perf policy": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"PerfPolicy": {
+					  "Type": "fastest"
+					}
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
+		"perf policy with unsupported bound": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"PerfPolicy": {
+					  "Type": "latency",
+					  "MaxDropRate": 0.01
+					}
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
+		"qos policy": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"Nets": [
+					  "172.20.4.0/24"
+					],
+					"QoS": {
+					  "RateLimit": 10000000,
+					  "Queues": [
+						{"Name": "voice", "Class": "dscp=0x2e", "Priority": 1, "Length": 64},
+						{"Name": "bulk", "Class": "dstport=873", "Weight": 2}
+					  ]
+					}
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected: control.SessionPolicies{
+				control.SessionPolicy{
+					ID:             0,
+					IA:             xtest.MustParseIA("1-ff00:0:110"),
+					TrafficMatcher: pktcls.CondTrue,
+					PerfPolicy:     control.DefaultPerfPolicy,
+					PathPolicy:     control.DefaultPathPolicy,
+					PathCount:      1,
+					QoS: &control.QoSPolicy{
+						RateLimit: 10000000,
+						Queues: []control.QueuePolicy{
+							{
+								Name: "voice",
+								TrafficMatcher: pktcls.NewCondIPv4(
+									&pktcls.IPv4MatchDSCP{DSCP: 0x2e}),
+								Priority: 1,
+								Length:   64,
+							},
+							{
+								Name: "bulk",
+								TrafficMatcher: pktcls.NewCondPorts(
+									&pktcls.PortMatchDestination{MinPort: 873, MaxPort: 873}),
+								Weight: 2,
+							},
+						},
+					},
+					Prefixes: []*net.IPNet{xtest.MustParseCIDR(t, "172.20.4.0/24")},
+				},
+			},
+			AssertErr: assert.NoError,
+		},
+		"qos policy with duplicate queue": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"QoS": {
+					  "Queues": [
+						{"Name": "default", "Class": "dscp=0x2e"}
+					  ]
+					}
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
+		"qos policy with invalid class": {
+			Input: []byte(`
+			{
+				"ASes": {
+				  "1-ff00:0:110": {
+					"QoS": {
+					  "Queues": [
+						{"Name": "voice", "Class": "dscp=46"}
+					  ]
+					}
+				  }
+				},
+				"ConfigVersion": 300
+			}
+			`),
+			Expected:  nil,
+			AssertErr: assert.Error,
+		},
 	}
 	for name, tc := range testCases {
 		name, tc := name, tc
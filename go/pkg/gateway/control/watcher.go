@@ -61,13 +61,18 @@ type Gateway struct {
 	Data *net.UDPAddr
 	// Interfaces are the last-hop SCION interfaces that should be preferred.
 	Interfaces []uint64
+	// Priority is the priority of the remote gateway. The traffic is sent to
+	// the healthy gateway with the highest priority; the other gateways of the
+	// remote AS are standbys.
+	Priority uint32
 }
 
 func (g Gateway) Equal(other Gateway) bool {
 	return g.Control.String() == other.Control.String() &&
 		g.Probe.String() == other.Probe.String() &&
 		g.Data.String() == other.Data.String() &&
-		interfacesKey(g.Interfaces) == interfacesKey(other.Interfaces)
+		interfacesKey(g.Interfaces) == interfacesKey(other.Interfaces) &&
+		g.Priority == other.Priority
 }
 
 func interfacesKey(interfaces []uint64) string {
@@ -257,13 +262,45 @@ func (w *GatewayWatcher) diagnostics() (remoteDiagnostics, error) {
 			DataAddr:   watcher.gateway.Data.String(),
 			ProbeAddr:  watcher.gateway.Probe.String(),
 			Interfaces: interfaces,
-			Prefixes:   watcher.prefixes,
+			Prefixes:   fmtPrefixes(watcher.prefixes),
 			Timestamp:  watcher.timestamp,
 		}
 	}
 	return diagnostics, nil
 }
 
+// RemoteGatewayInfo describes a discovered remote gateway and the IP prefixes
+// it announces.
+type RemoteGatewayInfo struct {
+	// Gateway contains the gateway specific information.
+	Gateway Gateway
+	// Prefixes are the IP prefixes the gateway announced last.
+	Prefixes []*net.IPNet
+	// Timestamp is the time the prefixes were fetched last. It is zero if the
+	// prefixes were not fetched yet.
+	Timestamp time.Time
+}
+
+func (w *GatewayWatcher) remoteGateways() []RemoteGatewayInfo {
+	w.stateMtx.RLock()
+	defer w.stateMtx.RUnlock()
+
+	gateways := make([]RemoteGatewayInfo, 0, len(w.currentWatchers))
+	for _, watcher := range w.currentWatchers {
+		watcher.stateMtx.RLock()
+		gateways = append(gateways, RemoteGatewayInfo{
+			Gateway:   watcher.gateway,
+			Prefixes:  watcher.prefixes,
+			Timestamp: watcher.timestamp,
+		})
+		watcher.stateMtx.RUnlock()
+	}
+	sort.Slice(gateways, func(i, j int) bool {
+		return gateways[i].Gateway.Control.String() < gateways[j].Gateway.Control.String()
+	})
+	return gateways
+}
+
 func (w *GatewayWatcher) validateParameters() error {
 	if w.Discoverer == nil {
 		return serrors.New("discoverer must not be nil")
@@ -339,7 +376,7 @@ type prefixWatcher struct {
 	// stateMtx protects the state below from concurrent access.
 	stateMtx sync.RWMutex
 	// state of last fetched prefixes
-	prefixes []string
+	prefixes []*net.IPNet
 	// timestamp of last fetched prefixes
 	timestamp time.Time
 }
@@ -394,7 +431,7 @@ func (w *prefixWatcher) run(ctx context.Context) {
 	}
 	logger.Debug("Fetched prefixes successfully", "prefixes", fmtPrefixes(prefixes))
 
-	snapshot := fmtPrefixes(prefixes)
+	snapshot := append([]*net.IPNet(nil), prefixes...)
 	w.Consumer.Prefixes(w.remote, w.gateway, prefixes)
 
 	w.stateMtx.Lock()
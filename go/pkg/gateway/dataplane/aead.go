@@ -0,0 +1,277 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"net"
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Encrypted frames carry version frameVersionAEAD in the frame header. The
+// header stays in the clear and is authenticated as additional data. The
+// payload is encrypted with AES-256-GCM and followed by the authentication tag.
+// The nonce is the last 12 bytes of the header, i.e., the stream ID and the
+// sequence number of the frame. Stream IDs are unique within an encrypted
+// session and sequence numbers are unique within a stream, so that a nonce is
+// never reused with the same key.
+
+const (
+	// frameVersion is the version of unencrypted frames.
+	frameVersion = 0
+	// frameVersionAEAD is the version of encrypted frames.
+	frameVersionAEAD = 1
+	// aeadOverhead is the number of bytes that encryption adds to a frame.
+	aeadOverhead = 16
+	// FrameKeyLen is the length of the keys used to encrypt frames.
+	FrameKeyLen = 32
+	// replayWindowSize is the number of sequence numbers below the highest
+	// accepted one that are tracked to detect replayed frames.
+	replayWindowSize = 1024
+)
+
+// FrameCipher encrypts and decrypts frames with a single key.
+type FrameCipher struct {
+	aead cipher.AEAD
+}
+
+// NewFrameCipher creates a frame cipher for the given key. The key must be
+// FrameKeyLen bytes long.
+func NewFrameCipher(key []byte) (*FrameCipher, error) {
+	if len(key) != FrameKeyLen {
+		return nil, serrors.New("invalid key length", "expected", FrameKeyLen,
+			"actual", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, serrors.WrapStr("creating block cipher", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, serrors.WrapStr("creating AEAD", err)
+	}
+	return &FrameCipher{aead: aead}, nil
+}
+
+// Seal encrypts the frame and appends the result to dst. The version in the
+// header of the frame is set to frameVersionAEAD. The frame and dst must not
+// overlap.
+func (c *FrameCipher) Seal(dst, frame []byte) []byte {
+	frame[versionPos] = frameVersionAEAD
+	dst = append(dst, frame[:hdrLen]...)
+	return c.aead.Seal(dst, frame[streamPos:hdrLen], frame[hdrLen:], frame[:hdrLen])
+}
+
+// Open authenticates the encrypted frame and appends the decrypted payload to
+// dst. The header is not part of the result. The frame and dst must not
+// overlap.
+func (c *FrameCipher) Open(dst, frame []byte) ([]byte, error) {
+	if len(frame) < hdrLen+aeadOverhead {
+		return nil, serrors.New("frame too short", "expected", hdrLen+aeadOverhead,
+			"actual", len(frame))
+	}
+	return c.aead.Open(dst, frame[streamPos:hdrLen], frame[hdrLen:], frame[:hdrLen])
+}
+
+// egressKey holds the cipher that is used to encrypt the frames of a session.
+// It is shared by all senders of the session.
+type egressKey struct {
+	mtx    sync.RWMutex
+	cipher *FrameCipher
+}
+
+func (k *egressKey) set(c *FrameCipher) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	k.cipher = c
+}
+
+func (k *egressKey) get() *FrameCipher {
+	k.mtx.RLock()
+	defer k.mtx.RUnlock()
+	return k.cipher
+}
+
+// IngressKeys holds the keys used to decrypt the frames received from remote
+// gateways. For every remote session the current and the previous key are
+// kept, so that frames in flight during a key rollover are not dropped. The
+// zero value is ready to use.
+type IngressKeys struct {
+	mtx  sync.RWMutex
+	keys map[ingressKeyID][2]*ingressKey
+}
+
+type ingressKeyID struct {
+	ia     addr.IA
+	ip     string
+	sessID uint8
+}
+
+// Set installs the key for the session sessID of the remote gateway with the
+// data IP ip in ia. The key that was installed before is kept as fallback.
+//
+// Setting the current key again has no effect. If the current key has not
+// authenticated any frame yet, it is replaced and the fallback is kept. The
+// remote gateway only switches to a key once it receives the response of the
+// key exchange. If that response is lost, the remote gateway still uses the
+// fallback key, which must therefore survive the next key exchange.
+func (k *IngressKeys) Set(ia addr.IA, ip net.IP, sessID uint8, key []byte) error {
+	c, err := NewFrameCipher(key)
+	if err != nil {
+		return err
+	}
+	id := ingressKeyID{ia: ia, ip: ip.String(), sessID: sessID}
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	if k.keys == nil {
+		k.keys = make(map[ingressKeyID][2]*ingressKey)
+	}
+	old := k.keys[id]
+	switch {
+	case old[0] != nil && bytes.Equal(old[0].key, key):
+		return nil
+	case old[0] != nil && old[1] != nil && !old[0].used():
+		k.keys[id] = [2]*ingressKey{newIngressKey(c, key), old[1]}
+	default:
+		k.keys[id] = [2]*ingressKey{newIngressKey(c, key), old[0]}
+	}
+	return nil
+}
+
+func (k *IngressKeys) get(ia addr.IA, ip net.IP, sessID uint8) [2]*ingressKey {
+	k.mtx.RLock()
+	defer k.mtx.RUnlock()
+	return k.keys[ingressKeyID{ia: ia, ip: ip.String(), sessID: sessID}]
+}
+
+// open authenticates and decrypts the frame with the keys of the given remote
+// session and appends the payload to dst. Replayed frames are rejected.
+func (k *IngressKeys) open(dst, frame []byte, ia addr.IA, ip net.IP,
+	sessID uint8) ([]byte, error) {
+
+	keys := k.get(ia, ip, sessID)
+	if keys[0] == nil {
+		return nil, errNoKey
+	}
+	for _, key := range keys {
+		if key == nil {
+			break
+		}
+		payload, err := key.cipher.Open(dst, frame)
+		if err != nil {
+			continue
+		}
+		stream := binary.BigEndian.Uint32(frame[streamPos:streamPos+4]) & 0xfffff
+		if !key.accept(stream, binary.BigEndian.Uint64(frame[seqPos:seqPos+8])) {
+			return nil, errReplayed
+		}
+		return payload, nil
+	}
+	return nil, errUnauthenticated
+}
+
+var (
+	errNoKey           = serrors.New("no key for session")
+	errReplayed        = serrors.New("replayed frame")
+	errUnauthenticated = serrors.New("frame authentication failed")
+)
+
+// ingressKey is a key for received frames together with the state that is
+// needed to detect replayed frames. The replay state lives as long as the key,
+// so that frames cannot be replayed after a stream was idle for a while.
+type ingressKey struct {
+	key    []byte
+	cipher *FrameCipher
+
+	mtx     sync.Mutex
+	windows map[uint32]*replayWindow
+}
+
+func newIngressKey(c *FrameCipher, key []byte) *ingressKey {
+	return &ingressKey{
+		key:     append([]byte(nil), key...),
+		cipher:  c,
+		windows: make(map[uint32]*replayWindow),
+	}
+}
+
+// accept reports whether the frame with sequence number seq is accepted on
+// the stream. A frame is accepted at most once.
+func (k *ingressKey) accept(stream uint32, seq uint64) bool {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	w, ok := k.windows[stream]
+	if !ok {
+		w = &replayWindow{}
+		k.windows[stream] = w
+	}
+	return w.accept(seq)
+}
+
+// used reports whether a frame was accepted with the key.
+func (k *ingressKey) used() bool {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	return len(k.windows) > 0
+}
+
+// replayWindow is a sliding window over the sequence numbers of a stream.
+type replayWindow struct {
+	// top is one above the highest sequence number accepted so far.
+	top uint64
+	// seen marks the sequence numbers in [top-replayWindowSize, top) that were
+	// accepted.
+	seen [replayWindowSize / 64]uint64
+}
+
+// accept reports whether seq was not accepted before and is not too old. If
+// so, seq is marked as accepted.
+func (w *replayWindow) accept(seq uint64) bool {
+	if seq >= w.top {
+		if seq-w.top >= replayWindowSize {
+			w.seen = [replayWindowSize / 64]uint64{}
+		} else {
+			for i := w.top; i < seq; i++ {
+				w.clear(i)
+			}
+		}
+		w.top = seq + 1
+		w.mark(seq)
+		return true
+	}
+	if w.top-seq > replayWindowSize || w.isMarked(seq) {
+		return false
+	}
+	w.mark(seq)
+	return true
+}
+
+func (w *replayWindow) mark(seq uint64) {
+	w.seen[seq/64%uint64(len(w.seen))] |= 1 << (seq % 64)
+}
+
+func (w *replayWindow) clear(seq uint64) {
+	w.seen[seq/64%uint64(len(w.seen))] &^= 1 << (seq % 64)
+}
+
+func (w *replayWindow) isMarked(seq uint64) bool {
+	return w.seen[seq/64%uint64(len(w.seen))]&(1<<(seq%64)) != 0
+}
@@ -0,0 +1,222 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestFrameCipher(t *testing.T) {
+	_, err := NewFrameCipher([]byte("short"))
+	assert.Error(t, err)
+
+	c, err := NewFrameCipher(bytes.Repeat([]byte{1}, FrameKeyLen))
+	require.NoError(t, err)
+	payload := []byte("some IP packets")
+	sealed := c.Seal(nil, testFrame(5, 7, payload))
+	assert.Equal(t, byte(frameVersionAEAD), sealed[versionPos])
+	assert.Len(t, sealed, hdrLen+len(payload)+aeadOverhead)
+	assert.NotContains(t, string(sealed), string(payload))
+
+	opened, err := c.Open(nil, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, payload, opened)
+
+	testCases := map[string]int{
+		"session":  sessPos,
+		"index":    indexPos,
+		"stream":   streamPos + 3,
+		"sequence": seqPos + 7,
+		"payload":  hdrLen,
+		"tag":      len(sealed) - 1,
+	}
+	for name, pos := range testCases {
+		t.Run("modified "+name, func(t *testing.T) {
+			modified := append([]byte(nil), sealed...)
+			modified[pos] ^= 0x01
+			_, err := c.Open(nil, modified)
+			assert.Error(t, err)
+		})
+	}
+	t.Run("too short", func(t *testing.T) {
+		_, err := c.Open(nil, sealed[:hdrLen+aeadOverhead-1])
+		assert.Error(t, err)
+	})
+}
+
+func TestReplayWindow(t *testing.T) {
+	testCases := map[string]struct {
+		Accepted []uint64
+		Seq      uint64
+		Expected bool
+	}{
+		"first": {
+			Seq:      0,
+			Expected: true,
+		},
+		"next": {
+			Accepted: []uint64{0, 1, 2},
+			Seq:      3,
+			Expected: true,
+		},
+		"duplicate": {
+			Accepted: []uint64{0, 1, 2},
+			Seq:      1,
+			Expected: false,
+		},
+		"reordered": {
+			Accepted: []uint64{0, 2},
+			Seq:      1,
+			Expected: true,
+		},
+		"oldest in window": {
+			Accepted: []uint64{replayWindowSize},
+			Seq:      1,
+			Expected: true,
+		},
+		"too old": {
+			Accepted: []uint64{replayWindowSize},
+			Seq:      0,
+			Expected: false,
+		},
+		"duplicate after jump": {
+			Accepted: []uint64{10, 10 + replayWindowSize/2},
+			Seq:      10,
+			Expected: false,
+		},
+		"slot reused after jump": {
+			Accepted: []uint64{10, 10 + replayWindowSize},
+			Seq:      11,
+			Expected: true,
+		},
+		"large jump": {
+			Accepted: []uint64{10, 10 + 5*replayWindowSize},
+			Seq:      11 + 4*replayWindowSize,
+			Expected: true,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			var w replayWindow
+			for _, seq := range tc.Accepted {
+				require.True(t, w.accept(seq), seq)
+			}
+			assert.Equal(t, tc.Expected, w.accept(tc.Seq))
+		})
+	}
+}
+
+func TestIngressKeys(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	ip := net.IP{192, 0, 2, 1}
+	keys := [][]byte{
+		bytes.Repeat([]byte{1}, FrameKeyLen),
+		bytes.Repeat([]byte{2}, FrameKeyLen),
+		bytes.Repeat([]byte{3}, FrameKeyLen),
+	}
+	seal := func(key []byte, seq uint64) []byte {
+		c, err := NewFrameCipher(key)
+		require.NoError(t, err)
+		return c.Seal(nil, testFrame(1, seq, []byte("payload")))
+	}
+
+	var ingress IngressKeys
+	_, err := ingress.open(nil, seal(keys[0], 0), ia, ip, 1)
+	assert.Equal(t, errNoKey, err)
+
+	require.NoError(t, ingress.Set(ia, ip, 1, keys[0]))
+	payload, err := ingress.open(nil, seal(keys[0], 0), ia, ip, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("payload"), payload)
+	_, err = ingress.open(nil, seal(keys[0], 0), ia, ip, 1)
+	assert.Equal(t, errReplayed, err)
+
+	// Other sessions do not share the key.
+	_, err = ingress.open(nil, seal(keys[0], 1), ia, ip, 2)
+	assert.Equal(t, errNoKey, err)
+	_, err = ingress.open(nil, seal(keys[0], 1), ia, net.IP{192, 0, 2, 2}, 1)
+	assert.Equal(t, errNoKey, err)
+
+	// The previous key remains valid after a rollover.
+	require.NoError(t, ingress.Set(ia, ip, 1, keys[1]))
+	_, err = ingress.open(nil, seal(keys[1], 0), ia, ip, 1)
+	assert.NoError(t, err)
+	_, err = ingress.open(nil, seal(keys[0], 1), ia, ip, 1)
+	assert.NoError(t, err)
+	_, err = ingress.open(nil, seal(keys[0], 0), ia, ip, 1)
+	assert.Equal(t, errReplayed, err)
+
+	require.NoError(t, ingress.Set(ia, ip, 1, keys[2]))
+	_, err = ingress.open(nil, seal(keys[0], 2), ia, ip, 1)
+	assert.Equal(t, errUnauthenticated, err)
+	_, err = ingress.open(nil, seal(keys[1], 1), ia, ip, 1)
+	assert.NoError(t, err)
+}
+
+func TestIngressKeysLostResponse(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	ip := net.IP{192, 0, 2, 1}
+	keys := [][]byte{
+		bytes.Repeat([]byte{1}, FrameKeyLen),
+		bytes.Repeat([]byte{2}, FrameKeyLen),
+		bytes.Repeat([]byte{3}, FrameKeyLen),
+	}
+	seal := func(key []byte, seq uint64) []byte {
+		c, err := NewFrameCipher(key)
+		require.NoError(t, err)
+		return c.Seal(nil, testFrame(1, seq, []byte("payload")))
+	}
+
+	var ingress IngressKeys
+	require.NoError(t, ingress.Set(ia, ip, 1, keys[0]))
+	_, err := ingress.open(nil, seal(keys[0], 0), ia, ip, 1)
+	require.NoError(t, err)
+
+	// The responses to the key exchanges for keys[1] and keys[2] are lost, so
+	// the remote gateway keeps using keys[0].
+	require.NoError(t, ingress.Set(ia, ip, 1, keys[1]))
+	require.NoError(t, ingress.Set(ia, ip, 1, keys[2]))
+	_, err = ingress.open(nil, seal(keys[0], 1), ia, ip, 1)
+	assert.NoError(t, err)
+	_, err = ingress.open(nil, seal(keys[1], 0), ia, ip, 1)
+	assert.Equal(t, errUnauthenticated, err)
+	_, err = ingress.open(nil, seal(keys[2], 0), ia, ip, 1)
+	assert.NoError(t, err)
+
+	// Setting the current key again keeps its replay state.
+	require.NoError(t, ingress.Set(ia, ip, 1, keys[2]))
+	_, err = ingress.open(nil, seal(keys[2], 0), ia, ip, 1)
+	assert.Equal(t, errReplayed, err)
+	_, err = ingress.open(nil, seal(keys[0], 2), ia, ip, 1)
+	assert.NoError(t, err)
+}
+
+func testFrame(stream uint32, seq uint64, payload []byte) []byte {
+	frame := make([]byte, hdrLen, hdrLen+len(payload))
+	frame[sessPos] = 1
+	binary.BigEndian.PutUint16(frame[indexPos:], 0xffff)
+	binary.BigEndian.PutUint32(frame[streamPos:], stream)
+	binary.BigEndian.PutUint64(frame[seqPos:], seq)
+	return append(frame, payload...)
+}
@@ -0,0 +1,483 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/topology/underlay"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+const (
+	// captureQueueLength is the number of packets that are buffered for a
+	// capture. If the capture is not written out fast enough, further packets
+	// are dropped, so that capturing never slows down the forwarding.
+	captureQueueLength = 1024
+	// maxSCIONHdrLen is the maximum length of the SCION and UDP headers that
+	// are prepended to captured frames.
+	maxSCIONHdrLen = 1024 + udpHdrLen
+)
+
+// The pcapng interfaces of a capture.
+const (
+	captureEgressIP = iota
+	captureEgressSCION
+	captureIngressIP
+	captureIngressSCION
+	numCaptureInterfaces
+)
+
+var captureInterfaces = []pcapgo.NgInterface{
+	captureEgressIP: {
+		Name:        "egress-ip",
+		Description: "IP packets sent to the remote gateway",
+		LinkType:    layers.LinkTypeRaw,
+	},
+	captureEgressSCION: {
+		Name:        "egress-scion",
+		Description: "Frames sent to the remote gateway",
+		LinkType:    layers.LinkTypeRaw,
+	},
+	captureIngressIP: {
+		Name:        "ingress-ip",
+		Description: "IP packets received from the remote gateway",
+		LinkType:    layers.LinkTypeRaw,
+	},
+	captureIngressSCION: {
+		Name:        "ingress-scion",
+		Description: "Frames received from the remote gateway",
+		LinkType:    layers.LinkTypeRaw,
+	},
+}
+
+// Captures keeps track of the running packet captures. It is shared by the
+// sessions, which record the traffic they send, and the ingress server, which
+// records the traffic received from the remote gateways of the sessions.
+//
+// Frames are recorded with the SCION and UDP headers, encapsulated in the UDP
+// and IP headers of the underlay. The headers are reconstructed from the
+// addresses and the path of the frames and may thus differ from the headers on
+// the wire, e.g., in the flow ID or in the current hop field of the path.
+type Captures struct {
+	// LocalIA is the ISD-AS of the gateway.
+	LocalIA addr.IA
+	// LocalAddr is the address the ingress server receives frames on.
+	LocalAddr *net.UDPAddr
+
+	// active is the number of running captures. It allows for checking whether
+	// any capture is running without taking the lock.
+	active   int32
+	mtx      sync.RWMutex
+	captures []*capture
+}
+
+func (c *Captures) add(pc *capture) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.captures = append(c.captures, pc)
+	atomic.AddInt32(&c.active, 1)
+}
+
+func (c *Captures) remove(pc *capture) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for i, other := range c.captures {
+		if other == pc {
+			c.captures = append(c.captures[:i], c.captures[i+1:]...)
+			atomic.AddInt32(&c.active, -1)
+			return
+		}
+	}
+}
+
+// running reports whether there might be a running capture. It is safe to
+// call on a nil Captures.
+func (c *Captures) running() bool {
+	return c != nil && atomic.LoadInt32(&c.active) > 0
+}
+
+// sentPacket records an IP packet sent by the session.
+func (c *Captures) sentPacket(s *Session, packet gopacket.Packet) {
+	if !c.running() {
+		return
+	}
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	for _, pc := range c.captures {
+		if pc.session == s && pc.match(packet) {
+			pc.record(captureEgressIP, packet.Data())
+		}
+	}
+}
+
+// sentFrame records a frame sent by the session to the remote address.
+func (c *Captures) sentFrame(s *Session, frame []byte, local *net.UDPAddr,
+	remote *snet.UDPAddr, path snet.Path) {
+
+	if !c.running() {
+		return
+	}
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	var raw []byte
+	for _, pc := range c.captures {
+		if pc.session != s || !pc.frames {
+			continue
+		}
+		if raw == nil {
+			var err error
+			raw, err = encapsulate(frame,
+				snet.SCIONAddress{IA: c.LocalIA, Host: addr.HostFromIP(local.IP)},
+				snet.SCIONAddress{IA: remote.IA, Host: addr.HostFromIP(remote.Host.IP)},
+				uint16(local.Port), uint16(remote.Host.Port), path.Path(),
+				&net.UDPAddr{IP: local.IP, Port: underlay.EndhostPort}, remote.NextHop)
+			if err != nil {
+				pc.fail(err)
+				continue
+			}
+		}
+		pc.record(captureEgressSCION, raw)
+	}
+}
+
+// receivedPacket records an IP packet received from the remote gateway in the
+// session sessID.
+func (c *Captures) receivedPacket(remote *snet.UDPAddr, sessID uint8, packet []byte) {
+	if !c.running() {
+		return
+	}
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	var decoded gopacket.Packet
+	for _, pc := range c.captures {
+		if !pc.from(remote, sessID) {
+			continue
+		}
+		if pc.filter != nil {
+			if decoded == nil {
+				decoded = decodeIPPacket(packet)
+			}
+			if !pc.match(decoded) {
+				continue
+			}
+		}
+		pc.record(captureIngressIP, packet)
+	}
+}
+
+// receivedFrame records a frame of the session sessID received from the remote
+// address. The path of the remote address is the reply path, as returned by
+// snet.
+func (c *Captures) receivedFrame(remote *snet.UDPAddr, sessID uint8, frame []byte) {
+	if !c.running() {
+		return
+	}
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	var raw []byte
+	for _, pc := range c.captures {
+		if !pc.frames || !pc.from(remote, sessID) {
+			continue
+		}
+		if raw == nil {
+			var local net.UDPAddr
+			if c.LocalAddr != nil {
+				local = *c.LocalAddr
+			}
+			path := remote.Path.Copy()
+			if err := path.Reverse(); err != nil {
+				pc.fail(serrors.WrapStr("reversing path", err))
+				continue
+			}
+			var err error
+			raw, err = encapsulate(frame,
+				snet.SCIONAddress{IA: remote.IA, Host: addr.HostFromIP(remote.Host.IP)},
+				snet.SCIONAddress{IA: c.LocalIA, Host: addr.HostFromIP(local.IP)},
+				uint16(remote.Host.Port), uint16(local.Port), path,
+				remote.NextHop, &net.UDPAddr{IP: local.IP, Port: underlay.EndhostPort})
+			if err != nil {
+				pc.fail(err)
+				continue
+			}
+		}
+		pc.record(captureIngressSCION, raw)
+	}
+}
+
+// Capture records the traffic of the session to w in pcapng format. See
+// control.PacketCapturer. The IP packets that are sent while the capture runs
+// are recorded after they have passed the QoS queues.
+func (s *Session) Capture(ctx context.Context, w io.Writer,
+	opts control.CaptureOptions) error {
+
+	if s.Captures == nil {
+		return control.ErrCaptureNotSupported
+	}
+	pc := &capture{
+		session:    s,
+		remoteIA:   s.RemoteIA,
+		remoteIP:   s.GatewayAddr.IP,
+		sessID:     s.SessionID,
+		filter:     opts.Filter,
+		frames:     opts.Frames,
+		maxPackets: opts.MaxPackets,
+		packets:    make(chan capturedPacket, captureQueueLength),
+		done:       make(chan struct{}),
+	}
+	s.Captures.add(pc)
+	defer s.Captures.remove(pc)
+	return pc.run(ctx, w)
+}
+
+// capturedPacket is a packet that is queued for writing.
+type capturedPacket struct {
+	intf int
+	ts   time.Time
+	data []byte
+}
+
+// capture is a running packet capture of a session.
+type capture struct {
+	// dropped is the number of packets that were dropped because the queue
+	// was full, by interface. It is accessed atomically.
+	dropped [numCaptureInterfaces]uint64
+
+	session    *Session
+	remoteIA   addr.IA
+	remoteIP   net.IP
+	sessID     uint8
+	filter     pktcls.Cond
+	frames     bool
+	maxPackets int
+
+	packets chan capturedPacket
+
+	mtx sync.Mutex
+	// err is the first error that occurred while preparing a packet.
+	err  error
+	done chan struct{}
+}
+
+func (c *capture) match(packet gopacket.Packet) bool {
+	if c.filter == nil {
+		return true
+	}
+	if packet == nil || packet.NetworkLayer() == nil {
+		return false
+	}
+	return c.filter.Eval(packet.NetworkLayer())
+}
+
+// from reports whether traffic of the session sessID received from remote
+// belongs to the captured session.
+func (c *capture) from(remote *snet.UDPAddr, sessID uint8) bool {
+	return c.sessID == sessID && c.remoteIA.Equal(remote.IA) &&
+		c.remoteIP.Equal(remote.Host.IP)
+}
+
+// record queues a copy of the packet for writing. It never blocks.
+func (c *capture) record(intf int, data []byte) {
+	p := capturedPacket{
+		intf: intf,
+		ts:   time.Now(),
+		data: append([]byte(nil), data...),
+	}
+	select {
+	case c.packets <- p:
+	default:
+		atomic.AddUint64(&c.dropped[intf], 1)
+	}
+}
+
+// fail stops the capture with the error.
+func (c *capture) fail(err error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	close(c.done)
+}
+
+// run writes the queued packets to w until the context is done, the maximum
+// number of packets is written or an error occurs.
+func (c *capture) run(ctx context.Context, w io.Writer) error {
+	ngw, err := newCaptureWriter(w)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	written := make([]uint64, numCaptureInterfaces)
+	total := 0
+	for c.maxPackets == 0 || total < c.maxPackets {
+		var p capturedPacket
+		select {
+		case <-ctx.Done():
+			return c.finish(ngw, start, written)
+		case <-c.done:
+			c.mtx.Lock()
+			defer c.mtx.Unlock()
+			return c.err
+		case p = <-c.packets:
+		}
+		ci := gopacket.CaptureInfo{
+			Timestamp:      p.ts,
+			CaptureLength:  len(p.data),
+			Length:         len(p.data),
+			InterfaceIndex: p.intf,
+		}
+		if err := ngw.WritePacket(ci, p.data); err != nil {
+			return serrors.WrapStr("writing packet", err)
+		}
+		written[p.intf]++
+		total++
+	}
+	return c.finish(ngw, start, written)
+}
+
+// finish writes the statistics of the interfaces and flushes the writer.
+func (c *capture) finish(ngw *pcapgo.NgWriter, start time.Time, written []uint64) error {
+	end := time.Now()
+	for i := range captureInterfaces {
+		dropped := atomic.LoadUint64(&c.dropped[i])
+		stats := pcapgo.NgInterfaceStatistics{
+			LastUpdate:      end,
+			StartTime:       start,
+			EndTime:         end,
+			PacketsReceived: written[i] + dropped,
+			PacketsDropped:  dropped,
+		}
+		if err := ngw.WriteInterfaceStats(i, stats); err != nil {
+			return serrors.WrapStr("writing interface statistics", err)
+		}
+	}
+	if err := ngw.Flush(); err != nil {
+		return serrors.WrapStr("flushing capture", err)
+	}
+	return nil
+}
+
+// newCaptureWriter writes the section header and the interfaces of a capture.
+func newCaptureWriter(w io.Writer) (*pcapgo.NgWriter, error) {
+	options := pcapgo.DefaultNgWriterOptions
+	options.SectionInfo.Application = "SCION IP Gateway"
+	var ngw *pcapgo.NgWriter
+	for i, intf := range captureInterfaces {
+		intf.OS = pcapgo.DefaultNgInterface.OS
+		intf.TimestampResolution = pcapgo.DefaultNgInterface.TimestampResolution
+		var err error
+		if i == 0 {
+			ngw, err = pcapgo.NewNgWriterInterface(w, intf, options)
+		} else {
+			_, err = ngw.AddInterface(intf)
+		}
+		if err != nil {
+			return nil, serrors.WrapStr("writing capture header", err)
+		}
+	}
+	// Write the header right away, so that the client knows that the capture
+	// started.
+	if err := ngw.Flush(); err != nil {
+		return nil, serrors.WrapStr("writing capture header", err)
+	}
+	return ngw, nil
+}
+
+// encapsulate prepends the SCION and UDP headers to the frame, and the UDP and
+// IP headers of the underlay to the resulting SCION packet.
+func encapsulate(frame []byte, src, dst snet.SCIONAddress, srcPort, dstPort uint16,
+	path spath.Path, underlaySrc, underlayDst *net.UDPAddr) ([]byte, error) {
+
+	pkt := &snet.Packet{
+		Bytes: make(snet.Bytes, len(frame)+maxSCIONHdrLen),
+		PacketInfo: snet.PacketInfo{
+			Source:      src,
+			Destination: dst,
+			Path:        path,
+			Payload: snet.UDPPayload{
+				SrcPort: srcPort,
+				DstPort: dstPort,
+				Payload: frame,
+			},
+		},
+	}
+	if err := pkt.Serialize(); err != nil {
+		return nil, serrors.WrapStr("serializing SCION packet", err)
+	}
+	if underlaySrc == nil || underlayDst == nil {
+		return nil, serrors.New("underlay address missing")
+	}
+	udp := &layers.UDP{
+		SrcPort: layers.UDPPort(underlaySrc.Port),
+		DstPort: layers.UDPPort(underlayDst.Port),
+	}
+	var ip gopacket.NetworkLayer
+	if src4, dst4 := underlaySrc.IP.To4(), underlayDst.IP.To4(); src4 != nil && dst4 != nil {
+		ip = &layers.IPv4{
+			Version:  4,
+			TTL:      64,
+			Protocol: layers.IPProtocolUDP,
+			SrcIP:    src4,
+			DstIP:    dst4,
+		}
+	} else {
+		ip = &layers.IPv6{
+			Version:    6,
+			HopLimit:   64,
+			NextHeader: layers.IPProtocolUDP,
+			SrcIP:      underlaySrc.IP.To16(),
+			DstIP:      underlayDst.IP.To16(),
+		}
+	}
+	if err := udp.SetNetworkLayerForChecksum(ip); err != nil {
+		return nil, serrors.WrapStr("setting network layer", err)
+	}
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{ComputeChecksums: true, FixLengths: true}
+	err := gopacket.SerializeLayers(buf, opts, ip.(gopacket.SerializableLayer), udp,
+		gopacket.Payload(pkt.Bytes))
+	if err != nil {
+		return nil, serrors.WrapStr("serializing underlay", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeIPPacket decodes an IP packet received from the remote gateway.
+func decodeIPPacket(raw []byte) gopacket.Packet {
+	if len(raw) == 0 {
+		return nil
+	}
+	var first gopacket.Decoder = layers.LayerTypeIPv4
+	if raw[0]>>4 == 6 {
+		first = layers.LayerTypeIPv6
+	}
+	return gopacket.NewPacket(raw, first, gopacket.DecodeOptions{NoCopy: true, Lazy: true})
+}
@@ -0,0 +1,252 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/mocks/net/mock_net"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+func TestSessionCapture(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+	remoteIA := xtest.MustParseIA("1-ff00:0:300")
+	remote := &snet.UDPAddr{
+		IA:      remoteIA,
+		Host:    &net.UDPAddr{IP: net.IP{192, 168, 1, 2}, Port: 40000},
+		NextHop: &net.UDPAddr{IP: net.IP{192, 168, 1, 254}, Port: 31000},
+	}
+
+	t.Run("egress", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		frames := make(chan []byte, 1)
+		sess, captures := createCaptureSession(ctrl, localIA, remoteIA, frames)
+		defer sess.Close()
+		require.NoError(t, sess.SetPaths([]snet.Path{createCapturePath(ctrl, remoteIA)}))
+
+		var buf bytes.Buffer
+		done := startCapture(t, sess, captures, &buf, control.CaptureOptions{
+			Frames:     true,
+			MaxPackets: 2,
+		})
+		pkt := createCapturePacket(t, 10)
+		sess.Write(pkt)
+		require.NoError(t, <-done)
+
+		pkts := readCapture(t, &buf)
+		require.Len(t, pkts[captureEgressIP], 1)
+		assert.Equal(t, pkt.Data(), pkts[captureEgressIP][0])
+		require.Len(t, pkts[captureEgressSCION], 1)
+
+		outer := gopacket.NewPacket(pkts[captureEgressSCION][0], layers.LayerTypeIPv4,
+			gopacket.Default)
+		ip := outer.Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+		assert.Equal(t, net.IP{192, 168, 1, 254}, ip.DstIP.To4())
+		var scion slayers.SCION
+		require.NoError(t, scion.DecodeFromBytes(
+			outer.Layer(layers.LayerTypeUDP).LayerPayload(), gopacket.NilDecodeFeedback))
+		assert.Equal(t, localIA, scion.SrcIA)
+		assert.Equal(t, remoteIA, scion.DstIA)
+		assert.Equal(t, <-frames, scion.LayerPayload()[udpHdrLen:])
+	})
+	t.Run("filter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		frames := make(chan []byte, 1)
+		sess, captures := createCaptureSession(ctrl, localIA, remoteIA, frames)
+		defer sess.Close()
+		require.NoError(t, sess.SetPaths([]snet.Path{createCapturePath(ctrl, remoteIA)}))
+
+		filter, err := pktcls.BuildClassTree("dst=10.0.0.0/8")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		done := startCapture(t, sess, captures, &buf, control.CaptureOptions{
+			Filter:     filter,
+			MaxPackets: 1,
+		})
+		sess.Write(createIPv4Packet(t, net.IP{192, 168, 0, 1}, 20))
+		matched := createCapturePacket(t, 30)
+		sess.Write(matched)
+		require.NoError(t, <-done)
+
+		pkts := readCapture(t, &buf)
+		assert.Equal(t, [][]byte{matched.Data()}, pkts[captureEgressIP])
+		assert.Empty(t, pkts[captureEgressSCION])
+	})
+	t.Run("ingress", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sess, captures := createCaptureSession(ctrl, localIA, remoteIA, nil)
+		defer sess.Close()
+
+		var buf bytes.Buffer
+		done := startCapture(t, sess, captures, &buf, control.CaptureOptions{
+			Frames:     true,
+			MaxPackets: 2,
+		})
+		other := *remote
+		other.IA = xtest.MustParseIA("1-ff00:0:400")
+		frame := make([]byte, hdrLen+10)
+		pkt := createCapturePacket(t, 10).Data()
+		captures.receivedFrame(&other, 1, frame)
+		captures.receivedPacket(&other, 1, pkt)
+		// Frames of other sessions with the same remote gateway are not
+		// recorded.
+		captures.receivedFrame(remote, 2, make([]byte, hdrLen+20))
+		captures.receivedPacket(remote, 2, createCapturePacket(t, 20).Data())
+		captures.receivedFrame(remote, 1, frame)
+		captures.receivedPacket(remote, 1, pkt)
+		require.NoError(t, <-done)
+
+		pkts := readCapture(t, &buf)
+		assert.Equal(t, [][]byte{pkt}, pkts[captureIngressIP])
+		require.Len(t, pkts[captureIngressSCION], 1)
+		outer := gopacket.NewPacket(pkts[captureIngressSCION][0], layers.LayerTypeIPv4,
+			gopacket.Default)
+		udp := outer.Layer(layers.LayerTypeUDP).(*layers.UDP)
+		var scion slayers.SCION
+		require.NoError(t, scion.DecodeFromBytes(udp.LayerPayload(),
+			gopacket.NilDecodeFeedback))
+		assert.Equal(t, remoteIA, scion.SrcIA)
+		assert.Equal(t, localIA, scion.DstIA)
+		assert.Equal(t, frame, scion.LayerPayload()[udpHdrLen:])
+	})
+	t.Run("context done", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sess, _ := createCaptureSession(ctrl, localIA, remoteIA, nil)
+		defer sess.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		var buf bytes.Buffer
+		require.NoError(t, sess.Capture(ctx, &buf, control.CaptureOptions{}))
+		assert.Empty(t, readCapture(t, &buf))
+	})
+	t.Run("not supported", func(t *testing.T) {
+		sess := &Session{}
+		err := sess.Capture(context.Background(), ioutil.Discard, control.CaptureOptions{})
+		assert.Equal(t, control.ErrCaptureNotSupported, err)
+	})
+}
+
+func createCaptureSession(ctrl *gomock.Controller, localIA, remoteIA addr.IA,
+	frames chan []byte) (*Session, *Captures) {
+
+	conn := mock_net.NewMockPacketConn(ctrl)
+	conn.EXPECT().LocalAddr().Return(
+		&net.UDPAddr{IP: net.IP{192, 168, 1, 1}, Port: 40000}).AnyTimes()
+	conn.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(f []byte, _ interface{}) (int, error) {
+			frames <- append([]byte(nil), f...)
+			return len(f), nil
+		}).AnyTimes()
+	captures := &Captures{
+		LocalIA:   localIA,
+		LocalAddr: &net.UDPAddr{IP: net.IP{192, 168, 1, 1}, Port: 30056},
+	}
+	sess := &Session{
+		SessionID:     1,
+		RemoteIA:      remoteIA,
+		GatewayAddr:   net.UDPAddr{IP: net.IP{192, 168, 1, 2}, Port: 30056},
+		DataPlaneConn: conn,
+		Captures:      captures,
+	}
+	return sess, captures
+}
+
+func createCapturePath(ctrl *gomock.Controller, dst addr.IA) snet.Path {
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Destination().Return(dst).AnyTimes()
+	path.EXPECT().Metadata().Return(&snet.PathMetadata{MTU: 1472}).AnyTimes()
+	path.EXPECT().Path().Return(spath.Path{Raw: []byte{}}).AnyTimes()
+	path.EXPECT().UnderlayNextHop().Return(
+		&net.UDPAddr{IP: net.IP{192, 168, 1, 254}, Port: 31000}).AnyTimes()
+	path.EXPECT().Copy().Return(path).AnyTimes()
+	return path
+}
+
+func createIPv4Packet(t *testing.T, dst net.IP, payloadLen int) gopacket.Packet {
+	ip := &layers.IPv4{
+		Version: 4,
+		TTL:     64,
+		SrcIP:   net.IP{10, 0, 0, 1},
+		DstIP:   dst,
+	}
+	buf := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		ip, gopacket.Payload(make([]byte, payloadLen)))
+	require.NoError(t, err)
+	return gopacket.NewPacket(buf.Bytes(), layers.LayerTypeIPv4, gopacket.Default)
+}
+
+func createCapturePacket(t *testing.T, payloadLen int) gopacket.Packet {
+	return createIPv4Packet(t, net.IP{10, 0, 0, 2}, payloadLen)
+}
+
+// startCapture starts the capture and waits until it is registered.
+func startCapture(t *testing.T, sess *Session, captures *Captures, w io.Writer,
+	opts control.CaptureOptions) <-chan error {
+
+	done := make(chan error, 1)
+	go func() {
+		done <- sess.Capture(context.Background(), w, opts)
+	}()
+	require.Eventually(t, captures.running, time.Second, time.Millisecond)
+	return done
+}
+
+// readCapture returns the captured packets by interface.
+func readCapture(t *testing.T, r io.Reader) map[int][][]byte {
+	ngr, err := pcapgo.NewNgReader(r, pcapgo.DefaultNgReaderOptions)
+	require.NoError(t, err)
+	pkts := make(map[int][][]byte)
+	for {
+		data, ci, err := ngr.ReadPacketData()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		pkts[ci.InterfaceIndex] = append(pkts[ci.InterfaceIndex], data)
+	}
+	assert.Equal(t, numCaptureInterfaces, ngr.NInterfaces())
+	return pkts
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"time"
+)
+
+const (
+	// DefaultFlowletTimeout is the default idle time after which the packets
+	// of a flow may be moved to a different path.
+	DefaultFlowletTimeout = 50 * time.Millisecond
+	// flowletTableSize is the number of flowlets that are tracked. Flows that
+	// hash to the same entry share the path.
+	flowletTableSize = 4096
+)
+
+type flowlet struct {
+	// index is the index of the path the flowlet is sent on.
+	index int
+	// lastSeen is the time the last packet of the flowlet was sent. The
+	// flowlet is unused if it is zero.
+	lastSeen time.Time
+}
+
+// flowletBalancer splits the traffic across weighted paths. The packets of a
+// flow are sent on the same path as long as they follow each other within the
+// flowlet timeout. After a longer gap, the flow is assigned to the path that is
+// the least loaded relative to its weight. If the timeout exceeds the latency
+// difference between the paths, moving a flow does not reorder its packets.
+type flowletBalancer struct {
+	timeout time.Duration
+	// weights are the relative shares of the traffic of the paths.
+	weights []float64
+	// load is the number of bytes sent on each path since the weights were
+	// last set.
+	load     []float64
+	flowlets [flowletTableSize]flowlet
+}
+
+func newFlowletBalancer(timeout time.Duration) *flowletBalancer {
+	if timeout == 0 {
+		timeout = DefaultFlowletTimeout
+	}
+	return &flowletBalancer{timeout: timeout}
+}
+
+// update sets the weights of the paths. The indices of the paths may change,
+// remap maps the old index of a path to the new one, or to -1 if the path is
+// no longer used. Flowlets on paths that are no longer used are reassigned on
+// their next packet.
+func (b *flowletBalancer) update(weights []float64, remap []int) {
+	b.weights = append(b.weights[:0], weights...)
+	b.load = make([]float64, len(weights))
+	for i := range b.flowlets {
+		f := &b.flowlets[i]
+		if f.lastSeen.IsZero() {
+			continue
+		}
+		if f.index >= len(remap) || remap[f.index] < 0 {
+			*f = flowlet{}
+			continue
+		}
+		f.index = remap[f.index]
+	}
+}
+
+// pick returns the index of the path the packet with the given flow hash and
+// size is sent on.
+func (b *flowletBalancer) pick(hash uint64, size int, now time.Time) int {
+	f := &b.flowlets[hash%flowletTableSize]
+	if f.lastSeen.IsZero() || now.Sub(f.lastSeen) > b.timeout || f.index >= len(b.weights) {
+		f.index = b.leastLoaded(hash)
+	}
+	f.lastSeen = now
+	b.load[f.index] += float64(size)
+	return f.index
+}
+
+// leastLoaded returns the index of the path with the lowest load relative to
+// its weight. Paths without weight are never chosen, unless no path has a
+// weight, in which case the flow is hashed onto one of the paths.
+func (b *flowletBalancer) leastLoaded(hash uint64) int {
+	best := -1
+	for i, w := range b.weights {
+		if w <= 0 {
+			continue
+		}
+		if best == -1 || b.load[i]/w < b.load[best]/b.weights[best] {
+			best = i
+		}
+	}
+	if best == -1 {
+		return int(hash % uint64(len(b.weights)))
+	}
+	return best
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlowletBalancerSplit(t *testing.T) {
+	testCases := map[string]struct {
+		Weights  []float64
+		Expected []float64
+	}{
+		"equal weights": {
+			Weights:  []float64{1, 1},
+			Expected: []float64{0.5, 0.5},
+		},
+		"proportional": {
+			Weights:  []float64{0.75, 0.25},
+			Expected: []float64{0.75, 0.25},
+		},
+		"zero weight": {
+			Weights:  []float64{0, 2, 2},
+			Expected: []float64{0, 0.5, 0.5},
+		},
+		"no weights": {
+			Weights:  []float64{0, 0},
+			Expected: []float64{0.5, 0.5},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			b := newFlowletBalancer(0)
+			b.update(tc.Weights, nil)
+			sent := make([]float64, len(tc.Weights))
+			now := time.Now()
+			for hash := uint64(0); hash < 1000; hash++ {
+				sent[b.pick(hash, 100, now)] += 100
+			}
+			for i := range sent {
+				assert.InDelta(t, tc.Expected[i], sent[i]/100000, 0.01, "path %d", i)
+			}
+		})
+	}
+}
+
+func TestFlowletBalancerPick(t *testing.T) {
+	now := time.Now()
+	b := newFlowletBalancer(10 * time.Millisecond)
+	b.update([]float64{1, 1}, nil)
+
+	// A new flow is assigned to the least loaded path and sticks to it as long
+	// as it is not idle for longer than the timeout.
+	assert.Equal(t, 0, b.pick(1, 1000, now))
+	for i := 0; i < 10; i++ {
+		now = now.Add(5 * time.Millisecond)
+		assert.Equal(t, 0, b.pick(1, 1000, now))
+	}
+	// A second flow goes to the other path, because the first path is loaded.
+	assert.Equal(t, 1, b.pick(2, 100, now))
+
+	// After a gap, the first flow moves to the less loaded path.
+	now = now.Add(20 * time.Millisecond)
+	assert.Equal(t, 1, b.pick(1, 1000, now))
+	assert.Equal(t, 1, b.pick(2, 100, now))
+
+	// Updating the weights keeps the flowlets on the paths that are still
+	// used, even if their index changes.
+	now = now.Add(5 * time.Millisecond)
+	b.update([]float64{1, 1, 1}, []int{2, 0})
+	assert.Equal(t, 0, b.pick(1, 100, now))
+	assert.Equal(t, 0, b.pick(2, 100, now))
+
+	// Flowlets on paths that are no longer used are reassigned.
+	b.update([]float64{1, 1}, []int{-1, 0, 1})
+	assert.True(t, b.flowlets[1].lastSeen.IsZero())
+	assert.True(t, b.flowlets[2].lastSeen.IsZero())
+}
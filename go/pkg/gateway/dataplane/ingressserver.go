@@ -67,6 +67,12 @@ type IngressServer struct {
 	Conn          ReadConn
 	DeviceManager control.DeviceManager
 	Metrics       IngressMetrics
+	// Keys are the keys used to decrypt frames. If set, only encrypted frames
+	// are accepted. Otherwise, only unencrypted frames are accepted.
+	Keys *IngressKeys
+	// Captures are the packet captures the received traffic is recorded to. If
+	// nil, the received traffic cannot be captured.
+	Captures *Captures
 
 	workers map[string]*worker
 }
@@ -100,11 +106,21 @@ func (d *IngressServer) read() error {
 						return serrors.New("frame too short",
 							"expected", sigHdrSize, "actual", read)
 					}
-					if frame.raw[0] != 0 {
+					version := frame.raw[versionPos]
+					if version != frameVersion && version != frameVersionAEAD {
 						metrics.CounterInc(metrics.CounterWith(d.Metrics.FramesDiscarded,
 							"remote_isd_as", v.IA.String(), "reason", "invalid"))
 						return serrors.New("unsupported SIG protocol version",
-							"supported", 0, "actual", frame.raw[0])
+							"supported", []int{frameVersion, frameVersionAEAD},
+							"actual", version)
+					}
+					if (version == frameVersionAEAD) != (d.Keys != nil) {
+						// Frames must be encrypted if and only if encryption
+						// is configured.
+						metrics.CounterInc(metrics.CounterWith(d.Metrics.FramesDiscarded,
+							"remote_isd_as", v.IA.String(), "reason", "encryption_mismatch"))
+						frame.Release()
+						break
 					}
 					frame.frameLen = read
 					frame.sessId = frame.raw[1]
@@ -144,7 +160,8 @@ func (d *IngressServer) dispatch(frame *frameBuf, src *snet.UDPAddr) {
 		}
 		// Handle will be cleaned up when worker goroutine finishes.
 
-		worker = newWorker(src, frame.sessId, handle, metrics)
+		worker = newWorker(src, frame.sessId, handle, d.Keys, metrics)
+		worker.captures = d.Captures
 		d.workers[dispatchStr] = worker
 		go func() {
 			defer log.HandlePanic()
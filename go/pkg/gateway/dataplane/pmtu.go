@@ -0,0 +1,140 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// minIPv4MTU is the smallest MTU an IPv4 link may have (RFC 791).
+	minIPv4MTU = 68
+	// minIPv6MTU is the smallest MTU an IPv6 link may have (RFC 8200). Hosts
+	// ignore Packet Too Big messages with a smaller MTU.
+	minIPv6MTU = 1280
+	// maxICMPv4Len is the maximum length of an ICMPv4 error message including
+	// the IP header (RFC 1812).
+	maxICMPv4Len = 576
+	// packetTooBigInterval is the minimum interval between two ICMP Packet Too
+	// Big messages sent by a session.
+	packetTooBigInterval = 10 * time.Millisecond
+)
+
+// exceedsPathMTU reports whether the IP packet is larger than the MTU and
+// must not be fragmented. IPv4 packets without the DF flag are split across
+// multiple frames instead. Packets are never rejected for an MTU below the
+// minimum MTU of their IP version, because hosts would not adapt to it.
+func exceedsPathMTU(packet gopacket.Packet, mtu int) bool {
+	if mtu == 0 || len(packet.Data()) <= mtu {
+		return false
+	}
+	switch ip := packet.NetworkLayer().(type) {
+	case *layers.IPv4:
+		return ip.Flags&layers.IPv4DontFragment != 0 && mtu >= minIPv4MTU
+	case *layers.IPv6:
+		return mtu >= minIPv6MTU
+	default:
+		return false
+	}
+}
+
+// packetTooBig creates the ICMP Fragmentation Needed (IPv4) or ICMPv6 Packet
+// Too Big message that informs the sender of the packet about the MTU. The
+// message is sent on behalf of the destination of the packet, so that it passes
+// reverse path filtering on the tunnel device. No message is created (nil is
+// returned) if the packet itself is an ICMP error message.
+func packetTooBig(packet gopacket.Packet, mtu int) ([]byte, error) {
+	var msg []gopacket.SerializableLayer
+	switch ip := packet.NetworkLayer().(type) {
+	case *layers.IPv4:
+		if icmp, ok := packet.Layer(layers.LayerTypeICMPv4).(*layers.ICMPv4); ok &&
+			isICMPv4Error(icmp.TypeCode.Type()) {
+			return nil, nil
+		}
+		outer := &layers.IPv4{
+			Version:  4,
+			TTL:      64,
+			Protocol: layers.IPProtocolICMPv4,
+			SrcIP:    ip.DstIP,
+			DstIP:    ip.SrcIP,
+		}
+		icmp := &layers.ICMPv4{
+			TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeDestinationUnreachable,
+				layers.ICMPv4CodeFragmentationNeeded),
+			// The next-hop MTU is encoded in the place of the sequence number.
+			Seq: uint16(mtu),
+		}
+		msg = []gopacket.SerializableLayer{outer, icmp,
+			gopacket.Payload(quote(packet.Data(), maxICMPv4Len-20-8))}
+	case *layers.IPv6:
+		if icmp, ok := packet.Layer(layers.LayerTypeICMPv6).(*layers.ICMPv6); ok &&
+			icmp.TypeCode.Type() < 128 {
+			// ICMPv6 types below 128 are error messages.
+			return nil, nil
+		}
+		outer := &layers.IPv6{
+			Version:    6,
+			HopLimit:   64,
+			NextHeader: layers.IPProtocolICMPv6,
+			SrcIP:      ip.DstIP,
+			DstIP:      ip.SrcIP,
+		}
+		icmp := &layers.ICMPv6{
+			TypeCode: layers.CreateICMPv6TypeCode(layers.ICMPv6TypePacketTooBig, 0),
+		}
+		if err := icmp.SetNetworkLayerForChecksum(outer); err != nil {
+			return nil, err
+		}
+		payload := make([]byte, 4, minIPv6MTU-40-4)
+		binary.BigEndian.PutUint32(payload, uint32(mtu))
+		payload = append(payload, quote(packet.Data(), cap(payload)-4)...)
+		msg = []gopacket.SerializableLayer{outer, icmp, gopacket.Payload(payload)}
+	default:
+		return nil, serrors.New("unsupported network layer",
+			"type", packet.NetworkLayer().LayerType())
+	}
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{ComputeChecksums: true, FixLengths: true}
+	if err := gopacket.SerializeLayers(buf, opts, msg...); err != nil {
+		return nil, serrors.WrapStr("serializing ICMP message", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func isICMPv4Error(t uint8) bool {
+	switch t {
+	case layers.ICMPv4TypeDestinationUnreachable, layers.ICMPv4TypeSourceQuench,
+		layers.ICMPv4TypeRedirect, layers.ICMPv4TypeTimeExceeded,
+		layers.ICMPv4TypeParameterProblem:
+		return true
+	default:
+		return false
+	}
+}
+
+// quote returns the beginning of the packet that is included in an ICMP error
+// message.
+func quote(data []byte, max int) []byte {
+	if len(data) > max {
+		return data[:max]
+	}
+	return data
+}
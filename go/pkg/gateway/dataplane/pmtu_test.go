@@ -0,0 +1,230 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+func TestExceedsPathMTU(t *testing.T) {
+	testCases := map[string]struct {
+		Packet   gopacket.Packet
+		MTU      int
+		Expected bool
+	}{
+		"no paths": {
+			Packet: createPMTUIPv4Packet(t, 1500, true),
+			MTU:    0,
+		},
+		"fits": {
+			Packet: createPMTUIPv4Packet(t, 1400, true),
+			MTU:    1400,
+		},
+		"IPv4 DF": {
+			Packet:   createPMTUIPv4Packet(t, 1401, true),
+			MTU:      1400,
+			Expected: true,
+		},
+		"IPv4 without DF": {
+			Packet: createPMTUIPv4Packet(t, 1401, false),
+			MTU:    1400,
+		},
+		"IPv6": {
+			Packet:   createPMTUIPv6Packet(t, 1401, layers.IPProtocolUDP),
+			MTU:      1400,
+			Expected: true,
+		},
+		"IPv6 below minimum MTU": {
+			Packet: createPMTUIPv6Packet(t, 1401, layers.IPProtocolUDP),
+			MTU:    1200,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.Expected, exceedsPathMTU(tc.Packet, tc.MTU))
+		})
+	}
+}
+
+func TestPacketTooBig(t *testing.T) {
+	t.Run("IPv4", func(t *testing.T) {
+		pkt := createPMTUIPv4Packet(t, 1500, true)
+		raw, err := packetTooBig(pkt, 1400)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(raw), maxICMPv4Len)
+
+		msg := gopacket.NewPacket(raw, layers.LayerTypeIPv4, gopacket.Default)
+		require.Nil(t, msg.ErrorLayer())
+		ip := msg.Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+		assert.Equal(t, net.IP{10, 0, 0, 2}, ip.SrcIP.To4())
+		assert.Equal(t, net.IP{10, 0, 0, 1}, ip.DstIP.To4())
+		icmp := msg.Layer(layers.LayerTypeICMPv4).(*layers.ICMPv4)
+		assert.Equal(t, layers.CreateICMPv4TypeCode(layers.ICMPv4TypeDestinationUnreachable,
+			layers.ICMPv4CodeFragmentationNeeded), icmp.TypeCode)
+		assert.Equal(t, uint16(1400), icmp.Seq)
+		assert.Equal(t, pkt.Data()[:len(icmp.Payload)], icmp.Payload)
+	})
+	t.Run("IPv6", func(t *testing.T) {
+		pkt := createPMTUIPv6Packet(t, 1500, layers.IPProtocolUDP)
+		raw, err := packetTooBig(pkt, 1400)
+		require.NoError(t, err)
+		assert.Equal(t, minIPv6MTU, len(raw))
+
+		msg := gopacket.NewPacket(raw, layers.LayerTypeIPv6, gopacket.Default)
+		ip := msg.Layer(layers.LayerTypeIPv6).(*layers.IPv6)
+		assert.Equal(t, net.ParseIP("2001:db8::2"), ip.SrcIP)
+		assert.Equal(t, net.ParseIP("2001:db8::1"), ip.DstIP)
+		icmp := msg.Layer(layers.LayerTypeICMPv6).(*layers.ICMPv6)
+		assert.Equal(t, layers.CreateICMPv6TypeCode(layers.ICMPv6TypePacketTooBig, 0),
+			icmp.TypeCode)
+		assert.Equal(t, uint32(1400), binary.BigEndian.Uint32(icmp.Payload[:4]))
+		assert.Equal(t, pkt.Data()[:len(icmp.Payload)-4], icmp.Payload[4:])
+	})
+	t.Run("ICMP error", func(t *testing.T) {
+		pkt := createPMTUIPv6Packet(t, 1500, layers.IPProtocolICMPv6)
+		raw, err := packetTooBig(pkt, 1400)
+		require.NoError(t, err)
+		assert.Nil(t, raw)
+	})
+}
+
+func TestSessionEnforcePathMTU(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	frameChan := make(chan []byte, 10)
+	sess := createSession(t, ctrl, frameChan)
+	tooBig := metrics.NewTestCounter()
+	sess.Metrics.IPPktsTooBig = tooBig
+	var icmp bytes.Buffer
+	sess.EnforcePathMTU(&icmp)
+	require.NoError(t, sess.SetPaths([]snet.Path{
+		createMockPath(ctrl, 1400),
+		createMockPath(ctrl, 1300),
+	}))
+	defer sess.Close()
+	mtu := sess.packetMTU
+	assert.Equal(t, 1300-12-2*8-4-udpHdrLen-hdrLen, mtu)
+
+	// A packet that fits is sent.
+	sess.Write(createPMTUIPv4Packet(t, mtu, true))
+	<-frameChan
+	assert.Zero(t, icmp.Len())
+
+	// A packet that does not fit is rejected. The second one is not answered
+	// because of the rate limit.
+	sess.Write(createPMTUIPv4Packet(t, mtu+1, true))
+	sess.Write(createPMTUIPv4Packet(t, mtu+1, true))
+	assert.Equal(t, float64(2), metrics.CounterValue(tooBig))
+	msg := gopacket.NewPacket(icmp.Bytes(), layers.LayerTypeIPv4, gopacket.Default)
+	require.NotNil(t, msg.Layer(layers.LayerTypeICMPv4))
+	assert.Equal(t, uint16(mtu), msg.Layer(layers.LayerTypeICMPv4).(*layers.ICMPv4).Seq)
+
+	// A packet without DF is split across frames instead.
+	sess.Write(createPMTUIPv4Packet(t, mtu+1, false))
+	<-frameChan
+	<-frameChan
+}
+
+func TestSessionPathMTUChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	frameChan := make(chan []byte, 10)
+	sess := createSession(t, ctrl, frameChan)
+	var icmp bytes.Buffer
+	sess.EnforcePathMTU(&icmp)
+	require.NoError(t, sess.SetPaths([]snet.Path{createMockPath(ctrl, 1400)}))
+	defer sess.Close()
+	oldMTU := sess.packetMTU
+	pkt := createPMTUIPv4Packet(t, oldMTU, false)
+
+	sess.Write(pkt)
+	assert.Len(t, <-frameChan, hdrLen+oldMTU)
+
+	// The same path with a smaller MTU replaces the sender, the frames are
+	// sized for the new MTU.
+	require.NoError(t, sess.SetPaths([]snet.Path{createMockPath(ctrl, 1300)}))
+	newMTU := sess.packetMTU
+	assert.Equal(t, oldMTU-100, newMTU)
+	sess.Write(pkt)
+	var sent int
+	for sent < oldMTU {
+		frame := <-frameChan
+		assert.LessOrEqual(t, len(frame), hdrLen+newMTU)
+		sent += len(frame) - hdrLen
+	}
+	assert.Equal(t, oldMTU, sent)
+
+	// Packets that must not be fragmented are answered with the new MTU.
+	sess.Write(createPMTUIPv4Packet(t, oldMTU, true))
+	msg := gopacket.NewPacket(icmp.Bytes(), layers.LayerTypeIPv4, gopacket.Default)
+	require.NotNil(t, msg.Layer(layers.LayerTypeICMPv4))
+	assert.Equal(t, uint16(newMTU), msg.Layer(layers.LayerTypeICMPv4).(*layers.ICMPv4).Seq)
+}
+
+func createPMTUIPv4Packet(t *testing.T, length int, df bool) gopacket.Packet {
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolUDP,
+		SrcIP:    net.IP{10, 0, 0, 1},
+		DstIP:    net.IP{10, 0, 0, 2},
+	}
+	if df {
+		ip.Flags = layers.IPv4DontFragment
+	}
+	buf := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		ip, gopacket.Payload(make([]byte, length-20)))
+	require.NoError(t, err)
+	return gopacket.NewPacket(buf.Bytes(), layers.LayerTypeIPv4, gopacket.Default)
+}
+
+func createPMTUIPv6Packet(t *testing.T, length int,
+	proto layers.IPProtocol) gopacket.Packet {
+
+	ip := &layers.IPv6{
+		Version:    6,
+		HopLimit:   64,
+		NextHeader: proto,
+		SrcIP:      net.ParseIP("2001:db8::1"),
+		DstIP:      net.ParseIP("2001:db8::2"),
+	}
+	payload := make([]byte, length-40)
+	if proto == layers.IPProtocolICMPv6 {
+		// Destination unreachable.
+		payload[0] = 1
+	}
+	buf := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		ip, gopacket.Payload(payload))
+	require.NoError(t, err)
+	return gopacket.NewPacket(buf.Bytes(), layers.LayerTypeIPv6, gopacket.Default)
+}
@@ -0,0 +1,316 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+const (
+	// DefaultQueueLength is the default maximum number of packets in a QoS
+	// queue.
+	DefaultQueueLength = 1024
+	// DefaultBurst is the default number of bytes that can be sent in excess
+	// of the rate limit.
+	DefaultBurst = 64 * 1024
+	// drrQuantum is the number of bytes a queue with weight 1 may send per
+	// round among the queues with the same priority.
+	drrQuantum = 1500
+)
+
+// qosQueue is a bounded FIFO queue of IP packets.
+type qosQueue struct {
+	name    string
+	matcher pktcls.Cond
+	// quantum is the number of bytes that is added to the deficit in each
+	// round.
+	quantum int
+	// deficit is the number of bytes the queue may still send in the current
+	// round.
+	deficit int
+	length  int
+	packets []gopacket.Packet
+	drops   metrics.Counter
+	// level is the level the queue belongs to.
+	level *qosLevel
+}
+
+func (q *qosQueue) push(pkt gopacket.Packet) bool {
+	if len(q.packets) >= q.length {
+		return false
+	}
+	q.packets = append(q.packets, pkt)
+	return true
+}
+
+func (q *qosQueue) pop() gopacket.Packet {
+	pkt := q.packets[0]
+	q.packets[0] = nil
+	q.packets = q.packets[1:]
+	if len(q.packets) == 0 {
+		// Release the backing array, it would otherwise only grow.
+		q.packets = nil
+	}
+	return pkt
+}
+
+// qosLevel contains the queues with the same priority. The queues are served
+// with deficit round robin in proportion to their weights.
+type qosLevel struct {
+	queues []*qosQueue
+	// next is the index of the queue that is currently served.
+	next int
+	// credited indicates whether the queue that is currently served already
+	// got its quantum in this round.
+	credited bool
+	// size is the number of packets in all the queues.
+	size int
+}
+
+func (l *qosLevel) pop() gopacket.Packet {
+	for {
+		q := l.queues[l.next]
+		if len(q.packets) > 0 {
+			if !l.credited {
+				q.deficit += q.quantum
+				l.credited = true
+			}
+			if length := len(q.packets[0].Data()); length <= q.deficit {
+				q.deficit -= length
+				l.size--
+				return q.pop()
+			}
+		} else {
+			// Empty queues must not accumulate credit.
+			q.deficit = 0
+		}
+		l.credited = false
+		l.next = (l.next + 1) % len(l.queues)
+	}
+}
+
+// tokenBucket limits the rate at which bytes are sent.
+type tokenBucket struct {
+	// rate is the rate in bytes per second.
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rateLimit uint64, burst int, now time.Time) *tokenBucket {
+	if burst == 0 {
+		burst = DefaultBurst
+	}
+	return &tokenBucket{
+		rate:   float64(rateLimit) / 8,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now,
+	}
+}
+
+// reserve takes the tokens for a packet of the given size and returns how long
+// the caller has to wait before sending it. The bucket may go into debt, which
+// delays the subsequent packets.
+func (b *tokenBucket) reserve(size int, now time.Time) time.Duration {
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+	b.tokens -= float64(size)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// shaper queues the packets of a session according to their traffic class,
+// and hands them to the session in priority order and at the configured rate.
+type shaper struct {
+	mtx  sync.Mutex
+	cond *sync.Cond
+	// queues are the queues in the order in which the packets are matched.
+	// The last queue is the default queue.
+	queues []*qosQueue
+	// levels are the queues grouped by priority, highest priority first.
+	levels []*qosLevel
+	// bucket is nil if the rate is not limited.
+	bucket *tokenBucket
+	closed bool
+	// send is called for every packet that leaves the shaper. It is called
+	// without holding the lock of the shaper.
+	send func(gopacket.Packet)
+}
+
+// newShaper creates a shaper. The shaper does not send any packet until run is
+// called. Drops are counted in the drops counter, labeled with the name of the
+// queue.
+func newShaper(policy control.QoSPolicy, drops metrics.Counter,
+	send func(gopacket.Packet)) (*shaper, error) {
+
+	type prioQueue struct {
+		priority int
+		queue    *qosQueue
+	}
+	queuePolicies := make([]control.QueuePolicy, 0, len(policy.Queues)+1)
+	queuePolicies = append(queuePolicies, policy.Queues...)
+	queuePolicies = append(queuePolicies, control.QueuePolicy{
+		Name:           control.DefaultQueueName,
+		TrafficMatcher: pktcls.CondTrue,
+	})
+	var queues []*qosQueue
+	var sorted []prioQueue
+	names := make(map[string]struct{}, len(queuePolicies))
+	for _, qp := range queuePolicies {
+		if qp.TrafficMatcher == nil {
+			return nil, serrors.New("queue without traffic matcher", "name", qp.Name)
+		}
+		if _, ok := names[qp.Name]; ok {
+			return nil, serrors.New("duplicate queue name", "name", qp.Name)
+		}
+		names[qp.Name] = struct{}{}
+		if qp.Weight < 0 || qp.Length < 0 {
+			return nil, serrors.New("queue weight and length must not be negative",
+				"name", qp.Name, "weight", qp.Weight, "length", qp.Length)
+		}
+		weight, length := qp.Weight, qp.Length
+		if weight == 0 {
+			weight = 1
+		}
+		if length == 0 {
+			length = DefaultQueueLength
+		}
+		q := &qosQueue{
+			name:    qp.Name,
+			matcher: qp.TrafficMatcher,
+			quantum: weight * drrQuantum,
+			length:  length,
+		}
+		if drops != nil {
+			q.drops = metrics.CounterWith(drops, "queue", qp.Name)
+		}
+		queues = append(queues, q)
+		sorted = append(sorted, prioQueue{priority: qp.Priority, queue: q})
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].priority > sorted[j].priority
+	})
+	var levels []*qosLevel
+	for i, pq := range sorted {
+		if i == 0 || pq.priority != sorted[i-1].priority {
+			levels = append(levels, &qosLevel{})
+		}
+		level := levels[len(levels)-1]
+		level.queues = append(level.queues, pq.queue)
+		pq.queue.level = level
+	}
+
+	s := &shaper{
+		queues: queues,
+		levels: levels,
+		send:   send,
+	}
+	if policy.RateLimit != 0 {
+		if policy.Burst < 0 {
+			return nil, serrors.New("burst must not be negative", "burst", policy.Burst)
+		}
+		s.bucket = newTokenBucket(policy.RateLimit, policy.Burst, time.Now())
+	}
+	s.cond = sync.NewCond(&s.mtx)
+	return s, nil
+}
+
+// Write puts the packet in the first queue it matches. If the queue is full,
+// the packet is dropped. Write never blocks.
+func (s *shaper) Write(pkt gopacket.Packet) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.closed {
+		return
+	}
+	layer := pkt.NetworkLayer()
+	for i, q := range s.queues {
+		// The default queue is last and matches everything.
+		if i != len(s.queues)-1 && !q.matcher.Eval(layer) {
+			continue
+		}
+		if !q.push(pkt) {
+			increaseCounterMetric(q.drops, 1)
+			return
+		}
+		q.level.size++
+		s.cond.Signal()
+		return
+	}
+}
+
+// next removes the next packet from the queues. It returns nil if no packet is
+// queued. It must be called with the lock held.
+func (s *shaper) next() gopacket.Packet {
+	for _, l := range s.levels {
+		if l.size > 0 {
+			return l.pop()
+		}
+	}
+	return nil
+}
+
+// run sends the queued packets until the shaper is closed.
+func (s *shaper) run() {
+	for {
+		s.mtx.Lock()
+		pkt := s.next()
+		for pkt == nil && !s.closed {
+			s.cond.Wait()
+			pkt = s.next()
+		}
+		if s.closed {
+			s.mtx.Unlock()
+			return
+		}
+		var delay time.Duration
+		if s.bucket != nil {
+			delay = s.bucket.reserve(len(pkt.Data()), time.Now())
+		}
+		s.mtx.Unlock()
+
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+		s.send(pkt)
+	}
+}
+
+// Close stops the shaper. The queued packets are discarded.
+func (s *shaper) Close() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.closed = true
+	s.queues, s.levels = nil, nil
+	s.cond.Broadcast()
+}
@@ -0,0 +1,199 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataplane
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+func TestShaperPriority(t *testing.T) {
+	s, err := newShaper(control.QoSPolicy{
+		Queues: []control.QueuePolicy{
+			{
+				Name:           "voice",
+				TrafficMatcher: pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x2e}),
+				Priority:       1,
+			},
+			{
+				Name:           "bulk",
+				TrafficMatcher: pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x08}),
+				Priority:       -1,
+			},
+		},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	s.Write(createQoSPacket(0x08, 100))
+	s.Write(createQoSPacket(0x00, 100))
+	s.Write(createQoSPacket(0x2e, 100))
+	s.Write(createQoSPacket(0x08, 100))
+	s.Write(createQoSPacket(0x2e, 100))
+
+	var dscps []uint8
+	for pkt := s.next(); pkt != nil; pkt = s.next() {
+		dscps = append(dscps, pkt.NetworkLayer().(*layers.IPv4).TOS>>2)
+	}
+	assert.Equal(t, []uint8{0x2e, 0x2e, 0x00, 0x08, 0x08}, dscps)
+}
+
+func TestShaperWeights(t *testing.T) {
+	s, err := newShaper(control.QoSPolicy{
+		Queues: []control.QueuePolicy{
+			{
+				Name:           "small",
+				TrafficMatcher: pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x01}),
+				Weight:         1,
+			},
+			{
+				Name:           "large",
+				TrafficMatcher: pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x02}),
+				Weight:         3,
+			},
+		},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	// The small queue has smaller packets, the split is by bytes nonetheless.
+	for i := 0; i < 1000; i++ {
+		s.Write(createQoSPacket(0x01, 200))
+		s.Write(createQoSPacket(0x01, 200))
+		s.Write(createQoSPacket(0x02, 400))
+	}
+	sent := map[uint8]int{}
+	for i := 0; i < 1000; i++ {
+		pkt := s.next()
+		sent[pkt.NetworkLayer().(*layers.IPv4).TOS>>2] += len(pkt.Data())
+	}
+	total := float64(sent[0x01] + sent[0x02])
+	assert.InDelta(t, 0.25, float64(sent[0x01])/total, 0.02)
+	assert.InDelta(t, 0.75, float64(sent[0x02])/total, 0.02)
+}
+
+func TestShaperDrops(t *testing.T) {
+	drops := metrics.NewTestCounter()
+	s, err := newShaper(control.QoSPolicy{
+		Queues: []control.QueuePolicy{
+			{
+				Name:           "voice",
+				TrafficMatcher: pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x2e}),
+				Length:         2,
+			},
+		},
+	}, drops, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		s.Write(createQoSPacket(0x2e, 100))
+	}
+	s.Write(createQoSPacket(0x00, 100))
+	assert.Equal(t, float64(3), metrics.CounterValue(drops.With("queue", "voice")))
+	assert.Equal(t, float64(0),
+		metrics.CounterValue(drops.With("queue", control.DefaultQueueName)))
+}
+
+func TestNewShaperErrors(t *testing.T) {
+	testCases := map[string]control.QoSPolicy{
+		"no matcher": {
+			Queues: []control.QueuePolicy{{Name: "voice"}},
+		},
+		"duplicate name": {
+			Queues: []control.QueuePolicy{
+				{Name: "voice", TrafficMatcher: pktcls.CondTrue},
+				{Name: "voice", TrafficMatcher: pktcls.CondTrue},
+			},
+		},
+		"default name": {
+			Queues: []control.QueuePolicy{
+				{Name: control.DefaultQueueName, TrafficMatcher: pktcls.CondTrue},
+			},
+		},
+		"negative weight": {
+			Queues: []control.QueuePolicy{
+				{Name: "voice", TrafficMatcher: pktcls.CondTrue, Weight: -1},
+			},
+		},
+		"negative burst": {
+			RateLimit: 1000,
+			Burst:     -1,
+		},
+	}
+	for name, policy := range testCases {
+		name, policy := name, policy
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := newShaper(policy, nil, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	// 8000 bits per second is 1000 bytes per second.
+	b := newTokenBucket(8000, 1500, now)
+
+	// The burst can be sent right away.
+	assert.Equal(t, time.Duration(0), b.reserve(1000, now))
+	assert.Equal(t, time.Duration(0), b.reserve(500, now))
+	// The next packet has to wait until the bucket is refilled.
+	assert.Equal(t, 100*time.Millisecond, b.reserve(100, now))
+	// The debt delays the subsequent packets, the next one is sent after 200ms.
+	assert.Equal(t, 100*time.Millisecond, b.reserve(100, now.Add(100*time.Millisecond)))
+	// The bucket does not fill beyond the burst.
+	assert.Equal(t, time.Duration(0), b.reserve(1500, now.Add(time.Hour)))
+	assert.Equal(t, 1*time.Millisecond, b.reserve(1, now.Add(time.Hour)))
+}
+
+func TestSessionQoS(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	frameChan := make(chan ([]byte))
+	sess := createSession(t, ctrl, frameChan)
+	err := sess.SetQoS(control.QoSPolicy{
+		RateLimit: 100000,
+		Queues: []control.QueuePolicy{
+			{Name: "all", TrafficMatcher: pktcls.CondTrue},
+		},
+	})
+	require.NoError(t, err)
+	sess.SetPaths([]snet.Path{createMockPath(ctrl, 200)})
+	sendPackets(t, sess, 22, 10)
+	waitFrames(t, frameChan, 22, 10)
+	sess.Close()
+}
+
+func createQoSPacket(dscp uint8, length int) gopacket.Packet {
+	bytes := append([]byte{
+		// IPv4 header.
+		0x45, dscp << 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	}, make([]byte, length-20)...)
+	return gopacket.NewPacket(bytes, layers.LayerTypeIPv4, gopacket.NoCopy)
+}
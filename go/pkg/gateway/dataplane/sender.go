@@ -40,23 +40,37 @@ type sender struct {
 	path               snet.Path
 	pathFingerprint    snet.PathFingerprint
 	metrics            SessionMetrics
+	// key is the key used to encrypt the frames. If nil, frames are sent
+	// unencrypted.
+	key *egressKey
+	// sealed is the buffer encrypted frames are written to.
+	sealed []byte
+	// session is the session the sender belongs to. The frames that are sent
+	// are recorded to the packet captures of the session.
+	session *Session
+	// packetMTU is the size of the largest IP packet that fits into a single
+	// frame on the path.
+	packetMTU int
 }
 
-func newSender(sessID uint8, conn net.PacketConn, path snet.Path,
+func newSender(sessID uint8, streamID uint32, conn net.PacketConn, path snet.Path,
 	gatewayAddr net.UDPAddr, pathStatsPublisher PathStatsPublisher,
-	metrics SessionMetrics) (*sender, error) {
+	metrics SessionMetrics, key *egressKey, session *Session) (*sender, error) {
 
 	// MTU must account for the size of the SCION header.
 	localAddr := conn.LocalAddr().(*net.UDPAddr)
 	addrLen := addr.IABytes*2 + len(localAddr.IP) + len(gatewayAddr.IP)
 	pathLen := len(path.Path().Raw)
 	mtu := int(path.Metadata().MTU) - slayers.CmnHdrLen - addrLen - pathLen - udpHdrLen
+	if key != nil {
+		mtu -= aeadOverhead
+	}
 	if mtu < minMTU {
 		return nil, serrors.New("insufficient MTU", "mtu", mtu, "minMTU", minMTU)
 	}
 
 	c := &sender{
-		encoder: newEncoder(sessID, NewStreamID(), uint16(mtu)),
+		encoder: newEncoder(sessID, streamID, uint16(mtu)),
 		conn:    conn,
 		address: &snet.UDPAddr{
 			IA:      path.Destination(),
@@ -68,6 +82,12 @@ func newSender(sessID uint8, conn net.PacketConn, path snet.Path,
 		path:               path,
 		pathFingerprint:    snet.Fingerprint(path),
 		metrics:            metrics,
+		key:                key,
+		session:            session,
+		packetMTU:          mtu - hdrLen,
+	}
+	if key != nil {
+		c.sealed = make([]byte, 0, mtu+aeadOverhead)
 	}
 	go func() {
 		defer log.HandlePanic()
@@ -97,11 +117,24 @@ func (c *sender) run() {
 			// Sender was closed and all the buffered frames were sent.
 			break
 		}
+		if c.key != nil {
+			cipher := c.key.get()
+			if cipher == nil {
+				// The key exchange has not completed yet. Frames of encrypted
+				// sessions are never sent in the clear.
+				continue
+			}
+			frame = cipher.Seal(c.sealed[:0], frame)
+		}
 		_, err := c.conn.WriteTo(frame, c.address)
 		if err != nil {
 			increaseCounterMetric(c.metrics.SendExternalErrors, 1)
 			continue
 		}
+		if c.session != nil {
+			c.session.Captures.sentFrame(c.session, frame,
+				c.conn.LocalAddr().(*net.UDPAddr), c.address.(*snet.UDPAddr), c.path)
+		}
 		increaseCounterMetric(c.metrics.FramesSent, 1)
 		increaseCounterMetric(c.metrics.FrameBytesSent, float64(len(frame)))
 
@@ -107,7 +107,8 @@ func TestSender(t *testing.T) {
 				IP:   net.IP{192, 168, 1, 2},
 				Port: 30041,
 			}
-			c, err := newSender(1, conn, createMockPath(ctrl, 256), addr, nil, SessionMetrics{})
+			c, err := newSender(1, NewStreamID(), conn, createMockPath(ctrl, 256), addr, nil,
+				SessionMetrics{}, nil, nil)
 			require.NoError(t, err)
 			defer c.Close()
 			if test.ExpFrames != 0 {
@@ -18,16 +18,22 @@ import (
 	"encoding/binary"
 	"fmt"
 	"hash/crc64"
+	"io"
 	"net"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
 
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
 )
 
 var (
@@ -51,43 +57,178 @@ type SessionMetrics struct {
 	FrameBytesSent metrics.Counter
 	// SendExternalError is the error count when sending frames to the external network.
 	SendExternalErrors metrics.Counter
+	// IPPktsQueueDropped is the count of IP packets dropped because their QoS
+	// queue was full. The session adds the label "queue".
+	IPPktsQueueDropped metrics.Counter
+	// IPPktsTooBig is the count of IP packets dropped because they exceed the
+	// path MTU and must not be fragmented.
+	IPPktsTooBig metrics.Counter
 }
 
 type Session struct {
-	SessionID          uint8
+	SessionID uint8
+	// RemoteIA is the ISD-AS of the remote gateway.
+	RemoteIA           addr.IA
 	GatewayAddr        net.UDPAddr
 	DataPlaneConn      net.PacketConn
 	PathStatsPublisher PathStatsPublisher
 	Metrics            SessionMetrics
+	// Encrypt indicates whether the frames of the session are encrypted. If
+	// set, no frames are sent until a key is set with SetKey.
+	Encrypt bool
+	// FlowletTimeout is the idle time after which the packets of a flow may be
+	// moved to a different path if the paths are weighted. It should exceed
+	// the latency difference between the paths, otherwise packets may be
+	// reordered. If zero, DefaultFlowletTimeout is used.
+	FlowletTimeout time.Duration
+	// Captures are the packet captures of the gateway. If nil, the traffic of
+	// the session cannot be captured.
+	Captures *Captures
 
 	mutex sync.Mutex
 	// senders is a list of currently used senders.
 	senders []*sender
+	// balancer splits the flows across the senders according to their
+	// weights. If nil, each flow is hashed onto one of the senders.
+	balancer *flowletBalancer
+	// streamID is the stream ID of the most recently created sender. It is
+	// only valid if streamIDSet is true.
+	streamID    uint32
+	streamIDSet bool
+	// key is the key used to encrypt the frames if Encrypt is set.
+	key egressKey
+	// shaper queues and rate limits the packets. If nil, the packets are
+	// sent right away.
+	shaper *shaper
+	// packetMTU is the size of the largest IP packet that fits into a single
+	// frame on all the paths. It is zero if there are no paths.
+	packetMTU int
+	// tooBigWriter is where the ICMP Packet Too Big messages are written to.
+	// If nil, the path MTU is not enforced.
+	tooBigWriter io.Writer
+	// lastTooBig is the time the last ICMP Packet Too Big message was sent.
+	lastTooBig time.Time
+}
+
+// SetKey sets the key that is used to encrypt the frames of the session. Frames
+// that are already encrypted with the previous key are still sent.
+func (s *Session) SetKey(key []byte) error {
+	c, err := NewFrameCipher(key)
+	if err != nil {
+		return err
+	}
+	s.key.set(c)
+	return nil
+}
+
+// SetQoS sets the rate limit and the queues of the session. The packets that
+// are queued with the previous policy are discarded.
+func (s *Session) SetQoS(policy control.QoSPolicy) error {
+	shaper, err := newShaper(policy, s.Metrics.IPPktsQueueDropped, s.send)
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.shaper != nil {
+		s.shaper.Close()
+	}
+	s.shaper = shaper
+	go func() {
+		defer log.HandlePanic()
+		shaper.run()
+	}()
+	return nil
+}
+
+// EnforcePathMTU makes the session drop the IP packets that do not fit into a
+// single frame on all of its paths and must not be fragmented, i.e., IPv6
+// packets and IPv4 packets with the DF flag. For each dropped packet, an ICMP
+// Packet Too Big message with the MTU is written to w, which is typically the
+// tunnel device, so that the sender adapts its packet size. The messages are
+// rate limited. The MTU follows the paths set with SetPaths. The MTU of the
+// tunnel device is left unchanged, since the device is shared by all sessions.
+func (s *Session) EnforcePathMTU(w io.Writer) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tooBigWriter = w
 }
 
 // Close signals that the session should close up its internal Connections. Close returns as
-// soon as forwarding goroutines are signaled to shut down (never blocks).
+// soon as forwarding goroutines are signaled to shut down (never blocks). Packets that are
+// still queued by the QoS policy are discarded.
 func (s *Session) Close() {
+	s.mutex.Lock()
+	if s.shaper != nil {
+		s.shaper.Close()
+	}
+	s.mutex.Unlock()
 	for _, snd := range s.senders {
 		snd.Close()
 	}
 }
 
-// Write encodes the packet and sends it to the network.
+// Write encodes the packet and sends it to the network. If a QoS policy is
+// set, the packet is queued first.
 // The packet may be silently dropped.
 func (s *Session) Write(packet gopacket.Packet) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	if s.tooBigWriter != nil && exceedsPathMTU(packet, s.packetMTU) {
+		s.rejectTooBig(packet)
+		return
+	}
+	if s.shaper != nil {
+		s.shaper.Write(packet)
+		return
+	}
+	s.write(packet)
+}
+
+// rejectTooBig sends an ICMP Packet Too Big message for the packet, unless
+// one was sent very recently. It must be called with the lock held.
+func (s *Session) rejectTooBig(packet gopacket.Packet) {
+	increaseCounterMetric(s.Metrics.IPPktsTooBig, 1)
+	now := time.Now()
+	if now.Sub(s.lastTooBig) < packetTooBigInterval {
+		return
+	}
+	msg, err := packetTooBig(packet, s.packetMTU)
+	if err != nil || msg == nil {
+		return
+	}
+	s.lastTooBig = now
+	if _, err := s.tooBigWriter.Write(msg); err != nil {
+		log.Debug("Unable to send ICMP Packet Too Big", "session_id", s.SessionID, "err", err)
+	}
+}
+
+// send hands a packet that leaves the shaper to one of the senders.
+func (s *Session) send(packet gopacket.Packet) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.write(packet)
+}
+
+// write hands the packet to one of the senders. It must be called with the
+// lock held.
+func (s *Session) write(packet gopacket.Packet) {
 	if len(s.senders) == 0 {
 		return
 	}
+	s.Captures.sentPacket(s, packet)
 	if len(s.senders) == 1 {
 		s.senders[0].Write(packet.Data())
 		return
 	}
 	// Choose the path based on the packet's quintuple.
 	hash := crc64.Checksum(extractQuintuple(packet), crcTable)
+	if s.balancer != nil {
+		index := s.balancer.pick(hash, len(packet.Data()), time.Now())
+		s.senders[index].Write(packet.Data())
+		return
+	}
 	index := hash % uint64(len(s.senders))
 	s.senders[index].Write(packet.Data())
 }
@@ -96,8 +237,11 @@ func (s *Session) String() string {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 	res := fmt.Sprintf("ID: %d", s.SessionID)
-	for _, snd := range s.senders {
+	for i, snd := range s.senders {
 		res += fmt.Sprintf("\n    %v", snd.path)
+		if s.balancer != nil {
+			res += fmt.Sprintf(" Weight: %.2f", s.balancer.weights[i])
+		}
 	}
 	return res
 }
@@ -114,6 +258,25 @@ func (s *Session) String() string {
 // ID causes creation of new reassemby queue on the remote side, thus avoiding the
 // reordering issues.
 func (s *Session) SetPaths(paths []snet.Path) error {
+	return s.SetWeightedPaths(paths, nil)
+}
+
+// SetWeightedPaths sets the paths for subsequent packets encapsulated by the
+// session, and splits the traffic across the paths in proportion to the
+// weights. The weights are in the same order as the paths. If weights is nil,
+// each flow is hashed onto one of the paths.
+//
+// With weights, the flows are rebalanced at flowlet boundaries, i.e., a flow
+// is only moved to a different path after it has been idle for
+// FlowletTimeout. Flows on paths that are kept stay on their path when the
+// weights change. As with SetPaths, packets that were written up to this point
+// are still sent via the old paths.
+func (s *Session) SetWeightedPaths(paths []snet.Path, weights []float64) error {
+	if weights != nil && len(weights) != len(paths) {
+		return serrors.New("number of weights does not match number of paths",
+			"paths", len(paths), "weights", len(weights))
+	}
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
@@ -122,22 +285,29 @@ func (s *Session) SetPaths(paths []snet.Path) error {
 	for _, existingSender := range s.senders {
 		reused[existingSender] = false
 	}
+	senderWeights := make(map[*sender]float64, len(paths))
 
-	for _, path := range paths {
+	for i, path := range paths {
 		// Find out whether we already have a sender for this path.
 		// Keep using old senders whenever possible.
 		if existingSender, ok := findSenderWithPath(s.senders, path); ok {
 			reused[existingSender] = true
+			if weights != nil {
+				senderWeights[existingSender] += weights[i]
+			}
 			continue
 		}
 
 		newSender, err := newSender(
 			s.SessionID,
+			s.nextStreamID(),
 			s.DataPlaneConn,
 			path,
 			s.GatewayAddr,
 			s.PathStatsPublisher,
 			s.Metrics,
+			s.egressKey(),
+			s,
 		)
 		if err != nil {
 			// Collect newly created senders to avoid go routine leak.
@@ -147,6 +317,9 @@ func (s *Session) SetPaths(paths []snet.Path) error {
 			return err
 		}
 		created = append(created, newSender)
+		if weights != nil {
+			senderWeights[newSender] = weights[i]
+		}
 	}
 
 	newSenders := created
@@ -164,10 +337,66 @@ func (s *Session) SetPaths(paths []snet.Path) error {
 		return strings.Compare(string(newSenders[x].pathFingerprint),
 			string(newSenders[y].pathFingerprint)) == -1
 	})
+	s.updateBalancer(newSenders, weights != nil, senderWeights)
 	s.senders = newSenders
+	s.packetMTU = 0
+	for i, snd := range newSenders {
+		if i == 0 || snd.packetMTU < s.packetMTU {
+			s.packetMTU = snd.packetMTU
+		}
+	}
 	return nil
 }
 
+// updateBalancer sets the weights of the new senders in the balancer. Flowlets
+// on senders that are kept stay on them.
+func (s *Session) updateBalancer(newSenders []*sender, weighted bool,
+	senderWeights map[*sender]float64) {
+
+	if !weighted {
+		s.balancer = nil
+		return
+	}
+	if s.balancer == nil {
+		s.balancer = newFlowletBalancer(s.FlowletTimeout)
+	}
+	newIndices := make(map[*sender]int, len(newSenders))
+	weights := make([]float64, 0, len(newSenders))
+	for i, snd := range newSenders {
+		newIndices[snd] = i
+		weights = append(weights, senderWeights[snd])
+	}
+	remap := make([]int, 0, len(s.senders))
+	for _, snd := range s.senders {
+		index, ok := newIndices[snd]
+		if !ok {
+			index = -1
+		}
+		remap = append(remap, index)
+	}
+	s.balancer.update(weights, remap)
+}
+
+// nextStreamID returns the stream ID for a new sender. Stream IDs are
+// allocated sequentially, starting at a random one, so that they do not repeat
+// within the session. For encrypted sessions this is required because the
+// stream ID is part of the nonce.
+func (s *Session) nextStreamID() uint32 {
+	if !s.streamIDSet {
+		s.streamID = NewStreamID()
+		s.streamIDSet = true
+	}
+	s.streamID = (s.streamID + 1) & 0xfffff
+	return s.streamID
+}
+
+func (s *Session) egressKey() *egressKey {
+	if !s.Encrypt {
+		return nil
+	}
+	return &s.key
+}
+
 func findSenderWithPath(senders []*sender, path snet.Path) (*sender, bool) {
 	for _, s := range senders {
 		if pathsEqual(path, s.path) {
@@ -83,6 +83,39 @@ func TestTwoPaths(t *testing.T) {
 	sess.Close()
 }
 
+func TestWeightedPaths(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	frameChan := make(chan ([]byte))
+	sess := createSession(t, ctrl, frameChan)
+
+	err := sess.SetWeightedPaths([]snet.Path{createMockPath(ctrl, 200)}, []float64{0.5, 0.5})
+	assert.Error(t, err)
+
+	err = sess.SetWeightedPaths([]snet.Path{
+		createMockPath(ctrl, 200),
+		createMockPath(ctrl, 201),
+	}, []float64{0.75, 0.25})
+	assert.NoError(t, err)
+	sendPackets(t, sess, 22, 10)
+
+	// Changing the weights keeps the senders.
+	err = sess.SetWeightedPaths([]snet.Path{
+		createMockPath(ctrl, 200),
+		createMockPath(ctrl, 201),
+	}, []float64{0.25, 0.75})
+	assert.NoError(t, err)
+	sendPackets(t, sess, 22, 10)
+
+	// Dropping the weights falls back to hashing the flows.
+	sess.SetPaths([]snet.Path{createMockPath(ctrl, 200), createMockPath(ctrl, 201)})
+	sendPackets(t, sess, 22, 10)
+	waitFrames(t, frameChan, 22, 30)
+
+	sess.Close()
+}
+
 func TestNoLeak(t *testing.T) {
 	defer goleak.VerifyNone(t)
 
@@ -17,11 +17,13 @@ package dataplane
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -50,10 +52,17 @@ type worker struct {
 	rlists           map[int]*reassemblyList
 	markedForCleanup bool
 	tunIO            io.WriteCloser
+	// keys are the keys to decrypt frames. If nil, frames are not encrypted.
+	keys *IngressKeys
+	// payload is the buffer frames are decrypted to.
+	payload []byte
+	// captures are the packet captures the received frames and IP packets are
+	// recorded to. If nil, nothing is recorded.
+	captures *Captures
 }
 
 func newWorker(remote *snet.UDPAddr, sessID uint8,
-	tunIO io.WriteCloser, metrics IngressMetrics) *worker {
+	tunIO io.WriteCloser, keys *IngressKeys, metrics IngressMetrics) *worker {
 
 	worker := &worker{
 		Logger:  log.New("ingress", remote.String(), "sessId", sessID),
@@ -63,6 +72,10 @@ func newWorker(remote *snet.UDPAddr, sessID uint8,
 		rlists:  make(map[int]*reassemblyList),
 		tunIO:   tunIO,
 		Metrics: metrics,
+		keys:    keys,
+	}
+	if keys != nil {
+		worker.payload = make([]byte, 0, frameBufCap)
 	}
 
 	return worker
@@ -101,6 +114,11 @@ func (w *worker) Run() {
 // packets to the wire and then adding the frame to the corresponding reassembly
 // list if needed.
 func (w *worker) processFrame(frame *frameBuf) {
+	w.captures.receivedFrame(w.Remote, w.SessID, frame.raw[:frame.frameLen])
+	if w.keys != nil && !w.decrypt(frame) {
+		frame.Release()
+		return
+	}
 	index := int(binary.BigEndian.Uint16(frame.raw[2:4]))
 	epoch := int(binary.BigEndian.Uint32(frame.raw[4:8]) & 0xfffff)
 	seqNr := binary.BigEndian.Uint64(frame.raw[8:16])
@@ -118,6 +136,26 @@ func (w *worker) processFrame(frame *frameBuf) {
 	rlist.Insert(frame)
 }
 
+// decrypt authenticates and decrypts the frame in place. It reports whether the
+// frame is valid.
+func (w *worker) decrypt(frame *frameBuf) bool {
+	payload, err := w.keys.open(w.payload[:0], frame.raw[:frame.frameLen],
+		w.Remote.IA, w.Remote.Host.IP, w.SessID)
+	if err != nil {
+		reason := "unauthenticated"
+		switch {
+		case errors.Is(err, errNoKey):
+			reason = "no_key"
+		case errors.Is(err, errReplayed):
+			reason = "replayed"
+		}
+		metrics.CounterInc(metrics.CounterWith(w.Metrics.FramesDiscarded, "reason", reason))
+		return false
+	}
+	frame.frameLen = hdrLen + copy(frame.raw[hdrLen:], payload)
+	return true
+}
+
 func (w *worker) getRlist(epoch int) *reassemblyList {
 	rlist, ok := w.rlists[epoch]
 	if !ok {
@@ -154,6 +192,7 @@ func (w *worker) send(packet []byte) error {
 		increaseCounterMetric(w.Metrics.SendLocalError, 1)
 		return serrors.New("Unable to write to internal ingress", "err", err, "length", len(packet))
 	}
+	w.captures.receivedPacket(w.Remote, w.SessID, packet)
 	// Update the metrics. Note that we are not doing any filtering yet and so
 	// the metrics for packets coming from the remote AS and packets sent to the
 	// local network are going to be the same, except for different labels.
@@ -15,10 +15,13 @@
 package dataplane
 
 import (
+	"bytes"
+	"encoding/binary"
 	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -73,7 +76,7 @@ func TestParsing(t *testing.T) {
 		},
 	}
 	mt := &MockTun{}
-	w := newWorker(addr, 1, mt, IngressMetrics{})
+	w := newWorker(addr, 1, mt, nil, IngressMetrics{})
 
 	// Single frame with a single IPv4 packet inside.
 	SendFrame(t, w, []byte{
@@ -319,3 +322,51 @@ func TestParsing(t *testing.T) {
 	})
 	mt.AssertDone(t)
 }
+
+func TestParsingEncrypted(t *testing.T) {
+	addr := &snet.UDPAddr{
+		IA: xtest.MustParseIA("1-ff00:0:300"),
+		Host: &net.UDPAddr{
+			IP:   net.IP{192, 168, 1, 1},
+			Port: 80,
+		},
+	}
+	key := bytes.Repeat([]byte{1}, FrameKeyLen)
+	keys := &IngressKeys{}
+	require.NoError(t, keys.Set(addr.IA, addr.Host.IP, 1, key))
+	c, err := NewFrameCipher(key)
+	require.NoError(t, err)
+
+	mt := &MockTun{}
+	w := newWorker(addr, 1, mt, keys, IngressMetrics{})
+
+	pkt := []byte{
+		// IPv4 header.
+		0x40, 0, 0, 23, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		// Payload.
+		101, 102, 103,
+	}
+	frame := append([]byte{
+		// SIG frame header.
+		0, 1, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 1,
+	}, pkt...)
+	sealed := c.Seal(nil, frame)
+	SendFrame(t, w, sealed)
+	mt.AssertPacket(t, pkt)
+	mt.AssertDone(t)
+
+	// Replayed frame.
+	SendFrame(t, w, sealed)
+	mt.AssertDone(t)
+
+	// Modified frame.
+	modified := c.Seal(nil, frame)
+	binary.BigEndian.PutUint64(modified[seqPos:], 2)
+	SendFrame(t, w, modified)
+	mt.AssertDone(t)
+
+	// Unencrypted frame.
+	binary.BigEndian.PutUint64(frame[seqPos:], 3)
+	SendFrame(t, w, frame)
+	mt.AssertDone(t)
+}
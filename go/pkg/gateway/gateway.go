@@ -23,11 +23,14 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/cors"
 	quic "github.com/lucas-clemente/quic-go"
 	"google.golang.org/grpc"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/daemon"
+	"github.com/scionproto/scion/go/lib/fatal"
 	"github.com/scionproto/scion/go/lib/infra/infraenv"
 	"github.com/scionproto/scion/go/lib/infra/messenger"
 	"github.com/scionproto/scion/go/lib/log"
@@ -40,6 +43,7 @@ import (
 	"github.com/scionproto/scion/go/lib/sock/reliable/reconnect"
 	"github.com/scionproto/scion/go/lib/svc"
 	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/pkg/gateway/api"
 	"github.com/scionproto/scion/go/pkg/gateway/config"
 	"github.com/scionproto/scion/go/pkg/gateway/control"
 	controlgrpc "github.com/scionproto/scion/go/pkg/gateway/control/grpc"
@@ -88,6 +92,11 @@ type DataplaneSessionFactory struct {
 	PacketConnFactory  PacketConnFactory
 	PathStatsPublisher dataplane.PathStatsPublisher
 	Metrics            dataplane.SessionMetrics
+	// Encrypt indicates whether the created sessions encrypt their frames.
+	Encrypt bool
+	// Captures are the packet captures of the gateway. If nil, the traffic of
+	// the created sessions cannot be captured.
+	Captures *dataplane.Captures
 }
 
 func (dpf DataplaneSessionFactory) New(id uint8, policyID int,
@@ -104,13 +113,18 @@ func (dpf DataplaneSessionFactory) New(id uint8, policyID int,
 		FrameBytesSent:     metrics.CounterWith(dpf.Metrics.FrameBytesSent, labels...),
 		FramesSent:         metrics.CounterWith(dpf.Metrics.FramesSent, labels...),
 		SendExternalErrors: dpf.Metrics.SendExternalErrors,
+		IPPktsQueueDropped: metrics.CounterWith(dpf.Metrics.IPPktsQueueDropped, labels...),
+		IPPktsTooBig:       metrics.CounterWith(dpf.Metrics.IPPktsTooBig, labels...),
 	}
 	sess := &dataplane.Session{
 		SessionID:          id,
+		RemoteIA:           remoteIA,
 		GatewayAddr:        *remoteAddr.(*net.UDPAddr),
 		DataPlaneConn:      conn,
 		PathStatsPublisher: dpf.PathStatsPublisher,
 		Metrics:            metrics,
+		Encrypt:            dpf.Encrypt,
+		Captures:           dpf.Captures,
 	}
 	return sess
 }
@@ -153,12 +167,31 @@ func (ignoreSCMP) Handle(pkt *snet.Packet) error {
 
 // SelectAdvertisedRoutes computes the networks that should be advertised
 // depending on the state of the last published routing policy file.
+// If a route source is set, the learned prefixes that match a redistribute-bgp
+// rule are advertised in addition to the static ones.
 type SelectAdvertisedRoutes struct {
 	ConfigPublisher *control.ConfigPublisher
+	RouteSource     control.RouteSource
 }
 
 func (a *SelectAdvertisedRoutes) AdvertiseList(from, to addr.IA) []*net.IPNet {
-	return routing.AdvertiseList(a.ConfigPublisher.RoutingPolicy(), from, to)
+	pol := a.ConfigPublisher.RoutingPolicy()
+	nets := routing.AdvertiseList(pol, from, to)
+	if a.RouteSource == nil {
+		return nets
+	}
+	seen := make(map[string]struct{}, len(nets))
+	for _, n := range nets {
+		seen[n.String()] = struct{}{}
+	}
+	for _, n := range routing.RedistributeList(pol, from, to, a.RouteSource.Prefixes()) {
+		if _, ok := seen[n.String()]; ok {
+			continue
+		}
+		seen[n.String()] = struct{}{}
+		nets = append(nets, n)
+	}
+	return nets
 }
 
 type RoutingPolicyPublisherAdapter struct {
@@ -202,6 +235,21 @@ type Gateway struct {
 	// DataIP is the IP that should be used for dataplane traffic.
 	DataAddr *net.UDPAddr
 
+	// EncryptionKey is the pre-shared key that authenticates the key exchange
+	// with remote gateways. If set, frames sent to remote gateways are
+	// encrypted, and unencrypted frames from remote gateways are dropped.
+	EncryptionKey []byte
+
+	// FailoverTimeout is the time after the last successful probe of a remote
+	// gateway after which traffic fails over to another remote gateway. If
+	// zero, the session monitor default is used.
+	FailoverTimeout time.Duration
+
+	// PathMTUDiscovery makes the gateway drop the IP packets that exceed the
+	// MTU of the paths and must not be fragmented, and answer them with ICMP
+	// Packet Too Big messages.
+	PathMTUDiscovery bool
+
 	// Dispatcher is the API of the SCION Dispatcher on the local host.
 	Dispatcher reliable.Dispatcher
 
@@ -214,6 +262,17 @@ type Gateway struct {
 	RouteSourceIPv6 net.IP
 	// TunnelName is the device name for the Linux global tunnel device.
 	TunnelName string
+	// RouteTableID is the ID of the Linux routing table the prefixes of remote
+	// gateways are added to. If zero, the main table is used.
+	RouteTableID int
+	// RouteMetric is the metric of the routes added to the Linux routing
+	// table. If zero, xnet.SIGRPriority is used.
+	RouteMetric int
+	// RouteImportTableID is the ID of the Linux routing table the prefixes of
+	// the local host are learned from, e.g., the routes of a BGP daemon. They
+	// are redistributed to remote gateways according to the routing policy. If
+	// zero, only the static prefixes are advertised.
+	RouteImportTableID int
 
 	// RoutingTableReader is used for routing the packets.
 	RoutingTableReader control.RoutingTableReader
@@ -227,6 +286,10 @@ type Gateway struct {
 	// HTTPServeMux is the http server mux that is used to expose gateway http
 	// endpoints.
 	HTTPServeMux *http.ServeMux
+	// APIAddr is the address the management API is exposed on. If empty, the
+	// API is not exposed. The config, info and log/level operations are served
+	// by the corresponding HTTPEndpoints.
+	APIAddr string
 
 	// Logger is the base logger for all modules initialized by the gateway.
 	Logger log.Logger
@@ -237,6 +300,18 @@ type Gateway struct {
 func (g *Gateway) Run() error {
 	log.SafeDebug(g.Logger, "Gateway starting up...")
 
+	// ctx is canceled when the gateway shuts down.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		defer log.HandlePanic()
+		select {
+		case <-fatal.ShutdownChan():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
 	// *************************************************************************
 	// Set up support for Linux tunnel devices.
 	// *************************************************************************
@@ -262,7 +337,11 @@ func (g *Gateway) Run() error {
 	tunnelReader := TunnelReader{
 		DeviceOpener: xnet.UseNameResolver(
 			routemgr.FixedTunnelName(tunnelName),
-			xnet.OpenerWithOptions(xnet.WithLogger(g.Logger)),
+			xnet.OpenerWithOptions(
+				xnet.WithLogger(g.Logger),
+				xnet.WithRouteTable(g.RouteTableID),
+				xnet.WithRouteMetric(g.RouteMetric),
+			),
 		),
 		Router:  g.RoutingTableReader,
 		Logger:  g.Logger,
@@ -276,6 +355,25 @@ func (g *Gateway) Run() error {
 
 	routePublisherFactory := createRouteManager(deviceManager)
 
+	// *************************************************************************
+	// Learn the prefixes of the local host from the Linux routing table.
+	// *************************************************************************
+	var routeSource control.RouteSource
+	if g.RouteImportTableID != 0 {
+		s := &xnet.RouteSource{
+			TableID: g.RouteImportTableID,
+			Logger:  g.Logger,
+		}
+		go func() {
+			defer log.HandlePanic()
+			if err := s.Run(ctx); err != nil {
+				fatal.Fatal(serrors.WrapStr("learning routes", err))
+			}
+		}()
+		routeSource = s
+		log.SafeDebug(g.Logger, "Route import started", "table", g.RouteImportTableID)
+	}
+
 	// *************************************************************************
 	// Initialize base SCION network information: IA + Dispatcher connectivity
 	// *************************************************************************
@@ -309,10 +407,11 @@ func (g *Gateway) Run() error {
 	revocationHandler := daemon.RevHandler{Connector: g.Daemon}
 
 	var pathsMonitored, sessionPathsAvailable metrics.Gauge
-	var probesSent, probesReceived metrics.Counter
+	var probesSent, probesReceived, sessionPathSwitches metrics.Counter
 	if g.Metrics != nil {
 		pathsMonitored = metrics.NewPromGauge(g.Metrics.PathsMonitored)
 		sessionPathsAvailable = metrics.NewPromGauge(g.Metrics.SessionPathsAvailable)
+		sessionPathSwitches = metrics.NewPromCounter(g.Metrics.SessionPathSwitches)
 		probesSent = metrics.NewPromCounter(g.Metrics.PathProbesSent)
 		probesReceived = metrics.NewPromCounter(g.Metrics.PathProbesReceived)
 	}
@@ -341,6 +440,7 @@ func (g *Gateway) Run() error {
 		},
 		revStore:              revStore,
 		sessionPathsAvailable: sessionPathsAvailable,
+		sessionPathSwitches:   sessionPathSwitches,
 	}
 	go func() {
 		defer log.HandlePanic()
@@ -561,6 +661,7 @@ func (g *Gateway) Run() error {
 			LocalIA: localIA,
 			Advertiser: &SelectAdvertisedRoutes{
 				ConfigPublisher: configPublisher,
+				RouteSource:     routeSource,
 			},
 			PrefixesAdvertised: paMetric,
 		},
@@ -584,7 +685,19 @@ func (g *Gateway) Run() error {
 	if err != nil {
 		return serrors.WrapStr("creating server probe conn", err)
 	}
+	var keyExchange *control.KeyExchange
+	var ingressKeys *dataplane.IngressKeys
 	probeServer := controlgrpc.ProbeDispatcher{}
+	if len(g.EncryptionKey) != 0 {
+		keyExchange = &control.KeyExchange{
+			PSK:    g.EncryptionKey,
+			DataIP: g.DataClientIP,
+		}
+		ingressKeys = &dataplane.IngressKeys{}
+		probeServer.KeyExchange = keyExchange
+		probeServer.IngressKeys = ingressKeys
+		log.SafeInfo(g.Logger, "Frame encryption enabled")
+	}
 	probeServerCtx, probeServerCancel := context.WithCancel(context.Background())
 	defer probeServerCancel()
 	go func() {
@@ -594,8 +707,17 @@ func (g *Gateway) Run() error {
 		}
 	}()
 
+	// Packet captures record the traffic of the sessions on request of the
+	// management API.
+	captures := &dataplane.Captures{
+		LocalIA:   localIA,
+		LocalAddr: g.DataServerAddr,
+	}
+
 	// Start dataplane ingress
-	if err := StartIngress(scionNetwork, g.DataServerAddr, deviceManager, g.Metrics); err != nil {
+	err = StartIngress(scionNetwork, g.DataServerAddr, deviceManager, ingressKeys, captures,
+		g.Metrics)
+	if err != nil {
 		return err
 	}
 	log.SafeDebug(g.Logger, "Ingress started")
@@ -644,10 +766,15 @@ func (g *Gateway) Run() error {
 					Network: scionNetwork,
 					Addr:    &net.UDPAddr{IP: g.DataClientIP},
 				},
-				Metrics: CreateSessionMetrics(g.Metrics),
+				Metrics:  CreateSessionMetrics(g.Metrics),
+				Encrypt:  keyExchange != nil,
+				Captures: captures,
 			},
-			Logger:  g.Logger,
-			Metrics: CreateEngineMetrics(g.Metrics),
+			KeyExchange:      keyExchange,
+			HealthExpiration: g.FailoverTimeout,
+			PathMTUDiscovery: g.PathMTUDiscovery,
+			Logger:           g.Logger,
+			Metrics:          CreateEngineMetrics(g.Metrics),
 		},
 		RoutePublisherFactory: routePublisherFactory,
 		RouteSourceIPv4:       g.RouteSourceIPv4,
@@ -679,7 +806,7 @@ func (g *Gateway) Run() error {
 		},
 	}
 	g.HTTPEndpoints["diagnostics/sgrp"] = service.StatusPage{
-		Handler: g.diagnosticsSGRP(routePublisherFactory, configPublisher),
+		Handler: g.diagnosticsSGRP(routePublisherFactory, configPublisher, routeSource),
 	}
 
 	// XXX(scrye): Use an empty file here because the server often doesn't have
@@ -692,18 +819,59 @@ func (g *Gateway) Run() error {
 	if err := g.HTTPEndpoints.Register(g.HTTPServeMux, g.ID); err != nil {
 		return serrors.WrapStr("registering HTTP pages", err)
 	}
+	g.startAPI(&api.Server{
+		LocalIA: localIA,
+		Remotes: remoteMonitor,
+		Engine:  engineController,
+		Advertiser: &SelectAdvertisedRoutes{
+			ConfigPublisher: configPublisher,
+			RouteSource:     routeSource,
+		},
+	})
 	select {}
 }
 
+// startAPI exposes the management API if an address is configured.
+func (g *Gateway) startAPI(server *api.Server) {
+	if g.APIAddr == "" {
+		return
+	}
+	server.Config = g.statusPageHandler("config")
+	server.Info = g.statusPageHandler("info")
+	server.LogLevel = g.statusPageHandler("log/level")
+
+	r := chi.NewRouter()
+	r.Use(cors.Handler(cors.Options{
+		AllowedOrigins: []string{"*"},
+	}))
+	log.SafeInfo(g.Logger, "Exposing API", "addr", g.APIAddr)
+	h := api.HandlerFromMux(server, r)
+	go func() {
+		defer log.HandlePanic()
+		if err := http.ListenAndServe(g.APIAddr, h); err != nil {
+			fatal.Fatal(serrors.WrapStr("serving HTTP API", err))
+		}
+	}()
+}
+
+func (g *Gateway) statusPageHandler(name string) http.HandlerFunc {
+	if page, ok := g.HTTPEndpoints[name]; ok {
+		return page.Handler
+	}
+	return http.NotFound
+}
+
 func (g *Gateway) diagnosticsSGRP(
 	routePublisherFactory control.PublisherFactory,
 	pub *control.ConfigPublisher,
+	routeSource control.RouteSource,
 ) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		var d struct {
 			Advertise struct {
-				Static []string `json:"static"`
+				Static   []string `json:"static"`
+				Imported []string `json:"imported"`
 			} `json:"advertise"`
 			Learned struct {
 				Dynamic []string `json:"dynamic"`
@@ -711,11 +879,17 @@ func (g *Gateway) diagnosticsSGRP(
 		}
 		// Avoid null in json output.
 		d.Advertise.Static = []string{}
+		d.Advertise.Imported = []string{}
 		d.Learned.Dynamic = []string{}
 
 		for _, s := range routing.StaticAdvertised(pub.RoutingPolicy()) {
 			d.Advertise.Static = append(d.Advertise.Static, s.String())
 		}
+		if routeSource != nil {
+			for _, s := range routeSource.Prefixes() {
+				d.Advertise.Imported = append(d.Advertise.Imported, s.String())
+			}
+		}
 		if p, ok := routePublisherFactory.(interface{ Diagnostics() control.Diagnostics }); ok {
 			for _, r := range p.Diagnostics().Routes {
 				d.Learned.Dynamic = append(d.Learned.Dynamic, r.Prefix.String())
@@ -758,8 +932,12 @@ func CreateIngressMetrics(m *Metrics) dataplane.IngressMetrics {
 	}
 }
 
+// StartIngress starts the server for encapsulated traffic from remote gateways.
+// If keys is set, only encrypted frames are accepted. If captures is set, the
+// received traffic is recorded to the running packet captures.
 func StartIngress(scionNetwork *snet.SCIONNetwork, dataAddr *net.UDPAddr,
-	deviceManager control.DeviceManager, metrics *Metrics) error {
+	deviceManager control.DeviceManager, keys *dataplane.IngressKeys,
+	captures *dataplane.Captures, metrics *Metrics) error {
 
 	dataplaneServerConn, err := scionNetwork.Listen(
 		context.TODO(),
@@ -775,6 +953,8 @@ func StartIngress(scionNetwork *snet.SCIONNetwork, dataAddr *net.UDPAddr,
 		Conn:          dataplaneServerConn,
 		DeviceManager: deviceManager,
 		Metrics:       ingressMetrics,
+		Keys:          keys,
+		Captures:      captures,
 	}
 	go func() {
 		defer log.HandlePanic()
@@ -796,6 +976,8 @@ func CreateSessionMetrics(m *Metrics) dataplane.SessionMetrics {
 		FrameBytesSent:     metrics.NewPromCounter(m.FrameBytesSentTotal),
 		FramesSent:         metrics.NewPromCounter(m.FramesSentTotal),
 		SendExternalErrors: metrics.NewPromCounter(m.SendExternalErrorsTotal),
+		IPPktsQueueDropped: metrics.NewPromCounter(m.IPPktsQueueDroppedTotal),
+		IPPktsTooBig:       metrics.NewPromCounter(m.IPPktsTooBigTotal),
 	}
 }
 
@@ -91,6 +91,16 @@ var (
 		Help:   "Total number of discarded IP packets received from the local network.",
 		Labels: []string{"reason"},
 	}
+	IPPktsQueueDroppedTotalMeta = MetricMeta{
+		Name:   "gateway_ippkts_queue_dropped_total",
+		Help:   "Total number of IP packets dropped because their QoS queue was full.",
+		Labels: []string{"remote_isd_as", "policy_id", "queue"},
+	}
+	IPPktsTooBigTotalMeta = MetricMeta{
+		Name:   "gateway_ippkts_too_big_total",
+		Help:   "Total number of IP packets dropped because they exceeded the path MTU.",
+		Labels: []string{"remote_isd_as", "policy_id"},
+	}
 	SendExternalErrorsTotalMeta = MetricMeta{
 		Name:   "gateway_send_external_errors_total",
 		Help:   "Total number of errors when sending frames to the network (WAN).",
@@ -146,6 +156,12 @@ var (
 		Help:   "Total number of paths available per session policy.",
 		Labels: []string{"remote_isd_as", "policy_id", "status"},
 	}
+	SessionPathSwitchesMeta = MetricMeta{
+		Name: "gateway_session_path_switches_total",
+		Help: "Total number of times the best path of a session policy changed, " +
+			"by the reason the new path was chosen.",
+		Labels: []string{"remote_isd_as", "policy_id", "reason"},
+	}
 	RemotesMeta = MetricMeta{
 		Name:   "gateway_remotes",
 		Help:   "Total number of discovered remote gateways.",
@@ -213,6 +229,8 @@ type Metrics struct {
 	// Error Metrics
 	FramesDiscardedTotal       *prometheus.CounterVec
 	IPPktsDiscardedTotal       *prometheus.CounterVec
+	IPPktsQueueDroppedTotal    *prometheus.CounterVec
+	IPPktsTooBigTotal          *prometheus.CounterVec
 	SendExternalErrorsTotal    *prometheus.CounterVec
 	SendLocalErrorsTotal       *prometheus.CounterVec
 	ReceiveExternalErrorsTotal *prometheus.CounterVec
@@ -221,6 +239,7 @@ type Metrics struct {
 	// Path Monitoring Metrics
 	PathsMonitored        *prometheus.GaugeVec
 	SessionPathsAvailable *prometheus.GaugeVec
+	SessionPathSwitches   *prometheus.CounterVec
 	PathProbesSent        *prometheus.CounterVec
 	PathProbesReceived    *prometheus.CounterVec
 
@@ -253,6 +272,8 @@ func NewMetrics() *Metrics {
 		FramesReceivedTotal:          FramesReceivedTotalMeta.NewCounterVec(),
 		FramesDiscardedTotal:         FramesDiscardedTotalMeta.NewCounterVec(),
 		IPPktsDiscardedTotal:         IPPktsDiscardedTotalMeta.NewCounterVec(),
+		IPPktsQueueDroppedTotal:      IPPktsQueueDroppedTotalMeta.NewCounterVec(),
+		IPPktsTooBigTotal:            IPPktsTooBigTotalMeta.NewCounterVec(),
 		SendExternalErrorsTotal:      SendExternalErrorsTotalMeta.NewCounterVec(),
 		SendLocalErrorsTotal:         SendLocalErrorsTotalMeta.NewCounterVec(),
 		ReceiveExternalErrorsTotal:   ReceiveExternalErrorsTotalMeta.NewCounterVec(),
@@ -264,6 +285,7 @@ func NewMetrics() *Metrics {
 		SessionProbes:                SessionProbesMeta.NewCounterVec(),
 		SessionProbeReplies:          SessionProbeRepliesMeta.NewCounterVec(),
 		SessionPathsAvailable:        SessionPathsAvailableMeta.NewGaugeVec(),
+		SessionPathSwitches:          SessionPathSwitchesMeta.NewCounterVec(),
 		Remotes:                      RemotesMeta.NewGaugeVec(),
 		PrefixesAdvertised:           PrefixesAdvertisedMeta.NewGaugeVec(),
 		PrefixesAccepted:             PrefixesAcceptedMeta.NewGaugeVec(),
@@ -16,6 +16,7 @@ package pathhealth
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -97,13 +98,13 @@ func (pw *DefaultPathWatcher) SendProbe(conn snet.PacketConn, localAddr snet.SCI
 		log.SafeError(pw.logger, "Failed to send path probe", "err", err)
 		return
 	}
-	pw.pathState.sendProbe(time.Now())
+	pw.pathState.sendProbe(pw.nextSeq, time.Now())
 	pw.nextSeq++
 }
 
 // HandleProbeReply dispatches a single probe reply packet.
 func (pw *DefaultPathWatcher) HandleProbeReply(seq uint16) {
-	pw.pathState.receiveProbe(time.Now())
+	pw.pathState.receiveProbe(seq, time.Now())
 }
 
 // Path returns a fresh copy of the monitored path.
@@ -120,8 +121,12 @@ func (pw *DefaultPathWatcher) State() State {
 			IsExpired: true,
 		}
 	}
+	latency, jitter, dropRate := pw.pathState.stats(now)
 	return State{
-		IsAlive: pw.pathState.active(),
+		IsAlive:  pw.pathState.active(),
+		Latency:  latency,
+		Jitter:   jitter,
+		DropRate: dropRate,
 	}
 }
 
@@ -170,15 +175,31 @@ func (pw *DefaultPathWatcher) createProbepacket(localAddr snet.SCIONAddress) (*s
 	}, nil
 }
 
+// statsWindow is the number of most recent probes the path statistics are
+// computed from.
+const statsWindow = 32
+
 type pathState struct {
 	mu                sync.Mutex
 	consecutiveProbes int
 	lastReceived      time.Time
+	// probes contains the most recent probes, indexed by sequence number
+	// modulo statsWindow.
+	probes [statsWindow]probe
+}
+
+// probe is a probe sent on the path.
+type probe struct {
+	seq     uint16
+	sent    time.Time
+	rtt     time.Duration
+	replied bool
 }
 
-func (s *pathState) sendProbe(now time.Time) {
+func (s *pathState) sendProbe(seq uint16, now time.Time) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.probes[seq%statsWindow] = probe{seq: seq, sent: now}
 	// Probe timed out.
 	if s.lastReceived.Add(defaultProbeInterval * 2).Before(now) {
 		s.consecutiveProbes = 0
@@ -186,13 +207,18 @@ func (s *pathState) sendProbe(now time.Time) {
 	}
 }
 
-func (s *pathState) receiveProbe(now time.Time) {
+func (s *pathState) receiveProbe(seq uint16, now time.Time) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.lastReceived = now
 	if s.consecutiveProbes < 3 {
 		s.consecutiveProbes++
 	}
+	p := &s.probes[seq%statsWindow]
+	if p.seq == seq && !p.sent.IsZero() && !p.replied {
+		p.rtt = now.Sub(p.sent)
+		p.replied = true
+	}
 }
 
 func (s *pathState) active() bool {
@@ -200,3 +226,55 @@ func (s *pathState) active() bool {
 	defer s.mu.Unlock()
 	return s.consecutiveProbes == 3
 }
+
+// stats computes the latency, the jitter and the drop rate of the path from
+// the most recent probes. The latency is the median of the one-way latencies,
+// which are approximated as half the round-trip time. Probes that did not get
+// a reply yet are only counted as dropped once they timed out.
+func (s *pathState) stats(now time.Time) (time.Duration, time.Duration, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var replied []probe
+	var sent, dropped int
+	for _, p := range s.probes {
+		if p.sent.IsZero() {
+			continue
+		}
+		if !p.replied {
+			if now.Sub(p.sent) < defaultProbeInterval*2 {
+				continue
+			}
+			dropped++
+		} else {
+			replied = append(replied, p)
+		}
+		sent++
+	}
+	if sent == 0 {
+		return 0, 0, 0
+	}
+	dropRate := float64(dropped) / float64(sent)
+	if len(replied) == 0 {
+		return 0, 0, dropRate
+	}
+
+	sort.Slice(replied, func(i, j int) bool { return replied[i].sent.Before(replied[j].sent) })
+	var jitter time.Duration
+	for i := 1; i < len(replied); i++ {
+		d := replied[i].rtt - replied[i-1].rtt
+		if d < 0 {
+			d = -d
+		}
+		jitter += d / 2
+	}
+	if len(replied) > 1 {
+		jitter /= time.Duration(len(replied) - 1)
+	}
+	latencies := make([]time.Duration, 0, len(replied))
+	for _, p := range replied {
+		latencies = append(latencies, p.rtt/2)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	return latencies[len(latencies)/2], jitter, dropRate
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathhealth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathStateStats(t *testing.T) {
+	start := time.Now()
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+
+	testCases := map[string]struct {
+		Probes   func(s *pathState)
+		Now      time.Time
+		Latency  time.Duration
+		Jitter   time.Duration
+		DropRate float64
+	}{
+		"no probes": {
+			Probes: func(s *pathState) {},
+			Now:    at(time.Second),
+		},
+		"outstanding probe": {
+			Probes: func(s *pathState) {
+				s.sendProbe(0, at(0))
+			},
+			Now: at(100 * time.Millisecond),
+		},
+		"all replied": {
+			Probes: func(s *pathState) {
+				s.sendProbe(0, at(0))
+				s.receiveProbe(0, at(20*time.Millisecond))
+				s.sendProbe(1, at(500*time.Millisecond))
+				s.receiveProbe(1, at(540*time.Millisecond))
+				s.sendProbe(2, at(time.Second))
+				s.receiveProbe(2, at(time.Second+30*time.Millisecond))
+			},
+			Now:     at(2 * time.Second),
+			Latency: 15 * time.Millisecond,
+			Jitter:  7500 * time.Microsecond,
+		},
+		"dropped probe": {
+			Probes: func(s *pathState) {
+				s.sendProbe(0, at(0))
+				s.receiveProbe(0, at(20*time.Millisecond))
+				s.sendProbe(1, at(500*time.Millisecond))
+				s.sendProbe(2, at(time.Second))
+				s.receiveProbe(2, at(time.Second+20*time.Millisecond))
+				s.sendProbe(3, at(1500*time.Millisecond))
+				s.receiveProbe(3, at(1500*time.Millisecond+20*time.Millisecond))
+			},
+			Now:      at(3 * time.Second),
+			Latency:  10 * time.Millisecond,
+			DropRate: 0.25,
+		},
+		"unknown reply": {
+			Probes: func(s *pathState) {
+				s.sendProbe(0, at(0))
+				s.receiveProbe(statsWindow, at(20*time.Millisecond))
+			},
+			Now:      at(2 * time.Second),
+			DropRate: 1,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var s pathState
+			tc.Probes(&s)
+			latency, jitter, dropRate := s.stats(tc.Now)
+			assert.Equal(t, tc.Latency, latency)
+			assert.Equal(t, tc.Jitter, jitter)
+			assert.Equal(t, tc.DropRate, dropRate)
+		})
+	}
+}
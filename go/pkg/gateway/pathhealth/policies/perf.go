@@ -0,0 +1,284 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policies
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultHysteresis is the default relative margin by which a path must be
+// better than the current path to replace it.
+const DefaultHysteresis = 0.1
+
+const (
+	// minLatencyMargin is the minimum margin for latency comparisons.
+	minLatencyMargin = time.Millisecond
+	// minDropRateMargin is the minimum margin for drop rate comparisons.
+	minDropRateMargin = 0.01
+)
+
+// Reasons why a path is preferred over another path.
+const (
+	// ReasonOnlyPath indicates that there is no other path to choose from.
+	ReasonOnlyPath = "only_path"
+	// ReasonRevocation indicates that the other path is revoked.
+	ReasonRevocation = "revocation"
+	// ReasonLatency indicates that the path has a lower latency.
+	ReasonLatency = "latency"
+	// ReasonDropRate indicates that the path has a lower drop rate.
+	ReasonDropRate = "drop_rate"
+	// ReasonBandwidth indicates that the path has a higher bandwidth.
+	ReasonBandwidth = "bandwidth"
+	// ReasonSLO indicates that the path satisfies the service level objective
+	// and the other path does not.
+	ReasonSLO = "slo"
+	// ReasonHysteresis indicates that the path is the current path, and the
+	// other path is not better by a large enough margin.
+	ReasonHysteresis = "hysteresis"
+	// ReasonPathLength indicates that the path has fewer hops.
+	ReasonPathLength = "path_length"
+	// ReasonPerfPolicy indicates that the path is preferred by a perf policy
+	// that does not explain its decisions.
+	ReasonPerfPolicy = "perf_policy"
+	// ReasonFingerprint indicates that the paths are equal, and the path is
+	// preferred for its fingerprint.
+	ReasonFingerprint = "fingerprint"
+)
+
+// Explainer is implemented by perf policies that can explain their decisions.
+type Explainer interface {
+	// Reason returns why x is better than y. It returns an empty string if x
+	// is not better than y.
+	Reason(x, y *Stats) string
+}
+
+// LowestLatency prefers paths with the lowest latency. Paths with equal
+// latency are ordered by drop rate.
+type LowestLatency struct {
+	// Hysteresis is the relative margin by which the latency of a path must be
+	// lower than the latency of the current path to replace it. If zero,
+	// DefaultHysteresis is used. If negative, the best path is always chosen.
+	Hysteresis float64
+}
+
+// Better returns whether x has a lower latency than y.
+func (p LowestLatency) Better(x, y *Stats) bool {
+	return p.Reason(x, y) != ""
+}
+
+// Reason returns why x is better than y.
+func (p LowestLatency) Reason(x, y *Stats) string {
+	h := hysteresis(p.Hysteresis)
+	if r := compareLatency(x, y, h); r != 0 {
+		return reason(r, ReasonLatency)
+	}
+	if r := compareDropRate(x, y, 0); r != 0 {
+		return reason(r, ReasonDropRate)
+	}
+	return ""
+}
+
+// LowestLoss prefers paths with the lowest drop rate. Paths with equal drop
+// rate are ordered by latency.
+type LowestLoss struct {
+	// Hysteresis is the relative margin by which the drop rate of a path must be
+	// lower than the drop rate of the current path to replace it. If zero,
+	// DefaultHysteresis is used. If negative, the best path is always chosen.
+	Hysteresis float64
+}
+
+// Better returns whether x has a lower drop rate than y.
+func (p LowestLoss) Better(x, y *Stats) bool {
+	return p.Reason(x, y) != ""
+}
+
+// Reason returns why x is better than y.
+func (p LowestLoss) Reason(x, y *Stats) string {
+	h := hysteresis(p.Hysteresis)
+	if r := compareDropRate(x, y, h); r != 0 {
+		return reason(r, ReasonDropRate)
+	}
+	if r := compareLatency(x, y, 0); r != 0 {
+		return reason(r, ReasonLatency)
+	}
+	return ""
+}
+
+// SLO prefers paths that satisfy a service level objective. Among those, the
+// paths with the highest bandwidth are preferred, and paths with equal
+// bandwidth are ordered by latency. Paths that violate the objective are
+// ordered by latency.
+type SLO struct {
+	// MaxLatency is the maximum latency of the path. If zero, the latency is
+	// not bounded.
+	MaxLatency time.Duration
+	// MaxJitter is the maximum jitter of the path. If zero, the jitter is not
+	// bounded.
+	MaxJitter time.Duration
+	// MaxDropRate is the maximum drop rate of the path. If zero, the drop rate
+	// is not bounded.
+	MaxDropRate float64
+	// Hysteresis is the relative margin by which a path must be better than
+	// the current path to replace it. The current path is considered to satisfy
+	// the objective as long as it does not exceed the bounds by more than the
+	// margin. If zero, DefaultHysteresis is used. If negative, the best path
+	// is always chosen.
+	Hysteresis float64
+}
+
+// Better returns whether x is better than y according to the objective.
+func (p SLO) Better(x, y *Stats) bool {
+	return p.Reason(x, y) != ""
+}
+
+// Reason returns why x is better than y.
+func (p SLO) Reason(x, y *Stats) string {
+	h := hysteresis(p.Hysteresis)
+	xOK, yOK := p.satisfied(x, h), p.satisfied(y, h)
+	switch {
+	case xOK && !yOK:
+		return ReasonSLO
+	case !xOK && yOK:
+		return ""
+	case xOK && yOK:
+		if r := compareBandwidth(x, y, h); r != 0 {
+			return reason(r, ReasonBandwidth)
+		}
+		if r := compareLatency(x, y, 0); r != 0 {
+			return reason(r, ReasonLatency)
+		}
+		return ""
+	default:
+		if r := compareLatency(x, y, h); r != 0 {
+			return reason(r, ReasonLatency)
+		}
+		return ""
+	}
+}
+
+// satisfied returns whether the path satisfies the objective. The bounds of
+// the current path are relaxed by the hysteresis.
+func (p SLO) satisfied(s *Stats, h float64) bool {
+	relax := 1.0
+	if s.IsCurrent {
+		relax += h
+	}
+	if p.MaxLatency != 0 && float64(s.Latency) > float64(p.MaxLatency)*relax {
+		return false
+	}
+	if p.MaxJitter != 0 && float64(s.Jitter) > float64(p.MaxJitter)*relax {
+		return false
+	}
+	if p.MaxDropRate != 0 && s.DropRate > p.MaxDropRate*relax {
+		return false
+	}
+	return true
+}
+
+func hysteresis(h float64) float64 {
+	switch {
+	case h == 0:
+		return DefaultHysteresis
+	case h < 0:
+		return 0
+	default:
+		return h
+	}
+}
+
+// reason returns the reason if the comparison result is positive, and an empty
+// string otherwise. Sticky results are reported as hysteresis.
+func reason(r result, name string) string {
+	switch r {
+	case better:
+		return name
+	case betterSticky:
+		return ReasonHysteresis
+	default:
+		return ""
+	}
+}
+
+func compareLatency(x, y *Stats, h float64) result {
+	margin := h * math.Max(float64(x.Latency), float64(y.Latency))
+	if h > 0 {
+		margin = math.Max(margin, float64(minLatencyMargin))
+	}
+	return compare(float64(x.Latency), float64(y.Latency), margin, x.IsCurrent, y.IsCurrent)
+}
+
+func compareDropRate(x, y *Stats, h float64) result {
+	margin := h * math.Max(x.DropRate, y.DropRate)
+	if h > 0 {
+		margin = math.Max(margin, minDropRateMargin)
+	}
+	return compare(x.DropRate, y.DropRate, margin, x.IsCurrent, y.IsCurrent)
+}
+
+func compareBandwidth(x, y *Stats, h float64) result {
+	// Higher bandwidth is better, so the values are negated.
+	xv, yv := -float64(x.Bandwidth), -float64(y.Bandwidth)
+	margin := h * math.Max(-xv, -yv)
+	return compare(xv, yv, margin, x.IsCurrent, y.IsCurrent)
+}
+
+// result is the result of comparing a metric of two paths.
+type result int
+
+const (
+	worse result = iota - 1
+	equal
+	better
+	// betterSticky indicates that the path is the current path and the other
+	// path is better, but not by more than the margin.
+	betterSticky
+)
+
+// compare compares a metric for which lower values are better. If exactly one
+// of the paths is the current path, the other path must be better by more than
+// the margin to be preferred.
+func compare(xv, yv, margin float64, xCurrent, yCurrent bool) result {
+	if xCurrent != yCurrent {
+		if yCurrent {
+			if compareCurrent(yv, xv, margin) == worse {
+				return better
+			}
+			return worse
+		}
+		return compareCurrent(xv, yv, margin)
+	}
+	switch {
+	case xv < yv:
+		return better
+	case xv > yv:
+		return worse
+	default:
+		return equal
+	}
+}
+
+// compareCurrent compares the value of the current path with the value of
+// another path.
+func compareCurrent(current, other, margin float64) result {
+	switch {
+	case other+margin < current:
+		return worse
+	case current <= other:
+		return better
+	default:
+		return betterSticky
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policies_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
+)
+
+func TestPerfPolicyReason(t *testing.T) {
+	ms := time.Millisecond
+	testCases := map[string]struct {
+		Policy   policies.Explainer
+		X        policies.Stats
+		Y        policies.Stats
+		Expected string
+	}{
+		"latency lower": {
+			Policy:   policies.LowestLatency{},
+			X:        policies.Stats{Latency: 10 * ms},
+			Y:        policies.Stats{Latency: 20 * ms},
+			Expected: policies.ReasonLatency,
+		},
+		"latency higher": {
+			Policy: policies.LowestLatency{},
+			X:      policies.Stats{Latency: 20 * ms},
+			Y:      policies.Stats{Latency: 10 * ms},
+		},
+		"latency equal drop rate lower": {
+			Policy:   policies.LowestLatency{},
+			X:        policies.Stats{Latency: 10 * ms},
+			Y:        policies.Stats{Latency: 10 * ms, DropRate: 0.1},
+			Expected: policies.ReasonDropRate,
+		},
+		"latency equal": {
+			Policy: policies.LowestLatency{},
+			X:      policies.Stats{Latency: 10 * ms},
+			Y:      policies.Stats{Latency: 10 * ms},
+		},
+		"latency current within margin": {
+			Policy:   policies.LowestLatency{},
+			X:        policies.Stats{Latency: 105 * ms, IsCurrent: true},
+			Y:        policies.Stats{Latency: 100 * ms},
+			Expected: policies.ReasonHysteresis,
+		},
+		"latency other within margin": {
+			Policy: policies.LowestLatency{},
+			X:      policies.Stats{Latency: 100 * ms},
+			Y:      policies.Stats{Latency: 105 * ms, IsCurrent: true},
+		},
+		"latency other beyond margin": {
+			Policy:   policies.LowestLatency{},
+			X:        policies.Stats{Latency: 80 * ms},
+			Y:        policies.Stats{Latency: 100 * ms, IsCurrent: true},
+			Expected: policies.ReasonLatency,
+		},
+		"latency minimum margin": {
+			Policy:   policies.LowestLatency{},
+			X:        policies.Stats{Latency: 2500 * time.Microsecond, IsCurrent: true},
+			Y:        policies.Stats{Latency: 2 * ms},
+			Expected: policies.ReasonHysteresis,
+		},
+		"latency hysteresis disabled": {
+			Policy: policies.LowestLatency{Hysteresis: -1},
+			X:      policies.Stats{Latency: 105 * ms, IsCurrent: true},
+			Y:      policies.Stats{Latency: 100 * ms},
+		},
+		"loss lower": {
+			Policy:   policies.LowestLoss{},
+			X:        policies.Stats{Latency: 50 * ms, DropRate: 0.01},
+			Y:        policies.Stats{Latency: 10 * ms, DropRate: 0.2},
+			Expected: policies.ReasonDropRate,
+		},
+		"loss equal latency lower": {
+			Policy:   policies.LowestLoss{},
+			X:        policies.Stats{Latency: 10 * ms},
+			Y:        policies.Stats{Latency: 50 * ms},
+			Expected: policies.ReasonLatency,
+		},
+		"loss current within minimum margin": {
+			Policy:   policies.LowestLoss{},
+			X:        policies.Stats{DropRate: 0.005, IsCurrent: true},
+			Y:        policies.Stats{},
+			Expected: policies.ReasonHysteresis,
+		},
+		"slo satisfied": {
+			Policy:   policies.SLO{MaxLatency: 50 * ms, MaxDropRate: 0.01},
+			X:        policies.Stats{Latency: 40 * ms},
+			Y:        policies.Stats{Latency: 10 * ms, DropRate: 0.1},
+			Expected: policies.ReasonSLO,
+		},
+		"slo violated": {
+			Policy: policies.SLO{MaxLatency: 50 * ms, MaxJitter: 5 * ms},
+			X:      policies.Stats{Latency: 10 * ms, Jitter: 10 * ms},
+			Y:      policies.Stats{Latency: 40 * ms, Jitter: time.Millisecond},
+		},
+		"slo both satisfied bandwidth higher": {
+			Policy:   policies.SLO{MaxLatency: 50 * ms},
+			X:        policies.Stats{Latency: 40 * ms, Bandwidth: 1000000},
+			Y:        policies.Stats{Latency: 10 * ms, Bandwidth: 100000},
+			Expected: policies.ReasonBandwidth,
+		},
+		"slo both satisfied bandwidth equal": {
+			Policy:   policies.SLO{MaxLatency: 50 * ms},
+			X:        policies.Stats{Latency: 10 * ms, Bandwidth: 1000},
+			Y:        policies.Stats{Latency: 40 * ms, Bandwidth: 1000},
+			Expected: policies.ReasonLatency,
+		},
+		"slo both violated": {
+			Policy:   policies.SLO{MaxLatency: 5 * ms},
+			X:        policies.Stats{Latency: 10 * ms},
+			Y:        policies.Stats{Latency: 40 * ms, Bandwidth: 1000},
+			Expected: policies.ReasonLatency,
+		},
+		"slo current relaxed": {
+			Policy:   policies.SLO{MaxLatency: 50 * ms},
+			X:        policies.Stats{Latency: 52 * ms, IsCurrent: true},
+			Y:        policies.Stats{Latency: 60 * ms},
+			Expected: policies.ReasonSLO,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.Expected, tc.Policy.Reason(&tc.X, &tc.Y))
+			perf := tc.Policy.(policies.PerfPolicy)
+			assert.Equal(t, tc.Expected != "", perf.Better(&tc.X, &tc.Y))
+		})
+	}
+}
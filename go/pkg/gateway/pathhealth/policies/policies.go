@@ -42,6 +42,10 @@ type Stats struct {
 	Jitter time.Duration
 	// DropRate is a percentage of probes with no replies. From interval (0,1).
 	DropRate float64
+	// Bandwidth is the bandwidth of the path in Kbit/s, i.e., the lowest
+	// bandwidth of the links announced in the path metadata. It is zero if
+	// the bandwidth is unknown.
+	Bandwidth uint64
 
 	// Is Alive is true if the probes are passing through at the moment.
 	IsAlive bool
@@ -60,4 +64,7 @@ type Policies struct {
 	PerfPolicy PerfPolicy
 	// PathCount is the max number of paths to return to the user. Defaults to 1.
 	PathCount int
+	// Multipath indicates that the traffic is split across the returned paths
+	// in proportion to their measured performance.
+	Multipath bool
 }
@@ -16,8 +16,10 @@ package pathhealth
 
 import (
 	"sync"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
 )
 
 // State is the path state used during selection.
@@ -27,6 +29,13 @@ type State struct {
 	// IsExpired indicates that the path is expired. IsExpired == true implies IsAlive == false but
 	// not vice versa.
 	IsExpired bool
+	// Latency is the median one-way latency of the recent probes.
+	Latency time.Duration
+	// Jitter is the average difference between the one-way latencies of
+	// consecutive probes.
+	Jitter time.Duration
+	// DropRate is the fraction of the recent probes that did not get a reply.
+	DropRate float64
 }
 
 // Selectable is a subset of the PathWatcher that is used for path selection.
@@ -48,6 +57,17 @@ type Selection struct {
 	// Path is the list of selected paths. The list is sorted from best to worst
 	// according to the scoring function used by the selector.
 	Paths []snet.Path
+	// Stats contains the statistics of the selected paths. The entries are in
+	// the same order as Paths.
+	Stats []policies.Stats
+	// Weights contains the share of the traffic of each selected path. The
+	// entries are in the same order as Paths, and sum up to 1. It is only set
+	// if the selector is in multipath mode.
+	Weights []float64
+	// Reason explains why the first path is preferred over the second best
+	// path. It is one of the reasons defined in the policies package, or empty
+	// if no path is selected.
+	Reason string
 	// Info is an info string providing more info about why the path was selected.
 	Info string
 	// PathsAlive is the number of active paths available.
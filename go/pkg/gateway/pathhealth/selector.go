@@ -18,8 +18,10 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
 )
 
 const (
@@ -27,6 +29,10 @@ const (
 	deadInfo = "dead (probes are not passing through)"
 	// rejectedInfo is a string to log about paths rejected by path policies.
 	rejectedInfo = "rejected by path policy"
+	// minWeightLatency is the latency below which the weight of a path does not
+	// increase further. It prevents a path with a tiny latency from getting
+	// all the traffic.
+	minWeightLatency = time.Millisecond
 )
 
 // PathPolicy filters the set of paths.
@@ -40,18 +46,22 @@ type FilteringPathSelector struct {
 	PathPolicy PathPolicy
 	// RevocationStore keeps track of the revocations.
 	RevocationStore
+	// PerfPolicy orders the allowed paths. Paths that are equal according to
+	// the policy are ordered by length. If nil, the shortest paths are
+	// preferred.
+	PerfPolicy policies.PerfPolicy
 	// PathCount is the max number of paths to return to the user. Defaults to 1.
 	PathCount int
+	// Multipath indicates that the traffic is split across the returned paths.
+	// If set, the selection contains the weights of the paths.
+	Multipath bool
 }
 
 // Select selects the best paths.
 func (f *FilteringPathSelector) Select(selectables []Selectable, current FingerprintSet) Selection {
 	type Allowed struct {
-		Fingerprint snet.PathFingerprint
-		Path        snet.Path
-		Selectable  Selectable
-		IsCurrent   bool
-		IsRevoked   bool
+		Path  snet.Path
+		Stats policies.Stats
 	}
 
 	// Sort out the paths allowed by the path policy.
@@ -73,27 +83,53 @@ func (f *FilteringPathSelector) Select(selectables []Selectable, current Fingerp
 		fingerprint := snet.Fingerprint(path)
 		_, isCurrent := current[fingerprint]
 		allowed = append(allowed, Allowed{
-			Path:        path,
-			Fingerprint: fingerprint,
-			IsCurrent:   isCurrent,
-			IsRevoked:   f.RevocationStore.IsRevoked(path),
+			Path: path,
+			Stats: policies.Stats{
+				Fingerprint: fingerprint,
+				Latency:     state.Latency,
+				Jitter:      state.Jitter,
+				DropRate:    state.DropRate,
+				Bandwidth:   bandwidth(path),
+				IsAlive:     state.IsAlive,
+				IsCurrent:   isCurrent,
+				IsRevoked:   f.RevocationStore.IsRevoked(path),
+			},
 		})
 	}
 	// Sort the allowed paths according the the perf policy.
-	sort.SliceStable(allowed, func(i, j int) bool {
+	less := func(x, y Allowed) (bool, string) {
 		// If some of the paths are alive (probes are passing through), yet still revoked
 		// prefer the non-revoked paths as the revoked ones may be flaky.
 		switch {
-		case allowed[i].IsRevoked && !allowed[j].IsRevoked:
-			return false
-		case !allowed[i].IsRevoked && allowed[j].IsRevoked:
-			return true
+		case x.Stats.IsRevoked && !y.Stats.IsRevoked:
+			return false, ""
+		case !x.Stats.IsRevoked && y.Stats.IsRevoked:
+			return true, policies.ReasonRevocation
+		}
+		if f.PerfPolicy != nil {
+			if f.PerfPolicy.Better(&x.Stats, &y.Stats) {
+				return true, perfReason(f.PerfPolicy, &x.Stats, &y.Stats)
+			}
+			if f.PerfPolicy.Better(&y.Stats, &x.Stats) {
+				return false, ""
+			}
 		}
-		if shorter, ok := isShorter(allowed[i].Path, allowed[j].Path); ok {
-			return shorter
+		if shorter, ok := isShorter(x.Path, y.Path); ok {
+			return shorter, policies.ReasonPathLength
 		}
-		return allowed[i].Fingerprint > allowed[j].Fingerprint
+		return x.Stats.Fingerprint > y.Stats.Fingerprint, policies.ReasonFingerprint
+	}
+	sort.SliceStable(allowed, func(i, j int) bool {
+		isLess, _ := less(allowed[i], allowed[j])
+		return isLess
 	})
+	var reason string
+	switch {
+	case len(allowed) == 1:
+		reason = policies.ReasonOnlyPath
+	case len(allowed) > 1:
+		_, reason = less(allowed[0], allowed[1])
+	}
 
 	// Make the info string.
 	var format = "      %-44s %s"
@@ -101,7 +137,7 @@ func (f *FilteringPathSelector) Select(selectables []Selectable, current Fingerp
 	info = append(info, fmt.Sprintf(format, "STATE", "PATH"))
 	for _, a := range allowed {
 		var state string
-		if a.IsCurrent {
+		if a.Stats.IsCurrent {
 			state = "-->"
 		}
 		info = append(info, fmt.Sprintf(format, state, a.Path))
@@ -122,11 +158,20 @@ func (f *FilteringPathSelector) Select(selectables []Selectable, current Fingerp
 	}
 
 	paths := make([]snet.Path, 0, pathCount)
+	stats := make([]policies.Stats, 0, pathCount)
 	for i := 0; i < pathCount; i++ {
 		paths = append(paths, allowed[i].Path)
+		stats = append(stats, allowed[i].Stats)
+	}
+	var weights []float64
+	if f.Multipath {
+		weights = pathWeights(stats)
 	}
 	return Selection{
 		Paths:         paths,
+		Stats:         stats,
+		Weights:       weights,
+		Reason:        reason,
 		Info:          strings.Join(info, "\n"),
 		PathsAlive:    len(allowed),
 		PathsDead:     len(dead),
@@ -134,6 +179,72 @@ func (f *FilteringPathSelector) Select(selectables []Selectable, current Fingerp
 	}
 }
 
+// perfReason returns why the perf policy prefers x over y.
+func perfReason(policy policies.PerfPolicy, x, y *policies.Stats) string {
+	if e, ok := policy.(policies.Explainer); ok {
+		return e.Reason(x, y)
+	}
+	return policies.ReasonPerfPolicy
+}
+
+// bandwidth returns the lowest known link bandwidth of the path in Kbit/s, or
+// zero if it is unknown.
+func bandwidth(path snet.Path) uint64 {
+	meta := path.Metadata()
+	if meta == nil {
+		return 0
+	}
+	var min uint64
+	for _, bw := range meta.Bandwidth {
+		if bw != 0 && (min == 0 || bw < min) {
+			min = bw
+		}
+	}
+	return min
+}
+
+// pathWeights computes the share of the traffic of each path. The weight of a
+// path is the ratio of delivered probes divided by the latency, i.e., the
+// traffic is moved away from slow and lossy paths. Paths without latency
+// measurements get the average weight of the measured paths. The weights are
+// normalized to sum up to 1.
+func pathWeights(stats []policies.Stats) []float64 {
+	weights := make([]float64, len(stats))
+	var measuredSum float64
+	var measured int
+	for i, s := range stats {
+		if s.Latency <= 0 {
+			continue
+		}
+		latency := s.Latency
+		if latency < minWeightLatency {
+			latency = minWeightLatency
+		}
+		weights[i] = (1 - s.DropRate) / latency.Seconds()
+		measuredSum += weights[i]
+		measured++
+	}
+	unmeasured := 1.0
+	if measured > 0 {
+		unmeasured = measuredSum / float64(measured)
+	}
+	var sum float64
+	for i, s := range stats {
+		if s.Latency <= 0 {
+			weights[i] = unmeasured
+		}
+		sum += weights[i]
+	}
+	for i := range weights {
+		if sum == 0 {
+			weights[i] = 1 / float64(len(weights))
+			continue
+		}
+		weights[i] /= sum
+	}
+	return weights
+}
+
 // isPathAllowed returns true is path is allowed by the policy.
 func isPathAllowed(policy PathPolicy, path snet.Path) bool {
 	if policy == nil {
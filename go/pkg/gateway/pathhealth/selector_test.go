@@ -0,0 +1,264 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathhealth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth"
+	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
+)
+
+type selectable struct {
+	path  snet.Path
+	state pathhealth.State
+}
+
+func (s selectable) Path() snet.Path         { return s.path }
+func (s selectable) State() pathhealth.State { return s.state }
+
+func TestFilteringPathSelectorStats(t *testing.T) {
+	newPath := func(ifIDs ...common.IFIDType) snet.Path {
+		p := snetpath.Path{Dst: testIA}
+		for _, id := range ifIDs {
+			p.Meta.Interfaces = append(p.Meta.Interfaces, snet.PathInterface{IA: testIA, ID: id})
+		}
+		return p
+	}
+	short := newPath(1, 2)
+	long := newPath(3, 4, 5, 6)
+	dead := newPath(7, 8)
+
+	selector := pathhealth.FilteringPathSelector{
+		RevocationStore: &pathhealth.MemoryRevocationStore{},
+		PathCount:       3,
+	}
+	selection := selector.Select(
+		[]pathhealth.Selectable{
+			selectable{
+				path: long,
+				state: pathhealth.State{
+					IsAlive:  true,
+					Latency:  30 * time.Millisecond,
+					Jitter:   time.Millisecond,
+					DropRate: 0.5,
+				},
+			},
+			selectable{
+				path: short,
+				state: pathhealth.State{
+					IsAlive: true,
+					Latency: 10 * time.Millisecond,
+				},
+			},
+			selectable{path: dead},
+		},
+		pathhealth.FingerprintSet{snet.Fingerprint(long): {}},
+	)
+	assert.Equal(t, []snet.Path{short, long}, selection.Paths)
+	assert.Equal(t, []policies.Stats{
+		{
+			Fingerprint: snet.Fingerprint(short),
+			Latency:     10 * time.Millisecond,
+			IsAlive:     true,
+		},
+		{
+			Fingerprint: snet.Fingerprint(long),
+			Latency:     30 * time.Millisecond,
+			Jitter:      time.Millisecond,
+			DropRate:    0.5,
+			IsAlive:     true,
+			IsCurrent:   true,
+		},
+	}, selection.Stats)
+	assert.Nil(t, selection.Weights)
+	assert.Equal(t, 2, selection.PathsAlive)
+	assert.Equal(t, 1, selection.PathsDead)
+}
+
+func TestFilteringPathSelectorWeights(t *testing.T) {
+	newPath := func(ifIDs ...common.IFIDType) snet.Path {
+		p := snetpath.Path{Dst: testIA}
+		for _, id := range ifIDs {
+			p.Meta.Interfaces = append(p.Meta.Interfaces, snet.PathInterface{IA: testIA, ID: id})
+		}
+		return p
+	}
+	testCases := map[string]struct {
+		Selectables []pathhealth.Selectable
+		Expected    []float64
+	}{
+		"latency and drop rate": {
+			Selectables: []pathhealth.Selectable{
+				selectable{
+					path:  newPath(1, 2),
+					state: pathhealth.State{IsAlive: true, Latency: 10 * time.Millisecond},
+				},
+				selectable{
+					path: newPath(3, 4, 5, 6),
+					state: pathhealth.State{
+						IsAlive:  true,
+						Latency:  30 * time.Millisecond,
+						DropRate: 0.5,
+					},
+				},
+				// Unmeasured paths get the average weight.
+				selectable{
+					path:  newPath(7, 8, 9, 10, 11, 12),
+					state: pathhealth.State{IsAlive: true},
+				},
+			},
+			// 100, 16.67 and 58.33 normalized.
+			Expected: []float64{4.0 / 7, 2.0 / 21, 1.0 / 3},
+		},
+		"minimum latency": {
+			Selectables: []pathhealth.Selectable{
+				selectable{
+					path:  newPath(1, 2),
+					state: pathhealth.State{IsAlive: true, Latency: time.Microsecond},
+				},
+				selectable{
+					path:  newPath(3, 4, 5, 6),
+					state: pathhealth.State{IsAlive: true, Latency: time.Millisecond},
+				},
+			},
+			Expected: []float64{0.5, 0.5},
+		},
+		"unmeasured": {
+			Selectables: []pathhealth.Selectable{
+				selectable{path: newPath(1, 2), state: pathhealth.State{IsAlive: true}},
+				selectable{path: newPath(3, 4, 5, 6), state: pathhealth.State{IsAlive: true}},
+			},
+			Expected: []float64{0.5, 0.5},
+		},
+		"all probes dropped": {
+			Selectables: []pathhealth.Selectable{
+				selectable{
+					path: newPath(1, 2),
+					state: pathhealth.State{
+						IsAlive:  true,
+						Latency:  10 * time.Millisecond,
+						DropRate: 1,
+					},
+				},
+				selectable{
+					path: newPath(3, 4, 5, 6),
+					state: pathhealth.State{
+						IsAlive:  true,
+						Latency:  10 * time.Millisecond,
+						DropRate: 1,
+					},
+				},
+			},
+			Expected: []float64{0.5, 0.5},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			selector := pathhealth.FilteringPathSelector{
+				RevocationStore: &pathhealth.MemoryRevocationStore{},
+				PathCount:       3,
+				Multipath:       true,
+			}
+			selection := selector.Select(tc.Selectables, nil)
+			assert.InDeltaSlice(t, tc.Expected, selection.Weights, 0.0001)
+		})
+	}
+}
+
+func TestFilteringPathSelectorPerfPolicy(t *testing.T) {
+	newPath := func(bandwidth uint64, ifIDs ...common.IFIDType) snet.Path {
+		p := snetpath.Path{Dst: testIA}
+		for _, id := range ifIDs {
+			p.Meta.Interfaces = append(p.Meta.Interfaces, snet.PathInterface{IA: testIA, ID: id})
+		}
+		p.Meta.Bandwidth = []uint64{0, bandwidth, 2 * bandwidth}
+		return p
+	}
+	short := newPath(1000, 1, 2)
+	long := newPath(100000, 3, 4, 5, 6)
+
+	testCases := map[string]struct {
+		Policy         policies.PerfPolicy
+		Current        pathhealth.FingerprintSet
+		LongLatency    time.Duration
+		ExpectedPath   snet.Path
+		ExpectedReason string
+	}{
+		"no policy": {
+			LongLatency:    5 * time.Millisecond,
+			ExpectedPath:   short,
+			ExpectedReason: policies.ReasonPathLength,
+		},
+		"lowest latency": {
+			Policy:         policies.LowestLatency{},
+			LongLatency:    5 * time.Millisecond,
+			ExpectedPath:   long,
+			ExpectedReason: policies.ReasonLatency,
+		},
+		"lowest latency hysteresis": {
+			Policy:         policies.LowestLatency{},
+			Current:        pathhealth.FingerprintSet{snet.Fingerprint(short): {}},
+			LongLatency:    9500 * time.Microsecond,
+			ExpectedPath:   short,
+			ExpectedReason: policies.ReasonHysteresis,
+		},
+		"slo bandwidth": {
+			Policy:         policies.SLO{MaxLatency: 20 * time.Millisecond},
+			LongLatency:    15 * time.Millisecond,
+			ExpectedPath:   long,
+			ExpectedReason: policies.ReasonBandwidth,
+		},
+		"slo violated": {
+			Policy:         policies.SLO{MaxLatency: 12 * time.Millisecond},
+			LongLatency:    15 * time.Millisecond,
+			ExpectedPath:   short,
+			ExpectedReason: policies.ReasonSLO,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			selector := pathhealth.FilteringPathSelector{
+				RevocationStore: &pathhealth.MemoryRevocationStore{},
+				PerfPolicy:      tc.Policy,
+			}
+			selection := selector.Select(
+				[]pathhealth.Selectable{
+					selectable{
+						path:  short,
+						state: pathhealth.State{IsAlive: true, Latency: 10 * time.Millisecond},
+					},
+					selectable{
+						path:  long,
+						state: pathhealth.State{IsAlive: true, Latency: tc.LongLatency},
+					},
+				},
+				tc.Current,
+			)
+			assert.Equal(t, []snet.Path{tc.ExpectedPath}, selection.Paths)
+			assert.Equal(t, tc.ExpectedReason, selection.Reason)
+		})
+	}
+}
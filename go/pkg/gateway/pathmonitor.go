@@ -16,9 +16,11 @@ package gateway
 
 import (
 	"strconv"
+	"sync"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/pkg/gateway/control"
 	"github.com/scionproto/scion/go/pkg/gateway/pathhealth"
 	"github.com/scionproto/scion/go/pkg/gateway/pathhealth/policies"
@@ -29,6 +31,7 @@ type PathMonitor struct {
 	*pathhealth.Monitor
 	revStore              pathhealth.RevocationStore
 	sessionPathsAvailable metrics.Gauge
+	sessionPathSwitches   metrics.Counter
 }
 
 func (pm *PathMonitor) Register(remote addr.IA, policies *policies.Policies,
@@ -36,7 +39,9 @@ func (pm *PathMonitor) Register(remote addr.IA, policies *policies.Policies,
 
 	reg := pm.Monitor.Register(remote, &pathhealth.FilteringPathSelector{
 		PathPolicy:      policies.PathPolicy,
+		PerfPolicy:      policies.PerfPolicy,
 		PathCount:       policies.PathCount,
+		Multipath:       policies.Multipath,
 		RevocationStore: pm.revStore,
 	})
 	return &registration{
@@ -46,12 +51,22 @@ func (pm *PathMonitor) Register(remote addr.IA, policies *policies.Policies,
 			"remote_isd_as", remote.String(),
 			"policy_id", strconv.Itoa(policyID),
 		),
+		sessionPathSwitches: metrics.CounterWith(
+			pm.sessionPathSwitches,
+			"remote_isd_as", remote.String(),
+			"policy_id", strconv.Itoa(policyID),
+		),
 	}
 }
 
 type registration struct {
 	*pathhealth.Registration
 	sessionPathsAvailable metrics.Gauge
+	sessionPathSwitches   metrics.Counter
+
+	mtx sync.Mutex
+	// best is the fingerprint of the best path of the last selection.
+	best snet.PathFingerprint
 }
 
 func (r *registration) Get() pathhealth.Selection {
@@ -61,5 +76,22 @@ func (r *registration) Get() pathhealth.Selection {
 		r.sessionPathsAvailable.With("status", "timeout").Set(float64(selection.PathsDead))
 		r.sessionPathsAvailable.With("status", "rejected").Set(float64(selection.PathsRejected))
 	}
+	if len(selection.Stats) != 0 {
+		r.recordSwitch(selection.Stats[0].Fingerprint, selection.Reason)
+	}
 	return selection
 }
+
+// recordSwitch counts the change of the best path, if any, with the reason
+// the new path was chosen.
+func (r *registration) recordSwitch(best snet.PathFingerprint, reason string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if best == r.best {
+		return
+	}
+	r.best = best
+	if r.sessionPathSwitches != nil {
+		r.sessionPathSwitches.With("reason", reason).Add(1)
+	}
+}
@@ -32,6 +32,25 @@ func AllowedPrefixesBGP(pol *Policy, from, to addr.IA) []*net.IPNet {
 	return extractList(pol, from, to, RedistributeBGP)
 }
 
+// RedistributeList returns the learned prefixes that are redistributed from
+// the given ISD-AS to the other one. A learned prefix is redistributed if it
+// matches a redistribute-bgp rule of the policy.
+func RedistributeList(pol *Policy, from, to addr.IA, learned []*net.IPNet) []*net.IPNet {
+	if pol == nil {
+		return []*net.IPNet{}
+	}
+	var nets []*net.IPNet
+	for _, prefix := range learned {
+		for _, r := range pol.Rules {
+			if r.Action == RedistributeBGP && r.Match(from, to, prefix) {
+				nets = append(nets, prefix)
+				break
+			}
+		}
+	}
+	return nets
+}
+
 func extractList(pol *Policy, from, to addr.IA, action Action) []*net.IPNet {
 	if pol == nil {
 		return []*net.IPNet{}
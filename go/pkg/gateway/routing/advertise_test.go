@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/pkg/gateway/routing"
 )
 
@@ -103,3 +104,47 @@ func TestStaticAdvertiseList(t *testing.T) {
 		{IP: net.ParseIP("10.0.0.0").To4(), Mask: net.CIDRMask(16, 32)},
 	}, routing.StaticAdvertised(&policy))
 }
+
+func TestRedistributeList(t *testing.T) {
+	from := addr.IA{I: 1}
+	to := addr.IA{I: 2}
+	learned := []*net.IPNet{
+		xtest.MustParseCIDR(t, "10.0.1.0/24"),
+		xtest.MustParseCIDR(t, "10.1.0.0/16"),
+		xtest.MustParseCIDR(t, "127.1.0.0/30"),
+		xtest.MustParseCIDR(t, "192.168.0.0/24"),
+	}
+
+	policy := routing.Policy{DefaultAction: routing.Reject}
+
+	assert.Empty(t, routing.RedistributeList(nil, from, to, learned))
+	assert.Empty(t, routing.RedistributeList(&policy, from, to, learned))
+
+	policy.Rules = append(policy.Rules, routing.Rule{
+		Action:  routing.Advertise,
+		From:    routing.NewIAMatcher(t, "1-0"),
+		To:      routing.NewIAMatcher(t, "2-0"),
+		Network: routing.NewNetworkMatcher(t, "192.168.0.0/16"),
+	})
+	policy.Rules = append(policy.Rules, routing.Rule{
+		Action:  routing.RedistributeBGP,
+		From:    routing.NewIAMatcher(t, "1-0"),
+		To:      routing.NewIAMatcher(t, "2-0"),
+		Network: routing.NewNetworkMatcher(t, "127.1.0.0/30,10.0.0.0/16"),
+	})
+	policy.Rules = append(policy.Rules, routing.Rule{
+		Action:  routing.RedistributeBGP,
+		From:    routing.NewIAMatcher(t, "2-0"),
+		To:      routing.NewIAMatcher(t, "1-0"),
+		Network: routing.NewNetworkMatcher(t, "!127.1.0.0/30"),
+	})
+	assert.Equal(t, []*net.IPNet{
+		xtest.MustParseCIDR(t, "10.0.1.0/24"),
+		xtest.MustParseCIDR(t, "127.1.0.0/30"),
+	}, routing.RedistributeList(&policy, from, to, learned))
+	assert.Equal(t, []*net.IPNet{
+		xtest.MustParseCIDR(t, "10.0.1.0/24"),
+		xtest.MustParseCIDR(t, "10.1.0.0/16"),
+		xtest.MustParseCIDR(t, "192.168.0.0/24"),
+	}, routing.RedistributeList(&policy, to, from, learned))
+}
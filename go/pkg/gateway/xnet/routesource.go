@@ -0,0 +1,143 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xnet
+
+import (
+	"context"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/vishvananda/netlink"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// defaultResyncDelay is the time the route source waits after a change in the
+// routing table before it re-reads the table. This way, bursts of changes,
+// e.g., when a BGP session comes up, only cause a single sync.
+const defaultResyncDelay = time.Second
+
+// RouteSource learns IP prefixes from a Linux routing table. This allows other
+// routing daemons running on the same host, e.g., BIRD or FRR, to inject the
+// prefixes the gateway should redistribute to remote gateways. Only unicast
+// routes are considered, default routes are ignored.
+type RouteSource struct {
+	// TableID is the ID of the Linux routing table the prefixes are learned
+	// from.
+	TableID int
+	// ResyncDelay is the time to wait after a change in the routing table
+	// before the table is re-read. If it is zero, a default of 1s is used.
+	ResyncDelay time.Duration
+	// Logger is used to log the changes of the learned prefixes. If nil, no
+	// logging is done.
+	Logger log.Logger
+
+	// netlink is used to access the routing table. If nil, the routing tables
+	// of the host are used.
+	netlink netlinkHandle
+
+	mtx      sync.RWMutex
+	prefixes []*net.IPNet
+}
+
+// Run subscribes to route updates and keeps the learned prefixes in sync with
+// the routing table until the context is canceled.
+func (s *RouteSource) Run(ctx context.Context) error {
+	updates := make(chan netlink.RouteUpdate)
+	done := make(chan struct{})
+	if err := s.nl().RouteSubscribe(updates, done); err != nil {
+		return serrors.WrapStr("subscribing to route updates", err)
+	}
+	defer func() {
+		close(done)
+		// Drain the updates so that the subscription go routine can exit.
+		for range updates {
+		}
+	}()
+
+	if err := s.sync(); err != nil {
+		return err
+	}
+	delay := s.ResyncDelay
+	if delay == 0 {
+		delay = defaultResyncDelay
+	}
+	timer := time.NewTimer(delay)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case u, ok := <-updates:
+			if !ok {
+				return serrors.New("route update subscription closed unexpectedly")
+			}
+			if u.Table != s.TableID {
+				continue
+			}
+			timer.Reset(delay)
+		case <-timer.C:
+			if err := s.sync(); err != nil {
+				log.SafeInfo(s.Logger, "Failed to read routing table",
+					"table", s.TableID, "err", err)
+				timer.Reset(delay)
+			}
+		}
+	}
+}
+
+// Prefixes returns the currently learned prefixes.
+func (s *RouteSource) Prefixes() []*net.IPNet {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return append([]*net.IPNet(nil), s.prefixes...)
+}
+
+func (s *RouteSource) nl() netlinkHandle {
+	if s.netlink == nil {
+		return systemNetlink{}
+	}
+	return s.netlink
+}
+
+func (s *RouteSource) sync() error {
+	filter := &netlink.Route{
+		Table: s.TableID,
+		Type:  syscall.RTN_UNICAST,
+	}
+	routes, err := s.nl().RouteListFiltered(netlink.FAMILY_ALL, filter,
+		netlink.RT_FILTER_TABLE|netlink.RT_FILTER_TYPE)
+	if err != nil {
+		return serrors.WrapStr("listing routes", err, "table", s.TableID)
+	}
+	prefixes := make([]*net.IPNet, 0, len(routes))
+	for _, r := range routes {
+		if r.Dst == nil {
+			continue
+		}
+		prefixes = append(prefixes, r.Dst)
+	}
+
+	log.SafeDebug(s.Logger, "Synced learned prefixes",
+		"table", s.TableID, "prefixes", prefixes)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.prefixes = prefixes
+	return nil
+}
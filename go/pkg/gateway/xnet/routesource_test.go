@@ -0,0 +1,301 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xnet
+
+import (
+	"context"
+	"net"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestRouteSourceSync(t *testing.T) {
+	testCases := map[string]struct {
+		Routes    []netlink.Route
+		ListErr   error
+		Expected  []*net.IPNet
+		ExpectErr bool
+	}{
+		"empty table": {},
+		"unicast routes": {
+			Routes: []netlink.Route{
+				unicastRoute(t, 10, "10.1.0.0/16"),
+				unicastRoute(t, 10, "2001:db8::/32"),
+			},
+			Expected: []*net.IPNet{
+				xtest.MustParseCIDR(t, "10.1.0.0/16"),
+				xtest.MustParseCIDR(t, "2001:db8::/32"),
+			},
+		},
+		"default route is ignored": {
+			Routes: []netlink.Route{
+				{Table: 10, Type: syscall.RTN_UNICAST},
+				unicastRoute(t, 10, "10.1.0.0/16"),
+			},
+			Expected: []*net.IPNet{xtest.MustParseCIDR(t, "10.1.0.0/16")},
+		},
+		"other tables are ignored": {
+			Routes: []netlink.Route{
+				unicastRoute(t, 10, "10.1.0.0/16"),
+				unicastRoute(t, 20, "10.2.0.0/16"),
+			},
+			Expected: []*net.IPNet{xtest.MustParseCIDR(t, "10.1.0.0/16")},
+		},
+		"non-unicast routes are ignored": {
+			Routes: []netlink.Route{
+				unicastRoute(t, 10, "10.1.0.0/16"),
+				{
+					Table: 10,
+					Type:  syscall.RTN_BLACKHOLE,
+					Dst:   xtest.MustParseCIDR(t, "10.3.0.0/16"),
+				},
+			},
+			Expected: []*net.IPNet{xtest.MustParseCIDR(t, "10.1.0.0/16")},
+		},
+		"list error": {
+			ListErr:   serrors.New("test error"),
+			ExpectErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			nl := &fakeNetlink{routes: tc.Routes, listErr: tc.ListErr}
+			s := &RouteSource{TableID: 10, netlink: nl}
+			err := s.sync()
+			if tc.ExpectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, s.Prefixes())
+		})
+	}
+}
+
+func TestRouteSourceRun(t *testing.T) {
+	const delay = time.Millisecond
+	testCases := map[string]struct {
+		Updates       []netlink.RouteUpdate
+		Expected      []*net.IPNet
+		ExpectedLists int
+	}{
+		"initial sync": {
+			Expected:      []*net.IPNet{xtest.MustParseCIDR(t, "10.1.0.0/16")},
+			ExpectedLists: 1,
+		},
+		"update of the table": {
+			Updates: []netlink.RouteUpdate{
+				{Type: syscall.RTM_NEWROUTE, Route: unicastRoute(t, 10, "10.2.0.0/16")},
+			},
+			Expected: []*net.IPNet{
+				xtest.MustParseCIDR(t, "10.1.0.0/16"),
+				xtest.MustParseCIDR(t, "10.2.0.0/16"),
+			},
+			ExpectedLists: 2,
+		},
+		"update of another table": {
+			Updates: []netlink.RouteUpdate{
+				{Type: syscall.RTM_NEWROUTE, Route: unicastRoute(t, 20, "10.2.0.0/16")},
+			},
+			Expected:      []*net.IPNet{xtest.MustParseCIDR(t, "10.1.0.0/16")},
+			ExpectedLists: 1,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			nl := &fakeNetlink{
+				routes:     []netlink.Route{unicastRoute(t, 10, "10.1.0.0/16")},
+				subscribed: make(chan struct{}),
+			}
+			s := &RouteSource{TableID: 10, ResyncDelay: delay, netlink: nl}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			errs := make(chan error, 1)
+			go func() {
+				errs <- s.Run(ctx)
+			}()
+			<-nl.subscribed
+
+			for _, u := range tc.Updates {
+				nl.add(u.Route)
+				nl.send(u)
+			}
+			// The updates are processed in order, so an update that is
+			// received after the others guarantees that they were handled.
+			nl.send(netlink.RouteUpdate{Route: netlink.Route{Table: 30}})
+
+			if tc.ExpectedLists > 1 {
+				assert.Eventually(t, func() bool {
+					return nl.lists() == tc.ExpectedLists
+				}, time.Second, delay)
+			} else {
+				time.Sleep(20 * delay)
+			}
+			assert.Equal(t, tc.ExpectedLists, nl.lists())
+			assert.Equal(t, tc.Expected, s.Prefixes())
+
+			cancel()
+			assert.NoError(t, <-errs)
+		})
+	}
+
+	t.Run("closed subscription", func(t *testing.T) {
+		nl := &fakeNetlink{subscribed: make(chan struct{})}
+		s := &RouteSource{TableID: 10, netlink: nl}
+		errs := make(chan error, 1)
+		go func() {
+			errs <- s.Run(context.Background())
+		}()
+		<-nl.subscribed
+		nl.closeUpdates()
+		assert.Error(t, <-errs)
+	})
+
+	t.Run("subscribe error", func(t *testing.T) {
+		nl := &fakeNetlink{subscribeErr: serrors.New("test error")}
+		s := &RouteSource{TableID: 10, netlink: nl}
+		assert.Error(t, s.Run(context.Background()))
+	})
+}
+
+func unicastRoute(t *testing.T, table int, dst string) netlink.Route {
+	return netlink.Route{
+		Table: table,
+		Type:  syscall.RTN_UNICAST,
+		Dst:   xtest.MustParseCIDR(t, dst),
+	}
+}
+
+// fakeNetlink is a netlink handle that operates on an in-memory routing
+// table.
+type fakeNetlink struct {
+	listErr      error
+	subscribeErr error
+	// subscribed is closed when the subscription to route updates is set up.
+	subscribed chan struct{}
+
+	mtx       sync.Mutex
+	routes    []netlink.Route
+	added     []netlink.Route
+	deleted   []netlink.Route
+	listCalls int
+
+	subMtx  sync.Mutex
+	updates chan<- netlink.RouteUpdate
+	done    <-chan struct{}
+	closed  bool
+}
+
+func (f *fakeNetlink) RouteSubscribe(ch chan<- netlink.RouteUpdate,
+	done <-chan struct{}) error {
+
+	if f.subscribeErr != nil {
+		return f.subscribeErr
+	}
+	f.subMtx.Lock()
+	f.updates, f.done = ch, done
+	f.subMtx.Unlock()
+	go func() {
+		<-done
+		f.closeUpdates()
+	}()
+	close(f.subscribed)
+	return nil
+}
+
+func (f *fakeNetlink) RouteListFiltered(family int, filter *netlink.Route,
+	filterMask uint64) ([]netlink.Route, error) {
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.listCalls++
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	var routes []netlink.Route
+	for _, r := range f.routes {
+		if filterMask&netlink.RT_FILTER_TABLE != 0 && r.Table != filter.Table {
+			continue
+		}
+		if filterMask&netlink.RT_FILTER_TYPE != 0 && r.Type != filter.Type {
+			continue
+		}
+		routes = append(routes, r)
+	}
+	return routes, nil
+}
+
+func (f *fakeNetlink) RouteAdd(route *netlink.Route) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.added = append(f.added, *route)
+	return nil
+}
+
+func (f *fakeNetlink) RouteDel(route *netlink.Route) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.deleted = append(f.deleted, *route)
+	return nil
+}
+
+func (f *fakeNetlink) add(r netlink.Route) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.routes = append(f.routes, r)
+}
+
+func (f *fakeNetlink) lists() int {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.listCalls
+}
+
+// send delivers the update to the subscriber. It returns once the update is
+// received or the subscription is closed.
+func (f *fakeNetlink) send(u netlink.RouteUpdate) {
+	f.subMtx.Lock()
+	defer f.subMtx.Unlock()
+	if f.closed {
+		return
+	}
+	select {
+	case f.updates <- u:
+	case <-f.done:
+	}
+}
+
+func (f *fakeNetlink) closeUpdates() {
+	f.subMtx.Lock()
+	defer f.subMtx.Unlock()
+	if f.closed {
+		return
+	}
+	f.closed = true
+	close(f.updates)
+}
@@ -31,7 +31,7 @@ import (
 )
 
 const (
-	// SIGRPriority is the metric to use when inserting routes in the Linux routing table.
+	// SIGRPriority is the default metric to use when inserting routes in the Linux routing table.
 	// This follows the convention of FRR, where BGP routes are inserted with a metric
 	// equal to the administrative distance (in the case of BGP, this would be 20).
 	// For Gateway routes, the administrative distance is 15, hence the priority.
@@ -121,6 +121,9 @@ func open(name string, options ...DeviceOption) (control.Device, error) {
 			link:            link,
 			ReadWriteCloser: &errorReadWriteCloser{},
 			logger:          o.logger,
+			nl:              systemNetlink{},
+			table:           o.table,
+			metric:          o.metric,
 		}, nil
 	}
 
@@ -135,17 +138,26 @@ func open(name string, options ...DeviceOption) (control.Device, error) {
 		link:            link,
 		ReadWriteCloser: rwc,
 		logger:          o.logger,
+		nl:              systemNetlink{},
+		table:           o.table,
+		metric:          o.metric,
 	}, nil
 }
 
 type deviceHandle struct {
 	link   netlink.Link
 	logger log.Logger
+	// table is the Linux routing table the routes are inserted in. 0 means the
+	// main table.
+	table int
+	// metric is the priority the routes are inserted with.
+	metric int
+	nl     netlinkHandle
 	io.ReadWriteCloser
 }
 
 func (h deviceHandle) AddRoute(r *control.Route) error {
-	err := addRoute(0, h.link, r.Prefix, r.Source)
+	err := addRoute(h.nl, h.table, h.metric, h.link, r.Prefix, r.Source)
 	if err != nil {
 		log.SafeDebug(h.logger, "Failed to add route",
 			"tun", h.link.Attrs().Name, "route", r, "err", err)
@@ -157,7 +169,7 @@ func (h deviceHandle) AddRoute(r *control.Route) error {
 }
 
 func (h deviceHandle) DeleteRoute(r *control.Route) error {
-	err := deleteRoute(0, h.link, r.Prefix, r.Source)
+	err := deleteRoute(h.nl, h.table, h.metric, h.link, r.Prefix, r.Source)
 	if err != nil {
 		log.SafeDebug(h.logger, "Failed to delete route",
 			"tun", h.link.Attrs().Name, "route", r, "err", err)
@@ -179,49 +191,86 @@ func (h deviceHandle) Close() error {
 	return nil
 }
 
-func addRoute(rTable int, link netlink.Link, dest *net.IPNet, src net.IP) error {
+func addRoute(nl netlinkHandle, rTable, metric int, link netlink.Link, dest *net.IPNet,
+	src net.IP) error {
+
 	route := &netlink.Route{
 		LinkIndex: link.Attrs().Index,
 		Dst:       dest,
-		Priority:  SIGRPriority,
+		Priority:  metric,
 		Table:     rTable,
 	}
 	if len(src) > 0 {
 		route.Src = src
 	}
-	if err := netlink.RouteAdd(route); err != nil {
+	if err := nl.RouteAdd(route); err != nil {
 		return serrors.WrapStr("EgressReader: Unable to add SIG route", err,
 			"route", route)
 	}
 	return nil
 }
 
-func deleteRoute(rTable int, link netlink.Link, dest *net.IPNet, src net.IP) error {
+func deleteRoute(nl netlinkHandle, rTable, metric int, link netlink.Link, dest *net.IPNet,
+	src net.IP) error {
+
 	route := &netlink.Route{
 		LinkIndex: link.Attrs().Index,
 		Dst:       dest,
-		Priority:  SIGRPriority,
+		Priority:  metric,
 		Table:     rTable,
 	}
 	if len(src) > 0 {
 		route.Src = src
 	}
-	if err := netlink.RouteDel(route); err != nil {
+	if err := nl.RouteDel(route); err != nil {
 		return serrors.WrapStr("EgressReader: Unable to delete SIG route", err,
 			"route", route)
 	}
 	return nil
 }
 
+// netlinkHandle is the part of the netlink API that is used to import routes
+// from and export routes to the Linux routing tables.
+type netlinkHandle interface {
+	RouteSubscribe(ch chan<- netlink.RouteUpdate, done <-chan struct{}) error
+	RouteListFiltered(family int, filter *netlink.Route,
+		filterMask uint64) ([]netlink.Route, error)
+	RouteAdd(route *netlink.Route) error
+	RouteDel(route *netlink.Route) error
+}
+
+// systemNetlink uses the routing tables of the host.
+type systemNetlink struct{}
+
+func (systemNetlink) RouteSubscribe(ch chan<- netlink.RouteUpdate, done <-chan struct{}) error {
+	return netlink.RouteSubscribe(ch, done)
+}
+
+func (systemNetlink) RouteListFiltered(family int, filter *netlink.Route,
+	filterMask uint64) ([]netlink.Route, error) {
+
+	return netlink.RouteListFiltered(family, filter, filterMask)
+}
+
+func (systemNetlink) RouteAdd(route *netlink.Route) error {
+	return netlink.RouteAdd(route)
+}
+
+func (systemNetlink) RouteDel(route *netlink.Route) error {
+	return netlink.RouteDel(route)
+}
+
 type deviceOptions struct {
 	logger              log.Logger
 	routingOnlyNoCreate bool
+	table               int
+	metric              int
 }
 
 type DeviceOption func(*deviceOptions)
 
 func applyDeviceOptions(fs []DeviceOption) deviceOptions {
-	o := deviceOptions{}
+	o := deviceOptions{metric: SIGRPriority}
 	for _, f := range fs {
 		f(&o)
 	}
@@ -246,6 +295,24 @@ func WithRoutingOnlyNoCreate() DeviceOption {
 	}
 }
 
+// WithRouteTable sets the Linux routing table the routes of the device are
+// inserted in. If the table is 0, the main table is used.
+func WithRouteTable(table int) DeviceOption {
+	return func(o *deviceOptions) {
+		o.table = table
+	}
+}
+
+// WithRouteMetric sets the metric the routes of the device are inserted with.
+// If the metric is 0, SIGRPriority is used.
+func WithRouteMetric(metric int) DeviceOption {
+	return func(o *deviceOptions) {
+		if metric != 0 {
+			o.metric = metric
+		}
+	}
+}
+
 type errorReadWriteCloser struct{}
 
 func (*errorReadWriteCloser) Read(b []byte) (int, error) {
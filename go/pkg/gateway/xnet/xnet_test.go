@@ -0,0 +1,88 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xnet
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/gateway/control"
+)
+
+func TestDeviceHandleRoutes(t *testing.T) {
+	link := &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Index: 7, Name: "tun0"}}
+	testCases := map[string]struct {
+		Options  []DeviceOption
+		Route    *control.Route
+		Expected netlink.Route
+	}{
+		"defaults": {
+			Route: &control.Route{Prefix: xtest.MustParseCIDR(t, "10.1.0.0/16")},
+			Expected: netlink.Route{
+				LinkIndex: 7,
+				Dst:       xtest.MustParseCIDR(t, "10.1.0.0/16"),
+				Priority:  SIGRPriority,
+			},
+		},
+		"table and metric": {
+			Options: []DeviceOption{WithRouteTable(100), WithRouteMetric(30)},
+			Route:   &control.Route{Prefix: xtest.MustParseCIDR(t, "10.1.0.0/16")},
+			Expected: netlink.Route{
+				LinkIndex: 7,
+				Dst:       xtest.MustParseCIDR(t, "10.1.0.0/16"),
+				Priority:  30,
+				Table:     100,
+			},
+		},
+		"zero metric": {
+			Options: []DeviceOption{WithRouteMetric(0)},
+			Route:   &control.Route{Prefix: xtest.MustParseCIDR(t, "10.1.0.0/16")},
+			Expected: netlink.Route{
+				LinkIndex: 7,
+				Dst:       xtest.MustParseCIDR(t, "10.1.0.0/16"),
+				Priority:  SIGRPriority,
+			},
+		},
+		"source hint": {
+			Route: &control.Route{
+				Prefix: xtest.MustParseCIDR(t, "10.1.0.0/16"),
+				Source: net.IP{192, 0, 2, 1},
+			},
+			Expected: netlink.Route{
+				LinkIndex: 7,
+				Dst:       xtest.MustParseCIDR(t, "10.1.0.0/16"),
+				Priority:  SIGRPriority,
+				Src:       net.IP{192, 0, 2, 1},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			o := applyDeviceOptions(tc.Options)
+			nl := &fakeNetlink{}
+			h := deviceHandle{link: link, table: o.table, metric: o.metric, nl: nl}
+			assert.NoError(t, h.AddRoute(tc.Route))
+			assert.NoError(t, h.DeleteRoute(tc.Route))
+			assert.Equal(t, []netlink.Route{tc.Expected}, nl.added)
+			assert.Equal(t, []netlink.Route{tc.Expected}, nl.deleted)
+		})
+	}
+}
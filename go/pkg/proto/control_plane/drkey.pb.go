@@ -0,0 +1,597 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.15.3
+// source: proto/control_plane/v1/drkey.proto
+
+package control_plane
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type DRKeyLvl1Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=val_time,json=valTime,proto3" json:"val_time,omitempty"`
+}
+
+func (x *DRKeyLvl1Request) Reset() {
+	*x = DRKeyLvl1Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_drkey_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyLvl1Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyLvl1Request) ProtoMessage() {}
+
+func (x *DRKeyLvl1Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_drkey_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyLvl1Request.ProtoReflect.Descriptor instead.
+func (*DRKeyLvl1Request) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_drkey_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DRKeyLvl1Request) GetValTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValTime
+	}
+	return nil
+}
+
+type DRKeyLvl1Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EpochBegin *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=epoch_begin,json=epochBegin,proto3" json:"epoch_begin,omitempty"`
+	EpochEnd   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=epoch_end,json=epochEnd,proto3" json:"epoch_end,omitempty"`
+	Key        []byte                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *DRKeyLvl1Response) Reset() {
+	*x = DRKeyLvl1Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_drkey_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyLvl1Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyLvl1Response) ProtoMessage() {}
+
+func (x *DRKeyLvl1Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_drkey_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyLvl1Response.ProtoReflect.Descriptor instead.
+func (*DRKeyLvl1Response) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_drkey_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DRKeyLvl1Response) GetEpochBegin() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EpochBegin
+	}
+	return nil
+}
+
+func (x *DRKeyLvl1Response) GetEpochEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EpochEnd
+	}
+	return nil
+}
+
+func (x *DRKeyLvl1Response) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type DRKeyIntraLvl1Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValTime  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=val_time,json=valTime,proto3" json:"val_time,omitempty"`
+	SrcIsdAs uint64                 `protobuf:"varint,2,opt,name=src_isd_as,json=srcIsdAs,proto3" json:"src_isd_as,omitempty"`
+	DstIsdAs uint64                 `protobuf:"varint,3,opt,name=dst_isd_as,json=dstIsdAs,proto3" json:"dst_isd_as,omitempty"`
+}
+
+func (x *DRKeyIntraLvl1Request) Reset() {
+	*x = DRKeyIntraLvl1Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_drkey_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyIntraLvl1Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyIntraLvl1Request) ProtoMessage() {}
+
+func (x *DRKeyIntraLvl1Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_drkey_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyIntraLvl1Request.ProtoReflect.Descriptor instead.
+func (*DRKeyIntraLvl1Request) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_drkey_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DRKeyIntraLvl1Request) GetValTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValTime
+	}
+	return nil
+}
+
+func (x *DRKeyIntraLvl1Request) GetSrcIsdAs() uint64 {
+	if x != nil {
+		return x.SrcIsdAs
+	}
+	return 0
+}
+
+func (x *DRKeyIntraLvl1Request) GetDstIsdAs() uint64 {
+	if x != nil {
+		return x.DstIsdAs
+	}
+	return 0
+}
+
+type DRKeyIntraLvl1Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EpochBegin *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=epoch_begin,json=epochBegin,proto3" json:"epoch_begin,omitempty"`
+	EpochEnd   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=epoch_end,json=epochEnd,proto3" json:"epoch_end,omitempty"`
+	Key        []byte                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *DRKeyIntraLvl1Response) Reset() {
+	*x = DRKeyIntraLvl1Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_control_plane_v1_drkey_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DRKeyIntraLvl1Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DRKeyIntraLvl1Response) ProtoMessage() {}
+
+func (x *DRKeyIntraLvl1Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_control_plane_v1_drkey_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DRKeyIntraLvl1Response.ProtoReflect.Descriptor instead.
+func (*DRKeyIntraLvl1Response) Descriptor() ([]byte, []int) {
+	return file_proto_control_plane_v1_drkey_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DRKeyIntraLvl1Response) GetEpochBegin() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EpochBegin
+	}
+	return nil
+}
+
+func (x *DRKeyIntraLvl1Response) GetEpochEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EpochEnd
+	}
+	return nil
+}
+
+func (x *DRKeyIntraLvl1Response) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+var File_proto_control_plane_v1_drkey_proto protoreflect.FileDescriptor
+
+var file_proto_control_plane_v1_drkey_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f,
+	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x72, 0x6b, 0x65, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x49, 0x0a,
+	0x10, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x31, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x35, 0x0a, 0x08, 0x76, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x07, 0x76, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x9b, 0x01, 0x0a, 0x11, 0x44, 0x52, 0x4b,
+	0x65, 0x79, 0x4c, 0x76, 0x6c, 0x31, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b,
+	0x0a, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0a, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x37, 0x0a, 0x09, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x45, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x8a, 0x01, 0x0a, 0x15, 0x44, 0x52, 0x4b, 0x65, 0x79,
+	0x49, 0x6e, 0x74, 0x72, 0x61, 0x4c, 0x76, 0x6c, 0x31, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x35, 0x0a, 0x08, 0x76, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07,
+	0x76, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x0a, 0x73, 0x72, 0x63, 0x5f, 0x69,
+	0x73, 0x64, 0x5f, 0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x72, 0x63,
+	0x49, 0x73, 0x64, 0x41, 0x73, 0x12, 0x1c, 0x0a, 0x0a, 0x64, 0x73, 0x74, 0x5f, 0x69, 0x73, 0x64,
+	0x5f, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x73, 0x74, 0x49, 0x73,
+	0x64, 0x41, 0x73, 0x22, 0xa0, 0x01, 0x0a, 0x16, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x49, 0x6e, 0x74,
+	0x72, 0x61, 0x4c, 0x76, 0x6c, 0x31, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b,
+	0x0a, 0x0b, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0a, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x37, 0x0a, 0x09, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x45, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x32, 0x77, 0x0a, 0x11, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x62, 0x0a, 0x09, 0x44,
+	0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x31, 0x12, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x4c, 0x76, 0x6c, 0x31, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x52, 0x4b, 0x65,
+	0x79, 0x4c, 0x76, 0x6c, 0x31, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32,
+	0x86, 0x01, 0x0a, 0x11, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x49, 0x6e, 0x74, 0x72, 0x61, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x71, 0x0a, 0x0e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x49, 0x6e,
+	0x74, 0x72, 0x61, 0x4c, 0x76, 0x6c, 0x31, 0x12, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x52, 0x4b, 0x65, 0x79, 0x49, 0x6e, 0x74, 0x72, 0x61, 0x4c, 0x76, 0x6c, 0x31, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x52, 0x4b, 0x65, 0x79, 0x49, 0x6e, 0x74, 0x72, 0x61, 0x4c, 0x76, 0x6c, 0x31, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x70, 0x6c, 0x61,
+	0x6e, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_control_plane_v1_drkey_proto_rawDescOnce sync.Once
+	file_proto_control_plane_v1_drkey_proto_rawDescData = file_proto_control_plane_v1_drkey_proto_rawDesc
+)
+
+func file_proto_control_plane_v1_drkey_proto_rawDescGZIP() []byte {
+	file_proto_control_plane_v1_drkey_proto_rawDescOnce.Do(func() {
+		file_proto_control_plane_v1_drkey_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_control_plane_v1_drkey_proto_rawDescData)
+	})
+	return file_proto_control_plane_v1_drkey_proto_rawDescData
+}
+
+var file_proto_control_plane_v1_drkey_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_control_plane_v1_drkey_proto_goTypes = []interface{}{
+	(*DRKeyLvl1Request)(nil),       // 0: proto.control_plane.v1.DRKeyLvl1Request
+	(*DRKeyLvl1Response)(nil),      // 1: proto.control_plane.v1.DRKeyLvl1Response
+	(*DRKeyIntraLvl1Request)(nil),  // 2: proto.control_plane.v1.DRKeyIntraLvl1Request
+	(*DRKeyIntraLvl1Response)(nil), // 3: proto.control_plane.v1.DRKeyIntraLvl1Response
+	(*timestamppb.Timestamp)(nil),  // 4: google.protobuf.Timestamp
+}
+var file_proto_control_plane_v1_drkey_proto_depIdxs = []int32{
+	4, // 0: proto.control_plane.v1.DRKeyLvl1Request.val_time:type_name -> google.protobuf.Timestamp
+	4, // 1: proto.control_plane.v1.DRKeyLvl1Response.epoch_begin:type_name -> google.protobuf.Timestamp
+	4, // 2: proto.control_plane.v1.DRKeyLvl1Response.epoch_end:type_name -> google.protobuf.Timestamp
+	4, // 3: proto.control_plane.v1.DRKeyIntraLvl1Request.val_time:type_name -> google.protobuf.Timestamp
+	4, // 4: proto.control_plane.v1.DRKeyIntraLvl1Response.epoch_begin:type_name -> google.protobuf.Timestamp
+	4, // 5: proto.control_plane.v1.DRKeyIntraLvl1Response.epoch_end:type_name -> google.protobuf.Timestamp
+	0, // 6: proto.control_plane.v1.DRKeyInterService.DRKeyLvl1:input_type -> proto.control_plane.v1.DRKeyLvl1Request
+	2, // 7: proto.control_plane.v1.DRKeyIntraService.DRKeyIntraLvl1:input_type -> proto.control_plane.v1.DRKeyIntraLvl1Request
+	1, // 8: proto.control_plane.v1.DRKeyInterService.DRKeyLvl1:output_type -> proto.control_plane.v1.DRKeyLvl1Response
+	3, // 9: proto.control_plane.v1.DRKeyIntraService.DRKeyIntraLvl1:output_type -> proto.control_plane.v1.DRKeyIntraLvl1Response
+	8, // [8:10] is the sub-list for method output_type
+	6, // [6:8] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_proto_control_plane_v1_drkey_proto_init() }
+func file_proto_control_plane_v1_drkey_proto_init() {
+	if File_proto_control_plane_v1_drkey_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_control_plane_v1_drkey_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyLvl1Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_drkey_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyLvl1Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_drkey_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyIntraLvl1Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_control_plane_v1_drkey_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DRKeyIntraLvl1Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_control_plane_v1_drkey_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_proto_control_plane_v1_drkey_proto_goTypes,
+		DependencyIndexes: file_proto_control_plane_v1_drkey_proto_depIdxs,
+		MessageInfos:      file_proto_control_plane_v1_drkey_proto_msgTypes,
+	}.Build()
+	File_proto_control_plane_v1_drkey_proto = out.File
+	file_proto_control_plane_v1_drkey_proto_rawDesc = nil
+	file_proto_control_plane_v1_drkey_proto_goTypes = nil
+	file_proto_control_plane_v1_drkey_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// DRKeyInterServiceClient is the client API for DRKeyInterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DRKeyInterServiceClient interface {
+	DRKeyLvl1(ctx context.Context, in *DRKeyLvl1Request, opts ...grpc.CallOption) (*DRKeyLvl1Response, error)
+}
+
+type dRKeyInterServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDRKeyInterServiceClient(cc grpc.ClientConnInterface) DRKeyInterServiceClient {
+	return &dRKeyInterServiceClient{cc}
+}
+
+func (c *dRKeyInterServiceClient) DRKeyLvl1(ctx context.Context, in *DRKeyLvl1Request, opts ...grpc.CallOption) (*DRKeyLvl1Response, error) {
+	out := new(DRKeyLvl1Response)
+	err := c.cc.Invoke(ctx, "/proto.control_plane.v1.DRKeyInterService/DRKeyLvl1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DRKeyInterServiceServer is the server API for DRKeyInterService service.
+type DRKeyInterServiceServer interface {
+	DRKeyLvl1(context.Context, *DRKeyLvl1Request) (*DRKeyLvl1Response, error)
+}
+
+// UnimplementedDRKeyInterServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedDRKeyInterServiceServer struct {
+}
+
+func (*UnimplementedDRKeyInterServiceServer) DRKeyLvl1(context.Context, *DRKeyLvl1Request) (*DRKeyLvl1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DRKeyLvl1 not implemented")
+}
+
+func RegisterDRKeyInterServiceServer(s *grpc.Server, srv DRKeyInterServiceServer) {
+	s.RegisterService(&_DRKeyInterService_serviceDesc, srv)
+}
+
+func _DRKeyInterService_DRKeyLvl1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DRKeyLvl1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DRKeyInterServiceServer).DRKeyLvl1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.control_plane.v1.DRKeyInterService/DRKeyLvl1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DRKeyInterServiceServer).DRKeyLvl1(ctx, req.(*DRKeyLvl1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DRKeyInterService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.control_plane.v1.DRKeyInterService",
+	HandlerType: (*DRKeyInterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DRKeyLvl1",
+			Handler:    _DRKeyInterService_DRKeyLvl1_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/control_plane/v1/drkey.proto",
+}
+
+// DRKeyIntraServiceClient is the client API for DRKeyIntraService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DRKeyIntraServiceClient interface {
+	DRKeyIntraLvl1(ctx context.Context, in *DRKeyIntraLvl1Request, opts ...grpc.CallOption) (*DRKeyIntraLvl1Response, error)
+}
+
+type dRKeyIntraServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDRKeyIntraServiceClient(cc grpc.ClientConnInterface) DRKeyIntraServiceClient {
+	return &dRKeyIntraServiceClient{cc}
+}
+
+func (c *dRKeyIntraServiceClient) DRKeyIntraLvl1(ctx context.Context, in *DRKeyIntraLvl1Request, opts ...grpc.CallOption) (*DRKeyIntraLvl1Response, error) {
+	out := new(DRKeyIntraLvl1Response)
+	err := c.cc.Invoke(ctx, "/proto.control_plane.v1.DRKeyIntraService/DRKeyIntraLvl1", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DRKeyIntraServiceServer is the server API for DRKeyIntraService service.
+type DRKeyIntraServiceServer interface {
+	DRKeyIntraLvl1(context.Context, *DRKeyIntraLvl1Request) (*DRKeyIntraLvl1Response, error)
+}
+
+// UnimplementedDRKeyIntraServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedDRKeyIntraServiceServer struct {
+}
+
+func (*UnimplementedDRKeyIntraServiceServer) DRKeyIntraLvl1(context.Context, *DRKeyIntraLvl1Request) (*DRKeyIntraLvl1Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DRKeyIntraLvl1 not implemented")
+}
+
+func RegisterDRKeyIntraServiceServer(s *grpc.Server, srv DRKeyIntraServiceServer) {
+	s.RegisterService(&_DRKeyIntraService_serviceDesc, srv)
+}
+
+func _DRKeyIntraService_DRKeyIntraLvl1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DRKeyIntraLvl1Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DRKeyIntraServiceServer).DRKeyIntraLvl1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.control_plane.v1.DRKeyIntraService/DRKeyIntraLvl1",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DRKeyIntraServiceServer).DRKeyIntraLvl1(ctx, req.(*DRKeyIntraLvl1Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DRKeyIntraService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.control_plane.v1.DRKeyIntraService",
+	HandlerType: (*DRKeyIntraServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DRKeyIntraLvl1",
+			Handler:    _DRKeyIntraService_DRKeyIntraLvl1_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/control_plane/v1/drkey.proto",
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/scionproto/scion/go/pkg/proto/control_plane (interfaces: ChainRenewalServiceServer,TrustMaterialServiceServer)
+// Source: github.com/scionproto/scion/go/pkg/proto/control_plane (interfaces: ChainRenewalServiceServer,DRKeyInterServiceServer,DRKeyIntraServiceServer,TrustMaterialServiceServer)
 
 // Package mock_control_plane is a generated GoMock package.
 package mock_control_plane
@@ -50,6 +50,82 @@ func (mr *MockChainRenewalServiceServerMockRecorder) ChainRenewal(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainRenewal", reflect.TypeOf((*MockChainRenewalServiceServer)(nil).ChainRenewal), arg0, arg1)
 }
 
+// MockDRKeyInterServiceServer is a mock of DRKeyInterServiceServer interface.
+type MockDRKeyInterServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDRKeyInterServiceServerMockRecorder
+}
+
+// MockDRKeyInterServiceServerMockRecorder is the mock recorder for MockDRKeyInterServiceServer.
+type MockDRKeyInterServiceServerMockRecorder struct {
+	mock *MockDRKeyInterServiceServer
+}
+
+// NewMockDRKeyInterServiceServer creates a new mock instance.
+func NewMockDRKeyInterServiceServer(ctrl *gomock.Controller) *MockDRKeyInterServiceServer {
+	mock := &MockDRKeyInterServiceServer{ctrl: ctrl}
+	mock.recorder = &MockDRKeyInterServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDRKeyInterServiceServer) EXPECT() *MockDRKeyInterServiceServerMockRecorder {
+	return m.recorder
+}
+
+// DRKeyLvl1 mocks base method.
+func (m *MockDRKeyInterServiceServer) DRKeyLvl1(arg0 context.Context, arg1 *control_plane.DRKeyLvl1Request) (*control_plane.DRKeyLvl1Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DRKeyLvl1", arg0, arg1)
+	ret0, _ := ret[0].(*control_plane.DRKeyLvl1Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DRKeyLvl1 indicates an expected call of DRKeyLvl1.
+func (mr *MockDRKeyInterServiceServerMockRecorder) DRKeyLvl1(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DRKeyLvl1", reflect.TypeOf((*MockDRKeyInterServiceServer)(nil).DRKeyLvl1), arg0, arg1)
+}
+
+// MockDRKeyIntraServiceServer is a mock of DRKeyIntraServiceServer interface.
+type MockDRKeyIntraServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDRKeyIntraServiceServerMockRecorder
+}
+
+// MockDRKeyIntraServiceServerMockRecorder is the mock recorder for MockDRKeyIntraServiceServer.
+type MockDRKeyIntraServiceServerMockRecorder struct {
+	mock *MockDRKeyIntraServiceServer
+}
+
+// NewMockDRKeyIntraServiceServer creates a new mock instance.
+func NewMockDRKeyIntraServiceServer(ctrl *gomock.Controller) *MockDRKeyIntraServiceServer {
+	mock := &MockDRKeyIntraServiceServer{ctrl: ctrl}
+	mock.recorder = &MockDRKeyIntraServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDRKeyIntraServiceServer) EXPECT() *MockDRKeyIntraServiceServerMockRecorder {
+	return m.recorder
+}
+
+// DRKeyIntraLvl1 mocks base method.
+func (m *MockDRKeyIntraServiceServer) DRKeyIntraLvl1(arg0 context.Context, arg1 *control_plane.DRKeyIntraLvl1Request) (*control_plane.DRKeyIntraLvl1Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DRKeyIntraLvl1", arg0, arg1)
+	ret0, _ := ret[0].(*control_plane.DRKeyIntraLvl1Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DRKeyIntraLvl1 indicates an expected call of DRKeyIntraLvl1.
+func (mr *MockDRKeyIntraServiceServerMockRecorder) DRKeyIntraLvl1(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DRKeyIntraLvl1", reflect.TypeOf((*MockDRKeyIntraServiceServer)(nil).DRKeyIntraLvl1), arg0, arg1)
+}
+
 // MockTrustMaterialServiceServer is a mock of TrustMaterialServiceServer interface.
 type MockTrustMaterialServiceServer struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,184 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/pkg/api"
+	"github.com/scionproto/scion/go/pkg/router"
+	"github.com/scionproto/scion/go/pkg/router/bfd"
+)
+
+// DataPlane is the router data plane whose state is exposed by the API.
+type DataPlane interface {
+	// Interfaces returns the external interfaces known to the data plane,
+	// sorted by interface ID.
+	Interfaces() []router.InterfaceInfo
+	// Services returns the addresses of the service instances known to the
+	// data plane, by service.
+	Services() map[addr.HostSVC][]*net.UDPAddr
+}
+
+// Server implements the Router API.
+type Server struct {
+	DataPlane DataPlane
+	Config    http.HandlerFunc
+	Info      http.HandlerFunc
+	LogLevel  http.HandlerFunc
+}
+
+// GetInterfaces lists the interfaces known to the data plane.
+func (s *Server) GetInterfaces(w http.ResponseWriter, r *http.Request) {
+	infos := s.DataPlane.Interfaces()
+	rep := make([]Interface, 0, len(infos))
+	for _, info := range infos {
+		rep = append(rep, makeInterface(info))
+	}
+	writeJSON(w, rep)
+}
+
+// GetInterface gets the description of the interface specified by its ID.
+func (s *Server) GetInterface(w http.ResponseWriter, r *http.Request, interfaceId InterfaceID) {
+	for _, info := range s.DataPlane.Interfaces() {
+		if int(info.ID) != int(interfaceId) {
+			continue
+		}
+		writeJSON(w, makeInterface(info))
+		return
+	}
+	Error(w, Problem{
+		Detail: api.StringRef(fmt.Sprintf("interface %d is not known", interfaceId)),
+		Status: http.StatusNotFound,
+		Title:  "interface not found",
+		Type:   api.StringRef(api.NotFound),
+	})
+}
+
+// GetServices lists the service resolution entries of the data plane.
+func (s *Server) GetServices(w http.ResponseWriter, r *http.Request) {
+	svcs := s.DataPlane.Services()
+	rep := make([]Service, 0, len(svcs))
+	for svc, addrs := range svcs {
+		entry := Service{
+			Service:   svc.BaseString(),
+			Addresses: make([]string, 0, len(addrs)),
+		}
+		for _, a := range addrs {
+			entry.Addresses = append(entry.Addresses, a.String())
+		}
+		sort.Strings(entry.Addresses)
+		rep = append(rep, entry)
+	}
+	sort.Slice(rep, func(i, j int) bool { return rep[i].Service < rep[j].Service })
+	writeJSON(w, rep)
+}
+
+// GetConfig is an indirection to the http handler.
+func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
+	s.Config(w, r)
+}
+
+// GetInfo is an indirection to the http handler.
+func (s *Server) GetInfo(w http.ResponseWriter, r *http.Request) {
+	s.Info(w, r)
+}
+
+// GetLogLevel is an indirection to the http handler.
+func (s *Server) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	s.LogLevel(w, r)
+}
+
+// SetLogLevel is an indirection to the http handler.
+func (s *Server) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	s.LogLevel(w, r)
+}
+
+// Error creates an detailed error response.
+func Error(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	// no point in catching error here, there is nothing we can do about it anymore.
+	enc.Encode(p)
+}
+
+// writeJSON writes the indented JSON encoding of the response.
+func writeJSON(w http.ResponseWriter, rep interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(rep); err != nil {
+		Error(w, Problem{
+			Detail: api.StringRef(err.Error()),
+			Status: http.StatusInternalServerError,
+			Title:  "unable to marshal response",
+			Type:   api.StringRef(api.InternalError),
+		})
+		return
+	}
+}
+
+func makeInterface(info router.InterfaceInfo) Interface {
+	intf := Interface{
+		InterfaceId: InterfaceID(info.ID),
+		LinkType:    LinkType(info.LinkType.String()),
+		Internal:    info.NextHop != nil,
+	}
+	if !info.NeighborIA.IsZero() {
+		ia := IsdAs(info.NeighborIA.String())
+		intf.NeighborIsdAs = &ia
+	}
+	if info.NextHop != nil {
+		intf.SiblingAddress = api.StringRef(info.NextHop.String())
+	}
+	if info.BFD != nil {
+		intf.Bfd = makeBFDSession(*info.BFD)
+	}
+	return intf
+}
+
+func makeBFDSession(status bfd.Status) *BFDSession {
+	return &BFDSession{
+		LocalState:          bfdState(status.LocalState),
+		RemoteState:         bfdState(status.RemoteState),
+		LocalDiscriminator:  int(status.LocalDiscriminator),
+		RemoteDiscriminator: int(status.RemoteDiscriminator),
+		PacketsSent:         int(status.PacketsSent),
+		PacketsReceived:     int(status.PacketsReceived),
+		StateChanges:        int(status.StateChanges),
+	}
+}
+
+func bfdState(s layers.BFDState) BFDState {
+	switch s {
+	case layers.BFDStateAdminDown:
+		return BFDStateAdminDown
+	case layers.BFDStateInit:
+		return BFDStateInit
+	case layers.BFDStateUp:
+		return BFDStateUp
+	default:
+		return BFDStateDown
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/router"
+	"github.com/scionproto/scion/go/pkg/router/api/mock_api"
+	"github.com/scionproto/scion/go/pkg/router/bfd"
+)
+
+var update = xtest.UpdateGoldenFiles()
+
+// TestAPI tests the API response generation of the endpoints implemented in the
+// api package
+func TestAPI(t *testing.T) {
+	testCases := map[string]struct {
+		Handler      func(t *testing.T, ctrl *gomock.Controller) http.Handler
+		RequestURL   string
+		ResponseFile string
+		Status       int
+	}{
+		"interfaces": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dp := mock_api.NewMockDataPlane(ctrl)
+				dp.EXPECT().Interfaces().Return(interfaces())
+				return Handler(&Server{DataPlane: dp})
+			},
+			RequestURL:   "/interfaces",
+			ResponseFile: "testdata/interfaces.json",
+			Status:       200,
+		},
+		"interfaces empty": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dp := mock_api.NewMockDataPlane(ctrl)
+				dp.EXPECT().Interfaces().Return(nil)
+				return Handler(&Server{DataPlane: dp})
+			},
+			RequestURL:   "/interfaces",
+			ResponseFile: "testdata/interfaces-empty.json",
+			Status:       200,
+		},
+		"interface": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dp := mock_api.NewMockDataPlane(ctrl)
+				dp.EXPECT().Interfaces().Return(interfaces())
+				return Handler(&Server{DataPlane: dp})
+			},
+			RequestURL:   "/interfaces/1",
+			ResponseFile: "testdata/interface.json",
+			Status:       200,
+		},
+		"interface not found": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dp := mock_api.NewMockDataPlane(ctrl)
+				dp.EXPECT().Interfaces().Return(interfaces())
+				return Handler(&Server{DataPlane: dp})
+			},
+			RequestURL:   "/interfaces/42",
+			ResponseFile: "testdata/interface-not-found.json",
+			Status:       404,
+		},
+		"interface malformed": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dp := mock_api.NewMockDataPlane(ctrl)
+				return Handler(&Server{DataPlane: dp})
+			},
+			RequestURL:   "/interfaces/one",
+			ResponseFile: "testdata/interface-malformed.txt",
+			Status:       400,
+		},
+		"services": {
+			Handler: func(t *testing.T, ctrl *gomock.Controller) http.Handler {
+				dp := mock_api.NewMockDataPlane(ctrl)
+				dp.EXPECT().Services().Return(map[addr.HostSVC][]*net.UDPAddr{
+					addr.SvcCS: {
+						{IP: net.ParseIP("192.0.2.2"), Port: 30252},
+						{IP: net.ParseIP("192.0.2.1"), Port: 30252},
+					},
+					addr.SvcDS: {
+						{IP: net.ParseIP("192.0.2.1"), Port: 30252},
+					},
+				})
+				return Handler(&Server{DataPlane: dp})
+			},
+			RequestURL:   "/services",
+			ResponseFile: "testdata/services.json",
+			Status:       200,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req, err := http.NewRequest("GET", tc.RequestURL, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			tc.Handler(t, ctrl).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.Status, rr.Result().StatusCode)
+
+			if *update {
+				require.NoError(t, ioutil.WriteFile(tc.ResponseFile, rr.Body.Bytes(), 0666))
+			}
+			golden, err := ioutil.ReadFile(tc.ResponseFile)
+			require.NoError(t, err)
+			assert.Equal(t, string(golden), rr.Body.String())
+		})
+	}
+}
+
+func interfaces() []router.InterfaceInfo {
+	return []router.InterfaceInfo{
+		{
+			ID:         1,
+			NeighborIA: xtest.MustParseIA("1-ff00:0:111"),
+			LinkType:   topology.Child,
+			BFD: &bfd.Status{
+				LocalState:          layers.BFDStateUp,
+				RemoteState:         layers.BFDStateUp,
+				LocalDiscriminator:  1234,
+				RemoteDiscriminator: 4321,
+				PacketsSent:         42,
+				PacketsReceived:     41,
+				StateChanges:        2,
+			},
+		},
+		{
+			ID:         2,
+			NeighborIA: xtest.MustParseIA("1-ff00:0:112"),
+			LinkType:   topology.Peer,
+			NextHop:    &net.UDPAddr{IP: net.ParseIP("192.0.2.3"), Port: 30042},
+			BFD: &bfd.Status{
+				LocalState:         layers.BFDStateDown,
+				RemoteState:        layers.BFDStateAdminDown,
+				LocalDiscriminator: 5678,
+				PacketsSent:        3,
+			},
+		},
+		{
+			ID:       3,
+			LinkType: topology.Parent,
+		},
+	}
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/scionproto/scion/go/pkg/router/api (interfaces: DataPlane)
+
+// Package mock_api is a generated GoMock package.
+package mock_api
+
+import (
+	net "net"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	addr "github.com/scionproto/scion/go/lib/addr"
+	router "github.com/scionproto/scion/go/pkg/router"
+)
+
+// MockDataPlane is a mock of DataPlane interface.
+type MockDataPlane struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataPlaneMockRecorder
+}
+
+// MockDataPlaneMockRecorder is the mock recorder for MockDataPlane.
+type MockDataPlaneMockRecorder struct {
+	mock *MockDataPlane
+}
+
+// NewMockDataPlane creates a new mock instance.
+func NewMockDataPlane(ctrl *gomock.Controller) *MockDataPlane {
+	mock := &MockDataPlane{ctrl: ctrl}
+	mock.recorder = &MockDataPlaneMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataPlane) EXPECT() *MockDataPlaneMockRecorder {
+	return m.recorder
+}
+
+// Interfaces mocks base method.
+func (m *MockDataPlane) Interfaces() []router.InterfaceInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Interfaces")
+	ret0, _ := ret[0].([]router.InterfaceInfo)
+	return ret0
+}
+
+// Interfaces indicates an expected call of Interfaces.
+func (mr *MockDataPlaneMockRecorder) Interfaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Interfaces", reflect.TypeOf((*MockDataPlane)(nil).Interfaces))
+}
+
+// Services mocks base method.
+func (m *MockDataPlane) Services() map[addr.HostSVC][]*net.UDPAddr {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Services")
+	ret0, _ := ret[0].(map[addr.HostSVC][]*net.UDPAddr)
+	return ret0
+}
+
+// Services indicates an expected call of Services.
+func (mr *MockDataPlaneMockRecorder) Services() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Services", reflect.TypeOf((*MockDataPlane)(nil).Services))
+}
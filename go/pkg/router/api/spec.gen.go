@@ -0,0 +1,131 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen DO NOT EDIT.
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/9xZX3PbNhL/Khi0D9cpJdGyc73qzUmanmacxhO504fU5wGJJYmaBBgAlKP69N1vFiAp",
+	"/pOt3M3len2ySALY3d/+drG7fqSxKkolQVpDV49UgymVNOAeXjL+Hj5WYCw+xUpakO4nK8tcxMwKJRe/",
+	"GSXxnYkzKBj++lpDQlf0q8Xh6IX/ahYbyyRnmv+gtdJ0v98HlIOJtSjxMLpCmUTXQvFrvdGp8+b1BowR",
+	"Xl5/28YyC0QlxGZAXr55TYxfSWzGLCmUFFZp474KaUEnLIY5eaO0f5QsP7w3gVvXnNDbbESUC5kSrSoL",
+	"ek7WlghDpLLEgCUicbKFIVwYFuXASaL0QCoNaKlVCdoKD3OuYpbfcYH2FEIyq/TYwNfdz42hbmejKB4M",
+	"n1hR5kBXZ8vzi4DaXQl0RVF2Cprug1qWQbSecxXC7dbtA1qy+B6sudMQg9gCH+t3oyzLiayKCJx6iEO9",
+	"izS7ehpeLKf0awSZmmmnC8EdJwjQUCgL/xbafmsLd+3730ErIvyK+hPJmOdEBCBJpJQ1VrOyBE52MFDy",
+	"fHn2hJqf7Si34S7OmEzBjO0aAuiWk3r586yaQNTp+rESGknxocevgRXBJNGPeGTAhAkGDm29DagVFrWk",
+	"3fj3FjLJSawqjEFDWyNU9BvEFmFrIVw9UpBVgaYwXgh5x9WDpAGt/wgpUJmqpLcdWPD5CekHgcZqIVMU",
+	"uG7SAUrsp4Mo4ad43EvAs9rUciee3dnKXb9ut0qWj5nySwY2g0HuQr6rBwmcRDvCBsmQCNmhz+Wmx5yE",
+	"5QZaHCKlcmBO+VzI+zv/+mnNr4S8v8F1+4BKEGkWKX0nDL9j5rmta8MvDe6rFb5jnGswE/Fx6T80kTAw",
+	"0N0l6kGO7hGfCZTMd+4aSKavlR4g9Oz75TycL+dnq/MwvFiOSTKIrJ6Xu7h1vNiJgc2r9bufOp7r2jkR",
+	"AF1erB4Par4IaME+iQJD4q8vXpzjs5D++ewgrd1O1hykFYkATafymndFVwI9myVJGK7C1dlZ6ALdojV0",
+	"Rf/x66/829lfPrBZEs6+v308Cy72q28el/v+q2/+ieu+7kTgevN6drmZVOUQgS2fxklyV7alBMJMrHK/",
+	"G9YhI2p616kiVhqc6tonqzgTOTqpBCe7kgZsP2M0KxqVr5wc786xqiq9gi3k41yRN6/7FlypNEUt/eeD",
+	"nhyiKnWESRS+dkVYT6/6y9NU9MfeTtDoWqsoh2KsKAfLxISmlySrCiaJBsaxYCLwqcyZdKUlMSXEIhGx",
+	"dwBGWBxXWoOMW/+UXqAPTWFIBnmZVDnuwDxkobcKr4FUbIEwvhV4iCSZesDFpVYxAJ+TX7SwFiQmsx9k",
+	"mguTuV2tfhjbIFMhAbQJSGUqluc7XwFWwtYVn1SSWIgzKdxVatk9ZCrnoI07DVe7NCl+H1RF9JWSEmJn",
+	"vlWEM8siZoBYUQAnqrJT/BDSWCZjmIL35/droiEBj5qHqYmLuqRtUD6KbkBgns5dzuccecVIollagOwc",
+	"ponSxFTRrGQ2a0Kmdc+uhDl5y3YkAlIZ4AMHaaWsFypMu6m+T4yqdAwkVhz6UC3qhYu4xWzmKP2VVfcg",
+	"Z8jlGTpu5tCbefQSpQtm8dLWYtYiMwUrXt7VVBGVAfn7zc018QucZiQFCZpZfzWi2kqLVEhiQG9B123A",
+	"UxTu2fYiPMenOK+M2MLbJgNbXUE3IYdhNx2HYWtEJ+PWGWbMDJMpjaQtCqZ3o3hyDvtfB8MGtIvTnyXb",
+	"MpGjzClH2TaNJ6zK0bcsUpVdRTmT9zQ4JSYqKT5WkO+GwdHFw1/wNStdN/zJdnDbCg6cXF6v5+RdWaqa",
+	"5N0I81lNSPL+zavZd38LvwuI8P0jCFdsaYhVUYDkfm8EhEOjqAMc8SqVkBY/M587Z607uIorDEovRypN",
+	"0lxFziXevrYb7bn5tKD6jNAZXBd1HDVUnLo3akeP7426UoPjtdqhb2nSYPvC+FPRQGGhGNYdnQJs+WJ5",
+	"nFiUac12+GwOavZ1+YkVMCG1k9Y3z8NUHx50jO4WdP4z0WBUXjkPg7R6N1XN9acsI1Ched03wq0mBRjD",
+	"0ue92hYPA+n7fV1fjB12vW4J6MvT966u7pRB/gUGEQ3oFrQf9tBwHs7P0DBVgmSloCt6jr7z5WLmjELC",
+	"JiLFnym42QGa7EJhzemK/gj2lV8R9KdcyzAcjLcwsBdlzsRgsDUEZDS82lRxDMZgAfKuEY5qX4ThsR6l",
+	"VWXRmbbhyXVipit6rYW0Ph3dvHt7RbyhlT+eJCJ3XLMsNb4WLQol6S2esWgccQyRtS/3/r/weMmMiImQ",
+	"Ph0hBiVLgbic3+ZmrfImEn1xZ8wTKDUtWgerQUUtjO0Q97DDXx9MA7mX6kE2Kb+ZD97gnS9knFccBm1j",
+	"Z2vbU/cbTjPVUk/4sNX+WU8+Pbhtk+RJE4RxghwTwMGmkhFq8w4LjqhX30Pfft58uWlAJnRZyy3LxWC6",
+	"3JLqqIM7pGlfjnizeGx/zwTfH6XRj+CFdN4iPOxQhA/EBzV5kBS40Zw25xb2aar4ZpUVgCyjqw+PFGPb",
+	"JVMaUMmKpohsTKLd5O/L0NMc0hs47W//Q4aeSMyJTNSHtZs9/hBcRA0uvqwGDRRSWZKoSvJBRDRcfWaS",
+	"dCQ2cpUu2unEseunHWz8F2nRyvhi9xMClw8mMKN7J6BlNQHKZgCKO/+l4rsvgkczN+rKP4T8/k/lpc0p",
+	"XkIm11XECbUBG3Yk9dZOZ+LS9KFA8NX8Fky79HCGVXNyXf9zi4OxQvqukA3XuhIiUfqB6bpxZHLXypxo",
+	"S0ZxuGlM/BIFRC3sc8oHM937iD90IXFc6Q7T6kVItbq/bC7kSud0RTNry9Vi8ZgpY/erx1Jpu8fWiGmB",
+	"Lb8DG7/1xx+uXnSvMc8oPfh8Hl5cLNGy21aRUS+4Bb2zmatFIXdzrbqy7dSvKulQeT5RONB9cPrBE3gd",
+	"E2BaCg2Pf+UiFxtInCS7IUy0q6+wepfpHFQH+v52/68BADfCxioJIQAA",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %s", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %s", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	var res = make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.Swagger, err error) {
+	var resolvePath = PathToRawSpec("")
+
+	loader := openapi3.NewSwaggerLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.SwaggerLoader, url *url.URL) ([]byte, error) {
+		var pathToFile = url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadSwaggerFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
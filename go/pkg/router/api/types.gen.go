@@ -0,0 +1,143 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen DO NOT EDIT.
+package api
+
+// Defines values for BFDState.
+const (
+	BFDStateAdminDown BFDState = "admin_down"
+
+	BFDStateDown BFDState = "down"
+
+	BFDStateInit BFDState = "init"
+
+	BFDStateUp BFDState = "up"
+)
+
+// Defines values for LinkType.
+const (
+	LinkTypeChild LinkType = "child"
+
+	LinkTypeCore LinkType = "core"
+
+	LinkTypeParent LinkType = "parent"
+
+	LinkTypePeer LinkType = "peer"
+
+	LinkTypeUnset LinkType = "unset"
+)
+
+// Defines values for LogLevelLevel.
+const (
+	LogLevelLevelDebug LogLevelLevel = "debug"
+
+	LogLevelLevelError LogLevelLevel = "error"
+
+	LogLevelLevelInfo LogLevelLevel = "info"
+)
+
+// State of the BFD session that monitors the interface. For internal interfaces, the session monitors the sibling router. It is not set if BFD is disabled for the interface.
+type BFDSession struct {
+
+	// Discriminator of the local session.
+	LocalDiscriminator int      `json:"local_discriminator"`
+	LocalState         BFDState `json:"local_state"`
+
+	// Total number of BFD packets received.
+	PacketsReceived int `json:"packets_received"`
+
+	// Total number of BFD packets sent.
+	PacketsSent int `json:"packets_sent"`
+
+	// Discriminator of the remote session. It is zero if the session has not been bootstrapped yet.
+	RemoteDiscriminator int      `json:"remote_discriminator"`
+	RemoteState         BFDState `json:"remote_state"`
+
+	// Total number of state changes of the local session.
+	StateChanges int `json:"state_changes"`
+}
+
+// BFDState defines model for BFDState.
+type BFDState string
+
+// Interface defines model for Interface.
+type Interface struct {
+
+	// State of the BFD session that monitors the interface. For internal interfaces, the session monitors the sibling router. It is not set if BFD is disabled for the interface.
+	Bfd         *BFDSession `json:"bfd,omitempty"`
+	InterfaceId InterfaceID `json:"interface_id"`
+
+	// Whether the interface is owned by a sibling router in the local AS.
+	Internal bool `json:"internal"`
+
+	// Type of the link to the neighboring AS.
+	LinkType      LinkType `json:"link_type"`
+	NeighborIsdAs *IsdAs   `json:"neighbor_isd_as,omitempty"`
+
+	// Address of the sibling router that owns the interface. It is only set for internal interfaces.
+	SiblingAddress *string `json:"sibling_address,omitempty"`
+}
+
+// InterfaceID defines model for InterfaceID.
+type InterfaceID int
+
+// IsdAs defines model for IsdAs.
+type IsdAs string
+
+// Type of the link to the neighboring AS.
+type LinkType string
+
+// LogLevel defines model for LogLevel.
+type LogLevel struct {
+
+	// Logging level
+	Level LogLevelLevel `json:"level"`
+}
+
+// Logging level
+type LogLevelLevel string
+
+// Problem defines model for Problem.
+type Problem struct {
+
+	// A human readable explanation specific to this occurrence of the problem that is helpful to locate the problem and give advice on how to proceed. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
+	Detail *string `json:"detail,omitempty"`
+
+	// A URI reference that identifies the specific occurrence of the problem, e.g. by adding a fragment identifier or sub-path to the problem type. May be used to locate the root of this problem in the source code.
+	Instance *string `json:"instance,omitempty"`
+
+	// The HTTP status code generated by the origin server for this occurrence of the problem.
+	Status int `json:"status"`
+
+	// A short summary of the problem type. Written in English and readable for engineers, usually not suited for non technical stakeholders and not localized.
+	Title string `json:"title"`
+
+	// A URI reference that uniquely identifies the problem type only in the context of the provided API. Opposed to the specification in RFC-7807, it is neither recommended to be dereferencable and point to a human-readable documentation nor globally unique for the problem type.
+	Type *string `json:"type,omitempty"`
+}
+
+// Service defines model for Service.
+type Service struct {
+
+	// Addresses of the instances of the service.
+	Addresses []string `json:"addresses"`
+
+	// Name of the service.
+	Service string `json:"service"`
+}
+
+// StandardError defines model for StandardError.
+type StandardError struct {
+
+	// Error message
+	Error string `json:"error"`
+}
+
+// BadRequest defines model for BadRequest.
+type BadRequest StandardError
+
+// SetLogLevelJSONBody defines parameters for SetLogLevel.
+type SetLogLevelJSONBody LogLevel
+
+// SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
+type SetLogLevelJSONRequestBody SetLogLevelJSONBody
@@ -0,0 +1,219 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/topology"
+	underlayconn "github.com/scionproto/scion/go/lib/underlay/conn"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/router"
+)
+
+const benchFlows = 256
+
+var benchKey = []byte("testkey_xxxxxxxx")
+
+// BenchmarkForwarding compares forwarding transit packets in a single
+// goroutine, as done by the read loop before the forwarding pipeline was
+// introduced, with the forwarding pipeline using different numbers of
+// processors.
+func BenchmarkForwarding(b *testing.B) {
+	pkts := prepBenchPkts(b)
+
+	b.Run("inline", func(b *testing.B) {
+		in := &benchConn{pkts: pkts, remaining: int64(b.N)}
+		out := &benchConn{}
+		dp := newBenchDP(in, out)
+		process := router.NewPacketProcessor(dp, 1)
+		msgs := newBenchMsgs(64)
+		b.ResetTimer()
+		for read := 0; read < b.N; {
+			n, _ := in.ReadBatch(msgs)
+			read += n
+			for _, m := range msgs[:n] {
+				result, err := process(m.Buffers[0][:m.N], m.Addr.(*net.UDPAddr))
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := out.WriteTo(result.OutPkt, result.OutAddr); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	for _, numProcs := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("processors=%d", numProcs), func(b *testing.B) {
+			out := &benchConn{target: int64(b.N), done: make(chan struct{})}
+			// Limit the packets in flight to the capacity of a single queue, such
+			// that the pipeline never drops packets.
+			in := &benchConn{pkts: pkts, remaining: int64(b.N), peer: out, window: 256}
+			dp := newBenchDP(in, out)
+			dp.RunConfig = router.RunConfig{NumProcessors: numProcs}
+			b.ResetTimer()
+			go func() {
+				_ = dp.Run()
+			}()
+			select {
+			case <-out.done:
+			case <-time.After(time.Minute):
+				b.Fatalf("time out, forwarded %d of %d packets",
+					atomic.LoadInt64(&out.written), b.N)
+			}
+			b.StopTimer()
+			if err := dp.Shutdown(context.Background()); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+}
+
+func newBenchDP(in, out router.BatchConn) *router.DataPlane {
+	dp := router.NewDP(
+		map[uint16]router.BatchConn{1: in, 2: out},
+		map[uint16]topology.LinkType{1: topology.Child, 2: topology.Parent},
+		&benchConn{},
+		nil,
+		nil,
+		xtest.MustParseIA("1-ff00:0:110"),
+		nil,
+		benchKey,
+	)
+	dp.Metrics = metrics
+	return dp
+}
+
+// prepBenchPkts prepares transit packets from interface 1 to interface 2 for a
+// number of different flows.
+func prepBenchPkts(b *testing.B) [][]byte {
+	mac, err := scrypto.InitMac(benchKey)
+	require.NoError(b, err)
+	pkts := make([][]byte, 0, benchFlows)
+	for i := 0; i < benchFlows; i++ {
+		spkt, dpath := prepBaseMsg(time.Now())
+		spkt.FlowID = uint32(i)
+		dpath.HopFields = []*path.HopField{
+			{ConsIngress: 31, ConsEgress: 30},
+			{ConsIngress: 1, ConsEgress: 2},
+			{ConsIngress: 40, ConsEgress: 41},
+		}
+		dpath.HopFields[1].Mac = path.MAC(mac, dpath.InfoFields[0], dpath.HopFields[1])
+		spkt.Path = dpath
+		buffer := gopacket.NewSerializeBuffer()
+		err := gopacket.SerializeLayers(buffer, gopacket.SerializeOptions{FixLengths: true},
+			spkt, gopacket.Payload("actualpayloadbytes"))
+		require.NoError(b, err)
+		pkts = append(pkts, append([]byte(nil), buffer.Bytes()...))
+	}
+	return pkts
+}
+
+func newBenchMsgs(n int) underlayconn.Messages {
+	msgs := underlayconn.NewReadMessages(n)
+	for i := range msgs {
+		msgs[i].Buffers[0] = make([]byte, 9000)
+	}
+	return msgs
+}
+
+// benchConn is a BatchConn that serves a fixed number of packets on reads, and
+// counts the packets that are written. If peer is set, reads are delayed until
+// at most window packets that were read have not been written to peer yet.
+// Once all packets have been served, reads block until the connection is
+// closed.
+type benchConn struct {
+	pkts      [][]byte
+	remaining int64
+	next      int
+	read      int64
+	peer      *benchConn
+	window    int64
+
+	written int64
+	target  int64
+	done    chan struct{}
+	once    sync.Once
+
+	closed     chan struct{}
+	closedOnce sync.Once
+	initOnce   sync.Once
+}
+
+func (c *benchConn) ReadBatch(msgs underlayconn.Messages) (int, error) {
+	if c.remaining <= 0 {
+		// All packets have been served, block until closed.
+		<-c.closedC()
+		return 0, serrors.New("connection closed")
+	}
+	n := len(msgs)
+	if int64(n) > c.remaining {
+		n = int(c.remaining)
+	}
+	if c.peer != nil {
+		for c.read+int64(n)-atomic.LoadInt64(&c.peer.written) > c.window {
+			runtime.Gosched()
+		}
+	}
+	for i := range msgs[:n] {
+		raw := c.pkts[c.next%len(c.pkts)]
+		c.next++
+		msgs[i].N = copy(msgs[i].Buffers[0], raw)
+		msgs[i].Addr = &net.UDPAddr{IP: net.IP{10, 0, 200, 200}}
+	}
+	c.remaining -= int64(n)
+	c.read += int64(n)
+	return n, nil
+}
+
+func (c *benchConn) WriteTo(raw []byte, _ *net.UDPAddr) (int, error) {
+	c.count(1)
+	return len(raw), nil
+}
+
+func (c *benchConn) WriteBatch(msgs underlayconn.Messages) (int, error) {
+	c.count(len(msgs))
+	return len(msgs), nil
+}
+
+func (c *benchConn) Close() error {
+	closed := c.closedC()
+	c.closedOnce.Do(func() { close(closed) })
+	return nil
+}
+
+func (c *benchConn) closedC() chan struct{} {
+	c.initOnce.Do(func() { c.closed = make(chan struct{}) })
+	return c.closed
+}
+
+func (c *benchConn) count(n int) {
+	if atomic.AddInt64(&c.written, int64(n)) >= c.target && c.done != nil {
+		c.once.Do(func() { close(c.done) })
+	}
+}
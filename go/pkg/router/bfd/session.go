@@ -15,6 +15,7 @@
 package bfd
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sync"
@@ -128,8 +129,17 @@ type Session struct {
 	messagesLock sync.Mutex
 	// messages is the channel on which the session receives BFD packets.
 	messages chan *layers.BFD
-
-	// localStateLock protects access to the local state.
+	// done is closed to shut down the session.
+	done chan struct{}
+	// finished is closed once Run returns.
+	finished chan struct{}
+	// closeOnce ensures that done is only closed once.
+	closeOnce sync.Once
+
+	// localStateLock protects access to the local state. It also protects
+	// writes of the remote state and the remote discriminator, and the
+	// counters reported by Status, such that they can be read while the
+	// session is running.
 	localStateLock sync.RWMutex
 	// localState is the state of the local BFD session.
 	localState state
@@ -156,6 +166,33 @@ type Session struct {
 	//
 	// If a metric is not initialized, it is not reported.
 	Metrics Metrics
+
+	// packetsSent, packetsReceived and stateChanges count the events reported
+	// by Status.
+	packetsSent     uint64
+	packetsReceived uint64
+	stateChanges    uint64
+}
+
+// Status is a snapshot of the state of a Session.
+type Status struct {
+	// LocalState is the state of the local session.
+	LocalState layers.BFDState
+	// RemoteState is the state of the remote session, as reported by the last
+	// BFD control packet received from the remote system.
+	RemoteState layers.BFDState
+	// LocalDiscriminator is the discriminator of the local session.
+	LocalDiscriminator layers.BFDDiscriminator
+	// RemoteDiscriminator is the discriminator of the remote session. It is
+	// zero if the session has not been bootstrapped yet.
+	RemoteDiscriminator layers.BFDDiscriminator
+	// PacketsSent is the total number of BFD packets sent by the session.
+	PacketsSent uint64
+	// PacketsReceived is the total number of BFD packets accepted by the
+	// session.
+	PacketsReceived uint64
+	// StateChanges is the total number of state changes of the local session.
+	StateChanges uint64
 }
 
 func (s *Session) String() string {
@@ -171,11 +208,13 @@ func (s *Session) Run() error {
 	if err := s.runOnceCheck(); err != nil {
 		return err
 	}
+	s.initMessages()
+	defer close(s.finished)
 	if err := s.validateParameters(); err != nil {
 		return err
 	}
 	if s.RemoteDiscriminator != 0 {
-		s.remoteDiscriminator = s.RemoteDiscriminator
+		s.setRemote(s.remoteState, s.RemoteDiscriminator)
 	}
 	s.initMessages()
 	s.initMetrics()
@@ -194,6 +233,9 @@ func (s *Session) Run() error {
 MainLoop:
 	for {
 		select {
+		case <-s.done:
+			s.sendAdminDown()
+			break MainLoop
 		case msg, ok := <-s.messages:
 			if !ok {
 				break MainLoop
@@ -218,15 +260,17 @@ MainLoop:
 				bfdIntervalToDuration(msg.DesiredMinTxInterval))
 			detectionTimer.Reset(detectionTime)
 
+			s.count(&s.packetsReceived)
 			if s.Metrics.PacketsReceived != nil {
 				s.Metrics.PacketsReceived.Add(1)
 			}
 
-			s.remoteState = state(msg.State)
 			s.remoteMinRxInterval = bfdIntervalToDuration(msg.RequiredMinRxInterval)
 			if s.remoteDiscriminator == 0 {
-				s.remoteDiscriminator = msg.MyDiscriminator
+				s.setRemote(state(msg.State), msg.MyDiscriminator)
 				s.debug("Bootstrapped")
+			} else {
+				s.setRemote(state(msg.State), s.remoteDiscriminator)
 			}
 
 			// If we transitioned out of the down state, we cancel the current send timer
@@ -265,6 +309,7 @@ MainLoop:
 				s.debug("error sending message", "err", err)
 				continue
 			}
+			s.count(&s.packetsSent)
 			if s.Metrics.PacketsSent != nil {
 				s.Metrics.PacketsSent.Add(1)
 			}
@@ -274,7 +319,7 @@ MainLoop:
 			detectionTimer.Reset(defaultDetectionTimeout)
 
 			s.transition(eventTimer)
-			s.remoteDiscriminator = 0
+			s.setRemote(s.remoteState, 0)
 			if s.getLocalState() == stateDown {
 				// Change the desired interval back to the default transmission interval, to
 				// avoid flooding the network while the session is down.
@@ -333,6 +378,39 @@ func (s *Session) IsUp() bool {
 	return s.getLocalState() == stateUp
 }
 
+// Status returns a snapshot of the state of the session. It is safe to call
+// Status while Run is executed.
+func (s *Session) Status() Status {
+	s.localStateLock.RLock()
+	defer s.localStateLock.RUnlock()
+	return Status{
+		LocalState:          layers.BFDState(s.localState),
+		RemoteState:         layers.BFDState(s.remoteState),
+		LocalDiscriminator:  s.LocalDiscriminator,
+		RemoteDiscriminator: s.remoteDiscriminator,
+		PacketsSent:         s.packetsSent,
+		PacketsReceived:     s.packetsReceived,
+		StateChanges:        s.stateChanges,
+	}
+}
+
+// setRemote is a concurrency-safe setter for the remote state and
+// discriminator. It must only be called by Run, which is also the only reader
+// that does not acquire the lock.
+func (s *Session) setRemote(st state, disc layers.BFDDiscriminator) {
+	s.localStateLock.Lock()
+	defer s.localStateLock.Unlock()
+	s.remoteState = st
+	s.remoteDiscriminator = disc
+}
+
+// count is a concurrency-safe incrementer for the counters reported by Status.
+func (s *Session) count(c *uint64) {
+	s.localStateLock.Lock()
+	defer s.localStateLock.Unlock()
+	*c++
+}
+
 // getLocalState is a concurrency-safe getter for local state.
 func (s *Session) getLocalState() state {
 	s.localStateLock.RLock()
@@ -358,14 +436,74 @@ func (s *Session) Messages() chan<- *layers.BFD {
 	return s.messages
 }
 
-// initMessages creates and sets the message receive queue if it is not
-// already created.
+// Close shuts down the Session. If the Session is running, it transitions to
+// the AdminDown state and notifies the remote system with a BFD control packet,
+// such that the remote system can fail over without waiting for the detection
+// timeout. Close waits for Run to return, or until ctx is done, in which case
+// the context error is returned and Run finishes in the background.
+//
+// In contrast to closing the channel returned by Messages, Close can be called
+// while other goroutines still write packets to the channel. Packets written
+// after Close are not processed, and writes block once the receive queue is
+// full. Close can be called multiple times.
+func (s *Session) Close(ctx context.Context) error {
+	s.initMessages()
+	s.closeOnce.Do(func() { close(s.done) })
+	s.runMarkerLock.Lock()
+	running := s.runMarker
+	s.runMarkerLock.Unlock()
+	if !running {
+		return nil
+	}
+	select {
+	case <-s.finished:
+		return nil
+	case <-ctx.Done():
+		return serrors.WrapStr("waiting for session to finish", ctx.Err())
+	}
+}
+
+// sendAdminDown transitions the session to the AdminDown state and sends a
+// BFD control packet announcing it to the remote system.
+func (s *Session) sendAdminDown() {
+	s.setLocalState(stateAdminDown)
+	if s.Metrics.Up != nil {
+		s.Metrics.Up.Set(0)
+	}
+	desiredMinTxInterval, _ := durationToBFDInterval(s.desiredMinTXInterval)
+	requiredMinRxInterval, _ := durationToBFDInterval(s.RequiredMinRxInterval)
+	pkt := layers.BFD{
+		Version:               1,
+		Diagnostic:            layers.BFDDiagnosticAdminDown,
+		State:                 layers.BFDStateAdminDown,
+		DetectMultiplier:      s.DetectMult,
+		MyDiscriminator:       s.LocalDiscriminator,
+		YourDiscriminator:     s.remoteDiscriminator,
+		DesiredMinTxInterval:  desiredMinTxInterval,
+		RequiredMinRxInterval: requiredMinRxInterval,
+	}
+	if err := s.Sender.Send(&pkt); err != nil {
+		s.debug("error sending admin down message", "err", err)
+		return
+	}
+	s.count(&s.packetsSent)
+	if s.Metrics.PacketsSent != nil {
+		s.Metrics.PacketsSent.Add(1)
+	}
+}
+
+// initMessages creates and sets the message receive queue, and the channels
+// used to shut down the session, if they are not already created.
 func (s *Session) initMessages() {
 	s.messagesLock.Lock()
 	defer s.messagesLock.Unlock()
 	if s.messages == nil {
 		s.messages = make(chan *layers.BFD, s.ReceiveQueueSize)
 	}
+	if s.done == nil {
+		s.done = make(chan struct{})
+		s.finished = make(chan struct{})
+	}
 }
 
 // initMetrics initializes the metrics to a zero value.
@@ -399,6 +537,7 @@ func (s *Session) transition(e event) {
 		s.debug(fmt.Sprintf("Transitioned from state %v to state %v on event %v",
 			s.localState, newState, e))
 		s.setLocalState(newState)
+		s.count(&s.stateChanges)
 		if s.Metrics.Up != nil {
 			if newState == stateUp {
 				s.Metrics.Up.Set(1)
@@ -15,6 +15,7 @@
 package bfd_test
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sync"
@@ -565,6 +566,138 @@ func TestSessionRunInit(t *testing.T) {
 	}
 }
 
+func TestSessionClose(t *testing.T) {
+	sent := make(chan *layers.BFD, 100)
+	sender := &redirectSender{Destination: sent}
+	sender.Sending(true)
+	session := &bfd.Session{
+		DetectMult:            1,
+		DesiredMinTxInterval:  time.Microsecond,
+		RequiredMinRxInterval: time.Microsecond,
+		LocalDiscriminator:    1,
+		RemoteDiscriminator:   2,
+		Sender:                sender,
+		ReceiveQueueSize:      1,
+	}
+
+	barrier := make(chan struct{})
+
+	go func() {
+		err := session.Run()
+		assert.NoError(t, err)
+		close(barrier)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.NoError(t, session.Close(context.Background()))
+	select {
+	case <-barrier:
+	default:
+		t.Fatalf("Run did not finish before Close returned")
+	}
+	// Writing to the messages channel after Close must not panic.
+	session.Messages() <- &layers.BFD{}
+	assert.NoError(t, session.Close(context.Background()))
+
+	var last *layers.BFD
+	for len(sent) > 0 {
+		last = <-sent
+	}
+	require.NotNil(t, last)
+	assert.Equal(t, layers.BFDStateAdminDown, last.State)
+	assert.Equal(t, layers.BFDDiagnosticAdminDown, last.Diagnostic)
+	assert.False(t, session.IsUp())
+}
+
+func TestSessionCloseContext(t *testing.T) {
+	release := make(chan struct{})
+	session := &bfd.Session{
+		DetectMult:            1,
+		DesiredMinTxInterval:  time.Microsecond,
+		RequiredMinRxInterval: time.Microsecond,
+		LocalDiscriminator:    1,
+		RemoteDiscriminator:   2,
+		Sender:                blockingSender{release: release},
+		ReceiveQueueSize:      1,
+	}
+	barrier := make(chan struct{})
+	go func() {
+		assert.NoError(t, session.Run())
+		close(barrier)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	// The session is stuck sending, Close must not wait beyond the context.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Error(t, session.Close(ctx))
+
+	close(release)
+	select {
+	case <-barrier:
+	case <-time.After(time.Second):
+		t.Fatalf("Run did not finish in time")
+	}
+}
+
+// blockingSender blocks sending until release is closed.
+type blockingSender struct {
+	release <-chan struct{}
+}
+
+func (s blockingSender) Send(*layers.BFD) error {
+	<-s.release
+	return nil
+}
+
+func TestSessionStatus(t *testing.T) {
+	sessionA := &bfd.Session{
+		DetectMult:            3,
+		DesiredMinTxInterval:  25 * time.Millisecond,
+		RequiredMinRxInterval: 25 * time.Millisecond,
+		LocalDiscriminator:    1,
+		ReceiveQueueSize:      10,
+	}
+	sessionB := &bfd.Session{
+		DetectMult:            3,
+		DesiredMinTxInterval:  25 * time.Millisecond,
+		RequiredMinRxInterval: 25 * time.Millisecond,
+		LocalDiscriminator:    2,
+		ReceiveQueueSize:      10,
+	}
+	linkAToB := &redirectSender{Destination: sessionB.Messages()}
+	linkBToA := &redirectSender{Destination: sessionA.Messages()}
+	sessionA.Sender = linkAToB
+	sessionB.Sender = linkBToA
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, sessionA.Run())
+	}()
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, sessionB.Run())
+	}()
+	linkAToB.Sending(true)
+	linkBToA.Sending(true)
+	time.Sleep(2 * time.Second)
+
+	status := sessionA.Status()
+	assert.Equal(t, layers.BFDStateUp, status.LocalState)
+	assert.Equal(t, layers.BFDStateUp, status.RemoteState)
+	assert.Equal(t, layers.BFDDiscriminator(1), status.LocalDiscriminator)
+	assert.Equal(t, layers.BFDDiscriminator(2), status.RemoteDiscriminator)
+	assert.NotZero(t, status.PacketsSent)
+	assert.NotZero(t, status.PacketsReceived)
+	assert.NotZero(t, status.StateChanges)
+
+	linkAToB.Close()
+	linkBToA.Close()
+	wg.Wait()
+}
+
 func TestPrintPacket(t *testing.T) {
 	testCases := []*struct {
 		packet         *layers.BFD
@@ -0,0 +1,126 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+)
+
+var (
+	expiredReservation = serrors.New("expired reservation")
+	invalidColibriMAC  = serrors.New("invalid COLIBRI hop field MAC")
+	invalidColibriHop  = serrors.New("invalid COLIBRI hop field")
+)
+
+// processColibri forwards a packet with a COLIBRI path. The reservation must
+// not be expired and the MAC of the current hop field must be valid. Packets
+// that fail the validation are dropped without an SCMP error, since the
+// reservation path cannot be used to reach the source.
+func (p *scionPacketProcessor) processColibri() (processResult, error) {
+	colPath, ok := p.scionLayer.Path.(*colibri.Path)
+	if !ok {
+		return processResult{}, malformedPath
+	}
+	info := &colPath.InfoField
+	hf, err := colPath.CurrentHopField()
+	if err != nil {
+		return processResult{}, serrors.Wrap(malformedPath, err)
+	}
+	if expiration := info.Expiration(); expiration.Before(time.Now()) {
+		return processResult{}, serrors.WithCtx(expiredReservation,
+			"expiration", expiration, "if_id", p.ingressID, "curr_hf", info.CurrHF)
+	}
+	if err := p.verifyColibriMAC(info, hf); err != nil {
+		return processResult{}, err
+	}
+
+	ingressID, egressID := hf.IngressID, hf.EgressID
+	if info.R {
+		ingressID, egressID = egressID, ingressID
+	}
+	if p.ingressID != 0 && p.ingressID != ingressID {
+		return processResult{}, serrors.WithCtx(invalidColibriHop,
+			"pkt_ingress", ingressID, "router_ingress", p.ingressID)
+	}
+
+	// Inbound: pkts destined to the local IA.
+	if colPath.IsLastHop() {
+		if !p.scionLayer.DstIA.Equal(p.d.localIA) || egressID != 0 {
+			return processResult{}, serrors.WithCtx(invalidColibriHop,
+				"details", "last hop not in destination AS", "dst", p.scionLayer.DstIA,
+				"egress", egressID)
+		}
+		a, err := p.d.resolveLocalDst(p.scionLayer, p.lastLayer)
+		if err != nil {
+			return processResult{}, serrors.Wrap(cannotRoute, err)
+		}
+		return processResult{OutConn: p.d.internal, OutAddr: a, OutPkt: p.rawPkt}, nil
+	}
+
+	// BRTransit: the packet leaves the local IA through this router, the
+	// egress router advances the path to the hop field of the next AS.
+	if c, ok := p.state.external[egressID]; ok {
+		if err := colPath.IncPath(); err != nil {
+			return processResult{}, serrors.Wrap(malformedPath, err)
+		}
+		if err := info.SerializeTo(p.rawPkt[p.pathPointer():]); err != nil {
+			return processResult{}, serrors.WrapStr("update info field", err)
+		}
+		return processResult{EgressID: egressID, OutConn: c, OutPkt: p.rawPkt}, nil
+	}
+
+	// ASTransit: pkts leaving from another AS BR.
+	if a, ok := p.state.internalNextHops[egressID]; ok {
+		return processResult{OutConn: p.d.internal, OutAddr: a, OutPkt: p.rawPkt}, nil
+	}
+	return processResult{}, serrors.WithCtx(cannotRoute, "egress_id", egressID)
+}
+
+// verifyColibriMAC verifies the MAC of the COLIBRI hop field with the current
+// key and, if that fails, with the previous key.
+func (p *scionPacketProcessor) verifyColibriMAC(info *colibri.InfoField,
+	hf *colibri.HopField) error {
+
+	resAS := p.colibriReservationAS(info)
+	expected := colibri.MAC(p.mac, info, hf, resAS)
+	if subtle.ConstantTimeCompare(hf.Mac[:], expected[:]) == 1 {
+		return nil
+	}
+	if p.prevMac != nil {
+		prev := colibri.MAC(p.prevMac, info, hf, resAS)
+		if subtle.ConstantTimeCompare(hf.Mac[:], prev[:]) == 1 {
+			return nil
+		}
+	}
+	return serrors.WithCtx(invalidColibriMAC,
+		"expected", fmt.Sprintf("%x", expected[:]), "actual", fmt.Sprintf("%x", hf.Mac[:]),
+		"if_id", p.ingressID, "curr_hf", info.CurrHF)
+}
+
+// colibriReservationAS returns the AS that owns the reservation. That is the
+// source AS of the packet, or the destination AS if the packet travels against
+// the direction of the reservation.
+func (p *scionPacketProcessor) colibriReservationAS(info *colibri.InfoField) addr.AS {
+	if info.R {
+		return p.scionLayer.DstIA.A
+	}
+	return p.scionLayer.SrcIA.A
+}
@@ -0,0 +1,380 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/ipv4"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/scrypto"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/router"
+	"github.com/scionproto/scion/go/pkg/router/control"
+	"github.com/scionproto/scion/go/pkg/router/mock_router"
+)
+
+func TestProcessColibri(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	now := time.Now()
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+	srcIA := xtest.MustParseIA("1-ff00:0:111")
+	dst := &net.IPAddr{IP: net.ParseIP("10.0.100.100").To4()}
+
+	newDP := func(ctrl *gomock.Controller) *router.DataPlane {
+		return router.NewDP(
+			map[uint16]router.BatchConn{
+				uint16(2): mock_router.NewMockBatchConn(ctrl),
+			},
+			map[uint16]topology.LinkType{
+				2: topology.Child,
+			},
+			mock_router.NewMockBatchConn(ctrl),
+			map[uint16]*net.UDPAddr{
+				uint16(3): {IP: net.ParseIP("10.0.200.200").To4(), Port: 30043},
+			}, nil, localIA, nil, key)
+	}
+
+	testCases := map[string]struct {
+		mockMsg      func(bool) *ipv4.Message
+		prepareDP    func(*gomock.Controller) *router.DataPlane
+		srcInterface uint16
+		assertFunc   assert.ErrorAssertionFunc
+	}{
+		"inbound": {
+			prepareDP: newDP,
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, srcIA, localIA, dst, now)
+				cpath.InfoField.CurrHF = 1
+				cpath.HopFields[1].Mac = colibriMAC(t, key, cpath, 1, srcIA.A)
+				ret := toMsg(t, spkt, cpath)
+				if afterProcessing {
+					ret.Addr = &net.UDPAddr{IP: dst.IP, Port: topology.EndhostPort}
+					ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				}
+				return ret
+			},
+			srcInterface: 1,
+			assertFunc:   assert.NoError,
+		},
+		"inbound with previous key": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				dp := newDP(ctrl)
+				require.NoError(t, dp.RolloverKey([]byte("new_testkey_xxxx")))
+				return dp
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, srcIA, localIA, dst, now)
+				cpath.InfoField.CurrHF = 1
+				cpath.HopFields[1].Mac = colibriMAC(t, key, cpath, 1, srcIA.A)
+				ret := toMsg(t, spkt, cpath)
+				if afterProcessing {
+					ret.Addr = &net.UDPAddr{IP: dst.IP, Port: topology.EndhostPort}
+					ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				}
+				return ret
+			},
+			srcInterface: 1,
+			assertFunc:   assert.NoError,
+		},
+		"outbound": {
+			prepareDP: newDP,
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, localIA, srcIA, dst, now)
+				cpath.HopFields[0] = colibri.HopField{IngressID: 0, EgressID: 2}
+				cpath.HopFields[0].Mac = colibriMAC(t, key, cpath, 0, localIA.A)
+				if afterProcessing {
+					cpath.InfoField.CurrHF = 1
+				}
+				ret := toMsg(t, spkt, cpath)
+				ret.Addr = nil
+				ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				return ret
+			},
+			srcInterface: 0,
+			assertFunc:   assert.NoError,
+		},
+		"outbound against reservation direction": {
+			prepareDP: newDP,
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				// The reservation goes from 1-ff00:0:111 to the local AS, the
+				// packet travels back to 1-ff00:0:111.
+				spkt, cpath := prepColibriMsg(t, localIA, srcIA, dst, now)
+				cpath.HopFields[1] = colibri.HopField{IngressID: 2, EgressID: 0}
+				cpath.HopFields[1].Mac = colibriMAC(t, key, cpath, 1, srcIA.A)
+				_, err := cpath.Reverse()
+				require.NoError(t, err)
+				cpath.InfoField.CurrHF = 0
+				if afterProcessing {
+					cpath.InfoField.CurrHF = 1
+				}
+				ret := toMsg(t, spkt, cpath)
+				ret.Addr = nil
+				ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				return ret
+			},
+			srcInterface: 0,
+			assertFunc:   assert.NoError,
+		},
+		"AS transit": {
+			prepareDP: newDP,
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, srcIA, xtest.MustParseIA("1-ff00:0:112"),
+					dst, now)
+				cpath.InfoField.HFCount = 3
+				cpath.HopFields = append(cpath.HopFields, colibri.HopField{IngressID: 5})
+				cpath.HopFields[1] = colibri.HopField{IngressID: 1, EgressID: 3}
+				cpath.InfoField.CurrHF = 1
+				cpath.HopFields[1].Mac = colibriMAC(t, key, cpath, 1, srcIA.A)
+				ret := toMsg(t, spkt, cpath)
+				if afterProcessing {
+					ret.Addr = &net.UDPAddr{IP: net.ParseIP("10.0.200.200").To4(), Port: 30043}
+					ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				}
+				return ret
+			},
+			srcInterface: 1,
+			assertFunc:   assert.NoError,
+		},
+		"expired reservation": {
+			prepareDP: newDP,
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, srcIA, localIA, dst, now)
+				cpath.InfoField.CurrHF = 1
+				cpath.InfoField.ExpTick = uint32(now.Add(-time.Minute).Unix() / 4)
+				cpath.HopFields[1].Mac = colibriMAC(t, key, cpath, 1, srcIA.A)
+				return toMsg(t, spkt, cpath)
+			},
+			srcInterface: 1,
+			assertFunc:   assert.Error,
+		},
+		"invalid MAC": {
+			prepareDP: newDP,
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, srcIA, localIA, dst, now)
+				cpath.InfoField.CurrHF = 1
+				cpath.HopFields[1].Mac = [colibri.MacLen]byte{1, 2, 3, 4}
+				return toMsg(t, spkt, cpath)
+			},
+			srcInterface: 1,
+			assertFunc:   assert.Error,
+		},
+		"MAC of other reservation AS": {
+			prepareDP: newDP,
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, srcIA, localIA, dst, now)
+				cpath.InfoField.CurrHF = 1
+				cpath.HopFields[1].Mac = colibriMAC(t, key, cpath, 1, localIA.A)
+				return toMsg(t, spkt, cpath)
+			},
+			srcInterface: 1,
+			assertFunc:   assert.Error,
+		},
+		"invalid ingress": {
+			prepareDP: newDP,
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, cpath := prepColibriMsg(t, srcIA, localIA, dst, now)
+				cpath.InfoField.CurrHF = 1
+				cpath.HopFields[1].Mac = colibriMAC(t, key, cpath, 1, srcIA.A)
+				return toMsg(t, spkt, cpath)
+			},
+			srcInterface: 2,
+			assertFunc:   assert.Error,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dp := tc.prepareDP(ctrl)
+			input, want := tc.mockMsg(false), tc.mockMsg(true)
+			result, err := dp.ProcessPkt(tc.srcInterface, input)
+			tc.assertFunc(t, err)
+			if err != nil {
+				return
+			}
+			assert.NotNil(t, result.OutConn)
+			outPkt := &ipv4.Message{
+				Buffers: [][]byte{result.OutPkt},
+				Addr:    result.OutAddr,
+			}
+			if result.OutAddr == nil {
+				outPkt.Addr = nil
+			}
+			assert.Equal(t, want, outPkt)
+		})
+	}
+}
+
+// TestProcessColibriMultiASToken forwards a packet with the COLIBRI path of a
+// segment reservation over three ASes. The hop fields are created from the AS
+// master keys the same way the control service creates the token, every router
+// must accept the packet with its own key.
+func TestProcessColibriMultiASToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	srcIA := xtest.MustParseIA("1-ff00:0:110")
+	transitIA := xtest.MustParseIA("1-ff00:0:111")
+	dstIA := xtest.MustParseIA("1-ff00:0:112")
+	dst := &net.IPAddr{IP: net.ParseIP("10.0.100.100").To4()}
+	masterKeys := [][]byte{
+		[]byte("master key AS110"),
+		[]byte("master key AS111"),
+		[]byte("master key AS112"),
+	}
+
+	suffix := [colibri.ResIDSuffixLen]byte{0x01, 0x23, 0x45, 0x67}
+	tok := reservation.Token{
+		InfoField: reservation.InfoField{
+			ExpirationTick: reservation.TickFromTime(now.Add(time.Minute)),
+			BWCls:          5,
+			RLC:            2,
+			Idx:            1,
+		},
+		HopFields: []reservation.HopField{
+			{Ingress: 0, Egress: 1},
+			{Ingress: 2, Egress: 3},
+			{Ingress: 4, Egress: 0},
+		},
+	}
+	cpath := &colibri.Path{
+		InfoField: colibri.InfoField{
+			C:           true,
+			S:           true,
+			HFCount:     uint8(len(tok.HopFields)),
+			ResIDSuffix: suffix,
+			ExpTick:     uint32(tok.ExpirationTick),
+			BWCls:       uint8(tok.BWCls),
+			RLC:         uint8(tok.RLC),
+			Idx:         uint8(tok.Idx),
+		},
+	}
+	for i := range tok.HopFields {
+		macGen, err := scrypto.HFMacFactory(masterKeys[i])
+		require.NoError(t, err)
+		hf := colibri.HopField{
+			IngressID: tok.HopFields[i].Ingress,
+			EgressID:  tok.HopFields[i].Egress,
+		}
+		tok.HopFields[i].Mac = colibri.MAC(macGen(), &cpath.InfoField, &hf, srcIA.A)
+		hf.Mac = tok.HopFields[i].Mac
+		cpath.HopFields = append(cpath.HopFields, hf)
+	}
+	spkt, _ := prepColibriMsg(t, srcIA, dstIA, dst, now)
+	msg := toMsg(t, spkt, cpath)
+
+	routers := []struct {
+		dp      *router.DataPlane
+		ingress uint16
+	}{
+		{
+			dp: router.NewDP(
+				map[uint16]router.BatchConn{1: mock_router.NewMockBatchConn(ctrl)},
+				map[uint16]topology.LinkType{1: topology.Parent},
+				mock_router.NewMockBatchConn(ctrl), nil, nil, srcIA, nil,
+				control.DeriveHFMacKey(masterKeys[0])),
+			ingress: 0,
+		},
+		{
+			dp: router.NewDP(
+				map[uint16]router.BatchConn{
+					2: mock_router.NewMockBatchConn(ctrl),
+					3: mock_router.NewMockBatchConn(ctrl),
+				},
+				map[uint16]topology.LinkType{2: topology.Child, 3: topology.Parent},
+				mock_router.NewMockBatchConn(ctrl), nil, nil, transitIA, nil,
+				control.DeriveHFMacKey(masterKeys[1])),
+			ingress: 2,
+		},
+		{
+			dp: router.NewDP(
+				map[uint16]router.BatchConn{4: mock_router.NewMockBatchConn(ctrl)},
+				map[uint16]topology.LinkType{4: topology.Child},
+				mock_router.NewMockBatchConn(ctrl), nil, nil, dstIA, nil,
+				control.DeriveHFMacKey(masterKeys[2])),
+			ingress: 4,
+		},
+	}
+	for i, r := range routers {
+		result, err := r.dp.ProcessPkt(r.ingress, msg)
+		require.NoError(t, err, "router %d", i)
+		require.NotNil(t, result.OutConn, "router %d", i)
+		msg = &ipv4.Message{Buffers: [][]byte{append([]byte(nil), result.OutPkt...)}}
+		msg.N = len(result.OutPkt)
+		if i == len(routers)-1 {
+			assert.Equal(t, &net.UDPAddr{IP: dst.IP, Port: topology.EndhostPort},
+				result.OutAddr)
+		}
+	}
+}
+
+// prepColibriMsg returns a packet with a COLIBRI path with two hop fields,
+// (0,1) in the source AS and (1,0) in the destination AS. The MACs are not set.
+func prepColibriMsg(t *testing.T, srcIA, dstIA addr.IA, dst *net.IPAddr,
+	now time.Time) (*slayers.SCION, *colibri.Path) {
+
+	spkt := &slayers.SCION{
+		Version:      0,
+		TrafficClass: 0xb8,
+		FlowID:       0xdead,
+		NextHdr:      common.L4UDP,
+		PathType:     colibri.PathType,
+		DstIA:        dstIA,
+		SrcIA:        srcIA,
+		PayloadLen:   18,
+	}
+	require.NoError(t, spkt.SetDstAddr(dst))
+	require.NoError(t, spkt.SetSrcAddr(&net.IPAddr{IP: net.ParseIP("10.0.0.100").To4()}))
+	cpath := &colibri.Path{
+		InfoField: colibri.InfoField{
+			HFCount:     2,
+			ResIDSuffix: [colibri.ResIDSuffixLen]byte{0xde, 0xad},
+			ExpTick:     uint32(now.Add(time.Minute).Unix() / 4),
+			BWCls:       5,
+			RLC:         2,
+			Idx:         1,
+		},
+		HopFields: []colibri.HopField{
+			{IngressID: 0, EgressID: 1},
+			{IngressID: 1, EgressID: 0},
+		},
+	}
+	return spkt, cpath
+}
+
+func colibriMAC(t *testing.T, key []byte, cpath *colibri.Path, hf int,
+	resAS addr.AS) [colibri.MacLen]byte {
+
+	mac, err := scrypto.InitMac(key)
+	require.NoError(t, err)
+	return colibri.MAC(mac, &cpath.InfoField, &cpath.HopFields[hf], resAS)
+}
@@ -18,19 +18,38 @@ package config
 
 import (
 	"io"
+	"runtime"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/pkg/api"
 )
 
 const idSample = "router-1"
 
+// DefaultBatchSize is the default number of packets read or written in a
+// single system call.
+const DefaultBatchSize = 64
+
+// DefaultDrainTimeout is the default maximum time the router waits on
+// shutdown for the packets in flight to be forwarded.
+const DefaultDrainTimeout = 3 * time.Second
+
+// DefaultSCMPRate is the default maximum number of SCMP messages per second the
+// router generates.
+const DefaultSCMPRate = 100
+
 type Config struct {
 	General  env.General  `toml:"general,omitempty"`
 	Features env.Features `toml:"features,omitempty"`
 	Logging  log.Config   `toml:"log,omitempty"`
 	Metrics  env.Metrics  `toml:"metrics,omitempty"`
+	API      api.Config   `toml:"api,omitempty"`
+	Router   RouterConfig `toml:"router,omitempty"`
 }
 
 func (cfg *Config) InitDefaults() {
@@ -39,6 +58,8 @@ func (cfg *Config) InitDefaults() {
 		&cfg.Features,
 		&cfg.Logging,
 		&cfg.Metrics,
+		&cfg.API,
+		&cfg.Router,
 	)
 }
 
@@ -48,6 +69,8 @@ func (cfg *Config) Validate() error {
 		&cfg.Features,
 		&cfg.Logging,
 		&cfg.Metrics,
+		&cfg.API,
+		&cfg.Router,
 	)
 }
 
@@ -57,5 +80,102 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.Features,
 		&cfg.Logging,
 		&cfg.Metrics,
+		&cfg.API,
+		&cfg.Router,
 	)
 }
+
+var _ config.Config = (*RouterConfig)(nil)
+
+// RouterConfig holds the configuration of the forwarding pipeline.
+type RouterConfig struct {
+	// NumProcessors is the number of goroutines processing packets. Packets of
+	// the same flow are always processed by the same goroutine. (default
+	// GOMAXPROCS)
+	NumProcessors int `toml:"num_processors,omitempty"`
+	// BatchSize is the maximum number of packets read or written in a single
+	// system call. (default 64)
+	BatchSize int `toml:"batch_size,omitempty"`
+	// MaxFlushLatency is the maximum time packets are held back to fill up a
+	// batch before it is written. If it is zero, packets are written as soon
+	// as no further packets are queued for the same interface. (default 0s)
+	MaxFlushLatency util.DurWrap `toml:"max_flush_latency,omitempty"`
+	// DrainTimeout is the maximum time the router waits on shutdown for the
+	// packets in flight to be forwarded. It should be shorter than the grace
+	// period after which the process is killed. (default 3s)
+	DrainTimeout util.DurWrap `toml:"drain_timeout,omitempty"`
+	// SCMPRate is the maximum number of SCMP messages per second the router
+	// generates per interface, e.g., in response to packets with an invalid
+	// path. Packets exceeding the rate are dropped without SCMP message.
+	// (default 100)
+	SCMPRate int `toml:"scmp_rate,omitempty"`
+	// SCMPBurst is the maximum number of SCMP messages the router generates in
+	// a burst per interface. (default scmp_rate)
+	SCMPBurst int `toml:"scmp_burst,omitempty"`
+	// IngressRate is the maximum number of packets per second the router
+	// accepts from a neighboring AS, summed over all interfaces to the
+	// neighbor. Packets exceeding the rate are dropped. If it is zero, the
+	// rate is not limited. (default 0)
+	IngressRate int `toml:"ingress_rate,omitempty"`
+	// IngressBurst is the maximum number of packets the router accepts from a
+	// neighboring AS in a burst. (default ingress_rate)
+	IngressBurst int `toml:"ingress_burst,omitempty"`
+}
+
+func (cfg *RouterConfig) InitDefaults() {
+	if cfg.NumProcessors == 0 {
+		cfg.NumProcessors = runtime.GOMAXPROCS(0)
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.DrainTimeout.Duration == 0 {
+		cfg.DrainTimeout.Duration = DefaultDrainTimeout
+	}
+	if cfg.SCMPRate == 0 {
+		cfg.SCMPRate = DefaultSCMPRate
+	}
+	if cfg.SCMPBurst == 0 {
+		cfg.SCMPBurst = cfg.SCMPRate
+	}
+	if cfg.IngressBurst == 0 {
+		cfg.IngressBurst = cfg.IngressRate
+	}
+}
+
+func (cfg *RouterConfig) Validate() error {
+	if cfg.NumProcessors < 0 {
+		return serrors.New("num_processors must not be negative", "value", cfg.NumProcessors)
+	}
+	if cfg.BatchSize < 0 {
+		return serrors.New("batch_size must not be negative", "value", cfg.BatchSize)
+	}
+	if cfg.MaxFlushLatency.Duration < 0 {
+		return serrors.New("max_flush_latency must not be negative",
+			"value", cfg.MaxFlushLatency)
+	}
+	if cfg.DrainTimeout.Duration < 0 {
+		return serrors.New("drain_timeout must not be negative", "value", cfg.DrainTimeout)
+	}
+	if cfg.SCMPRate < 0 {
+		return serrors.New("scmp_rate must not be negative", "value", cfg.SCMPRate)
+	}
+	if cfg.SCMPBurst < 0 {
+		return serrors.New("scmp_burst must not be negative", "value", cfg.SCMPBurst)
+	}
+	if cfg.IngressRate < 0 {
+		return serrors.New("ingress_rate must not be negative", "value", cfg.IngressRate)
+	}
+	if cfg.IngressBurst < 0 {
+		return serrors.New("ingress_burst must not be negative", "value", cfg.IngressBurst)
+	}
+	return nil
+}
+
+func (cfg *RouterConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
+	config.WriteString(dst, routerSample)
+}
+
+func (cfg *RouterConfig) ConfigName() string {
+	return "router"
+}
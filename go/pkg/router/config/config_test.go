@@ -23,6 +23,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/env/envtest"
 	"github.com/scionproto/scion/go/lib/log/logtest"
+	"github.com/scionproto/scion/go/pkg/api/apitest"
 	"github.com/scionproto/scion/go/pkg/router/config"
 )
 
@@ -35,14 +36,24 @@ func TestConfigSample(t *testing.T) {
 	err := toml.NewDecoder(bytes.NewReader(sample.Bytes())).Strict(true).Decode(&cfg)
 	assert.NoError(t, err)
 	CheckTestConfig(t, &cfg, config.IDSample)
+	assert.Zero(t, cfg.Router.NumProcessors)
+	assert.Equal(t, config.DefaultBatchSize, cfg.Router.BatchSize)
+	assert.Zero(t, cfg.Router.MaxFlushLatency.Duration)
+	assert.Equal(t, config.DefaultDrainTimeout, cfg.Router.DrainTimeout.Duration)
+	assert.Equal(t, config.DefaultSCMPRate, cfg.Router.SCMPRate)
+	assert.Zero(t, cfg.Router.SCMPBurst)
+	assert.Zero(t, cfg.Router.IngressRate)
+	assert.Zero(t, cfg.Router.IngressBurst)
 }
 
 func InitTestConfig(cfg *config.Config) {
+	apitest.InitConfig(&cfg.API)
 	envtest.InitTest(&cfg.General, &cfg.Metrics, nil, nil)
 	logtest.InitTestLogging(&cfg.Logging)
 }
 
 func CheckTestConfig(t *testing.T, cfg *config.Config, id string) {
+	apitest.CheckConfig(t, &cfg.API)
 	envtest.CheckTest(t, &cfg.General, &cfg.Metrics, nil, nil, id)
 	logtest.CheckTestLogging(t, &cfg.Logging, id)
 }
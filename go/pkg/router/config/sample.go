@@ -0,0 +1,54 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+const routerSample = `
+# The number of goroutines processing packets. Packets of the same flow are
+# always processed by the same goroutine. If it is zero, GOMAXPROCS goroutines
+# are used. (default 0)
+num_processors = 0
+
+# The maximum number of packets read or written in a single system call.
+# (default 64)
+batch_size = 64
+
+# The maximum time packets are held back to fill up a batch before it is
+# written. If it is zero, packets are written as soon as no further packets are
+# queued for the same interface. (default 0s)
+max_flush_latency = "0s"
+
+# The maximum time the router waits on shutdown for the packets in flight to be
+# forwarded. It should be shorter than the grace period after which the process
+# is killed. (default 3s)
+drain_timeout = "3s"
+
+# The maximum number of SCMP messages per second the router generates per
+# interface, e.g., in response to packets with an invalid path. Packets
+# exceeding the rate are dropped without SCMP message. (default 100)
+scmp_rate = 100
+
+# The maximum number of SCMP messages the router generates in a burst per
+# interface. If it is zero, scmp_rate is used. (default 0)
+scmp_burst = 0
+
+# The maximum number of packets per second the router accepts from a
+# neighboring AS, summed over all interfaces to the neighbor. Packets exceeding
+# the rate are dropped. If it is zero, the rate is not limited. (default 0)
+ingress_rate = 0
+
+# The maximum number of packets the router accepts from a neighboring AS in a
+# burst. If it is zero, ingress_rate is used. (default 0)
+ingress_burst = 0
+`
@@ -63,7 +63,9 @@ func (c *Connector) AddInternalInterface(ia addr.IA, local net.UDPAddr) error {
 	return c.DataPlane.AddInternalInterface(connection, local.IP)
 }
 
-// AddExternalInterface adds a link between the local and remote address.
+// AddExternalInterface adds a link between the local and remote address. If
+// adding the link fails after parts of it have been added to the DataPlane,
+// these parts are removed again, such that adding the link can be retried.
 func (c *Connector) AddExternalInterface(localIfID common.IFIDType, link control.LinkInfo,
 	owned bool) error {
 
@@ -77,10 +79,26 @@ func (c *Connector) AddExternalInterface(localIfID common.IFIDType, link control
 		return serrors.WithCtx(errMultiIA, "current", c.ia, "new", link.Local.IA)
 	}
 	if err := c.DataPlane.AddLinkType(intf, link.LinkTo); err != nil {
+		// Nothing was added, the link type might belong to an existing
+		// interface that must not be removed.
 		return serrors.WrapStr("adding link type", err, "if_id", localIfID)
 	}
+	if err := c.addExternalInterface(intf, link, owned); err != nil {
+		if rbErr := c.DataPlane.DelExternalInterface(intf); rbErr != nil {
+			log.Info("Failed to remove partially added interface", "interface", localIfID,
+				"err", rbErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// addExternalInterface adds the parts of the link besides the link type.
+func (c *Connector) addExternalInterface(intf uint16, link control.LinkInfo,
+	owned bool) error {
+
 	if err := c.DataPlane.AddNeighborIA(intf, link.Remote.IA); err != nil {
-		return serrors.WrapStr("adding neighboring IA", err, "if_id", localIfID)
+		return serrors.WrapStr("adding neighboring IA", err, "if_id", intf)
 	}
 
 	if !owned {
@@ -88,7 +106,7 @@ func (c *Connector) AddExternalInterface(localIfID common.IFIDType, link control
 			err := c.DataPlane.AddNextHopBFD(intf, link.Local.Addr, link.Remote.Addr,
 				link.BFD, link.Instance)
 			if err != nil {
-				return serrors.WrapStr("adding next hop BFD", err, "if_id", localIfID)
+				return serrors.WrapStr("adding next hop BFD", err, "if_id", intf)
 			}
 		}
 		return c.DataPlane.AddNextHop(intf, link.Remote.Addr)
@@ -102,10 +120,25 @@ func (c *Connector) AddExternalInterface(localIfID common.IFIDType, link control
 		err := c.DataPlane.AddExternalInterfaceBFD(intf, connection, link.Local,
 			link.Remote, link.BFD)
 		if err != nil {
-			return serrors.WrapStr("adding external BFD", err, "if_id", localIfID)
+			connection.Close()
+			return serrors.WrapStr("adding external BFD", err, "if_id", intf)
 		}
 	}
-	return c.DataPlane.AddExternalInterface(intf, connection)
+	if err := c.DataPlane.AddExternalInterface(intf, connection); err != nil {
+		// The connection is only closed by the DataPlane once it was added.
+		connection.Close()
+		return err
+	}
+	return nil
+}
+
+// DelExternalInterface deletes the link of the given interface.
+func (c *Connector) DelExternalInterface(localIfID common.IFIDType) error {
+	log.Debug("Deleting external interface", "interface", localIfID)
+	if err := c.DataPlane.DelExternalInterface(uint16(localIfID)); err != nil {
+		return serrors.WrapStr("deleting external interface", err, "if_id", localIfID)
+	}
+	return nil
 }
 
 // AddSvc adds the service address for the given ISD-AS.
@@ -126,16 +159,42 @@ func (c *Connector) DelSvc(ia addr.IA, svc addr.HostSVC, ip net.IP) error {
 	return c.DataPlane.DelSvc(svc, &net.UDPAddr{IP: ip, Port: topology.EndhostPort})
 }
 
-// SetKey sets the key for the given ISD-AS at the given index.
+// SetPortRange sets the range of ports on which end hosts in the given ISD-AS
+// receive packets directly.
+func (c *Connector) SetPortRange(ia addr.IA, start, end uint16) error {
+	log.Debug("Setting dispatched port range", "isd_as", ia, "start", start, "end", end)
+	if !c.ia.Equal(ia) {
+		return serrors.WithCtx(errMultiIA, "current", c.ia, "new", ia)
+	}
+	return c.DataPlane.SetPortRange(start, end)
+}
+
+// SetKey sets the key for the given ISD-AS at the given index. Index 0 is the
+// current key, index 1 the previous key, which is only used for MAC
+// verification.
 func (c *Connector) SetKey(ia addr.IA, index int, key []byte) error {
 	log.Debug("Setting key", "isd_as", ia, "index", index)
 	if !c.ia.Equal(ia) {
 		return serrors.WithCtx(errMultiIA, "current", c.ia, "new", ia)
 	}
-	if index != 0 {
-		return serrors.New("currently only index 0 key is supported")
+	switch index {
+	case 0:
+		return c.DataPlane.SetKey(key)
+	case 1:
+		return c.DataPlane.SetPreviousKey(key)
+	default:
+		return serrors.New("unsupported key index", "index", index)
+	}
+}
+
+// RolloverKey replaces the current key for the given ISD-AS. The replaced key
+// becomes the previous key.
+func (c *Connector) RolloverKey(ia addr.IA, key []byte) error {
+	log.Debug("Rolling over key", "isd_as", ia)
+	if !c.ia.Equal(ia) {
+		return serrors.WithCtx(errMultiIA, "current", c.ia, "new", ia)
 	}
-	return c.DataPlane.SetKey(key)
+	return c.DataPlane.RolloverKey(key)
 }
 
 // SetRevocation sets the revocation for the given ISD-AS and interface.
@@ -17,6 +17,7 @@ package control
 import (
 	"crypto/sha256"
 	"net"
+	"reflect"
 	"sort"
 
 	"golang.org/x/crypto/pbkdf2"
@@ -34,9 +35,12 @@ type Dataplane interface {
 	CreateIACtx(ia addr.IA) error
 	AddInternalInterface(ia addr.IA, local net.UDPAddr) error
 	AddExternalInterface(localIfID common.IFIDType, info LinkInfo, owned bool) error
+	DelExternalInterface(localIfID common.IFIDType) error
 	AddSvc(ia addr.IA, svc addr.HostSVC, ip net.IP) error
 	DelSvc(ia addr.IA, svc addr.HostSVC, ip net.IP) error
+	SetPortRange(ia addr.IA, start, end uint16) error
 	SetKey(ia addr.IA, index int, key []byte) error
+	RolloverKey(ia addr.IA, key []byte) error
 
 	SetRevocation(ia addr.IA, ifid common.IFIDType, rev []byte) error
 	DelRevocation(ia addr.IA, ifid common.IFIDType) error
@@ -69,8 +73,17 @@ func ConfigDataplane(dp Dataplane, cfg *Config) error {
 	if err := dp.CreateIACtx(cfg.IA); err != nil {
 		return err
 	}
+	// Set the port range on which end hosts receive packets directly.
+	if cfg.Topo != nil {
+		if start, end := cfg.Topo.PortRange(); start != 0 {
+			if err := dp.SetPortRange(cfg.IA, start, end); err != nil {
+				return err
+			}
+		}
+	}
 	// Set Keys
-	// XXX HSR currently only support 1 key, so use Key0
+	// Key0 is the current key, Key1 the previous key which is still accepted
+	// for MAC verification during a key rollover.
 	// Should it be an error if no key is set?
 	if len(cfg.MasterKeys.Key0) > 0 {
 		key0 := DeriveHFMacKey(cfg.MasterKeys.Key0)
@@ -78,6 +91,12 @@ func ConfigDataplane(dp Dataplane, cfg *Config) error {
 			return err
 		}
 	}
+	if len(cfg.MasterKeys.Key1) > 0 {
+		key1 := DeriveHFMacKey(cfg.MasterKeys.Key1)
+		if err := dp.SetKey(cfg.IA, 1, key1); err != nil {
+			return err
+		}
+	}
 	// Add internal interfaces
 	if cfg.BR != nil {
 		if cfg.BR.InternalAddr != nil {
@@ -97,6 +116,91 @@ func ConfigDataplane(dp Dataplane, cfg *Config) error {
 	return nil
 }
 
+// UpdateDataplane applies the changes from the old to the new configuration to
+// the data plane. External interfaces that were removed or changed are deleted
+// from the data plane, and interfaces that were added or changed are added.
+// The addresses of the SVC services are updated accordingly. The ISD-AS, the
+// internal address of the router, and the dispatched port range cannot be
+// changed. Keys are not updated.
+//
+// If changing the external interfaces fails, the changes of the interfaces
+// that were already applied are rolled back, such that the data plane keeps
+// the interfaces of the old configuration and the update can be retried.
+func UpdateDataplane(dp Dataplane, old, cfg *Config) error {
+	if old == nil || cfg == nil {
+		return serrors.New("empty configuration")
+	}
+	if !old.IA.Equal(cfg.IA) {
+		return serrors.New("ISD-AS changed", "old", old.IA, "new", cfg.IA)
+	}
+	if (old.BR == nil) != (cfg.BR == nil) ||
+		(old.BR != nil && old.BR.InternalAddr.String() != cfg.BR.InternalAddr.String()) {
+		return serrors.New("internal address changed")
+	}
+	if portRange(old) != portRange(cfg) {
+		return serrors.New("dispatched port range changed")
+	}
+	oldIfs, newIfs := externalInterfaces(old), externalInterfaces(cfg)
+	var deleted, added []common.IFIDType
+	for _, ifid := range sortedIFIDs(oldIfs) {
+		if reflect.DeepEqual(oldIfs[ifid], newIfs[ifid]) {
+			continue
+		}
+		if err := dp.DelExternalInterface(ifid); err != nil {
+			err = serrors.WrapStr("deleting external interface", err, "if_id", ifid)
+			return rollbackInterfaces(dp, err, oldIfs, deleted, added)
+		}
+		deleted = append(deleted, ifid)
+	}
+	for _, ifid := range sortedIFIDs(newIfs) {
+		if reflect.DeepEqual(oldIfs[ifid], newIfs[ifid]) {
+			continue
+		}
+		iface := newIfs[ifid]
+		if err := dp.AddExternalInterface(ifid, iface.LinkInfo, iface.owned); err != nil {
+			err = serrors.WrapStr("adding external interface", err, "if_id", ifid)
+			return rollbackInterfaces(dp, err, oldIfs, deleted, added)
+		}
+		added = append(added, ifid)
+	}
+	return updateServices(dp, old, cfg)
+}
+
+// rollbackInterfaces deletes the added interfaces and adds the deleted
+// interfaces of the old configuration again. It returns the error that caused
+// the rollback, with the errors of the rollback attached.
+func rollbackInterfaces(dp Dataplane, cause error,
+	oldIfs map[common.IFIDType]externalInterface, deleted, added []common.IFIDType) error {
+
+	var errs serrors.List
+	for i := len(added) - 1; i >= 0; i-- {
+		if err := dp.DelExternalInterface(added[i]); err != nil {
+			errs = append(errs, serrors.WrapStr("deleting external interface", err,
+				"if_id", added[i]))
+		}
+	}
+	for _, ifid := range deleted {
+		iface := oldIfs[ifid]
+		if err := dp.AddExternalInterface(ifid, iface.LinkInfo, iface.owned); err != nil {
+			errs = append(errs, serrors.WrapStr("adding external interface", err,
+				"if_id", ifid))
+		}
+	}
+	if len(errs) != 0 {
+		return serrors.WithCtx(cause, "rollback_errors", errs.ToError())
+	}
+	return cause
+}
+
+// portRange returns the dispatched port range of the configuration.
+func portRange(cfg *Config) [2]uint16 {
+	if cfg.Topo == nil {
+		return [2]uint16{}
+	}
+	start, end := cfg.Topo.PortRange()
+	return [2]uint16{start, end}
+}
+
 // DeriveHFMacKey derives the MAC key from the given key.
 func DeriveHFMacKey(k []byte) []byte {
 	if len(k) == 0 {
@@ -110,20 +214,33 @@ func DeriveHFMacKey(k []byte) []byte {
 }
 
 func confExternalInterfaces(dp Dataplane, cfg *Config) error {
+	ifs := externalInterfaces(cfg)
 	// Sort out keys/ifids to get deterministic order for unit testing
-	infoMap := cfg.Topo.IFInfoMap()
-	if len(infoMap) == 0 {
-		// nothing to do
-		return nil
+	for _, ifid := range sortedIFIDs(ifs) {
+		iface := ifs[ifid]
+		if err := dp.AddExternalInterface(ifid, iface.LinkInfo, iface.owned); err != nil {
+			return err
+		}
 	}
-	ifids := []common.IFIDType{}
-	for k := range infoMap {
-		ifids = append(ifids, k)
+	return nil
+}
+
+// externalInterface is the data-plane configuration of an external interface.
+type externalInterface struct {
+	LinkInfo
+	// owned indicates whether the interface is owned by this router.
+	owned bool
+}
+
+// externalInterfaces returns the data-plane configuration of all the external
+// interfaces in the AS.
+func externalInterfaces(cfg *Config) map[common.IFIDType]externalInterface {
+	if cfg.Topo == nil || cfg.BR == nil {
+		return nil
 	}
-	sort.Slice(ifids, func(i, j int) bool { return ifids[i] < ifids[j] })
-	// External interfaces
-	for _, ifid := range ifids {
-		iface := infoMap[ifid]
+	infoMap := cfg.Topo.IFInfoMap()
+	ifs := make(map[common.IFIDType]externalInterface, len(infoMap))
+	for ifid, iface := range infoMap {
 		linkInfo := LinkInfo{
 			Local: LinkEnd{
 				IA:   cfg.IA,
@@ -152,12 +269,18 @@ func confExternalInterfaces(dp Dataplane, cfg *Config) error {
 			// the env variables.
 			linkInfo.BFD = bfdDefaults
 		}
+		ifs[ifid] = externalInterface{LinkInfo: linkInfo, owned: owned}
+	}
+	return ifs
+}
 
-		if err := dp.AddExternalInterface(ifid, linkInfo, owned); err != nil {
-			return err
-		}
+func sortedIFIDs(ifs map[common.IFIDType]externalInterface) []common.IFIDType {
+	ifids := make([]common.IFIDType, 0, len(ifs))
+	for k := range ifs {
+		ifids = append(ifids, k)
 	}
-	return nil
+	sort.Slice(ifids, func(i, j int) bool { return ifids[i] < ifids[j] })
+	return ifids
 }
 
 var svcTypes = []addr.HostSVC{
@@ -174,16 +297,33 @@ func confServices(dp Dataplane, cfg *Config) error {
 		return nil
 	}
 	for _, svc := range svcTypes {
-		addrs, err := cfg.Topo.UnderlayMulticast(svc)
-		if err != nil {
-			// XXX assumption is that any error means there are no addresses for the SVC type
-			continue
+		for _, a := range svcAddrs(cfg, svc) {
+			if err := dp.AddSvc(cfg.IA, svc, a.IP); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// updateServices adds the SVC addresses that are only in the new configuration
+// to the data plane, and deletes the ones that are only in the old
+// configuration.
+func updateServices(dp Dataplane, old, cfg *Config) error {
+	for _, svc := range svcTypes {
+		oldAddrs, newAddrs := svcAddrs(old, svc), svcAddrs(cfg, svc)
+		for _, a := range oldAddrs {
+			if containsIP(newAddrs, a.IP) {
+				continue
+			}
+			if err := dp.DelSvc(cfg.IA, svc, a.IP); err != nil {
+				return err
+			}
 		}
-		// Sort to get deterministic unit test, shouldn't matter for SVC resolution
-		sort.Slice(addrs, func(i, j int) bool {
-			return addrs[i].IP.String() < addrs[j].IP.String()
-		})
-		for _, a := range addrs {
+		for _, a := range newAddrs {
+			if containsIP(oldAddrs, a.IP) {
+				continue
+			}
 			if err := dp.AddSvc(cfg.IA, svc, a.IP); err != nil {
 				return err
 			}
@@ -191,3 +331,30 @@ func confServices(dp Dataplane, cfg *Config) error {
 	}
 	return nil
 }
+
+// svcAddrs returns the underlay addresses of the given SVC service, sorted by
+// IP.
+func svcAddrs(cfg *Config, svc addr.HostSVC) []*net.UDPAddr {
+	if cfg.Topo == nil {
+		return nil
+	}
+	addrs, err := cfg.Topo.UnderlayMulticast(svc)
+	if err != nil {
+		// XXX assumption is that any error means there are no addresses for the SVC type
+		return nil
+	}
+	// Sort to get deterministic unit test, shouldn't matter for SVC resolution
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].IP.String() < addrs[j].IP.String()
+	})
+	return addrs
+}
+
+func containsIP(addrs []*net.UDPAddr, ip net.IP) bool {
+	for _, a := range addrs {
+		if a.IP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package control_test
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/pkg/router/control"
+)
+
+// recordingDataplane records the calls that modify the data plane. If ifs is
+// set, it tracks the external interfaces and fails like the data plane when
+// adding an existing or deleting an unknown interface.
+type recordingDataplane struct {
+	calls []string
+	// ifs contains the external interfaces of the data plane.
+	ifs map[common.IFIDType]bool
+	// failAdd contains the interfaces that cannot be added.
+	failAdd map[common.IFIDType]bool
+}
+
+func (d *recordingDataplane) record(format string, args ...interface{}) error {
+	d.calls = append(d.calls, fmt.Sprintf(format, args...))
+	return nil
+}
+
+func (d *recordingDataplane) CreateIACtx(ia addr.IA) error {
+	return d.record("CreateIACtx %s", ia)
+}
+
+func (d *recordingDataplane) AddInternalInterface(ia addr.IA, local net.UDPAddr) error {
+	return d.record("AddInternalInterface %s", &local)
+}
+
+func (d *recordingDataplane) AddExternalInterface(ifID common.IFIDType,
+	info control.LinkInfo, owned bool) error {
+
+	_ = d.record("AddExternalInterface %d %s %t", ifID, info.Remote.Addr, owned)
+	if d.failAdd[ifID] {
+		return serrors.New("failed to add interface", "if_id", ifID)
+	}
+	if d.ifs == nil {
+		return nil
+	}
+	if d.ifs[ifID] {
+		return serrors.New("interface already set", "if_id", ifID)
+	}
+	d.ifs[ifID] = true
+	return nil
+}
+
+func (d *recordingDataplane) DelExternalInterface(ifID common.IFIDType) error {
+	_ = d.record("DelExternalInterface %d", ifID)
+	if d.ifs == nil {
+		return nil
+	}
+	if !d.ifs[ifID] {
+		return serrors.New("interface not found", "if_id", ifID)
+	}
+	delete(d.ifs, ifID)
+	return nil
+}
+
+func (d *recordingDataplane) AddSvc(ia addr.IA, svc addr.HostSVC, ip net.IP) error {
+	return d.record("AddSvc %s %s", svc.BaseString(), ip)
+}
+
+func (d *recordingDataplane) DelSvc(ia addr.IA, svc addr.HostSVC, ip net.IP) error {
+	return d.record("DelSvc %s %s", svc.BaseString(), ip)
+}
+
+func (d *recordingDataplane) SetPortRange(ia addr.IA, start, end uint16) error {
+	return d.record("SetPortRange %d-%d", start, end)
+}
+
+func (d *recordingDataplane) SetKey(ia addr.IA, index int, key []byte) error {
+	return d.record("SetKey %d", index)
+}
+
+func (d *recordingDataplane) RolloverKey(ia addr.IA, key []byte) error {
+	return d.record("RolloverKey")
+}
+
+func (d *recordingDataplane) SetRevocation(ia addr.IA, ifID common.IFIDType,
+	rev []byte) error {
+
+	return d.record("SetRevocation %d", ifID)
+}
+
+func (d *recordingDataplane) DelRevocation(ia addr.IA, ifID common.IFIDType) error {
+	return d.record("DelRevocation %d", ifID)
+}
+
+func loadConfig(t *testing.T) *control.Config {
+	cfg, err := control.LoadConfig("br1-ff00_0_110-2", "testdata")
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestUpdateDataplane(t *testing.T) {
+	testCases := map[string]struct {
+		Modify       func(*topology.RWTopology)
+		ErrAssertion assert.ErrorAssertionFunc
+		Expected     []string
+	}{
+		"no change": {
+			Modify:       func(*topology.RWTopology) {},
+			ErrAssertion: assert.NoError,
+		},
+		"interfaces and services changed": {
+			Modify: func(topo *topology.RWTopology) {
+				// Interface 1 is owned by the sibling router and removed.
+				delete(topo.IFInfoMap, 1)
+				// Interface 2 is owned by this router and its remote changes.
+				iface := topo.IFInfoMap[2]
+				iface.Remote = xtest.MustParseUDPAddr(t, "127.0.0.3:50000")
+				topo.IFInfoMap[2] = iface
+				// Interface 3 is owned by the sibling router and added.
+				topo.IFInfoMap[3] = topology.IFInfo{
+					ID:           3,
+					BRName:       "br1-ff00_0_110-1",
+					InternalAddr: xtest.MustParseUDPAddr(t, "127.0.0.1:50000"),
+					IA:           xtest.MustParseIA("1-ff00:0:130"),
+					LinkType:     topology.Child,
+				}
+				topo.CS["cs1-ff00_0_110-3"] = topology.TopoAddr{
+					SCIONAddress:    xtest.MustParseUDPAddr(t, "127.0.0.5:60005"),
+					UnderlayAddress: xtest.MustParseUDPAddr(t, "127.0.0.5:60005"),
+				}
+				delete(topo.SIG, "sig1-ff00_0_110-1")
+				delete(topo.SIG, "sig1-ff00_0_110-2")
+			},
+			ErrAssertion: assert.NoError,
+			Expected: []string{
+				"DelExternalInterface 1",
+				"DelExternalInterface 2",
+				"AddExternalInterface 2 127.0.0.3:50000 true",
+				"AddExternalInterface 3 127.0.0.1:50000 false",
+				"AddSvc CS 127.0.0.5",
+				"DelSvc SIG 127.0.0.1",
+			},
+		},
+		"dispatched port range changed": {
+			Modify: func(topo *topology.RWTopology) {
+				topo.DispatchedPortStart = 31000
+				topo.DispatchedPortEnd = 32767
+			},
+			ErrAssertion: assert.Error,
+		},
+		"ISD-AS changed": {
+			Modify: func(topo *topology.RWTopology) {
+				topo.IA = xtest.MustParseIA("1-ff00:0:111")
+			},
+			ErrAssertion: assert.Error,
+		},
+	}
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			old, cfg := loadConfig(t), loadConfig(t)
+			tc.Modify(cfg.Topo.Writable())
+			cfg.IA = cfg.Topo.IA()
+
+			dp := &recordingDataplane{}
+			err := control.UpdateDataplane(dp, old, cfg)
+			tc.ErrAssertion(t, err)
+			assert.Equal(t, tc.Expected, dp.calls)
+		})
+	}
+}
+
+func TestUpdateDataplaneRollback(t *testing.T) {
+	old, cfg := loadConfig(t), loadConfig(t)
+	topo := cfg.Topo.Writable()
+	// Interface 2 is owned by this router and its remote changes.
+	iface := topo.IFInfoMap[2]
+	iface.Remote = xtest.MustParseUDPAddr(t, "127.0.0.3:50000")
+	topo.IFInfoMap[2] = iface
+	// Interface 3 is owned by the sibling router and added.
+	topo.IFInfoMap[3] = topology.IFInfo{
+		ID:           3,
+		BRName:       "br1-ff00_0_110-1",
+		InternalAddr: xtest.MustParseUDPAddr(t, "127.0.0.1:50000"),
+		IA:           xtest.MustParseIA("1-ff00:0:130"),
+		LinkType:     topology.Child,
+	}
+
+	dp := &recordingDataplane{
+		ifs:     make(map[common.IFIDType]bool),
+		failAdd: map[common.IFIDType]bool{3: true},
+	}
+	require.NoError(t, control.ConfigDataplane(dp, old))
+	ifs := make(map[common.IFIDType]bool)
+	for ifID := range dp.ifs {
+		ifs[ifID] = true
+	}
+
+	// The second add fails, the first one is rolled back.
+	dp.calls = nil
+	assert.Error(t, control.UpdateDataplane(dp, old, cfg))
+	assert.Equal(t, []string{
+		"DelExternalInterface 2",
+		"AddExternalInterface 2 127.0.0.3:50000 true",
+		"AddExternalInterface 3 127.0.0.1:50000 false",
+		"DelExternalInterface 2",
+		"AddExternalInterface 2 127.0.0.1:50000 true",
+	}, dp.calls)
+	assert.Equal(t, ifs, dp.ifs)
+
+	// Retrying the update succeeds once the interface can be added.
+	dp.calls, dp.failAdd = nil, nil
+	assert.NoError(t, control.UpdateDataplane(dp, old, cfg))
+	assert.Equal(t, []string{
+		"DelExternalInterface 2",
+		"AddExternalInterface 2 127.0.0.3:50000 true",
+		"AddExternalInterface 3 127.0.0.1:50000 false",
+	}, dp.calls)
+}
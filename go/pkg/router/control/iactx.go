@@ -15,6 +15,7 @@
 package control
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -96,11 +97,17 @@ func (cfg *Config) initTopo(id string, topo topology.Topology) error {
 // loadMasterKeys loads the master keys from the config directory.
 func (cfg *Config) loadMasterKeys(confDir string) error {
 	var err error
-	cfg.MasterKeys, err = keyconf.LoadMaster(filepath.Join(confDir, "keys"))
+	cfg.MasterKeys, err = LoadMasterKeys(confDir)
+	return err
+}
+
+// LoadMasterKeys loads the master keys from the config directory.
+func LoadMasterKeys(confDir string) (keyconf.Master, error) {
+	keys, err := keyconf.LoadMaster(filepath.Join(confDir, "keys"))
 	if err != nil {
-		return serrors.WrapStr("loading master keys", err)
+		return keyconf.Master{}, serrors.WrapStr("loading master keys", err)
 	}
-	return nil
+	return keys, nil
 }
 
 // IACtx is the context for the router for a given IA.
@@ -117,6 +124,8 @@ type IACtx struct {
 
 	// svcHealthWatcher watches for service health changes.
 	svcHealthWatcher *periodic.Runner
+	// mtx serializes updates of the configuration.
+	mtx sync.Mutex
 }
 
 // Start configures the dataplane for the given context.
@@ -153,6 +162,53 @@ func (iac *IACtx) Start(wg *sync.WaitGroup) error {
 	return nil
 }
 
+// RolloverMasterKeys rolls the data plane over to the hop field MAC key derived
+// from the current master key (Key0) in keys, if it differs from the one in
+// use. The replaced key is still accepted for MAC verification, such that
+// paths constructed with it keep working until they expire.
+func (iac *IACtx) RolloverMasterKeys(keys keyconf.Master) error {
+	iac.mtx.Lock()
+	defer iac.mtx.Unlock()
+	return iac.rolloverMasterKeys(keys)
+}
+
+func (iac *IACtx) rolloverMasterKeys(keys keyconf.Master) error {
+	if len(keys.Key0) == 0 {
+		return serrors.New("empty master key")
+	}
+	if bytes.Equal(keys.Key0, iac.Config.MasterKeys.Key0) {
+		return nil
+	}
+	if err := iac.DP.RolloverKey(iac.Config.IA, DeriveHFMacKey(keys.Key0)); err != nil {
+		return serrors.WrapStr("rolling over key", err)
+	}
+	iac.Config.MasterKeys = keys
+	log.Info("Rolled over hop field MAC key")
+	return nil
+}
+
+// UpdateConfig updates the running data plane to the given configuration. The
+// changes of the external interfaces and SVC addresses are applied with
+// UpdateDataplane, and the data plane is rolled over to the new master key, if
+// it changed. Service health watching keeps using the topology the context was
+// started with.
+func (iac *IACtx) UpdateConfig(cfg *Config) error {
+	iac.mtx.Lock()
+	defer iac.mtx.Unlock()
+	if err := UpdateDataplane(iac.DP, iac.Config, cfg); err != nil {
+		return serrors.WrapStr("updating dataplane", err)
+	}
+	keys := iac.Config.MasterKeys
+	iac.Config = &Config{
+		Topo:       cfg.Topo,
+		IA:         cfg.IA,
+		BR:         cfg.BR,
+		MasterKeys: keys,
+	}
+	log.Info("Updated dataplane configuration", "config", iac.Config)
+	return iac.rolloverMasterKeys(cfg.MasterKeys)
+}
+
 func (iac *IACtx) watchSVCHealth() error {
 	w := svchealth.Watcher{
 		Discoverer: iac.Discoverer,
@@ -16,6 +16,7 @@ package router
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/subtle"
 	"errors"
@@ -23,8 +24,10 @@ import (
 	"hash"
 	"math/big"
 	"net"
+	"runtime"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/gopacket"
@@ -40,6 +43,7 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/slayers/path/empty"
 	"github.com/scionproto/scion/go/lib/slayers/path/epic"
 	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
@@ -56,6 +60,22 @@ const (
 	// Number of packets to read in a single ReadBatch call.
 	inputBatchCnt = 64
 
+	// queueBatches is the capacity, in batches, of the queues between the
+	// stages of the forwarding pipeline.
+	queueBatches = 4
+
+	// drainPollInterval is the interval in which Shutdown checks whether all
+	// in-flight packets have been forwarded.
+	drainPollInterval = time.Millisecond
+
+	// bfdCloseTimeout is the maximum time DelExternalInterface waits for the
+	// BFD session of a removed interface to announce that it is going down.
+	bfdCloseTimeout = time.Second
+
+	// defaultSCMPRate is the default maximum number of SCMP messages per
+	// second the dataplane generates.
+	defaultSCMPRate = 100
+
 	// TODO(karampok). Investigate whether that value should be higher.  In
 	// theory, PayloadLen in SCION header is 16 bits long, supporting a maximum
 	// payload size of 64KB. At the moment we are limited by Ethernet size
@@ -65,18 +85,28 @@ const (
 	// hopFieldDefaultExpTime is the default validity of the hop field
 	// and 63 is equivalent to 6h.
 	hopFieldDefaultExpTime = 63
+
+	// dstIAPointer and srcIAPointer are the offsets of the destination and
+	// the source ISD-AS in the packet, dstAddrPointer is the offset of the
+	// destination host address.
+	dstIAPointer   = slayers.CmnHdrLen
+	srcIAPointer   = dstIAPointer + addr.IABytes
+	dstAddrPointer = srcIAPointer + addr.IABytes
 )
 
 type bfdSession interface {
 	Run() error
 	Messages() chan<- *layers.BFD
 	IsUp() bool
+	Status() bfd.Status
+	Close(ctx context.Context) error
 }
 
 // BatchConn is a connection that supports batch reads and writes.
 type BatchConn interface {
 	ReadBatch(underlayconn.Messages) (int, error)
 	WriteTo([]byte, *net.UDPAddr) (int, error)
+	WriteBatch(underlayconn.Messages) (int, error)
 	Close() error
 }
 
@@ -87,6 +117,8 @@ type BatchConn interface {
 // XXX(lukedirtwalker): this is still in development and not feature complete.
 // Currently, only the following features are supported:
 //  - initializing connections; MUST be done prior to calling Run
+//  - adding and removing external interfaces of a running dataplane
+//  - shutting down a running dataplane
 type DataPlane struct {
 	external          map[uint16]BatchConn
 	linkTypes         map[uint16]topology.LinkType
@@ -96,26 +128,70 @@ type DataPlane struct {
 	internalNextHops  map[uint16]*net.UDPAddr
 	svc               *services
 	macFactory        func() hash.Hash
+	keys              atomic.Value // *macKeys
+	state             atomic.Value // *forwardingState
 	bfdSessions       map[uint16]bfdSession
 	localIA           addr.IA
 	mtx               sync.Mutex
 	running           bool
 	Metrics           *Metrics
 	forwardingMetrics map[uint16]forwardingMetrics
+	packetPool        sync.Pool
+
+	// runCfg is the configuration of the running forwarding pipeline.
+	runCfg RunConfig
+	// procQs are the queues of the packet processors.
+	procQs []chan *packet
+	// fwQs are the queues of the forwarders, per connection.
+	fwQs map[BatchConn]chan *packet
+	// runners are the receivers and forwarders of the interfaces, per
+	// interface ID. ID 0 refers to the internal interface.
+	runners map[uint16]*interfaceRunner
+	// processors is done once all packet processors have stopped.
+	processors sync.WaitGroup
+	// inFlight is the number of packets that have been accepted by a
+	// receiver, and have not been written or dropped yet. It is accessed
+	// atomically.
+	inFlight int32
+	// draining is closed when the dataplane is shut down. Afterwards, the
+	// receivers drop all packets they read.
+	draining chan struct{}
+	// stopped is closed once the dataplane has been shut down.
+	stopped chan struct{}
+	// scmpLimiters limit the rate of SCMP messages generated in response to
+	// packets received on the interface with the given ID. If there is no
+	// limiter for an interface, the rate is not limited.
+	scmpLimiters map[uint16]*tokenBucket
+	// ingressLimiters limit the rate of packets accepted from the neighboring
+	// IA, summed over all interfaces to the IA. They are only created if
+	// RunConfig.IngressRate is set.
+	ingressLimiters map[addr.IA]*tokenBucket
+	// dispatchedPortStart and dispatchedPortEnd are the first and last port
+	// on which end hosts receive packets directly. If the start is zero, all
+	// packets are delivered to the end host port of the dispatcher.
+	dispatchedPortStart uint16
+	dispatchedPortEnd   uint16
+
+	// RunConfig configures the forwarding pipeline started by Run.
+	RunConfig RunConfig
 }
 
 var (
 	alreadySet                    = serrors.New("already set")
 	cannotRoute                   = serrors.New("cannot route, dropping pkt")
 	emptyValue                    = serrors.New("empty value")
+	invalidDstAddr                = serrors.New("invalid destination address")
 	malformedPath                 = serrors.New("malformed path content")
 	modifyExisting                = serrors.New("modifying a running dataplane is not allowed")
+	notFound                      = serrors.New("not found")
 	noSVCBackend                  = serrors.New("cannot find internal IP for the SVC")
 	unsupportedPathType           = serrors.New("unsupported path type")
 	unsupportedPathTypeNextHeader = serrors.New("unsupported combination")
 	noBFDSessionFound             = serrors.New("no BFD sessions was found")
 	noBFDSessionConfigured        = serrors.New("no BFD sessions have been configured")
 	errBFDDisabled                = serrors.New("BFD is disabled")
+	scmpRateLimited               = serrors.New("SCMP rate limit exceeded")
+	errBFDQueueFull               = serrors.New("BFD receive queue full")
 )
 
 type scmpError struct {
@@ -144,6 +220,24 @@ func (d *DataPlane) SetIA(ia addr.IA) error {
 	return nil
 }
 
+// SetPortRange sets the range of ports on which end hosts in the local AS
+// receive packets directly, instead of through the dispatcher.
+func (d *DataPlane) SetPortRange(start, end uint16) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.running {
+		return modifyExisting
+	}
+	if start == 0 || start > end {
+		return serrors.New("invalid port range", "start", start, "end", end)
+	}
+	if d.dispatchedPortStart != 0 {
+		return alreadySet
+	}
+	d.dispatchedPortStart, d.dispatchedPortEnd = start, end
+	return nil
+}
+
 // SetKey sets the key used for MAC verification. The key provided here should
 // already be derived as in scrypto.HFMacFactory.
 func (d *DataPlane) SetKey(key []byte) error {
@@ -166,9 +260,77 @@ func (d *DataPlane) SetKey(key []byte) error {
 		mac, _ := scrypto.InitMac(key)
 		return mac
 	}
+	keys := d.loadKeys()
+	d.keys.Store(&macKeys{current: key, previous: keys.previous})
+	return nil
+}
+
+// SetPreviousKey sets the key that was used for MAC computation before the
+// current key was set, i.e., before the last key rollover. Hop fields with a
+// MAC that does not verify with the current key are verified with the previous
+// key. The key provided here should already be derived as in
+// scrypto.HFMacFactory. This can be called on a running dataplane.
+func (d *DataPlane) SetPreviousKey(key []byte) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if len(key) == 0 {
+		return emptyValue
+	}
+	if _, err := scrypto.InitMac(key); err != nil {
+		return err
+	}
+	keys := d.loadKeys()
+	d.keys.Store(&macKeys{current: keys.current, previous: key})
+	return nil
+}
+
+// RolloverKey replaces the key used for MAC computation and verification. The
+// replaced key becomes the previous key, and is still accepted for MAC
+// verification until the next rollover. Hop fields created with the previous
+// key expire at the latest path.MaxTTL seconds after the rollover, which
+// bounds the rollover window. The key provided here should already be derived
+// as in scrypto.HFMacFactory. This can be called on a running dataplane.
+func (d *DataPlane) RolloverKey(key []byte) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if len(key) == 0 {
+		return emptyValue
+	}
+	if _, err := scrypto.InitMac(key); err != nil {
+		return err
+	}
+	keys := d.loadKeys()
+	if bytes.Equal(keys.current, key) {
+		return nil
+	}
+	d.macFactory = func() hash.Hash {
+		mac, _ := scrypto.InitMac(key)
+		return mac
+	}
+	d.keys.Store(&macKeys{current: key, previous: keys.current})
 	return nil
 }
 
+// loadKeys returns the keys used for MAC computation and verification. If no
+// keys are set, an empty set of keys is returned.
+func (d *DataPlane) loadKeys() *macKeys {
+	if keys, ok := d.keys.Load().(*macKeys); ok {
+		return keys
+	}
+	return &macKeys{}
+}
+
+// macKeys are the keys used for hop field MAC computation and verification.
+// They are replaced as a whole on updates, such that the packet processors can
+// detect key changes by comparing the pointers.
+type macKeys struct {
+	// current is the key used to compute and verify MACs.
+	current []byte
+	// previous is the key that was current before the last key rollover. It is
+	// only used to verify MACs, and is nil if there is no previous key.
+	previous []byte
+}
+
 // AddInternalInterface sets the interface the data-plane will use to
 // send/receive traffic in the local AS. This can only be called once; future
 // calls will return an error. This can only be called on a not yet running
@@ -192,13 +354,12 @@ func (d *DataPlane) AddInternalInterface(conn BatchConn, ip net.IP) error {
 
 // AddExternalInterface adds the inter AS connection for the given interface ID.
 // If a connection for the given ID is already set this method will return an
-// error. This can only be called on a not yet running dataplane.
+// error. If the dataplane is running, packets are received and forwarded on the
+// connection right away. The link type, the neighboring IA and the BFD session
+// of the interface should thus be added before.
 func (d *DataPlane) AddExternalInterface(ifID uint16, conn BatchConn) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.running {
-		return modifyExisting
-	}
 	if conn == nil {
 		return emptyValue
 	}
@@ -209,18 +370,104 @@ func (d *DataPlane) AddExternalInterface(ifID uint16, conn BatchConn) error {
 		d.external = make(map[uint16]BatchConn)
 	}
 	d.external[ifID] = conn
+	if d.running {
+		if d.forwardingMetrics == nil {
+			d.forwardingMetrics = make(map[uint16]forwardingMetrics)
+		}
+		labels := interfaceToMetricLabels(ifID, d.localIA, d.neighborIAs)
+		d.forwardingMetrics[ifID] = initForwardingMetrics(d.Metrics, labels)
+		d.addRunner(ifID, conn)
+		d.publishState()
+		d.startRunner(ifID, conn)
+	}
 	return nil
 }
 
+// DelExternalInterface removes the interface with the given ID, together with
+// its link type, neighboring IA, next hop and BFD session. For interfaces owned
+// by this router, the connection is closed. This can be called on a running
+// dataplane, in which case the receiver and the forwarder of the connection
+// are stopped before the method returns. Packets that are still queued for the
+// interface are dropped. Interfaces that were only partially added, e.g.,
+// without connection or next hop, are removed as well.
+func (d *DataPlane) DelExternalInterface(ifID uint16) error {
+	d.mtx.Lock()
+	conn, owned := d.external[ifID]
+	_, nextHop := d.internalNextHops[ifID]
+	_, hasLinkType := d.linkTypes[ifID]
+	_, hasNeighbor := d.neighborIAs[ifID]
+	session, hasBFD := d.bfdSessions[ifID]
+	if !owned && !nextHop && !hasLinkType && !hasNeighbor && !hasBFD {
+		d.mtx.Unlock()
+		return serrors.WithCtx(notFound, "ifID", ifID)
+	}
+	delete(d.external, ifID)
+	delete(d.internalNextHops, ifID)
+	delete(d.linkTypes, ifID)
+	delete(d.neighborIAs, ifID)
+	delete(d.bfdSessions, ifID)
+	if d.running {
+		d.publishState()
+	}
+	closeSession := session != nil && !d.bfdSessionInUse(session)
+	// The receiver and the forwarder are stopped after the lock is released,
+	// they might need it to make progress.
+	var runner *interfaceRunner
+	var q chan *packet
+	if owned {
+		runner = d.runners[ifID]
+		q = d.fwQs[conn]
+		delete(d.runners, ifID)
+		delete(d.fwQs, conn)
+		delete(d.scmpLimiters, ifID)
+	}
+	d.mtx.Unlock()
+
+	if closeSession {
+		ctx, cancel := context.WithTimeout(context.Background(), bfdCloseTimeout)
+		err := session.Close(ctx)
+		cancel()
+		if err != nil {
+			log.Info("Failed to close BFD session", "ifID", ifID, "err", err)
+		}
+	}
+	if !owned {
+		return nil
+	}
+	if runner != nil {
+		close(runner.stop)
+	}
+	err := conn.Close()
+	if runner != nil {
+		runner.wg.Wait()
+	}
+	if q != nil {
+		// Processors that still use the previous forwarding state might have
+		// queued packets after the forwarder stopped.
+		d.dropQueued(q)
+	}
+	if err != nil {
+		return serrors.WrapStr("closing connection", err, "ifID", ifID)
+	}
+	return nil
+}
+
+// bfdSessionInUse returns whether the session is used by any interface. BFD
+// sessions to sibling routers are shared by all interfaces of the sibling.
+func (d *DataPlane) bfdSessionInUse(session bfdSession) bool {
+	for _, s := range d.bfdSessions {
+		if s == session {
+			return true
+		}
+	}
+	return false
+}
+
 // AddNeighborIA adds the neighboring IA for a given interface ID. If an IA for
-// the given ID is already set, this method will return an error. This can only
-// be called on a yet running dataplane.
+// the given ID is already set, this method will return an error.
 func (d *DataPlane) AddNeighborIA(ifID uint16, remote addr.IA) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.running {
-		return modifyExisting
-	}
 	if remote.IsZero() {
 		return emptyValue
 	}
@@ -231,13 +478,17 @@ func (d *DataPlane) AddNeighborIA(ifID uint16, remote addr.IA) error {
 		d.neighborIAs = make(map[uint16]addr.IA)
 	}
 	d.neighborIAs[ifID] = remote
+	if d.running {
+		d.publishState()
+	}
 	return nil
 }
 
 // AddLinkType adds the link type for a given interface ID. If a link type for
-// the given ID is already set, this method will return an error. This can only
-// be called on a not yet running dataplane.
+// the given ID is already set, this method will return an error.
 func (d *DataPlane) AddLinkType(ifID uint16, linkTo topology.LinkType) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
 	if _, exists := d.linkTypes[ifID]; exists {
 		return serrors.WithCtx(alreadySet, "ifID", ifID)
 	}
@@ -245,18 +496,19 @@ func (d *DataPlane) AddLinkType(ifID uint16, linkTo topology.LinkType) error {
 		d.linkTypes = make(map[uint16]topology.LinkType)
 	}
 	d.linkTypes[ifID] = linkTo
+	if d.running {
+		d.publishState()
+	}
 	return nil
 }
 
-// AddExternalInterfaceBFD adds the inter AS connection BFD session.
+// AddExternalInterfaceBFD adds the inter AS connection BFD session. If the
+// dataplane is running, the session is started right away.
 func (d *DataPlane) AddExternalInterfaceBFD(ifID uint16, conn BatchConn,
 	src, dst control.LinkEnd, cfg control.BFD) error {
 
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.running {
-		return modifyExisting
-	}
 	if conn == nil {
 		return emptyValue
 	}
@@ -287,7 +539,14 @@ func (d *DataPlane) AddExternalInterfaceBFD(ifID uint16, conn BatchConn,
 		ifID:    ifID,
 		mac:     d.macFactory(),
 	}
-	return d.addBFDController(ifID, s, cfg, m)
+	if err := d.addBFDController(ifID, s, cfg, m); err != nil {
+		return err
+	}
+	if d.running {
+		d.publishState()
+		d.runBFDSession(ifID, d.bfdSessions[ifID])
+	}
+	return nil
 }
 
 func (d *DataPlane) addBFDController(ifID uint16, s *bfdSend, cfg control.BFD,
@@ -360,14 +619,10 @@ func (d *DataPlane) DelSvc(svc addr.HostSVC, a *net.UDPAddr) error {
 }
 
 // AddNextHop sets the next hop address for the given interface ID. If the
-// interface ID already has an address associated this operation fails. This can
-// only be called on a not yet running dataplane.
+// interface ID already has an address associated this operation fails.
 func (d *DataPlane) AddNextHop(ifID uint16, a *net.UDPAddr) error {
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.running {
-		return modifyExisting
-	}
 	if a == nil {
 		return emptyValue
 	}
@@ -378,20 +633,21 @@ func (d *DataPlane) AddNextHop(ifID uint16, a *net.UDPAddr) error {
 		d.internalNextHops = make(map[uint16]*net.UDPAddr)
 	}
 	d.internalNextHops[ifID] = a
+	if d.running {
+		d.publishState()
+	}
 	return nil
 }
 
 // AddNextHopBFD adds the BFD session for the next hop address.
 // If the remote ifID belongs to an existing address, the existing
-// BFD session will be re-used.
+// BFD session will be re-used. If the dataplane is running, a new session is
+// started right away.
 func (d *DataPlane) AddNextHopBFD(ifID uint16, src, dst *net.UDPAddr, cfg control.BFD,
 	sibling string) error {
 
 	d.mtx.Lock()
 	defer d.mtx.Unlock()
-	if d.running {
-		return modifyExisting
-	}
 
 	if dst == nil {
 		return emptyValue
@@ -401,6 +657,9 @@ func (d *DataPlane) AddNextHopBFD(ifID uint16, src, dst *net.UDPAddr, cfg contro
 		if v.String() == dst.String() {
 			if c, ok := d.bfdSessions[k]; ok {
 				d.bfdSessions[ifID] = c
+				if d.running {
+					d.publishState()
+				}
 				return nil
 			}
 		}
@@ -428,98 +687,741 @@ func (d *DataPlane) AddNextHopBFD(ifID uint16, src, dst *net.UDPAddr, cfg contro
 		ifID:    0,
 		mac:     d.macFactory(),
 	}
-	return d.addBFDController(ifID, s, cfg, m)
+	if err := d.addBFDController(ifID, s, cfg, m); err != nil {
+		return err
+	}
+	if d.running {
+		d.publishState()
+		d.runBFDSession(ifID, d.bfdSessions[ifID])
+	}
+	return nil
 }
 
-// Run starts running the dataplane. Note that configuration is not possible
-// after calling this method.
+// RunConfig contains the configuration of the packet forwarding pipeline of the
+// DataPlane. Zero values are replaced by sensible defaults when the DataPlane
+// is started.
+type RunConfig struct {
+	// NumProcessors is the number of goroutines that process packets. Packets
+	// are sharded onto the processors by flow, such that packets of the same
+	// flow are always processed, and thus forwarded, in order.
+	NumProcessors int
+	// BatchSize is the maximum number of packets that are read or written with
+	// a single system call.
+	BatchSize int
+	// MaxFlushLatency is the maximum time a forwarder waits for further
+	// packets to fill up a batch before writing it. If it is zero, a batch is
+	// written as soon as no further packets are queued for the connection.
+	MaxFlushLatency time.Duration
+	// SCMPRate is the maximum number of SCMP messages per second the DataPlane
+	// generates in response to packets received on a single interface. SCMP
+	// messages exceeding the rate are not generated, the packets that caused
+	// them are dropped.
+	SCMPRate int
+	// SCMPBurst is the maximum number of SCMP messages generated in a burst in
+	// response to packets received on a single interface. If it is zero,
+	// SCMPRate is used.
+	SCMPBurst int
+	// IngressRate is the maximum number of packets per second the DataPlane
+	// accepts from a neighboring IA, summed over all interfaces to that IA.
+	// Packets exceeding the rate are dropped by the receiver. If it is zero,
+	// the rate is not limited.
+	IngressRate int
+	// IngressBurst is the maximum number of packets accepted in a burst from a
+	// neighboring IA. If it is zero, IngressRate is used.
+	IngressBurst int
+}
+
+func (cfg RunConfig) withDefaults() RunConfig {
+	if cfg.NumProcessors <= 0 {
+		cfg.NumProcessors = runtime.GOMAXPROCS(0)
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = inputBatchCnt
+	}
+	if cfg.MaxFlushLatency < 0 {
+		cfg.MaxFlushLatency = 0
+	}
+	if cfg.SCMPRate <= 0 {
+		cfg.SCMPRate = defaultSCMPRate
+	}
+	if cfg.SCMPBurst <= 0 {
+		cfg.SCMPBurst = cfg.SCMPRate
+	}
+	if cfg.IngressRate < 0 {
+		cfg.IngressRate = 0
+	}
+	if cfg.IngressBurst <= 0 {
+		cfg.IngressBurst = cfg.IngressRate
+	}
+	return cfg
+}
+
+// packet is a packet that is passed through the forwarding pipeline, from the
+// receiver over a processor to the forwarder of the egress connection.
+type packet struct {
+	// buffer is the underlying memory of the packet. It is allocated once and
+	// re-used for the lifetime of the packet.
+	buffer []byte
+	// rawPacket is the part of buffer holding the packet content.
+	rawPacket []byte
+	// srcAddr is the underlay address the packet was received from.
+	srcAddr *net.UDPAddr
+	// dstAddr is the underlay address the packet is sent to. It is nil for
+	// packets sent on a connected socket.
+	dstAddr *net.UDPAddr
+	// ingress is the interface ID the packet was received on.
+	ingress uint16
+	// egress is the interface ID the packet is sent on.
+	egress uint16
+}
+
+// Run starts running the dataplane. Note that only the external interfaces,
+// the services and the keys can be configured after calling this method. Run
+// returns once the dataplane has been shut down with Shutdown. A dataplane
+// that has been shut down cannot be run again.
+//
+// The dataplane runs a pipeline with three stages. For every connection, a
+// receiver reads batches of packets and hands them to one of
+// RunConfig.NumProcessors processors, selected based on the flow the packet
+// belongs to. The processors process the packets and enqueue them to the
+// forwarder of the egress connection, which writes them in batches.
 func (d *DataPlane) Run() error {
 	d.mtx.Lock()
 	d.running = true
 
 	d.initMetrics()
 
-	read := func(ingressID uint16, rd BatchConn) {
+	d.runCfg = d.RunConfig.withDefaults()
+	d.packetPool.New = func() interface{} {
+		return &packet{buffer: make([]byte, bufSize)}
+	}
 
-		msgs := conn.NewReadMessages(inputBatchCnt)
-		for _, msg := range msgs {
-			msg.Buffers[0] = make([]byte, bufSize)
+	d.draining = make(chan struct{})
+	d.stopped = make(chan struct{})
+	d.procQs = make([]chan *packet, d.runCfg.NumProcessors)
+	for i := range d.procQs {
+		d.procQs[i] = make(chan *packet, d.runCfg.BatchSize*queueBatches)
+	}
+	d.addRunner(0, d.internal)
+	for ifID, c := range d.external {
+		d.addRunner(ifID, c)
+	}
+	d.publishState()
+
+	for k, v := range d.bfdSessions {
+		d.runBFDSession(k, v)
+	}
+	for _, q := range d.procQs {
+		d.processors.Add(1)
+		go func(q <-chan *packet) {
+			defer log.HandlePanic()
+			defer d.processors.Done()
+			d.runProcessor(q)
+		}(q)
+	}
+	d.startRunner(0, d.internal)
+	for ifID, c := range d.external {
+		d.startRunner(ifID, c)
+	}
+
+	stopped := d.stopped
+	d.mtx.Unlock()
+
+	<-stopped
+	return nil
+}
+
+// Shutdown shuts down a running dataplane. The receivers stop accepting new
+// packets, and the BFD sessions are closed, which notifies the neighbors that
+// the links are administratively down. Shutdown then waits until the packets
+// that are in flight have been forwarded, or until ctx is done. Finally, all
+// the connections are closed, and Run returns. If ctx is done before all
+// packets have been forwarded, or before the pipeline has stopped, the
+// remaining packets are dropped and an error is returned.
+//
+// The dataplane is marked as not running right away, such that it can still be
+// configured, and inspected, while it shuts down. Shutdown is a no-op for a
+// dataplane that is not running.
+func (d *DataPlane) Shutdown(ctx context.Context) error {
+	d.mtx.Lock()
+	if !d.running {
+		d.mtx.Unlock()
+		return nil
+	}
+	log.Info("Shutting down dataplane")
+	d.running = false
+	close(d.draining)
+
+	// The pipeline is taken over by Shutdown, such that it is not modified by
+	// concurrent calls once the lock is released.
+	var sessions []bfdSession
+	seen := make(map[bfdSession]struct{}, len(d.bfdSessions))
+	for _, s := range d.bfdSessions {
+		if _, ok := seen[s]; ok {
+			continue
 		}
+		seen[s] = struct{}{}
+		sessions = append(sessions, s)
+	}
+	runners := d.runners
+	d.runners = nil
+	internal := d.internal
+	external := make(map[uint16]BatchConn, len(d.external))
+	for ifID, c := range d.external {
+		external[ifID] = c
+	}
+	procQs, stopped := d.procQs, d.stopped
+	d.mtx.Unlock()
 
-		processor := newPacketProcessor(d, ingressID)
-		var scmpErr scmpError
-		for d.running {
-			pkts, err := rd.ReadBatch(msgs)
-			if err != nil {
-				log.Debug("Failed to read batch", "err", err)
-				// error metric
-				continue
+	// The BFD sessions announce that they are going down on the connections,
+	// hence they are closed before the connections.
+	for _, s := range sessions {
+		if err := s.Close(ctx); err != nil {
+			log.Info("Failed to close BFD session", "err", err)
+		}
+	}
+	err := d.waitDrained(ctx)
+
+	for _, r := range runners {
+		close(r.stop)
+	}
+	if err := internal.Close(); err != nil {
+		log.Info("Failed to close internal connection", "err", err)
+	}
+	for ifID, c := range external {
+		if err := c.Close(); err != nil {
+			log.Info("Failed to close external connection", "ifID", ifID, "err", err)
+		}
+	}
+	runnersDone := waitCtx(ctx, func() {
+		for _, r := range runners {
+			r.wg.Wait()
+		}
+	})
+	if runnersDone == nil {
+		// The receivers have stopped, no more packets are handed to the
+		// processors.
+		for _, q := range procQs {
+			close(q)
+		}
+		if procErr := waitCtx(ctx, d.processors.Wait); procErr != nil && err == nil {
+			err = serrors.WrapStr("stopping processors", procErr)
+		}
+	} else if err == nil {
+		err = serrors.WrapStr("stopping receivers and forwarders", runnersDone)
+	}
+
+	close(stopped)
+	log.Info("Dataplane shut down")
+	return err
+}
+
+// waitCtx calls wait and waits for it to return, or until ctx is done. If ctx
+// is done first, the context error is returned, and wait keeps running in the
+// background.
+func waitCtx(ctx context.Context, wait func()) error {
+	done := make(chan struct{})
+	go func() {
+		defer log.HandlePanic()
+		wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitDrained waits until all packets that are in flight have been written or
+// dropped, or until ctx is done.
+func (d *DataPlane) waitDrained(ctx context.Context) error {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for {
+		inFlight := atomic.LoadInt32(&d.inFlight)
+		if inFlight <= 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return serrors.WrapStr("draining packets", ctx.Err(), "in_flight", inFlight)
+		case <-ticker.C:
+		}
+	}
+}
+
+// releasePacket returns a packet that has been accepted by a receiver to the
+// packet pool, once it has been written or dropped.
+func (d *DataPlane) releasePacket(p *packet) {
+	p.rawPacket, p.srcAddr, p.dstAddr = nil, nil, nil
+	d.packetPool.Put(p)
+	atomic.AddInt32(&d.inFlight, -1)
+}
+
+// dropQueued drops the packets that are still queued in q and counts them as
+// dropped on their egress interface.
+func (d *DataPlane) dropQueued(q <-chan *packet) {
+	state := d.loadState()
+	for {
+		select {
+		case p := <-q:
+			if c := state.forwardingMetrics[p.egress].DroppedPacketsTotal; c != nil {
+				c.Inc()
+			}
+			d.releasePacket(p)
+		default:
+			return
+		}
+	}
+}
+
+// interfaceRunner keeps track of the receiver and the forwarder of an
+// interface.
+type interfaceRunner struct {
+	// stop is closed to stop the receiver and the forwarder.
+	stop chan struct{}
+	// wg is done once the receiver and the forwarder have stopped.
+	wg sync.WaitGroup
+}
+
+// addRunner creates the forwarder queue and the runner for the connection of
+// the given interface. The forwarder queue only becomes visible to the packet
+// processors with the next call to publishState. It must be called with d.mtx
+// held.
+func (d *DataPlane) addRunner(ifID uint16, c BatchConn) {
+	if d.fwQs == nil {
+		d.fwQs = make(map[BatchConn]chan *packet)
+	}
+	if d.runners == nil {
+		d.runners = make(map[uint16]*interfaceRunner)
+	}
+	if d.scmpLimiters == nil {
+		d.scmpLimiters = make(map[uint16]*tokenBucket)
+	}
+	d.fwQs[c] = make(chan *packet, d.runCfg.BatchSize*queueBatches)
+	d.runners[ifID] = &interfaceRunner{stop: make(chan struct{})}
+	d.scmpLimiters[ifID] = newTokenBucket(d.runCfg.SCMPRate, d.runCfg.SCMPBurst)
+}
+
+// startRunner starts the receiver and the forwarder for the connection of the
+// given interface. It must be called with d.mtx held, after addRunner.
+func (d *DataPlane) startRunner(ifID uint16, c BatchConn) {
+	r := d.runners[ifID]
+	q := d.fwQs[c]
+	cfg := d.runCfg
+	counters := d.forwardingMetrics[ifID]
+	r.wg.Add(2)
+	go func() {
+		defer log.HandlePanic()
+		defer r.wg.Done()
+		d.runForwarder(c, q, r.stop, counters.OutputBatchesTotal, cfg)
+	}()
+	go func() {
+		defer log.HandlePanic()
+		defer r.wg.Done()
+		d.runReceiver(ifID, c, r.stop, counters, cfg.BatchSize)
+	}()
+}
+
+// runBFDSession runs the BFD session of the given interface in the
+// background.
+func (d *DataPlane) runBFDSession(ifID uint16, s bfdSession) {
+	go func() {
+		defer log.HandlePanic()
+		if err := s.Run(); err != nil && err != bfd.AlreadyRunning {
+			log.Error("BFD session failed to start", "ifID", ifID, "err", err)
+		}
+	}()
+}
+
+// runReceiver reads packets from the connection and distributes them to the
+// processor queues. If the queue of the selected processor is full, the
+// packet is dropped. The receiver runs until stop is closed.
+func (d *DataPlane) runReceiver(ingressID uint16, rd BatchConn, stop <-chan struct{},
+	inputCounters forwardingMetrics, batchSize int) {
+
+	procQs := d.procQs
+	msgs := conn.NewReadMessages(batchSize)
+	pkts := make([]*packet, batchSize)
+	for i := range msgs {
+		pkts[i] = d.packetPool.Get().(*packet)
+		msgs[i].Buffers[0] = pkts[i].buffer
+	}
+	for !isClosed(stop) {
+		n, err := rd.ReadBatch(msgs)
+		if err != nil {
+			if isClosed(stop) {
+				return
 			}
-			if pkts == 0 {
+			log.Debug("Failed to read batch", "err", err)
+			// error metric
+			continue
+		}
+		if isClosed(d.draining) {
+			// The dataplane is shutting down and does not accept new packets.
+			continue
+		}
+		limiter := d.loadState().ingressLimiters[ingressID]
+		now := time.Now()
+		for i, msg := range msgs[:n] {
+			inputCounters.InputPacketsTotal.Inc()
+			inputCounters.InputBytesTotal.Add(float64(msg.N))
+			if limiter != nil && !limiter.allow(now) {
+				// The neighbor exceeds its rate, the packet buffer is re-used.
+				inputCounters.IngressRateLimitedTotal.Inc()
 				continue
 			}
-			for _, p := range msgs[:pkts] {
-				// input metric
-				inputCounters := d.forwardingMetrics[ingressID]
-				inputCounters.InputPacketsTotal.Inc()
-				inputCounters.InputBytesTotal.Add(float64(p.N))
-
-				srcAddr := p.Addr.(*net.UDPAddr)
-				result, err := processor.processPkt(p.Buffers[0][:p.N], srcAddr)
-
-				switch {
-				case err == nil:
-				case errors.As(err, &scmpErr):
-					if !scmpErr.TypeCode.InfoMsg() {
-						log.Debug("SCMP", "err", scmpErr, "dst_addr", p.Addr)
-					}
-					// SCMP go back the way they came.
-					result.OutAddr = srcAddr
-					result.OutConn = rd
-				default:
-					log.Debug("Error processing packet", "err", err)
-					inputCounters.DroppedPacketsTotal.Inc()
-					continue
-				}
-				if result.OutConn == nil { // e.g. BFD case no message is forwarded
-					continue
-				}
-				_, err = result.OutConn.WriteTo(result.OutPkt, result.OutAddr)
-				if err != nil {
-					log.Debug("Error writing packet", "err", err)
-					// error metric
-					continue
-				}
-				// ok metric
-				outputCounters := d.forwardingMetrics[result.EgressID]
-				outputCounters.OutputPacketsTotal.Inc()
-				outputCounters.OutputBytesTotal.Add(float64(len(result.OutPkt)))
+
+			p := pkts[i]
+			p.rawPacket = p.buffer[:msg.N]
+			p.srcAddr = msg.Addr.(*net.UDPAddr)
+			p.ingress = ingressID
+
+			q := procQs[0]
+			if len(procQs) > 1 {
+				q = procQs[flowHash(p.rawPacket)%uint32(len(procQs))]
+			}
+			// The packet is accounted as in flight before it is handed to the
+			// processor, which might release it right away.
+			atomic.AddInt32(&d.inFlight, 1)
+			select {
+			case q <- p:
+				pkts[i] = d.packetPool.Get().(*packet)
+				msgs[i].Buffers[0] = pkts[i].buffer
+			default:
+				// The processor is overloaded, the packet buffer is re-used.
+				atomic.AddInt32(&d.inFlight, -1)
+				inputCounters.DroppedPacketsTotal.Inc()
 			}
 		}
 	}
+}
 
-	for k, v := range d.bfdSessions {
-		go func(ifID uint16, c bfdSession) {
-			defer log.HandlePanic()
-			if err := c.Run(); err != nil && err != bfd.AlreadyRunning {
-				log.Error("BFD session failed to start", "ifID", ifID, "err", err)
+// isClosed returns whether the channel is closed.
+func isClosed(c <-chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+// runProcessor processes the packets from the queue and hands them to the
+// forwarder of the egress connection.
+func (d *DataPlane) runProcessor(q <-chan *packet) {
+	processor := newPacketProcessor(d, 0)
+	var scmpErr scmpError
+	for p := range q {
+		processor.ingressID = p.ingress
+		result, err := processor.processPkt(p.rawPacket, p.srcAddr)
+		// The state is updated by processPkt, it is the one the packet was
+		// processed with.
+		state := processor.state
+
+		switch {
+		case err == nil:
+		case errors.As(err, &scmpErr):
+			if !scmpErr.TypeCode.InfoMsg() {
+				log.Debug("SCMP", "err", scmpErr, "dst_addr", p.srcAddr)
+			}
+			// SCMP go back the way they came.
+			result.OutAddr = p.srcAddr
+			result.OutConn = state.interfaceConn(p.ingress)
+		default:
+			log.Debug("Error processing packet", "err", err)
+			if errors.Is(err, scmpRateLimited) {
+				state.forwardingMetrics[p.ingress].SCMPRateLimitedTotal.Inc()
 			}
-		}(k, v)
+			state.forwardingMetrics[p.ingress].DroppedPacketsTotal.Inc()
+			d.releasePacket(p)
+			continue
+		}
+		if result.OutConn == nil { // e.g. BFD case no message is forwarded
+			d.releasePacket(p)
+			continue
+		}
+		fwQ, ok := state.forwarders[result.OutConn]
+		if !ok {
+			log.Debug("No forwarder for egress connection", "egress", result.EgressID)
+			state.forwardingMetrics[p.ingress].DroppedPacketsTotal.Inc()
+			d.releasePacket(p)
+			continue
+		}
+		// The result might point to the serialization buffer of the processor,
+		// e.g., for SCMP messages. Copy it to the packet buffer, which is owned
+		// by the packet until it is written.
+		p.rawPacket = append(p.buffer[:0], result.OutPkt...)
+		p.dstAddr = result.OutAddr
+		p.egress = result.EgressID
+		select {
+		case fwQ <- p:
+		default:
+			// The forwarder is overloaded.
+			state.forwardingMetrics[p.ingress].DroppedPacketsTotal.Inc()
+			d.releasePacket(p)
+		}
 	}
-	for ifID, v := range d.external {
-		go func(i uint16, c BatchConn) {
-			defer log.HandlePanic()
-			read(i, c)
-		}(ifID, v)
+}
+
+// runForwarder writes the packets from the queue to the connection in
+// batches. A batch is written once it is full, or, if the queue runs empty,
+// at the latest after the configured flush latency. The forwarder runs until
+// stop is closed.
+func (d *DataPlane) runForwarder(c BatchConn, q <-chan *packet, stop <-chan struct{},
+	batches prometheus.Counter, cfg RunConfig) {
+
+	msgs := conn.NewReadMessages(cfg.BatchSize)
+	pkts := make([]*packet, 0, cfg.BatchSize)
+	var flush *time.Timer
+	if cfg.MaxFlushLatency > 0 {
+		flush = time.NewTimer(cfg.MaxFlushLatency)
+		stopTimer(flush)
+	}
+	for {
+		var p *packet
+		select {
+		case p = <-q:
+		case <-stop:
+			d.dropQueued(q)
+			return
+		}
+		pkts = fillBatch(q, append(pkts[:0], p), flush, cfg.MaxFlushLatency)
+		d.writeBatch(c, msgs, pkts)
+		if batches != nil {
+			batches.Inc()
+		}
 	}
-	go func(c BatchConn) {
-		defer log.HandlePanic()
-		read(0, c)
-	}(d.internal)
+}
 
-	d.mtx.Unlock()
+// fillBatch appends the queued packets to pkts until pkts is full. If flush is
+// nil, the batch is complete as soon as the queue runs empty. Otherwise,
+// fillBatch waits for further packets until the flush timer, which is started
+// once the queue runs empty, expires after latency.
+func fillBatch(q <-chan *packet, pkts []*packet, flush *time.Timer,
+	latency time.Duration) []*packet {
+
+	armed := false
+	for len(pkts) < cap(pkts) {
+		select {
+		case p := <-q:
+			pkts = append(pkts, p)
+			continue
+		default:
+		}
+		if flush == nil {
+			return pkts
+		}
+		if !armed {
+			flush.Reset(latency)
+			armed = true
+		}
+		select {
+		case p := <-q:
+			pkts = append(pkts, p)
+		case <-flush.C:
+			return pkts
+		}
+	}
+	if armed {
+		stopTimer(flush)
+	}
+	return pkts
+}
+
+// stopTimer stops the timer and drains its channel, such that it can be reset
+// safely.
+func stopTimer(t *time.Timer) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+}
+
+// writeBatch writes the packets to the connection and returns them to the
+// packet pool.
+func (d *DataPlane) writeBatch(c BatchConn, msgs underlayconn.Messages, pkts []*packet) {
+	for i, p := range pkts {
+		msgs[i].Buffers[0] = p.rawPacket
+		msgs[i].Addr = nil
+		if p.dstAddr != nil {
+			msgs[i].Addr = p.dstAddr
+		}
+	}
+	written := 0
+	for written < len(pkts) {
+		n, err := c.WriteBatch(msgs[written:len(pkts)])
+		if err != nil {
+			log.Debug("Error writing packet", "err", err)
+			// error metric
+			break
+		}
+		if n == 0 {
+			// The connection does not make progress, the remaining packets
+			// are dropped instead of retrying forever.
+			break
+		}
+		written += n
+	}
+	state := d.loadState()
+	for i, p := range pkts {
+		counters := state.forwardingMetrics[p.egress]
+		if i < written {
+			counters.OutputPacketsTotal.Inc()
+			counters.OutputBytesTotal.Add(float64(len(p.rawPacket)))
+		} else {
+			counters.DroppedPacketsTotal.Inc()
+		}
+		d.releasePacket(p)
+		msgs[i].Buffers[0] = nil
+		msgs[i].Addr = nil
+	}
+}
+
+// forwardingState is the part of the dataplane configuration that is used by
+// the forwarding pipeline. Once stored in DataPlane.state, it is never
+// modified. Reconfigurations of a running dataplane store a new copy instead,
+// such that the pipeline can access the state without locking and never
+// observes partial updates.
+type forwardingState struct {
+	internal          BatchConn
+	external          map[uint16]BatchConn
+	linkTypes         map[uint16]topology.LinkType
+	neighborIAs       map[uint16]addr.IA
+	internalNextHops  map[uint16]*net.UDPAddr
+	bfdSessions       map[uint16]bfdSession
+	forwardingMetrics map[uint16]forwardingMetrics
+	forwarders        map[BatchConn]chan *packet
+	scmpLimiters      map[uint16]*tokenBucket
+	// ingressLimiters maps the interface IDs to the ingress limiter of the
+	// neighboring IA.
+	ingressLimiters map[uint16]*tokenBucket
+}
+
+// publishState stores a copy of the current configuration as the forwarding
+// state. It must be called with d.mtx held.
+func (d *DataPlane) publishState() {
+	s := &forwardingState{
+		internal:          d.internal,
+		external:          make(map[uint16]BatchConn, len(d.external)),
+		linkTypes:         make(map[uint16]topology.LinkType, len(d.linkTypes)),
+		neighborIAs:       make(map[uint16]addr.IA, len(d.neighborIAs)),
+		internalNextHops:  make(map[uint16]*net.UDPAddr, len(d.internalNextHops)),
+		bfdSessions:       make(map[uint16]bfdSession, len(d.bfdSessions)),
+		forwardingMetrics: make(map[uint16]forwardingMetrics, len(d.forwardingMetrics)),
+		forwarders:        make(map[BatchConn]chan *packet, len(d.fwQs)),
+		scmpLimiters:      make(map[uint16]*tokenBucket, len(d.scmpLimiters)),
+		ingressLimiters:   make(map[uint16]*tokenBucket),
+	}
+	for k, v := range d.external {
+		s.external[k] = v
+	}
+	for k, v := range d.linkTypes {
+		s.linkTypes[k] = v
+	}
+	for k, v := range d.neighborIAs {
+		s.neighborIAs[k] = v
+	}
+	for k, v := range d.internalNextHops {
+		s.internalNextHops[k] = v
+	}
+	for k, v := range d.bfdSessions {
+		s.bfdSessions[k] = v
+	}
+	for k, v := range d.forwardingMetrics {
+		s.forwardingMetrics[k] = v
+	}
+	for k, v := range d.fwQs {
+		s.forwarders[k] = v
+	}
+	for k, v := range d.scmpLimiters {
+		s.scmpLimiters[k] = v
+	}
+	for k := range d.external {
+		if l := d.ingressLimiter(d.neighborIAs[k]); l != nil {
+			s.ingressLimiters[k] = l
+		}
+	}
+	d.state.Store(s)
+}
+
+// ingressLimiter returns the ingress limiter of the given neighboring IA. The
+// limiter is created on first use, such that it is shared by all interfaces
+// to the IA. It returns nil if the ingress rate is not limited or the IA is
+// unknown. It must be called with d.mtx held.
+func (d *DataPlane) ingressLimiter(ia addr.IA) *tokenBucket {
+	if d.runCfg.IngressRate == 0 || ia.IsZero() {
+		return nil
+	}
+	if l, ok := d.ingressLimiters[ia]; ok {
+		return l
+	}
+	if d.ingressLimiters == nil {
+		d.ingressLimiters = make(map[addr.IA]*tokenBucket)
+	}
+	l := newTokenBucket(d.runCfg.IngressRate, d.runCfg.IngressBurst)
+	d.ingressLimiters[ia] = l
+	return l
+}
+
+// loadState returns the forwarding state. If no state has been published yet,
+// i.e., the dataplane is not running, the returned state refers to the
+// configuration of the dataplane directly.
+func (d *DataPlane) loadState() *forwardingState {
+	if s, ok := d.state.Load().(*forwardingState); ok {
+		return s
+	}
+	return &forwardingState{
+		internal:          d.internal,
+		external:          d.external,
+		linkTypes:         d.linkTypes,
+		neighborIAs:       d.neighborIAs,
+		internalNextHops:  d.internalNextHops,
+		bfdSessions:       d.bfdSessions,
+		forwardingMetrics: d.forwardingMetrics,
+		forwarders:        d.fwQs,
+		scmpLimiters:      d.scmpLimiters,
+	}
+}
 
-	select {}
+// interfaceConn returns the connection of the given interface. ID 0 refers to
+// the internal interface.
+func (s *forwardingState) interfaceConn(ifID uint16) BatchConn {
+	if ifID == 0 {
+		return s.internal
+	}
+	return s.external[ifID]
+}
+
+// flowHash computes a hash over the fields identifying the flow of the raw
+// SCION packet, i.e., the flow ID and the address header. Packets that are
+// too short to contain these fields are all hashed to the same value.
+func flowHash(raw []byte) uint32 {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	if len(raw) < slayers.CmnHdrLen {
+		return 0
+	}
+	addrHdrLen := 2*addr.IABytes + 4*(int(raw[9]>>4&0x3)+1) + 4*(int(raw[9]&0x3)+1)
+	end := slayers.CmnHdrLen + addrHdrLen
+	if len(raw) < end {
+		end = len(raw)
+	}
+	h := uint32(offset32)
+	// The flow ID is stored in the lower 20 bits of the first 4 bytes.
+	h = (h ^ uint32(raw[1]&0x0f)) * prime32
+	h = (h ^ uint32(raw[2])) * prime32
+	h = (h ^ uint32(raw[3])) * prime32
+	for _, b := range raw[slayers.CmnHdrLen:end] {
+		h = (h ^ uint32(b)) * prime32
+	}
+	return h
 }
 
 // initMetrics initializes the metrics related to packet forwarding. The
@@ -546,11 +1448,30 @@ type processResult struct {
 }
 
 func newPacketProcessor(d *DataPlane, ingressID uint16) *scionPacketProcessor {
-	return &scionPacketProcessor{
+	p := &scionPacketProcessor{
 		d:         d,
 		ingressID: ingressID,
 		buffer:    gopacket.NewSerializeBuffer(),
-		mac:       d.macFactory(),
+	}
+	p.updateKeys()
+	p.state = d.loadState()
+	return p
+}
+
+// updateKeys updates the MAC hashers of the processor if the keys of the
+// dataplane have changed since the last call.
+func (p *scionPacketProcessor) updateKeys() {
+	keys := p.d.loadKeys()
+	if keys == p.keys {
+		return
+	}
+	p.keys = keys
+	p.mac, p.prevMac = nil, nil
+	if keys.current != nil {
+		p.mac, _ = scrypto.InitMac(keys.current)
+	}
+	if keys.previous != nil {
+		p.prevMac, _ = scrypto.InitMac(keys.previous)
 	}
 }
 
@@ -558,13 +1479,19 @@ func (p *scionPacketProcessor) reset() error {
 	p.rawPkt = nil
 	//p.scionLayer // cannot easily be reset
 	p.path = nil
+	p.epicPath = nil
 	p.hopField = nil
 	p.infoField = nil
 	p.segmentChange = false
 	if err := p.buffer.Clear(); err != nil {
 		return serrors.WrapStr("Failed to clear buffer", err)
 	}
+	p.updateKeys()
+	p.state = p.d.loadState()
 	p.mac.Reset()
+	if p.prevMac != nil {
+		p.prevMac.Reset()
+	}
 	p.cachedMac = nil
 	return nil
 }
@@ -604,13 +1531,15 @@ func (p *scionPacketProcessor) processPkt(rawPkt []byte,
 		return p.processSCION()
 	case epic.PathType:
 		return p.processEPIC()
+	case colibri.PathType:
+		return p.processColibri()
 	default:
 		return processResult{}, serrors.WithCtx(unsupportedPathType, "type", pathType)
 	}
 }
 
 func (p *scionPacketProcessor) processInterBFD(oh *onehop.Path, data []byte) error {
-	if len(p.d.bfdSessions) == 0 {
+	if len(p.state.bfdSessions) == 0 {
 		return noBFDSessionConfigured
 	}
 
@@ -619,16 +1548,15 @@ func (p *scionPacketProcessor) processInterBFD(oh *onehop.Path, data []byte) err
 		return err
 	}
 
-	if v, ok := p.d.bfdSessions[p.ingressID]; ok {
-		v.Messages() <- bfd
-		return nil
+	if v, ok := p.state.bfdSessions[p.ingressID]; ok {
+		return deliverBFD(v, bfd)
 	}
 
 	return noBFDSessionFound
 }
 
 func (p *scionPacketProcessor) processIntraBFD(src *net.UDPAddr, data []byte) error {
-	if len(p.d.bfdSessions) == 0 {
+	if len(p.state.bfdSessions) == 0 {
 		return noBFDSessionConfigured
 	}
 	bfd := &layers.BFD{}
@@ -637,28 +1565,44 @@ func (p *scionPacketProcessor) processIntraBFD(src *net.UDPAddr, data []byte) er
 	}
 
 	ifID := uint16(0)
-	for k, v := range p.d.internalNextHops {
+	for k, v := range p.state.internalNextHops {
 		if bytes.Equal(v.IP, src.IP) && v.Port == src.Port {
 			ifID = k
 			continue
 		}
 	}
 
-	if v, ok := p.d.bfdSessions[ifID]; ok {
-		v.Messages() <- bfd
-		return nil
+	if v, ok := p.state.bfdSessions[ifID]; ok {
+		return deliverBFD(v, bfd)
 	}
 
 	return noBFDSessionFound
 }
 
+// deliverBFD hands the BFD message to the session. The message is dropped if
+// the receive queue of the session is full, e.g., because the session has been
+// closed, such that the processor never blocks on a session.
+func deliverBFD(s bfdSession, msg *layers.BFD) error {
+	select {
+	case s.Messages() <- msg:
+		return nil
+	default:
+		return errBFDQueueFull
+	}
+}
+
 func (p *scionPacketProcessor) processSCION() (processResult, error) {
 
 	var ok bool
 	p.path, ok = p.scionLayer.Path.(*scion.Raw)
 	if !ok {
-		// TODO(lukedirtwalker) parameter problem invalid path?
-		return processResult{}, malformedPath
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidPath),
+			},
+			&slayers.SCMPParameterProblem{Pointer: p.pathPointer()},
+			malformedPath,
+		)
 	}
 	return p.process()
 }
@@ -666,49 +1610,65 @@ func (p *scionPacketProcessor) processSCION() (processResult, error) {
 func (p *scionPacketProcessor) processEPIC() (processResult, error) {
 
 	epicPath, ok := p.scionLayer.Path.(*epic.Path)
-	if !ok {
-		return processResult{}, malformedPath
+	if !ok || epicPath.ScionPath == nil {
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidPath),
+			},
+			&slayers.SCMPParameterProblem{Pointer: p.pathPointer()},
+			malformedPath,
+		)
 	}
 
 	p.path = epicPath.ScionPath
-	if p.path == nil {
-		return processResult{}, malformedPath
-	}
-
-	info, err := p.path.GetCurrentInfoField()
-	if err != nil {
-		return processResult{}, err
-	}
+	p.epicPath = epicPath
+	return p.process()
+}
 
-	result, err := p.process()
-	if err != nil {
-		// TODO(mawyss): Send back SCMP packet
-		return processResult{}, err
+// verifyEPIC verifies the packet timestamp and the hop validation field of an
+// EPIC packet on the penultimate and the last hop. It must be called after the
+// MAC of the current hop field has been verified, and before the path is
+// updated for the next hop. For other path types and hops, it does nothing.
+func (p *scionPacketProcessor) verifyEPIC() (processResult, error) {
+	if p.epicPath == nil {
+		return processResult{}, nil
 	}
-
 	isPenultimate := p.path.IsPenultimateHop()
 	isLast := p.path.IsLastHop()
+	if !isPenultimate && !isLast {
+		return processResult{}, nil
+	}
 
-	if isPenultimate || isLast {
-		timestamp := time.Unix(int64(info.Timestamp), 0)
-		err = libepic.VerifyTimestamp(timestamp, epicPath.PktID.Timestamp, time.Now())
-		if err != nil {
-			// TODO(mawyss): Send back SCMP packet
-			return processResult{}, err
-		}
-
-		HVF := epicPath.PHVF
-		if isLast {
-			HVF = epicPath.LHVF
-		}
-		err = libepic.VerifyHVF(p.cachedMac, epicPath.PktID, &p.scionLayer, info.Timestamp, HVF)
-		if err != nil {
-			// TODO(mawyss): Send back SCMP packet
-			return processResult{}, err
-		}
+	pktIDPointer := p.pathPointer()
+	timestamp := time.Unix(int64(p.infoField.Timestamp), 0)
+	err := libepic.VerifyTimestamp(timestamp, p.epicPath.PktID.Timestamp, time.Now())
+	if err != nil {
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidPacketTimestamp),
+			},
+			&slayers.SCMPParameterProblem{Pointer: pktIDPointer},
+			serrors.WrapStr("verifying EPIC timestamp", err, "if_id", p.ingressID),
+		)
 	}
 
-	return result, nil
+	hvf, hvfPointer := p.epicPath.PHVF, pktIDPointer+epic.PktIDLen
+	if isLast {
+		hvf, hvfPointer = p.epicPath.LHVF, hvfPointer+epic.HVFLen
+	}
+	err = libepic.VerifyHVF(p.cachedMac, p.epicPath.PktID, &p.scionLayer,
+		p.infoField.Timestamp, hvf)
+	if err != nil {
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidHopFieldMAC),
+			},
+			&slayers.SCMPParameterProblem{Pointer: hvfPointer},
+			serrors.WrapStr("verifying EPIC hop validation field", err,
+				"if_id", p.ingressID, "last", isLast),
+		)
+	}
+	return processResult{}, nil
 }
 
 // scionPacketProcessor processes packets. It contains pre-allocated per-packet
@@ -726,6 +1686,14 @@ type scionPacketProcessor struct {
 	buffer gopacket.SerializeBuffer
 	// mac is the hasher for the MAC computation.
 	mac hash.Hash
+	// prevMac is the hasher for the MAC verification with the previous key. It
+	// is nil if there is no previous key.
+	prevMac hash.Hash
+	// keys are the keys the hashers are initialized with.
+	keys *macKeys
+	// state is the forwarding state the packet is processed with. It is
+	// updated for every packet.
+	state *forwardingState
 
 	// scionLayer is the SCION gopacket layer.
 	scionLayer slayers.SCION
@@ -736,6 +1704,9 @@ type scionPacketProcessor struct {
 
 	// path is the raw SCION path. Will be set during processing.
 	path *scion.Raw
+	// epicPath is the EPIC path, if the packet has one. The SCION path
+	// contained in it is also set as path. Will be set during processing.
+	epicPath *epic.Path
 	// hopField is the current hopField field, is updated during processing.
 	hopField *path.HopField
 	// infoField is the current infoField field, is updated during processing.
@@ -768,6 +1739,15 @@ func (p *scionPacketProcessor) packSCMP(scmpH *slayers.SCMP, scmpP gopacket.Seri
 		scmpP,
 		cause,
 	)
+	if rawSCMP == nil {
+		return processResult{}, err
+	}
+	// Only replies that are actually sent use up the rate limit budget.
+	limiter := p.state.scmpLimiters[p.ingressID]
+	if limiter != nil && !limiter.allow(time.Now()) {
+		return processResult{}, serrors.WithCtx(scmpRateLimited,
+			"typecode", scmpH.TypeCode, "cause", cause)
+	}
 	return processResult{OutPkt: rawSCMP}, err
 }
 
@@ -775,13 +1755,23 @@ func (p *scionPacketProcessor) parsePath() (processResult, error) {
 	var err error
 	p.hopField, err = p.path.GetCurrentHopField()
 	if err != nil {
-		// TODO(lukedirtwalker) parameter problem invalid path?
-		return processResult{}, err
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidPath),
+			},
+			&slayers.SCMPParameterProblem{Pointer: p.scionPathPointer()},
+			serrors.WrapStr("getting current hop field", err),
+		)
 	}
 	p.infoField, err = p.path.GetCurrentInfoField()
 	if err != nil {
-		// TODO(lukedirtwalker) parameter problem invalid path?
-		return processResult{}, err
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidPath),
+			},
+			&slayers.SCMPParameterProblem{Pointer: p.scionPathPointer()},
+			serrors.WrapStr("getting current info field", err),
+		)
 	}
 	if r, err := p.validateHopExpiry(); err != nil {
 		return r, err
@@ -831,8 +1821,8 @@ func (p *scionPacketProcessor) validateIngressID() (processResult, error) {
 
 func (p *scionPacketProcessor) validateEgressID() (processResult, error) {
 	pktEgressID := p.egressInterface()
-	_, ih := p.d.internalNextHops[pktEgressID]
-	_, eh := p.d.external[pktEgressID]
+	_, ih := p.state.internalNextHops[pktEgressID]
+	_, eh := p.state.external[pktEgressID]
 	if !ih && !eh {
 		errCode := slayers.SCMPCodeUnknownHopFieldEgress
 		if !p.infoField.ConsDir {
@@ -852,7 +1842,7 @@ func (p *scionPacketProcessor) validateEgressID() (processResult, error) {
 	}
 	// Check that the interface pair is valid on a segment switch.
 	// Having a segment change received from the internal interface is never valid.
-	ingress, egress := p.d.linkTypes[p.ingressID], p.d.linkTypes[pktEgressID]
+	ingress, egress := p.state.linkTypes[p.ingressID], p.state.linkTypes[pktEgressID]
 	switch {
 	case ingress == topology.Core && egress == topology.Child:
 		return processResult{}, nil
@@ -874,7 +1864,7 @@ func (p *scionPacketProcessor) validateEgressID() (processResult, error) {
 	}
 }
 
-func (p *scionPacketProcessor) updateNonConsDirIngressSegID() error {
+func (p *scionPacketProcessor) updateNonConsDirIngressSegID() (processResult, error) {
 	// against construction dir the ingress router updates the SegID, ifID == 0
 	// means this comes from this AS itself, so nothing has to be done.
 	// TODO(lukedirtwalker): For packets destined to peer links this shouldn't
@@ -882,25 +1872,45 @@ func (p *scionPacketProcessor) updateNonConsDirIngressSegID() error {
 	if !p.infoField.ConsDir && p.ingressID != 0 {
 		p.infoField.UpdateSegID(p.hopField.Mac)
 		if err := p.path.SetInfoField(p.infoField, int(p.path.PathMeta.CurrINF)); err != nil {
-			return serrors.WrapStr("update info field", err)
+			return p.packSCMP(
+				&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(
+					slayers.SCMPTypeParameterProblem, slayers.SCMPCodeInvalidPath),
+				},
+				&slayers.SCMPParameterProblem{Pointer: p.currentInfoPointer()},
+				serrors.WrapStr("update info field", err),
+			)
 		}
 	}
-	return nil
+	return processResult{}, nil
+}
+
+// pathPointer returns the offset of the path header in the packet.
+func (p *scionPacketProcessor) pathPointer() uint16 {
+	return uint16(slayers.CmnHdrLen + p.scionLayer.AddrHdrLen())
+}
+
+// scionPathPointer returns the offset of the SCION path in the packet. For
+// EPIC packets, the SCION path follows the EPIC specific fields.
+func (p *scionPacketProcessor) scionPathPointer() uint16 {
+	if p.epicPath != nil {
+		return p.pathPointer() + epic.MetadataLen
+	}
+	return p.pathPointer()
 }
 
 func (p *scionPacketProcessor) currentInfoPointer() uint16 {
-	return uint16(slayers.CmnHdrLen + p.scionLayer.AddrHdrLen() +
-		scion.MetaLen + path.InfoLen*int(p.path.PathMeta.CurrINF))
+	return p.scionPathPointer() +
+		uint16(scion.MetaLen+path.InfoLen*int(p.path.PathMeta.CurrINF))
 }
 
 func (p *scionPacketProcessor) currentHopPointer() uint16 {
-	return uint16(slayers.CmnHdrLen + p.scionLayer.AddrHdrLen() +
-		scion.MetaLen + path.InfoLen*p.path.NumINF + path.HopLen*int(p.path.PathMeta.CurrHF))
+	return p.scionPathPointer() + uint16(scion.MetaLen+path.InfoLen*p.path.NumINF+
+		path.HopLen*int(p.path.PathMeta.CurrHF))
 }
 
 func (p *scionPacketProcessor) verifyCurrentMAC() (processResult, error) {
-	fullMac := path.FullMAC(p.mac, p.infoField, p.hopField)
-	if subtle.ConstantTimeCompare(p.hopField.Mac[:path.MacLen], fullMac[:path.MacLen]) == 0 {
+	fullMac, ok := p.verifyFullMAC(p.infoField, p.hopField)
+	if !ok {
 		return p.packSCMP(
 			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
 				slayers.SCMPCodeInvalidHopFieldMAC),
@@ -921,8 +1931,29 @@ func (p *scionPacketProcessor) verifyCurrentMAC() (processResult, error) {
 	return processResult{}, nil
 }
 
+// verifyFullMAC verifies the MAC of the hop field with the current key and, if
+// that fails, with the previous key. It returns the full MAC computed with the
+// key that verified the MAC, or with the current key if the verification
+// failed.
+func (p *scionPacketProcessor) verifyFullMAC(info *path.InfoField,
+	hf *path.HopField) ([]byte, bool) {
+
+	fullMac := path.FullMAC(p.mac, info, hf)
+	if subtle.ConstantTimeCompare(hf.Mac[:path.MacLen], fullMac[:path.MacLen]) == 1 {
+		return fullMac, true
+	}
+	if p.prevMac == nil {
+		return fullMac, false
+	}
+	prevMac := path.FullMAC(p.prevMac, info, hf)
+	if subtle.ConstantTimeCompare(hf.Mac[:path.MacLen], prevMac[:path.MacLen]) == 1 {
+		return prevMac, true
+	}
+	return fullMac, false
+}
+
 func (p *scionPacketProcessor) resolveInbound() (*net.UDPAddr, processResult, error) {
-	a, err := p.d.resolveLocalDst(p.scionLayer)
+	a, err := p.d.resolveLocalDst(p.scionLayer, p.lastLayer)
 	switch {
 	case errors.Is(err, noSVCBackend):
 		r, err := p.packSCMP(
@@ -932,42 +1963,78 @@ func (p *scionPacketProcessor) resolveInbound() (*net.UDPAddr, processResult, er
 			},
 			&slayers.SCMPDestinationUnreachable{}, err)
 		return nil, r, err
+	case errors.Is(err, invalidDstAddr):
+		r, err := p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(
+				slayers.SCMPTypeParameterProblem, slayers.SCMPCodeInvalidDestinationAddress),
+			},
+			&slayers.SCMPParameterProblem{Pointer: dstAddrPointer},
+			err,
+		)
+		return nil, r, err
+	case err != nil:
+		return nil, processResult{}, err
 	default:
 		return a, processResult{}, nil
 	}
 }
 
-func (p *scionPacketProcessor) processEgress() error {
+func (p *scionPacketProcessor) processEgress() (processResult, error) {
 	// we are the egress router and if we go in construction direction we
 	// need to update the SegID.
 	if p.infoField.ConsDir {
 		p.infoField.UpdateSegID(p.hopField.Mac)
 		if err := p.path.SetInfoField(p.infoField, int(p.path.PathMeta.CurrINF)); err != nil {
-			// TODO parameter problem invalid path
-			return serrors.WrapStr("update info field", err)
+			return p.packSCMP(
+				&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(
+					slayers.SCMPTypeParameterProblem, slayers.SCMPCodeInvalidPath),
+				},
+				&slayers.SCMPParameterProblem{Pointer: p.currentInfoPointer()},
+				serrors.WrapStr("update info field", err),
+			)
 		}
 	}
 	if err := p.path.IncPath(); err != nil {
-		// TODO parameter problem invalid path
-		return serrors.WrapStr("incrementing path", err)
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidPath),
+			},
+			&slayers.SCMPParameterProblem{Pointer: p.currentHopPointer()},
+			serrors.WrapStr("incrementing path", err),
+		)
 	}
-	return nil
+	return processResult{}, nil
 }
 
 func (p *scionPacketProcessor) doXover() (processResult, error) {
 	p.segmentChange = true
 	if err := p.path.IncPath(); err != nil {
-		// TODO parameter problem invalid path
-		return processResult{}, serrors.WrapStr("incrementing path", err)
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidPath),
+			},
+			&slayers.SCMPParameterProblem{Pointer: p.currentHopPointer()},
+			serrors.WrapStr("incrementing path", err),
+		)
 	}
 	var err error
 	if p.hopField, err = p.path.GetCurrentHopField(); err != nil {
-		// TODO parameter problem invalid path
-		return processResult{}, err
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidPath),
+			},
+			&slayers.SCMPParameterProblem{Pointer: p.currentHopPointer()},
+			serrors.WrapStr("getting current hop field", err, "info", "after xover"),
+		)
 	}
 	if p.infoField, err = p.path.GetCurrentInfoField(); err != nil {
-		// TODO parameter problem invalid path
-		return processResult{}, err
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidPath),
+			},
+			&slayers.SCMPParameterProblem{Pointer: p.currentInfoPointer()},
+			serrors.WrapStr("getting current info field", err, "info", "after xover"),
+		)
 	}
 	if r, err := p.validateHopExpiry(); err != nil {
 		return r, err
@@ -988,7 +2055,7 @@ func (p *scionPacketProcessor) egressInterface() uint16 {
 
 func (p *scionPacketProcessor) validateEgressUp() (processResult, error) {
 	egressID := p.egressInterface()
-	if v, ok := p.d.bfdSessions[egressID]; ok {
+	if v, ok := p.state.bfdSessions[egressID]; ok {
 		if !v.IsUp() {
 			scmpH := &slayers.SCMP{
 				TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeExternalInterfaceDown, 0),
@@ -997,7 +2064,7 @@ func (p *scionPacketProcessor) validateEgressUp() (processResult, error) {
 				IA:   p.d.localIA,
 				IfID: uint64(egressID),
 			}
-			if _, external := p.d.external[egressID]; !external {
+			if _, external := p.state.external[egressID]; !external {
 				scmpH.TypeCode =
 					slayers.CreateSCMPTypeCode(slayers.SCMPTypeInternalConnectivityDown, 0)
 				scmpP = &slayers.SCMPInternalConnectivityDown{
@@ -1040,7 +2107,7 @@ func (p *scionPacketProcessor) handleEgressRouterAlert() (processResult, error)
 		return processResult{}, nil
 	}
 	egressID := p.egressInterface()
-	if _, ok := p.d.external[egressID]; !ok {
+	if _, ok := p.state.external[egressID]; !ok {
 		return processResult{}, nil
 	}
 	*alert = false
@@ -1115,8 +2182,8 @@ func (p *scionPacketProcessor) process() (processResult, error) {
 	if r, err := p.validatePktLen(); err != nil {
 		return r, err
 	}
-	if err := p.updateNonConsDirIngressSegID(); err != nil {
-		return processResult{}, err
+	if r, err := p.updateNonConsDirIngressSegID(); err != nil {
+		return r, err
 	}
 	if r, err := p.verifyCurrentMAC(); err != nil {
 		return r, err
@@ -1127,6 +2194,9 @@ func (p *scionPacketProcessor) process() (processResult, error) {
 
 	// Inbound: pkts destined to the local IA.
 	if p.scionLayer.DstIA.Equal(p.d.localIA) && int(p.path.PathMeta.CurrHF)+1 == p.path.NumHops {
+		if r, err := p.verifyEPIC(); err != nil {
+			return r, err
+		}
 		a, r, err := p.resolveInbound()
 		if err != nil {
 			return r, err
@@ -1142,6 +2212,9 @@ func (p *scionPacketProcessor) process() (processResult, error) {
 			return r, err
 		}
 	}
+	if r, err := p.verifyEPIC(); err != nil {
+		return r, err
+	}
 	if r, err := p.validateEgressID(); err != nil {
 		return r, err
 	}
@@ -1156,15 +2229,15 @@ func (p *scionPacketProcessor) process() (processResult, error) {
 	}
 
 	egressID := p.egressInterface()
-	if c, ok := p.d.external[egressID]; ok {
-		if err := p.processEgress(); err != nil {
-			return processResult{}, err
+	if c, ok := p.state.external[egressID]; ok {
+		if r, err := p.processEgress(); err != nil {
+			return r, err
 		}
 		return processResult{EgressID: egressID, OutConn: c, OutPkt: p.rawPkt}, nil
 	}
 
 	// ASTransit: pkts leaving from another AS BR.
-	if a, ok := p.d.internalNextHops[egressID]; ok {
+	if a, ok := p.state.internalNextHops[egressID]; ok {
 		return processResult{OutConn: p.d.internal, OutAddr: a, OutPkt: p.rawPkt}, nil
 	}
 	errCode := slayers.SCMPCodeUnknownHopFieldEgress
@@ -1184,41 +2257,72 @@ func (p *scionPacketProcessor) processOHP() (processResult, error) {
 	s := p.scionLayer
 	ohp, ok := s.Path.(*onehop.Path)
 	if !ok {
-		// TODO parameter problem -> invalid path
-		return processResult{}, malformedPath
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidPath),
+			},
+			&slayers.SCMPParameterProblem{Pointer: p.pathPointer()},
+			malformedPath,
+		)
 	}
 	if !ohp.Info.ConsDir {
-		// TODO parameter problem -> invalid path
-		return processResult{}, serrors.WrapStr(
-			"OneHop path in reverse construction direction is not allowed",
-			malformedPath, "srcIA", s.SrcIA, "dstIA", s.DstIA)
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidPath),
+			},
+			&slayers.SCMPParameterProblem{Pointer: p.pathPointer()},
+			serrors.WrapStr("OneHop path in reverse construction direction is not allowed",
+				malformedPath, "srcIA", s.SrcIA, "dstIA", s.DstIA),
+		)
 	}
+	firstHopPointer := p.pathPointer() + path.InfoLen
 
 	// OHP leaving our IA
 	if p.ingressID == 0 {
 		if !p.d.localIA.Equal(s.SrcIA) {
-			// TODO parameter problem -> invalid path
-			return processResult{}, serrors.WrapStr("bad source IA", cannotRoute,
-				"type", "ohp", "egress", ohp.FirstHop.ConsEgress,
-				"localIA", p.d.localIA, "srcIA", s.SrcIA)
+			return p.packSCMP(
+				&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(
+					slayers.SCMPTypeParameterProblem, slayers.SCMPCodeInvalidSourceAddress),
+				},
+				&slayers.SCMPParameterProblem{Pointer: srcIAPointer},
+				serrors.WrapStr("bad source IA", cannotRoute,
+					"type", "ohp", "egress", ohp.FirstHop.ConsEgress,
+					"localIA", p.d.localIA, "srcIA", s.SrcIA),
+			)
 		}
-		neighborIA, ok := p.d.neighborIAs[ohp.FirstHop.ConsEgress]
+		neighborIA, ok := p.state.neighborIAs[ohp.FirstHop.ConsEgress]
 		if !ok {
-			// TODO parameter problem invalid interface
-			return processResult{}, serrors.WithCtx(cannotRoute,
-				"type", "ohp", "egress", ohp.FirstHop.ConsEgress)
+			return p.packSCMP(
+				&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(
+					slayers.SCMPTypeParameterProblem, slayers.SCMPCodeUnknownHopFieldEgress),
+				},
+				&slayers.SCMPParameterProblem{Pointer: firstHopPointer},
+				serrors.WithCtx(cannotRoute, "type", "ohp", "egress", ohp.FirstHop.ConsEgress),
+			)
 		}
 		if !neighborIA.Equal(s.DstIA) {
-			return processResult{}, serrors.WrapStr("bad destination IA", cannotRoute,
-				"type", "ohp", "egress", ohp.FirstHop.ConsEgress,
-				"neighborIA", neighborIA, "dstIA", s.DstIA)
+			return p.packSCMP(
+				&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(
+					slayers.SCMPTypeParameterProblem, slayers.SCMPCodeInvalidDestinationAddress),
+				},
+				&slayers.SCMPParameterProblem{Pointer: dstIAPointer},
+				serrors.WrapStr("bad destination IA", cannotRoute,
+					"type", "ohp", "egress", ohp.FirstHop.ConsEgress,
+					"neighborIA", neighborIA, "dstIA", s.DstIA),
+			)
 		}
 
-		mac := path.MAC(p.mac, &ohp.Info, &ohp.FirstHop)
-		if subtle.ConstantTimeCompare(ohp.FirstHop.Mac[:path.MacLen], mac) == 0 {
-			// TODO parameter problem -> invalid MAC
-			return processResult{}, serrors.New("MAC", "expected", fmt.Sprintf("%x", mac),
-				"actual", fmt.Sprintf("%x", ohp.FirstHop.Mac[:path.MacLen]), "type", "ohp")
+		fullMac, ok := p.verifyFullMAC(&ohp.Info, &ohp.FirstHop)
+		if !ok {
+			mac := fullMac[:path.MacLen]
+			return p.packSCMP(
+				&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(
+					slayers.SCMPTypeParameterProblem, slayers.SCMPCodeInvalidHopFieldMAC),
+				},
+				&slayers.SCMPParameterProblem{Pointer: firstHopPointer},
+				serrors.New("MAC", "expected", fmt.Sprintf("%x", mac),
+					"actual", fmt.Sprintf("%x", ohp.FirstHop.Mac[:path.MacLen]), "type", "ohp"),
+			)
 		}
 		ohp.Info.UpdateSegID(ohp.FirstHop.Mac)
 
@@ -1226,24 +2330,37 @@ func (p *scionPacketProcessor) processOHP() (processResult, error) {
 			return processResult{}, err
 		}
 		// OHP should always be directed to the correct BR.
-		if c, ok := p.d.external[ohp.FirstHop.ConsEgress]; ok {
+		if c, ok := p.state.external[ohp.FirstHop.ConsEgress]; ok {
 			// buffer should already be correct
 			return processResult{EgressID: ohp.FirstHop.ConsEgress, OutConn: c, OutPkt: p.rawPkt},
 				nil
 		}
-		// TODO parameter problem invalid interface
-		return processResult{}, serrors.WithCtx(cannotRoute, "type", "ohp",
-			"egress", ohp.FirstHop.ConsEgress, "consDir", ohp.Info.ConsDir)
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeUnknownHopFieldEgress),
+			},
+			&slayers.SCMPParameterProblem{Pointer: firstHopPointer},
+			serrors.WithCtx(cannotRoute, "type", "ohp",
+				"egress", ohp.FirstHop.ConsEgress, "consDir", ohp.Info.ConsDir),
+		)
 	}
 
 	// OHP entering our IA
 	if !p.d.localIA.Equal(s.DstIA) {
-		return processResult{}, serrors.WrapStr("bad destination IA", cannotRoute,
-			"type", "ohp", "ingress", p.ingressID,
-			"localIA", p.d.localIA, "dstIA", s.DstIA)
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				slayers.SCMPCodeInvalidDestinationAddress),
+			},
+			&slayers.SCMPParameterProblem{Pointer: dstIAPointer},
+			serrors.WrapStr("bad destination IA", cannotRoute,
+				"type", "ohp", "ingress", p.ingressID,
+				"localIA", p.d.localIA, "dstIA", s.DstIA),
+		)
 	}
-	neighborIA := p.d.neighborIAs[p.ingressID]
+	neighborIA := p.state.neighborIAs[p.ingressID]
 	if !neighborIA.Equal(s.SrcIA) {
+		// No SCMP error is sent, it could not be delivered to the source IA
+		// anyway.
 		return processResult{}, serrors.WrapStr("bad source IA", cannotRoute,
 			"type", "ohp", "ingress", p.ingressID,
 			"neighborIA", neighborIA, "srcIA", s.SrcIA)
@@ -1258,18 +2375,28 @@ func (p *scionPacketProcessor) processOHP() (processResult, error) {
 	if err := updateSCIONLayer(p.rawPkt, s, p.buffer); err != nil {
 		return processResult{}, err
 	}
-	a, err := p.d.resolveLocalDst(s)
+	a, err := p.d.resolveLocalDst(s, p.lastLayer)
+	if errors.Is(err, invalidDstAddr) {
+		return p.packSCMP(
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(
+				slayers.SCMPTypeParameterProblem, slayers.SCMPCodeInvalidDestinationAddress),
+			},
+			&slayers.SCMPParameterProblem{Pointer: dstAddrPointer},
+			err,
+		)
+	}
 	if err != nil {
 		return processResult{}, err
 	}
 	return processResult{OutConn: p.d.internal, OutAddr: a, OutPkt: p.rawPkt}, nil
 }
 
-func (d *DataPlane) resolveLocalDst(s slayers.SCION) (*net.UDPAddr, error) {
+func (d *DataPlane) resolveLocalDst(s slayers.SCION,
+	lastLayer gopacket.DecodingLayer) (*net.UDPAddr, error) {
+
 	dst, err := s.DstAddr()
 	if err != nil {
-		// TODO parameter problem.
-		return nil, err
+		return nil, serrors.Wrap(invalidDstAddr, err)
 	}
 	switch v := dst.(type) {
 	case addr.HostSVC:
@@ -1281,13 +2408,21 @@ func (d *DataPlane) resolveLocalDst(s slayers.SCION) (*net.UDPAddr, error) {
 		}
 		return a, nil
 	case *net.IPAddr:
-		return addEndhostPort(v), nil
+		return d.addEndhostPort(v, lastLayer), nil
 	default:
 		panic("unexpected address type returned from DstAddr")
 	}
 }
 
-func addEndhostPort(dst *net.IPAddr) *net.UDPAddr {
+// addEndhostPort adds the underlay port of the end host to the destination.
+// Packets for a port in the dispatched port range are sent to that port
+// directly, all other packets are sent to the dispatcher.
+func (d *DataPlane) addEndhostPort(dst *net.IPAddr,
+	lastLayer gopacket.DecodingLayer) *net.UDPAddr {
+
+	if port, ok := d.dispatchedPort(lastLayer); ok {
+		return &net.UDPAddr{IP: dst.IP, Port: int(port)}
+	}
 	return &net.UDPAddr{IP: dst.IP, Port: topology.EndhostPort}
 }
 
@@ -1374,41 +2509,32 @@ func (b *bfdSend) Send(bfd *layers.BFD) error {
 func (p *scionPacketProcessor) prepareSCMP(scmpH *slayers.SCMP, scmpP gopacket.SerializableLayer,
 	cause error) ([]byte, error) {
 
-	// *copy* and reverse path -- the original path should not be modified as this writes directly
-	// back to rawPkt (quote).
-	path, ok := p.scionLayer.Path.(*scion.Raw)
-	if !ok {
+	var revPath path.Path
+	var err error
+	dstIA := p.scionLayer.SrcIA
+	switch v := p.scionLayer.Path.(type) {
+	case *scion.Raw:
+		revPath, err = p.reverseSCIONPath(v)
+	case *epic.Path:
+		// The SCMP message is sent back on the SCION path contained in the
+		// EPIC path.
+		if v.ScionPath == nil {
+			return nil, serrors.WithCtx(cannotRoute, "details", "EPIC path without SCION path")
+		}
+		revPath, err = p.reverseSCIONPath(v.ScionPath)
+	case *onehop.Path:
+		if p.ingressID == 0 {
+			// The OneHop packet originates in the local AS.
+			revPath, dstIA = &empty.Path{}, p.d.localIA
+		} else {
+			revPath = p.oneHopReplyPath()
+		}
+	default:
 		return nil, serrors.WithCtx(cannotRoute, "details", "unsupported path type",
 			"path type", p.scionLayer.Path.Type())
 	}
-	decPath, err := path.ToDecoded()
-	if err != nil {
-		return nil, serrors.Wrap(cannotRoute, err, "details", "decoding raw path")
-	}
-	revPathTmp, err := decPath.Reverse()
 	if err != nil {
-		return nil, serrors.Wrap(cannotRoute, err, "details", "reversing path for SCMP")
-	}
-	revPath := revPathTmp.(*scion.Decoded)
-
-	// Revert potential path segment switches that were done during processing.
-	if revPath.IsXover() {
-		if err := revPath.IncPath(); err != nil {
-			return nil, serrors.Wrap(cannotRoute, err, "details", "reverting cross over for SCMP")
-		}
-	}
-	// If the packet is sent to an external router, we need to increment the
-	// path to prepare it for the next hop.
-	_, external := p.d.external[p.ingressID]
-	if external {
-		infoField := revPath.InfoFields[revPath.PathMeta.CurrINF]
-		if infoField.ConsDir {
-			hopField := revPath.HopFields[revPath.PathMeta.CurrHF]
-			infoField.UpdateSegID(hopField.Mac)
-		}
-		if err := revPath.IncPath(); err != nil {
-			return nil, serrors.Wrap(cannotRoute, err, "details", "incrementing path for SCMP")
-		}
+		return nil, err
 	}
 
 	// create new SCION header for reply.
@@ -1417,7 +2543,7 @@ func (p *scionPacketProcessor) prepareSCMP(scmpH *slayers.SCMP, scmpP gopacket.S
 	scionL.TrafficClass = p.scionLayer.TrafficClass
 	scionL.PathType = revPath.Type()
 	scionL.Path = revPath
-	scionL.DstIA = p.scionLayer.SrcIA
+	scionL.DstIA = dstIA
 	scionL.SrcIA = p.d.localIA
 	srcA, err := p.scionLayer.SrcAddr()
 	if err != nil {
@@ -1468,6 +2594,68 @@ func (p *scionPacketProcessor) prepareSCMP(scmpH *slayers.SCMP, scmpP gopacket.S
 	return p.buffer.Bytes(), scmpError{TypeCode: scmpH.TypeCode, Cause: cause}
 }
 
+// reverseSCIONPath returns the path on which an SCMP message for a packet
+// with the given SCION path is sent back to the source.
+func (p *scionPacketProcessor) reverseSCIONPath(raw *scion.Raw) (*scion.Decoded, error) {
+	// *copy* and reverse path -- the original path should not be modified as this writes directly
+	// back to rawPkt (quote).
+	decPath, err := raw.ToDecoded()
+	if err != nil {
+		return nil, serrors.Wrap(cannotRoute, err, "details", "decoding raw path")
+	}
+	// A path with invalid indices cannot be reversed.
+	if decPath.NumINF == 0 || int(decPath.PathMeta.CurrINF) >= decPath.NumINF ||
+		int(decPath.PathMeta.CurrHF) >= decPath.NumHops {
+		return nil, serrors.WithCtx(cannotRoute, "details", "invalid path for SCMP",
+			"curr_inf", decPath.PathMeta.CurrINF, "curr_hf", decPath.PathMeta.CurrHF)
+	}
+	revPathTmp, err := decPath.Reverse()
+	if err != nil {
+		return nil, serrors.Wrap(cannotRoute, err, "details", "reversing path for SCMP")
+	}
+	revPath := revPathTmp.(*scion.Decoded)
+
+	// Revert potential path segment switches that were done during processing.
+	if revPath.IsXover() {
+		if err := revPath.IncPath(); err != nil {
+			return nil, serrors.Wrap(cannotRoute, err, "details", "reverting cross over for SCMP")
+		}
+	}
+	// If the packet is sent to an external router, we need to increment the
+	// path to prepare it for the next hop.
+	_, external := p.state.external[p.ingressID]
+	if external {
+		infoField := revPath.InfoFields[revPath.PathMeta.CurrINF]
+		if infoField.ConsDir {
+			hopField := revPath.HopFields[revPath.PathMeta.CurrHF]
+			infoField.UpdateSegID(hopField.Mac)
+		}
+		if err := revPath.IncPath(); err != nil {
+			return nil, serrors.Wrap(cannotRoute, err, "details", "incrementing path for SCMP")
+		}
+	}
+	return revPath, nil
+}
+
+// oneHopReplyPath returns the OneHop path on which an SCMP message for a
+// OneHop packet received from a neighboring AS is sent back. The path is
+// prepared for the neighbor, i.e., the first hop field is already processed.
+func (p *scionPacketProcessor) oneHopReplyPath() *onehop.Path {
+	ohp := &onehop.Path{
+		Info: path.InfoField{
+			ConsDir:   true,
+			Timestamp: util.TimeToSecs(time.Now()),
+		},
+		FirstHop: path.HopField{
+			ConsEgress: p.ingressID,
+			ExpTime:    hopFieldDefaultExpTime,
+		},
+	}
+	ohp.FirstHop.Mac = path.MAC(p.mac, &ohp.Info, &ohp.FirstHop)
+	ohp.Info.UpdateSegID(ohp.FirstHop.Mac)
+	return ohp
+}
+
 // decodeLayers implements roughly the functionality of
 // gopacket.DecodingLayerParser, but customized to our use case with a "base"
 // layer and additional, optional layers in the given order.
@@ -1507,11 +2695,14 @@ func nextHdr(layer gopacket.DecodingLayer) common.L4ProtocolType {
 // forwardingMetrics contains the subset of Metrics relevant for forwarding,
 // instantiated with some interface-specific labels.
 type forwardingMetrics struct {
-	InputBytesTotal     prometheus.Counter
-	OutputBytesTotal    prometheus.Counter
-	InputPacketsTotal   prometheus.Counter
-	OutputPacketsTotal  prometheus.Counter
-	DroppedPacketsTotal prometheus.Counter
+	InputBytesTotal         prometheus.Counter
+	OutputBytesTotal        prometheus.Counter
+	InputPacketsTotal       prometheus.Counter
+	OutputPacketsTotal      prometheus.Counter
+	OutputBatchesTotal      prometheus.Counter
+	DroppedPacketsTotal     prometheus.Counter
+	SCMPRateLimitedTotal    prometheus.Counter
+	IngressRateLimitedTotal prometheus.Counter
 }
 
 func initForwardingMetrics(metrics *Metrics, labels prometheus.Labels) forwardingMetrics {
@@ -1520,13 +2711,20 @@ func initForwardingMetrics(metrics *Metrics, labels prometheus.Labels) forwardin
 		InputPacketsTotal:   metrics.InputPacketsTotal.With(labels),
 		OutputBytesTotal:    metrics.OutputBytesTotal.With(labels),
 		OutputPacketsTotal:  metrics.OutputPacketsTotal.With(labels),
+		OutputBatchesTotal:  metrics.OutputBatchesTotal.With(labels),
 		DroppedPacketsTotal: metrics.DroppedPacketsTotal.With(labels),
+
+		SCMPRateLimitedTotal:    metrics.SCMPRateLimitedTotal.With(labels),
+		IngressRateLimitedTotal: metrics.IngressRateLimitedTotal.With(labels),
 	}
 	c.InputBytesTotal.Add(0)
 	c.InputPacketsTotal.Add(0)
 	c.OutputBytesTotal.Add(0)
 	c.OutputPacketsTotal.Add(0)
+	c.OutputBatchesTotal.Add(0)
 	c.DroppedPacketsTotal.Add(0)
+	c.SCMPRateLimitedTotal.Add(0)
+	c.IngressRateLimitedTotal.Add(0)
 	return c
 }
 
@@ -16,9 +16,11 @@ package router_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -49,6 +51,10 @@ import (
 	"github.com/scionproto/scion/go/pkg/router/mock_router"
 )
 
+// metrics are the metrics shared by all tests that run a data plane. They can
+// only be created once, because they are registered with the default registry.
+var metrics = router.NewMetrics()
+
 func TestDataPlaneAddInternalInterface(t *testing.T) {
 	t.Run("fails after serve", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -104,14 +110,68 @@ func TestDataPlaneSetKey(t *testing.T) {
 	})
 }
 
+func TestDataPlaneSetPreviousKey(t *testing.T) {
+	t.Run("succeeds after serve", func(t *testing.T) {
+		d := &router.DataPlane{}
+		d.FakeStart()
+		assert.NoError(t, d.SetPreviousKey([]byte("dummy key xxxxxx")))
+	})
+	t.Run("setting nil value is not allowed", func(t *testing.T) {
+		d := &router.DataPlane{}
+		assert.Error(t, d.SetPreviousKey(nil))
+	})
+	t.Run("set multiple times works", func(t *testing.T) {
+		d := &router.DataPlane{}
+		assert.NoError(t, d.SetKey([]byte("dummy key xxxxxx")))
+		assert.NoError(t, d.SetPreviousKey([]byte("dummy key yyyyyy")))
+		assert.NoError(t, d.SetPreviousKey([]byte("dummy key zzzzzz")))
+	})
+}
+
+func TestDataPlaneRolloverKey(t *testing.T) {
+	t.Run("succeeds after serve", func(t *testing.T) {
+		d := &router.DataPlane{}
+		assert.NoError(t, d.SetKey([]byte("dummy key xxxxxx")))
+		d.FakeStart()
+		assert.NoError(t, d.RolloverKey([]byte("dummy key yyyyyy")))
+	})
+	t.Run("setting nil value is not allowed", func(t *testing.T) {
+		d := &router.DataPlane{}
+		assert.NoError(t, d.SetKey([]byte("dummy key xxxxxx")))
+		assert.Error(t, d.RolloverKey(nil))
+	})
+	t.Run("rollover to same key works", func(t *testing.T) {
+		d := &router.DataPlane{}
+		assert.NoError(t, d.SetKey([]byte("dummy key xxxxxx")))
+		assert.NoError(t, d.RolloverKey([]byte("dummy key xxxxxx")))
+	})
+}
+
 func TestDataPlaneAddExternalInterface(t *testing.T) {
-	t.Run("fails after serve", func(t *testing.T) {
+	t.Run("succeeds after serve", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		d := &router.DataPlane{}
+		read := make(chan struct{})
+		var once sync.Once
+		c := mock_router.NewMockBatchConn(ctrl)
+		c.EXPECT().ReadBatch(gomock.Any()).DoAndReturn(
+			func(underlayconn.Messages) (int, error) {
+				once.Do(func() { close(read) })
+				return 0, nil
+			}).AnyTimes()
+		c.EXPECT().Close()
+
+		d := &router.DataPlane{Metrics: metrics}
 		d.FakeStart()
-		assert.Error(t, d.AddExternalInterface(42, mock_conn.NewMockConn(ctrl)))
+		require.NoError(t, d.AddExternalInterface(42, c))
+		select {
+		case <-read:
+		case <-time.After(time.Second):
+			t.Fatalf("connection was not read from")
+		}
+		// Stop the receiver.
+		assert.NoError(t, d.DelExternalInterface(42))
 	})
 	t.Run("setting nil value is not allowed", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -160,11 +220,76 @@ func TestDataPlaneAddSVC(t *testing.T) {
 	})
 }
 
+func TestDataPlaneDelExternalInterface(t *testing.T) {
+	t.Run("unknown interface fails", func(t *testing.T) {
+		d := &router.DataPlane{}
+		assert.Error(t, d.DelExternalInterface(42))
+	})
+	t.Run("lock is released while stopping the interface", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		d := &router.DataPlane{Metrics: metrics}
+		c := mock_router.NewMockBatchConn(ctrl)
+		c.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
+		c.EXPECT().Close().DoAndReturn(func() error {
+			// Accessing the dataplane must not block while the interface is
+			// stopped.
+			d.Interfaces()
+			return nil
+		})
+		d.FakeStart()
+		require.NoError(t, d.AddExternalInterface(42, c))
+		done := make(chan error, 1)
+		go func() {
+			done <- d.DelExternalInterface(42)
+		}()
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatalf("interface was not removed")
+		}
+	})
+	t.Run("external interface is closed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		c := mock_router.NewMockBatchConn(ctrl)
+		c.EXPECT().Close()
+		d := &router.DataPlane{}
+		require.NoError(t, d.AddLinkType(42, topology.Core))
+		require.NoError(t, d.AddNeighborIA(42, xtest.MustParseIA("1-ff00:0:111")))
+		require.NoError(t, d.AddExternalInterface(42, c))
+		assert.NoError(t, d.DelExternalInterface(42))
+		assert.Error(t, d.DelExternalInterface(42))
+		// The interface can be added again.
+		assert.NoError(t, d.AddLinkType(42, topology.Core))
+		assert.NoError(t, d.AddNeighborIA(42, xtest.MustParseIA("1-ff00:0:111")))
+		assert.NoError(t, d.AddExternalInterface(42, c))
+	})
+	t.Run("partially added interface is removed", func(t *testing.T) {
+		d := &router.DataPlane{}
+		require.NoError(t, d.AddLinkType(42, topology.Core))
+		require.NoError(t, d.AddNeighborIA(42, xtest.MustParseIA("1-ff00:0:111")))
+		assert.NoError(t, d.DelExternalInterface(42))
+		assert.Error(t, d.DelExternalInterface(42))
+		// The interface can be added again.
+		assert.NoError(t, d.AddLinkType(42, topology.Core))
+	})
+	t.Run("next hop is removed", func(t *testing.T) {
+		d := &router.DataPlane{}
+		require.NoError(t, d.AddNextHop(45, &net.UDPAddr{}))
+		assert.NoError(t, d.DelExternalInterface(45))
+		assert.NoError(t, d.AddNextHop(45, &net.UDPAddr{}))
+	})
+}
+
 func TestDataPlaneAddNextHop(t *testing.T) {
-	t.Run("fails after serve", func(t *testing.T) {
+	t.Run("succeeds after serve", func(t *testing.T) {
 		d := &router.DataPlane{}
 		d.FakeStart()
-		assert.Error(t, d.AddNextHop(45, &net.UDPAddr{}))
+		assert.NoError(t, d.AddNextHop(45, &net.UDPAddr{}))
 	})
 	t.Run("setting nil value is not allowed", func(t *testing.T) {
 		d := &router.DataPlane{}
@@ -182,13 +307,68 @@ func TestDataPlaneAddNextHop(t *testing.T) {
 	})
 }
 
+func TestDataPlaneInterfaces(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sibling := &net.UDPAddr{IP: net.ParseIP("10.0.200.200"), Port: 30042}
+	neighbor := xtest.MustParseIA("1-ff00:0:111")
+	c := mock_router.NewMockBatchConn(ctrl)
+	d := &router.DataPlane{}
+	require.NoError(t, d.SetKey([]byte("randomkeyformacs")))
+	require.NoError(t, d.AddExternalInterface(42, c))
+	require.NoError(t, d.AddLinkType(42, topology.Child))
+	require.NoError(t, d.AddNeighborIA(42, neighbor))
+	require.NoError(t, d.AddExternalInterfaceBFD(42, c,
+		control.LinkEnd{
+			IA:   xtest.MustParseIA("1-ff00:0:110"),
+			Addr: &net.UDPAddr{IP: net.ParseIP("10.0.0.100")},
+		},
+		control.LinkEnd{
+			IA:   neighbor,
+			Addr: &net.UDPAddr{IP: net.ParseIP("10.0.0.200")},
+		},
+		bfd(),
+	))
+	require.NoError(t, d.AddNextHop(7, sibling))
+	require.NoError(t, d.AddLinkType(7, topology.Core))
+
+	infos := d.Interfaces()
+	require.Len(t, infos, 2)
+	assert.Equal(t, uint16(7), infos[0].ID)
+	assert.Equal(t, topology.Core, infos[0].LinkType)
+	assert.Equal(t, sibling, infos[0].NextHop)
+	assert.Nil(t, infos[0].BFD)
+
+	assert.Equal(t, uint16(42), infos[1].ID)
+	assert.Equal(t, neighbor, infos[1].NeighborIA)
+	assert.Equal(t, topology.Child, infos[1].LinkType)
+	assert.Nil(t, infos[1].NextHop)
+	require.NotNil(t, infos[1].BFD)
+	assert.NotZero(t, infos[1].BFD.LocalDiscriminator)
+}
+
+func TestDataPlaneServices(t *testing.T) {
+	cs1 := &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 30252}
+	cs2 := &net.UDPAddr{IP: net.ParseIP("10.0.0.2"), Port: 30252}
+
+	d := &router.DataPlane{}
+	assert.Empty(t, d.Services())
+	require.NoError(t, d.AddSvc(addr.SvcCS, cs1))
+	require.NoError(t, d.AddSvc(addr.SvcCS, cs2))
+	require.NoError(t, d.AddSvc(addr.SvcDS, cs1))
+	require.NoError(t, d.DelSvc(addr.SvcDS, cs1))
+
+	svcs := d.Services()
+	assert.Len(t, svcs, 1)
+	assert.ElementsMatch(t, []*net.UDPAddr{cs1, cs2}, svcs[addr.SvcCS])
+}
+
 func TestDataPlaneRun(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	metrics := router.NewMetrics()
-
 	testCases := map[string]struct {
 		prepareDP func(*gomock.Controller, chan<- struct{}) *router.DataPlane
 	}{
@@ -203,21 +383,26 @@ func TestDataPlaneRun(t *testing.T) {
 				mInternal := mock_router.NewMockBatchConn(ctrl)
 				mInternal.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
 
-				for i := 0; i < 10; i++ {
-					ii := i
-					mInternal.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
-						func(data []byte, _ net.Addr) (int, error) {
-							want := bytes.Repeat([]byte("actualpayloadbytes"), ii)
-							if len(data) != len(want)+84 {
-								return 1, nil
+				// All packets belong to the same flow, they are processed by the
+				// same processor and must be written exactly once and in order.
+				written := 0
+				mInternal.EXPECT().WriteBatch(gomock.Any()).DoAndReturn(
+					func(ms underlayconn.Messages) (int, error) {
+						for _, m := range ms {
+							if written >= totalCount {
+								t.Errorf("unexpected packet written: %d bytes",
+									len(m.Buffers[0]))
+								continue
 							}
-							totalCount--
-							if totalCount == 0 {
+							want := bytes.Repeat([]byte("actualpayloadbytes"), written)
+							assert.Len(t, m.Buffers[0], len(want)+84)
+							written++
+							if written == totalCount {
 								done <- struct{}{}
 							}
-							return 1, nil
-						})
-				}
+						}
+						return len(ms), nil
+					}).AnyTimes()
 				_ = ret.AddInternalInterface(mInternal, net.IP{})
 
 				mExternal := mock_router.NewMockBatchConn(ctrl)
@@ -251,7 +436,139 @@ func TestDataPlaneRun(t *testing.T) {
 					},
 				).Times(1)
 				mExternal.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
-				mExternal.EXPECT().WriteTo(gomock.Any(), gomock.Any()).Return(0, nil).AnyTimes()
+				mExternal.EXPECT().WriteBatch(gomock.Any()).Return(0, nil).AnyTimes()
+
+				_ = ret.AddExternalInterface(1, mExternal)
+
+				_ = ret.SetIA(local)
+				_ = ret.SetKey(key)
+				return ret
+			},
+		},
+		"write 10 msg from external to internal in single batch": {
+			prepareDP: func(ctrl *gomock.Controller, done chan<- struct{}) *router.DataPlane {
+				ret := &router.DataPlane{
+					Metrics: metrics,
+					RunConfig: router.RunConfig{
+						NumProcessors:   1,
+						BatchSize:       10,
+						MaxFlushLatency: time.Second,
+					},
+				}
+
+				key := []byte("testkey_xxxxxxxx")
+				local := xtest.MustParseIA("1-ff00:0:110")
+
+				mInternal := mock_router.NewMockBatchConn(ctrl)
+				mInternal.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
+				mInternal.EXPECT().WriteBatch(gomock.Len(10)).DoAndReturn(
+					func(ms underlayconn.Messages) (int, error) {
+						done <- struct{}{}
+						return len(ms), nil
+					}).Times(1)
+				_ = ret.AddInternalInterface(mInternal, net.IP{})
+
+				mExternal := mock_router.NewMockBatchConn(ctrl)
+				// Deliver the packets in two reads, such that the first ones
+				// are held back until the batch is full.
+				for j := 0; j < 2; j++ {
+					mExternal.EXPECT().ReadBatch(gomock.Any()).DoAndReturn(
+						func(m underlayconn.Messages) (int, error) {
+							for i := 0; i < 5; i++ {
+								spkt, dpath := prepBaseMsg(time.Now())
+								spkt.DstIA = local
+								dpath.HopFields = []*path.HopField{
+									{ConsIngress: 41, ConsEgress: 40},
+									{ConsIngress: 31, ConsEgress: 30},
+									{ConsIngress: 1, ConsEgress: 0},
+								}
+								dpath.Base.PathMeta.CurrHF = 2
+								dpath.HopFields[2].Mac = computeMAC(t, key,
+									dpath.InfoFields[0], dpath.HopFields[2])
+								spkt.Path = dpath
+								buffer := gopacket.NewSerializeBuffer()
+								err := gopacket.SerializeLayers(buffer,
+									gopacket.SerializeOptions{FixLengths: true},
+									spkt, gopacket.Payload("actualpayloadbytes"))
+								require.NoError(t, err)
+								raw := buffer.Bytes()
+								copy(m[i].Buffers[0], raw)
+								m[i].N = len(raw)
+								m[i].Addr = &net.UDPAddr{IP: net.IP{10, 0, 200, 200}}
+							}
+							time.Sleep(10 * time.Millisecond)
+							return 5, nil
+						},
+					)
+				}
+				mExternal.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
+
+				_ = ret.AddExternalInterface(1, mExternal)
+
+				_ = ret.SetIA(local)
+				_ = ret.SetKey(key)
+				return ret
+			},
+		},
+		"drop msgs not accepted by the connection": {
+			prepareDP: func(ctrl *gomock.Controller, done chan<- struct{}) *router.DataPlane {
+				ret := &router.DataPlane{
+					Metrics: metrics,
+					RunConfig: router.RunConfig{
+						NumProcessors: 1,
+						BatchSize:     1,
+					},
+				}
+
+				key := []byte("testkey_xxxxxxxx")
+				local := xtest.MustParseIA("1-ff00:0:110")
+				payloads := []string{"refused", "actualpayloadbytes"}
+
+				// The first packet is not accepted by the connection and must
+				// be dropped, such that the second one is written.
+				mInternal := mock_router.NewMockBatchConn(ctrl)
+				mInternal.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
+				mInternal.EXPECT().WriteBatch(gomock.Any()).DoAndReturn(
+					func(ms underlayconn.Messages) (int, error) {
+						if len(ms[0].Buffers[0]) == len(payloads[1])+84 {
+							done <- struct{}{}
+							return len(ms), nil
+						}
+						return 0, nil
+					}).AnyTimes()
+				_ = ret.AddInternalInterface(mInternal, net.IP{})
+
+				mExternal := mock_router.NewMockBatchConn(ctrl)
+				for _, payload := range payloads {
+					payload := payload
+					mExternal.EXPECT().ReadBatch(gomock.Any()).DoAndReturn(
+						func(m underlayconn.Messages) (int, error) {
+							spkt, dpath := prepBaseMsg(time.Now())
+							spkt.DstIA = local
+							dpath.HopFields = []*path.HopField{
+								{ConsIngress: 41, ConsEgress: 40},
+								{ConsIngress: 31, ConsEgress: 30},
+								{ConsIngress: 1, ConsEgress: 0},
+							}
+							dpath.Base.PathMeta.CurrHF = 2
+							dpath.HopFields[2].Mac = computeMAC(t, key,
+								dpath.InfoFields[0], dpath.HopFields[2])
+							spkt.Path = dpath
+							buffer := gopacket.NewSerializeBuffer()
+							err := gopacket.SerializeLayers(buffer,
+								gopacket.SerializeOptions{FixLengths: true},
+								spkt, gopacket.Payload(payload))
+							require.NoError(t, err)
+							raw := buffer.Bytes()
+							copy(m[0].Buffers[0], raw)
+							m[0].N = len(raw)
+							m[0].Addr = &net.UDPAddr{IP: net.IP{10, 0, 200, 200}}
+							time.Sleep(10 * time.Millisecond)
+							return 1, nil
+						},
+					)
+				}
+				mExternal.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
 
 				_ = ret.AddExternalInterface(1, mExternal)
 
@@ -260,6 +577,70 @@ func TestDataPlaneRun(t *testing.T) {
 				return ret
 			},
 		},
+		"drop msgs from external exceeding the ingress rate": {
+			prepareDP: func(ctrl *gomock.Controller, done chan<- struct{}) *router.DataPlane {
+				ret := &router.DataPlane{
+					Metrics: metrics,
+					RunConfig: router.RunConfig{
+						NumProcessors:   1,
+						BatchSize:       10,
+						MaxFlushLatency: 100 * time.Millisecond,
+						IngressRate:     1,
+						IngressBurst:    5,
+					},
+				}
+
+				key := []byte("testkey_xxxxxxxx")
+				local := xtest.MustParseIA("1-ff00:0:110")
+
+				// Only the burst of packets is forwarded, all in one batch.
+				mInternal := mock_router.NewMockBatchConn(ctrl)
+				mInternal.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
+				mInternal.EXPECT().WriteBatch(gomock.Len(5)).DoAndReturn(
+					func(ms underlayconn.Messages) (int, error) {
+						done <- struct{}{}
+						return len(ms), nil
+					}).Times(1)
+				_ = ret.AddInternalInterface(mInternal, net.IP{})
+
+				mExternal := mock_router.NewMockBatchConn(ctrl)
+				mExternal.EXPECT().ReadBatch(gomock.Any()).DoAndReturn(
+					func(m underlayconn.Messages) (int, error) {
+						for i := 0; i < 10; i++ {
+							spkt, dpath := prepBaseMsg(time.Now())
+							spkt.DstIA = local
+							dpath.HopFields = []*path.HopField{
+								{ConsIngress: 41, ConsEgress: 40},
+								{ConsIngress: 31, ConsEgress: 30},
+								{ConsIngress: 1, ConsEgress: 0},
+							}
+							dpath.Base.PathMeta.CurrHF = 2
+							dpath.HopFields[2].Mac = computeMAC(t, key,
+								dpath.InfoFields[0], dpath.HopFields[2])
+							spkt.Path = dpath
+							buffer := gopacket.NewSerializeBuffer()
+							err := gopacket.SerializeLayers(buffer,
+								gopacket.SerializeOptions{FixLengths: true},
+								spkt, gopacket.Payload("actualpayloadbytes"))
+							require.NoError(t, err)
+							raw := buffer.Bytes()
+							copy(m[i].Buffers[0], raw)
+							m[i].N = len(raw)
+							m[i].Addr = &net.UDPAddr{IP: net.IP{10, 0, 200, 200}}
+						}
+						return 10, nil
+					},
+				).Times(1)
+				mExternal.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
+
+				_ = ret.AddExternalInterface(1, mExternal)
+				_ = ret.AddNeighborIA(1, xtest.MustParseIA("1-ff00:0:111"))
+
+				_ = ret.SetIA(local)
+				_ = ret.SetKey(key)
+				return ret
+			},
+		},
 		"bfd bootstrap internal session": {
 			prepareDP: func(ctrl *gomock.Controller, done chan<- struct{}) *router.DataPlane {
 				ret := &router.DataPlane{Metrics: metrics}
@@ -555,6 +936,212 @@ func TestDataPlaneRun(t *testing.T) {
 	}
 }
 
+func TestDataPlaneShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	local := xtest.MustParseIA("1-ff00:0:110")
+	d := &router.DataPlane{
+		Metrics:   metrics,
+		RunConfig: router.RunConfig{NumProcessors: 2},
+	}
+
+	totalCount := 10
+	var written int32
+	mInternal := mock_router.NewMockBatchConn(ctrl)
+	mInternal.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
+	mInternal.EXPECT().WriteBatch(gomock.Any()).DoAndReturn(
+		func(ms underlayconn.Messages) (int, error) {
+			// Slow down the forwarding, such that packets are still in
+			// flight when the dataplane is shut down.
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&written, int32(len(ms)))
+			return len(ms), nil
+		}).AnyTimes()
+	mInternal.EXPECT().Close()
+
+	accepted := make(chan struct{})
+	mExternal := mock_router.NewMockBatchConn(ctrl)
+	mExternal.EXPECT().ReadBatch(gomock.Any()).DoAndReturn(
+		func(m underlayconn.Messages) (int, error) {
+			for i := 0; i < totalCount; i++ {
+				spkt, dpath := prepBaseMsg(time.Now())
+				spkt.DstIA = local
+				dpath.HopFields = []*path.HopField{
+					{ConsIngress: 41, ConsEgress: 40},
+					{ConsIngress: 31, ConsEgress: 30},
+					{ConsIngress: 1, ConsEgress: 0},
+				}
+				dpath.Base.PathMeta.CurrHF = 2
+				dpath.HopFields[2].Mac = computeMAC(t, key,
+					dpath.InfoFields[0], dpath.HopFields[2])
+				spkt.Path = dpath
+				buffer := gopacket.NewSerializeBuffer()
+				err := gopacket.SerializeLayers(buffer,
+					gopacket.SerializeOptions{FixLengths: true},
+					spkt, gopacket.Payload([]byte("actualpayloadbytes")))
+				require.NoError(t, err)
+				raw := buffer.Bytes()
+				copy(m[i].Buffers[0], raw)
+				m[i].N = len(raw)
+				m[i].Addr = &net.UDPAddr{IP: net.IP{10, 0, 200, 200}}
+			}
+			return totalCount, nil
+		},
+	).Times(1)
+	// The second read happens after the packets of the first one have been
+	// handed to the processors.
+	mExternal.EXPECT().ReadBatch(gomock.Any()).DoAndReturn(
+		func(underlayconn.Messages) (int, error) {
+			close(accepted)
+			return 0, nil
+		}).Times(1)
+	mExternal.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
+	var adminDown int32
+	mExternal.EXPECT().WriteTo(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(data []byte, _ net.Addr) (int, error) {
+			pkt := gopacket.NewPacket(data, slayers.LayerTypeSCION, gopacket.Default)
+			if b := pkt.Layer(layers.LayerTypeBFD); b != nil &&
+				b.(*layers.BFD).State == layers.BFDStateAdminDown {
+
+				atomic.StoreInt32(&adminDown, 1)
+			}
+			return len(data), nil
+		}).AnyTimes()
+	mExternal.EXPECT().Close()
+
+	linkLocal := control.LinkEnd{
+		IA:   local,
+		Addr: &net.UDPAddr{IP: net.ParseIP("10.0.0.100")},
+	}
+	linkRemote := control.LinkEnd{
+		IA:   xtest.MustParseIA("1-ff00:0:111"),
+		Addr: &net.UDPAddr{IP: net.ParseIP("10.0.0.200")},
+	}
+	require.NoError(t, d.SetIA(local))
+	require.NoError(t, d.SetKey(key))
+	require.NoError(t, d.AddInternalInterface(mInternal, net.IP{}))
+	require.NoError(t, d.AddExternalInterfaceBFD(1, mExternal, linkLocal, linkRemote,
+		control.BFD{
+			DetectMult:            3,
+			DesiredMinTxInterval:  1 * time.Millisecond,
+			RequiredMinRxInterval: 25 * time.Millisecond,
+		}))
+	require.NoError(t, d.AddExternalInterface(1, mExternal))
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- d.Run()
+	}()
+	select {
+	case <-accepted:
+	case <-time.After(3 * time.Second):
+		t.Fatalf("time out")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	require.NoError(t, d.Shutdown(ctx))
+	assert.Equal(t, int32(totalCount), atomic.LoadInt32(&written))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&adminDown))
+	select {
+	case err := <-errs:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatalf("Run did not return")
+	}
+	// Shutting down a stopped dataplane is a no-op.
+	assert.NoError(t, d.Shutdown(ctx))
+}
+
+func TestDataPlaneShutdownTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	local := xtest.MustParseIA("1-ff00:0:110")
+	d := &router.DataPlane{
+		Metrics:   metrics,
+		RunConfig: router.RunConfig{NumProcessors: 1},
+	}
+
+	// The forwarder of the internal connection is stuck until released.
+	writing := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	mInternal := mock_router.NewMockBatchConn(ctrl)
+	mInternal.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
+	mInternal.EXPECT().WriteBatch(gomock.Any()).DoAndReturn(
+		func(ms underlayconn.Messages) (int, error) {
+			once.Do(func() { close(writing) })
+			<-release
+			return len(ms), nil
+		}).AnyTimes()
+	mInternal.EXPECT().Close()
+
+	mExternal := mock_router.NewMockBatchConn(ctrl)
+	mExternal.EXPECT().ReadBatch(gomock.Any()).DoAndReturn(
+		func(m underlayconn.Messages) (int, error) {
+			spkt, dpath := prepInboundMsg(t, key, time.Now())
+			msg := toMsg(t, spkt, dpath)
+			copy(m[0].Buffers[0], msg.Buffers[0])
+			m[0].N = len(msg.Buffers[0])
+			m[0].Addr = &net.UDPAddr{IP: net.IP{10, 0, 200, 200}}
+			return 1, nil
+		},
+	).Times(1)
+	mExternal.EXPECT().ReadBatch(gomock.Any()).Return(0, nil).AnyTimes()
+	mExternal.EXPECT().Close()
+
+	require.NoError(t, d.SetIA(local))
+	require.NoError(t, d.SetKey(key))
+	require.NoError(t, d.AddInternalInterface(mInternal, net.IP{}))
+	require.NoError(t, d.AddExternalInterface(1, mExternal))
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- d.Run()
+	}()
+	defer close(release)
+	select {
+	case <-writing:
+	case <-time.After(3 * time.Second):
+		t.Fatalf("time out")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- d.Shutdown(ctx)
+	}()
+	// The dataplane can be configured while it shuts down.
+	configured := make(chan error, 1)
+	go func() {
+		configured <- d.AddSvc(addr.SvcCS, &net.UDPAddr{IP: net.IP{10, 0, 0, 1}})
+	}()
+	select {
+	case err := <-configured:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatalf("configuring the dataplane blocked")
+	}
+	// Shutdown gives up on the stuck forwarder once ctx is done.
+	select {
+	case err := <-shutdown:
+		assert.Error(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Shutdown did not return")
+	}
+	select {
+	case err := <-errs:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatalf("Run did not return")
+	}
+}
+
 func TestProcessPkt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -597,6 +1184,145 @@ func TestProcessPkt(t *testing.T) {
 			srcInterface: 1,
 			assertFunc:   assert.NoError,
 		},
+		"inbound dispatched port": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				dp := router.NewDP(nil, nil, mock_router.NewMockBatchConn(ctrl), nil,
+					nil, xtest.MustParseIA("1-ff00:0:110"), nil, key)
+				require.NoError(t, dp.SetPortRange(31000, 32767))
+				return dp
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, dpath := prepInboundMsg(t, key, now)
+				ret := toMsgL4(t, spkt, dpath,
+					&slayers.UDP{SrcPort: 40000, DstPort: 31001},
+					gopacket.Payload("actualpayloadbytes"),
+				)
+				if afterProcessing {
+					ret.Addr = &net.UDPAddr{IP: net.IP{10, 0, 100, 100}, Port: 31001}
+					ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				}
+				return ret
+			},
+			srcInterface: 1,
+			assertFunc:   assert.NoError,
+		},
+		"inbound port outside dispatched range": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				dp := router.NewDP(nil, nil, mock_router.NewMockBatchConn(ctrl), nil,
+					nil, xtest.MustParseIA("1-ff00:0:110"), nil, key)
+				require.NoError(t, dp.SetPortRange(31000, 32767))
+				return dp
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, dpath := prepInboundMsg(t, key, now)
+				ret := toMsgL4(t, spkt, dpath,
+					&slayers.UDP{SrcPort: 40000, DstPort: 40001},
+					gopacket.Payload("actualpayloadbytes"),
+				)
+				if afterProcessing {
+					ret.Addr = &net.UDPAddr{IP: net.IP{10, 0, 100, 100},
+						Port: topology.EndhostPort}
+					ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				}
+				return ret
+			},
+			srcInterface: 1,
+			assertFunc:   assert.NoError,
+		},
+		"inbound SCMP error dispatched port": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				dp := router.NewDP(nil, nil, mock_router.NewMockBatchConn(ctrl), nil,
+					nil, xtest.MustParseIA("1-ff00:0:110"), nil, key)
+				require.NoError(t, dp.SetPortRange(31000, 32767))
+				return dp
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, dpath := prepInboundMsg(t, key, now)
+				spkt.NextHdr = common.L4SCMP
+				// The quoted packet was sent by the end host from port 31002.
+				quote := &slayers.SCION{
+					NextHdr:  common.L4UDP,
+					PathType: empty.PathType,
+					Path:     empty.Path{},
+					DstIA:    xtest.MustParseIA("4-ff00:0:411"),
+					SrcIA:    xtest.MustParseIA("1-ff00:0:110"),
+				}
+				require.NoError(t, quote.SetDstAddr(&net.IPAddr{IP: net.IP{10, 0, 200, 200}}))
+				require.NoError(t, quote.SetSrcAddr(&net.IPAddr{IP: net.IP{10, 0, 100, 100}}))
+				quoteBuf := gopacket.NewSerializeBuffer()
+				require.NoError(t, gopacket.SerializeLayers(quoteBuf,
+					gopacket.SerializeOptions{FixLengths: true},
+					quote, &slayers.UDP{SrcPort: 31002, DstPort: 40000},
+				))
+				ret := toMsgL4(t, spkt, dpath,
+					&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(
+						slayers.SCMPTypeDestinationUnreachable, slayers.SCMPCodeNoRoute)},
+					&slayers.SCMPDestinationUnreachable{},
+					gopacket.Payload(quoteBuf.Bytes()),
+				)
+				if afterProcessing {
+					ret.Addr = &net.UDPAddr{IP: net.IP{10, 0, 100, 100}, Port: 31002}
+					ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				}
+				return ret
+			},
+			srcInterface: 1,
+			assertFunc:   assert.NoError,
+		},
+		"inbound with previous key": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				dp := router.NewDP(nil, nil, mock_router.NewMockBatchConn(ctrl), nil,
+					nil, xtest.MustParseIA("1-ff00:0:110"), nil, key)
+				require.NoError(t, dp.RolloverKey([]byte("new_testkey_xxxx")))
+				return dp
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, dpath := prepBaseMsg(now)
+				spkt.DstIA = xtest.MustParseIA("1-ff00:0:110")
+				dst := &net.IPAddr{IP: net.ParseIP("10.0.100.100").To4()}
+				_ = spkt.SetDstAddr(dst)
+				dpath.HopFields = []*path.HopField{
+					{ConsIngress: 41, ConsEgress: 40},
+					{ConsIngress: 31, ConsEgress: 30},
+					{ConsIngress: 01, ConsEgress: 0},
+				}
+				dpath.Base.PathMeta.CurrHF = 2
+				dpath.HopFields[2].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[2])
+				ret := toMsg(t, spkt, dpath)
+				if afterProcessing {
+					ret.Addr = &net.UDPAddr{IP: dst.IP, Port: topology.EndhostPort}
+					ret.Flags, ret.NN, ret.N, ret.OOB = 0, 0, 0, nil
+				}
+				return ret
+			},
+			srcInterface: 1,
+			assertFunc:   assert.NoError,
+		},
+		"inbound with key before previous key": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				dp := router.NewDP(nil, nil, mock_router.NewMockBatchConn(ctrl), nil,
+					nil, xtest.MustParseIA("1-ff00:0:110"), nil, key)
+				require.NoError(t, dp.RolloverKey([]byte("new_testkey_xxxx")))
+				require.NoError(t, dp.RolloverKey([]byte("new_testkey_yyyy")))
+				return dp
+			},
+			mockMsg: func(afterProcessing bool) *ipv4.Message {
+				spkt, dpath := prepBaseMsg(now)
+				spkt.DstIA = xtest.MustParseIA("1-ff00:0:110")
+				dst := &net.IPAddr{IP: net.ParseIP("10.0.100.100").To4()}
+				_ = spkt.SetDstAddr(dst)
+				dpath.HopFields = []*path.HopField{
+					{ConsIngress: 41, ConsEgress: 40},
+					{ConsIngress: 31, ConsEgress: 30},
+					{ConsIngress: 01, ConsEgress: 0},
+				}
+				dpath.Base.PathMeta.CurrHF = 2
+				dpath.HopFields[2].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[2])
+				return toMsg(t, spkt, dpath)
+			},
+			srcInterface: 1,
+			assertFunc:   assert.Error,
+		},
 		"outbound": {
 			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
 				return router.NewDP(
@@ -1192,6 +1918,241 @@ func TestProcessPkt(t *testing.T) {
 	}
 }
 
+func TestProcessPktSCMP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := []byte("testkey_xxxxxxxx")
+	now := time.Now()
+	epicTS, err := libepic.CreateTimestamp(now, now)
+	require.NoError(t, err)
+	localIA := xtest.MustParseIA("1-ff00:0:110")
+	internalIP := net.ParseIP("10.0.0.1").To4()
+
+	// badMACOneHop returns an outbound OneHop packet with an invalid MAC.
+	badMACOneHop := func() (*ipv4.Message, uint16) {
+		spkt, _ := prepBaseMsg(now)
+		spkt.PathType = onehop.PathType
+		spkt.SrcIA = localIA
+		spkt.DstIA = xtest.MustParseIA("1-ff00:0:111")
+		require.NoError(t, spkt.SetDstAddr(addr.SVCMcast|addr.SvcCS))
+		require.NoError(t, spkt.SetSrcAddr(&net.IPAddr{IP: net.ParseIP("10.0.200.200").To4()}))
+		dpath := &onehop.Path{
+			Info: path.InfoField{
+				ConsDir:   true,
+				SegID:     0x222,
+				Timestamp: util.TimeToSecs(now),
+			},
+			FirstHop: path.HopField{
+				ExpTime:    63,
+				ConsEgress: 2,
+				Mac:        []byte{1, 2, 3, 4, 5, 6},
+			},
+		}
+		return toMsg(t, spkt, dpath), uint16(slayers.CmnHdrLen + spkt.AddrHdrLen() +
+			path.InfoLen)
+	}
+	oneHopDP := func(ctrl *gomock.Controller) *router.DataPlane {
+		return router.NewDP(
+			map[uint16]router.BatchConn{
+				uint16(2): mock_router.NewMockBatchConn(ctrl),
+			},
+			nil, mock_router.NewMockBatchConn(ctrl), nil, nil, localIA,
+			map[uint16]addr.IA{
+				uint16(2): xtest.MustParseIA("1-ff00:0:111"),
+			}, key)
+	}
+
+	testCases := map[string]struct {
+		prepareDP    func(*gomock.Controller) *router.DataPlane
+		mockMsg      func() (*ipv4.Message, uint16)
+		srcInterface uint16
+		wantCode     slayers.SCMPCode
+		wantPathType path.Type
+	}{
+		"epic invalid timestamp": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(nil, nil, mock_router.NewMockBatchConn(ctrl), nil,
+					nil, localIA, nil, key)
+			},
+			mockMsg: func() (*ipv4.Message, uint16) {
+				spkt, epicpath, dpath := prepEpicMsg(t, false, key, epicTS, now)
+				epicpath.PktID.Timestamp = epicpath.PktID.Timestamp + 250000
+				prepareEpicCrypto(t, spkt, epicpath, dpath, key)
+				msg := toIP(t, spkt, epicpath, false)
+				return msg, uint16(slayers.CmnHdrLen + spkt.AddrHdrLen())
+			},
+			srcInterface: 1,
+			wantCode:     slayers.SCMPCodeInvalidPacketTimestamp,
+			wantPathType: scion.PathType,
+		},
+		"epic invalid LHVF": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(nil, nil, mock_router.NewMockBatchConn(ctrl), nil,
+					nil, localIA, nil, key)
+			},
+			mockMsg: func() (*ipv4.Message, uint16) {
+				spkt, epicpath, dpath := prepEpicMsg(t, false, key, epicTS, now)
+				prepareEpicCrypto(t, spkt, epicpath, dpath, key)
+				epicpath.LHVF = []byte{0, 0, 0, 0}
+				msg := toIP(t, spkt, epicpath, false)
+				return msg, uint16(slayers.CmnHdrLen + spkt.AddrHdrLen() +
+					epic.PktIDLen + epic.HVFLen)
+			},
+			srcInterface: 1,
+			wantCode:     slayers.SCMPCodeInvalidHopFieldMAC,
+			wantPathType: scion.PathType,
+		},
+		"invalid destination address": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(nil, nil, mock_router.NewMockBatchConn(ctrl), nil,
+					nil, localIA, nil, key)
+			},
+			mockMsg: func() (*ipv4.Message, uint16) {
+				spkt, dpath := prepInboundMsg(t, key, now)
+				// There is no address type with a length of 8 bytes.
+				spkt.DstAddrType, spkt.DstAddrLen = slayers.T4Ip, slayers.AddrLen8
+				spkt.RawDstAddr = make([]byte, 8)
+				return toMsg(t, spkt, dpath), uint16(slayers.CmnHdrLen + 2*addr.IABytes)
+			},
+			srcInterface: 1,
+			wantCode:     slayers.SCMPCodeInvalidDestinationAddress,
+			wantPathType: scion.PathType,
+		},
+		"onehop outbound invalid MAC": {
+			prepareDP:    oneHopDP,
+			mockMsg:      badMACOneHop,
+			srcInterface: 0,
+			wantCode:     slayers.SCMPCodeInvalidHopFieldMAC,
+			wantPathType: empty.PathType,
+		},
+		"onehop outbound unknown interface": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(nil, nil, mock_router.NewMockBatchConn(ctrl), nil,
+					nil, localIA, nil, key)
+			},
+			mockMsg:      badMACOneHop,
+			srcInterface: 0,
+			wantCode:     slayers.SCMPCodeUnknownHopFieldEgress,
+			wantPathType: empty.PathType,
+		},
+		"onehop inbound reverse construction direction": {
+			prepareDP: func(ctrl *gomock.Controller) *router.DataPlane {
+				return router.NewDP(
+					map[uint16]router.BatchConn{
+						uint16(1): mock_router.NewMockBatchConn(ctrl),
+					},
+					nil, mock_router.NewMockBatchConn(ctrl), nil, nil, localIA,
+					map[uint16]addr.IA{
+						uint16(1): xtest.MustParseIA("1-ff00:0:111"),
+					}, key)
+			},
+			mockMsg: func() (*ipv4.Message, uint16) {
+				spkt, _ := prepBaseMsg(now)
+				spkt.PathType = onehop.PathType
+				spkt.SrcIA = xtest.MustParseIA("1-ff00:0:111")
+				spkt.DstIA = localIA
+				require.NoError(t, spkt.SetDstAddr(addr.SVCMcast|addr.SvcCS))
+				require.NoError(t, spkt.SetSrcAddr(
+					&net.IPAddr{IP: net.ParseIP("10.0.200.200").To4()}))
+				dpath := &onehop.Path{
+					Info: path.InfoField{
+						SegID:     0x222,
+						Timestamp: util.TimeToSecs(now),
+					},
+					FirstHop: path.HopField{
+						ExpTime:    63,
+						ConsEgress: 11,
+					},
+				}
+				return toMsg(t, spkt, dpath), uint16(slayers.CmnHdrLen + spkt.AddrHdrLen())
+			},
+			srcInterface: 1,
+			wantCode:     slayers.SCMPCodeInvalidPath,
+			wantPathType: onehop.PathType,
+		},
+	}
+
+	for name, tc := range testCases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dp := tc.prepareDP(ctrl)
+			dp.SetInternalIP(internalIP)
+			input, wantPointer := tc.mockMsg()
+			result, err := dp.ProcessPkt(tc.srcInterface, input)
+			require.Error(t, err)
+			require.NotNil(t, result.OutPkt)
+
+			pkt := gopacket.NewPacket(result.OutPkt, slayers.LayerTypeSCION, gopacket.Default)
+			require.Nil(t, pkt.ErrorLayer())
+			scn := pkt.Layer(slayers.LayerTypeSCION).(*slayers.SCION)
+			assert.Equal(t, tc.wantPathType, scn.PathType)
+			assert.Equal(t, localIA, scn.SrcIA)
+			scmp := pkt.Layer(slayers.LayerTypeSCMP).(*slayers.SCMP)
+			assert.Equal(t, slayers.CreateSCMPTypeCode(slayers.SCMPTypeParameterProblem,
+				tc.wantCode), scmp.TypeCode)
+			pp := pkt.Layer(slayers.LayerTypeSCMPParameterProblem).(*slayers.SCMPParameterProblem)
+			assert.Equal(t, wantPointer, pp.Pointer)
+		})
+	}
+
+	t.Run("rate limited", func(t *testing.T) {
+		dp := oneHopDP(ctrl)
+		dp.SetInternalIP(internalIP)
+		dp.SetSCMPRate(0, 1)
+
+		input, _ := badMACOneHop()
+		result, err := dp.ProcessPkt(0, input)
+		assert.Error(t, err)
+		assert.NotNil(t, result.OutPkt)
+
+		input, _ = badMACOneHop()
+		result, err = dp.ProcessPkt(0, input)
+		assert.Error(t, err)
+		assert.Nil(t, result.OutPkt)
+	})
+
+	t.Run("SCMP error does not use up the rate limit", func(t *testing.T) {
+		dp := oneHopDP(ctrl)
+		dp.SetInternalIP(internalIP)
+		dp.SetSCMPRate(0, 1)
+
+		spkt, _ := prepBaseMsg(now)
+		spkt.PathType = onehop.PathType
+		spkt.NextHdr = common.L4SCMP
+		spkt.SrcIA = localIA
+		spkt.DstIA = xtest.MustParseIA("1-ff00:0:111")
+		require.NoError(t, spkt.SetDstAddr(addr.SVCMcast|addr.SvcCS))
+		require.NoError(t, spkt.SetSrcAddr(&net.IPAddr{IP: net.ParseIP("10.0.200.200").To4()}))
+		dpath := &onehop.Path{
+			Info: path.InfoField{
+				ConsDir:   true,
+				SegID:     0x222,
+				Timestamp: util.TimeToSecs(now),
+			},
+			FirstHop: path.HopField{
+				ExpTime:    63,
+				ConsEgress: 2,
+				Mac:        []byte{1, 2, 3, 4, 5, 6},
+			},
+		}
+		input := toMsgL4(t, spkt, dpath,
+			&slayers.SCMP{TypeCode: slayers.CreateSCMPTypeCode(
+				slayers.SCMPTypeDestinationUnreachable, slayers.SCMPCodeNoRoute)},
+			gopacket.Payload("actualpayloadbytes"),
+		)
+		result, err := dp.ProcessPkt(0, input)
+		assert.Error(t, err)
+		assert.Nil(t, result.OutPkt)
+
+		input, _ = badMACOneHop()
+		result, err = dp.ProcessPkt(0, input)
+		assert.Error(t, err)
+		assert.NotNil(t, result.OutPkt)
+	})
+}
+
 func toMsg(t *testing.T, spkt *slayers.SCION, dpath path.Path) *ipv4.Message {
 	t.Helper()
 	ret := &ipv4.Message{}
@@ -1210,6 +2171,39 @@ func toMsg(t *testing.T, spkt *slayers.SCION, dpath path.Path) *ipv4.Message {
 	return ret
 }
 
+// toMsgL4 serializes the packet with the given L4 layers.
+func toMsgL4(t *testing.T, spkt *slayers.SCION, dpath path.Path,
+	l4 ...gopacket.SerializableLayer) *ipv4.Message {
+
+	t.Helper()
+	spkt.Path = dpath
+	buffer := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(buffer, gopacket.SerializeOptions{FixLengths: true},
+		append([]gopacket.SerializableLayer{spkt}, l4...)...)
+	require.NoError(t, err)
+	raw := buffer.Bytes()
+	return &ipv4.Message{
+		Buffers: [][]byte{append([]byte(nil), raw...)},
+		N:       len(raw),
+	}
+}
+
+// prepInboundMsg prepares a packet that is delivered to the end host
+// 10.0.100.100 in the local AS 1-ff00:0:110.
+func prepInboundMsg(t *testing.T, key []byte, now time.Time) (*slayers.SCION, *scion.Decoded) {
+	spkt, dpath := prepBaseMsg(now)
+	spkt.DstIA = xtest.MustParseIA("1-ff00:0:110")
+	require.NoError(t, spkt.SetDstAddr(&net.IPAddr{IP: net.IP{10, 0, 100, 100}}))
+	dpath.HopFields = []*path.HopField{
+		{ConsIngress: 41, ConsEgress: 40},
+		{ConsIngress: 31, ConsEgress: 30},
+		{ConsIngress: 01, ConsEgress: 0},
+	}
+	dpath.Base.PathMeta.CurrHF = 2
+	dpath.HopFields[2].Mac = computeMAC(t, key, dpath.InfoFields[0], dpath.HopFields[2])
+	return spkt, dpath
+}
+
 func prepBaseMsg(now time.Time) (*slayers.SCION, *scion.Decoded) {
 	spkt := &slayers.SCION{
 		Version:      0,
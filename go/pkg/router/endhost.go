@@ -0,0 +1,126 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/binary"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers"
+)
+
+// dispatchedPort returns the port on which the end host receives the packet,
+// if the port is in the dispatched port range. The port is the destination
+// port for UDP, the identifier for SCMP informational replies, and the source
+// port of the quoted packet for SCMP errors.
+func (d *DataPlane) dispatchedPort(lastLayer gopacket.DecodingLayer) (uint16, bool) {
+	if d.dispatchedPortStart == 0 {
+		return 0, false
+	}
+	port, ok := l4DstPort(lastLayer.NextLayerType(), lastLayer.LayerPayload())
+	if !ok || port < d.dispatchedPortStart || port > d.dispatchedPortEnd {
+		return 0, false
+	}
+	return port, true
+}
+
+// l4DstPort extracts the port of the end host from the L4 header.
+func l4DstPort(l4 gopacket.LayerType, pld []byte) (uint16, bool) {
+	switch l4 {
+	case slayers.LayerTypeSCIONUDP:
+		if len(pld) < 4 {
+			return 0, false
+		}
+		return binary.BigEndian.Uint16(pld[2:4]), true
+	case slayers.LayerTypeSCMP:
+		var scmp slayers.SCMP
+		if err := scmp.DecodeFromBytes(pld, gopacket.NilDecodeFeedback); err != nil {
+			return 0, false
+		}
+		return scmpDstPort(&scmp)
+	default:
+		return 0, false
+	}
+}
+
+// scmpDstPort extracts the port of the end host from the SCMP message.
+func scmpDstPort(scmp *slayers.SCMP) (uint16, bool) {
+	var msg interface {
+		DecodeFromBytes([]byte, gopacket.DecodeFeedback) error
+		LayerPayload() []byte
+	}
+	switch scmp.TypeCode.Type() {
+	case slayers.SCMPTypeEchoReply:
+		var echo slayers.SCMPEcho
+		if err := echo.DecodeFromBytes(scmp.Payload, gopacket.NilDecodeFeedback); err != nil {
+			return 0, false
+		}
+		return echo.Identifier, true
+	case slayers.SCMPTypeTracerouteReply:
+		var tr slayers.SCMPTraceroute
+		if err := tr.DecodeFromBytes(scmp.Payload, gopacket.NilDecodeFeedback); err != nil {
+			return 0, false
+		}
+		return tr.Identifier, true
+	case slayers.SCMPTypeDestinationUnreachable:
+		msg = &slayers.SCMPDestinationUnreachable{}
+	case slayers.SCMPTypePacketTooBig:
+		msg = &slayers.SCMPPacketTooBig{}
+	case slayers.SCMPTypeParameterProblem:
+		msg = &slayers.SCMPParameterProblem{}
+	case slayers.SCMPTypeExternalInterfaceDown:
+		msg = &slayers.SCMPExternalInterfaceDown{}
+	case slayers.SCMPTypeInternalConnectivityDown:
+		msg = &slayers.SCMPInternalConnectivityDown{}
+	default:
+		// Informational requests are answered by the dispatcher.
+		return 0, false
+	}
+	if err := msg.DecodeFromBytes(scmp.Payload, gopacket.NilDecodeFeedback); err != nil {
+		return 0, false
+	}
+	return quotedSrcPort(msg.LayerPayload())
+}
+
+// quotedSrcPort extracts the port of the sender of the packet quoted in an
+// SCMP error message.
+func quotedSrcPort(quote []byte) (uint16, bool) {
+	var s slayers.SCION
+	if err := s.DecodeFromBytes(quote, gopacket.NilDecodeFeedback); err != nil {
+		return 0, false
+	}
+	switch s.NextHdr {
+	case common.L4UDP:
+		if len(s.Payload) < 2 {
+			return 0, false
+		}
+		return binary.BigEndian.Uint16(s.Payload[0:2]), true
+	case common.L4SCMP:
+		var scmp slayers.SCMP
+		if err := scmp.DecodeFromBytes(s.Payload, gopacket.NilDecodeFeedback); err != nil {
+			return 0, false
+		}
+		switch scmp.TypeCode.Type() {
+		case slayers.SCMPTypeEchoRequest, slayers.SCMPTypeTracerouteRequest:
+			if len(scmp.Payload) < 2 {
+				return 0, false
+			}
+			return binary.BigEndian.Uint16(scmp.Payload[0:2]), true
+		}
+	}
+	return 0, false
+}
@@ -16,6 +16,7 @@ package router
 
 import (
 	"net"
+	"time"
 
 	"golang.org/x/net/ipv4"
 
@@ -69,6 +70,39 @@ func (d *DataPlane) ProcessPkt(ifID uint16, m *ipv4.Message) (ProcessResult, err
 	return ProcessResult{processResult: result}, err
 }
 
+// NewPacketProcessor returns a function that processes packets received on the
+// given interface. In contrast to ProcessPkt, all packets are processed by the
+// same processor, like in the forwarding pipeline.
+func NewPacketProcessor(d *DataPlane,
+	ifID uint16) func([]byte, *net.UDPAddr) (ProcessResult, error) {
+
+	p := newPacketProcessor(d, ifID)
+	return func(rawPkt []byte, srcAddr *net.UDPAddr) (ProcessResult, error) {
+		result, err := p.processPkt(rawPkt, srcAddr)
+		return ProcessResult{processResult: result}, err
+	}
+}
+
+// SetInternalIP sets the IP address of the internal interface, which is used
+// as source address of SCMP messages.
+func (d *DataPlane) SetInternalIP(ip net.IP) {
+	d.internalIP = ip
+}
+
+// SetSCMPRate limits the rate of SCMP messages generated in response to
+// packets received on the given interface.
+func (d *DataPlane) SetSCMPRate(ifID uint16, rate int) {
+	if d.scmpLimiters == nil {
+		d.scmpLimiters = make(map[uint16]*tokenBucket)
+	}
+	d.scmpLimiters[ifID] = newTokenBucket(rate, rate)
+}
+
+// NewTokenBucket returns the allow function of a new token bucket.
+func NewTokenBucket(rate, burst int) func(time.Time) bool {
+	return newTokenBucket(rate, burst).allow
+}
+
 func ExtractServices(s *services) map[addr.HostSVC][]*net.UDPAddr {
 	return s.m
 }
@@ -0,0 +1,88 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net"
+	"sort"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/pkg/router/bfd"
+)
+
+// InterfaceInfo describes an external interface known to the dataplane.
+type InterfaceInfo struct {
+	// ID is the interface ID.
+	ID uint16
+	// NeighborIA is the IA of the neighboring AS. It is zero if it is not
+	// known.
+	NeighborIA addr.IA
+	// LinkType is the type of the link to the neighboring AS.
+	LinkType topology.LinkType
+	// NextHop is the address of the sibling router that owns the interface.
+	// It is nil for interfaces owned by this router.
+	NextHop *net.UDPAddr
+	// BFD is the status of the BFD session that monitors the interface. For
+	// interfaces owned by a sibling router, the session monitors the sibling.
+	// It is nil if BFD is disabled.
+	BFD *bfd.Status
+}
+
+// Interfaces returns the external interfaces known to the dataplane, sorted by
+// interface ID. This includes the interfaces owned by sibling routers.
+func (d *DataPlane) Interfaces() []InterfaceInfo {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	ifIDs := make([]uint16, 0, len(d.external)+len(d.internalNextHops))
+	for ifID := range d.external {
+		ifIDs = append(ifIDs, ifID)
+	}
+	for ifID := range d.internalNextHops {
+		if _, ok := d.external[ifID]; !ok {
+			ifIDs = append(ifIDs, ifID)
+		}
+	}
+	sort.Slice(ifIDs, func(i, j int) bool { return ifIDs[i] < ifIDs[j] })
+
+	infos := make([]InterfaceInfo, 0, len(ifIDs))
+	for _, ifID := range ifIDs {
+		info := InterfaceInfo{
+			ID:         ifID,
+			NeighborIA: d.neighborIAs[ifID],
+			LinkType:   d.linkTypes[ifID],
+			NextHop:    d.internalNextHops[ifID],
+		}
+		if s, ok := d.bfdSessions[ifID]; ok {
+			status := s.Status()
+			info.BFD = &status
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Services returns the addresses of the service instances known to the
+// dataplane, by service. Packets destined to a service address are forwarded
+// to any of the addresses of the service.
+func (d *DataPlane) Services() map[addr.HostSVC][]*net.UDPAddr {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.svc == nil {
+		return map[addr.HostSVC][]*net.UDPAddr{}
+	}
+	return d.svc.List()
+}
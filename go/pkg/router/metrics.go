@@ -25,7 +25,10 @@ type Metrics struct {
 	OutputBytesTotal          *prometheus.CounterVec
 	InputPacketsTotal         *prometheus.CounterVec
 	OutputPacketsTotal        *prometheus.CounterVec
+	OutputBatchesTotal        *prometheus.CounterVec
 	DroppedPacketsTotal       *prometheus.CounterVec
+	SCMPRateLimitedTotal      *prometheus.CounterVec
+	IngressRateLimitedTotal   *prometheus.CounterVec
 	InterfaceUp               *prometheus.GaugeVec
 	BFDInterfaceStateChanges  *prometheus.CounterVec
 	BFDPacketsSent            *prometheus.CounterVec
@@ -70,6 +73,14 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"interface", "isd_as", "neighbor_isd_as"},
 		),
+		OutputBatchesTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "router_output_batches_total",
+				Help: "Total number of batches of packets sent. Together with " +
+					"router_output_pkts_total, this reports the average batch size.",
+			},
+			[]string{"interface", "isd_as", "neighbor_isd_as"},
+		),
 		DroppedPacketsTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "router_dropped_pkts_total",
@@ -78,6 +89,22 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"interface", "isd_as", "neighbor_isd_as"},
 		),
+		SCMPRateLimitedTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "router_scmp_rate_limited_total",
+				Help: "Total number of SCMP messages that were not generated because " +
+					"the SCMP rate limit of the ingress interface was exceeded.",
+			},
+			[]string{"interface", "isd_as", "neighbor_isd_as"},
+		),
+		IngressRateLimitedTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "router_ingress_rate_limited_pkts_total",
+				Help: "Total number of packets dropped because the ingress rate limit " +
+					"of the neighboring AS was exceeded.",
+			},
+			[]string{"interface", "isd_as", "neighbor_isd_as"},
+		),
 		InterfaceUp: promauto.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "router_interface_up",
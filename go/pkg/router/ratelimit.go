@@ -0,0 +1,62 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket is a token bucket rate limiter. The bucket holds at most burst
+// tokens and is refilled with rate tokens per second. It is safe for
+// concurrent use.
+type tokenBucket struct {
+	mtx    sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full token bucket.
+func newTokenBucket(rate, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// allow takes a token from the bucket at the given time. It returns false if
+// no token is available.
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if now.After(b.last) {
+		if !b.last.IsZero() {
+			b.tokens += now.Sub(b.last).Seconds() * b.rate
+			if b.tokens > b.burst {
+				b.tokens = b.burst
+			}
+		}
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,48 @@
+// Copyright 2021 Anapaya Systems
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/pkg/router"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	allow := router.NewTokenBucket(10, 2)
+
+	// The bucket is initially full.
+	assert.True(t, allow(now))
+	assert.True(t, allow(now))
+	assert.False(t, allow(now))
+
+	// One token is added every 100ms.
+	assert.False(t, allow(now.Add(50*time.Millisecond)))
+	assert.True(t, allow(now.Add(100*time.Millisecond)))
+	assert.False(t, allow(now.Add(100*time.Millisecond)))
+
+	// The bucket does not hold more than burst tokens.
+	later := now.Add(time.Hour)
+	assert.True(t, allow(later))
+	assert.True(t, allow(later))
+	assert.False(t, allow(later))
+
+	// Going back in time does not add tokens.
+	assert.False(t, allow(now))
+}
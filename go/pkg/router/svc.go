@@ -67,6 +67,21 @@ func (s *services) Any(svc addr.HostSVC) (*net.UDPAddr, bool) {
 	return addrs[mrand.Intn(len(addrs))], true
 }
 
+// List returns a copy of the addresses of all services.
+func (s *services) List() map[addr.HostSVC][]*net.UDPAddr {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	m := make(map[addr.HostSVC][]*net.UDPAddr, len(s.m))
+	for svc, addrs := range s.m {
+		if len(addrs) == 0 {
+			continue
+		}
+		m[svc] = append([]*net.UDPAddr(nil), addrs...)
+	}
+	return m
+}
+
 func (s *services) index(a *net.UDPAddr, addrs []*net.UDPAddr) (int, bool) {
 	for i, o := range addrs {
 		if a.IP.Equal(o.IP) && a.Port == o.Port {
@@ -23,6 +23,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/env/envtest"
 	"github.com/scionproto/scion/go/lib/log/logtest"
+	"github.com/scionproto/scion/go/pkg/api/apitest"
 	"github.com/scionproto/scion/go/pkg/gateway/config/configtest"
 	"github.com/scionproto/scion/go/posix-gateway/config"
 )
@@ -43,6 +44,7 @@ func InitConfig(cfg *config.Config) {
 	logtest.InitTestLogging(&cfg.Logging)
 	configtest.InitGateway(&cfg.Gateway)
 	configtest.InitTunnel(&cfg.Tunnel)
+	apitest.InitConfig(&cfg.API)
 }
 
 func CheckConfig(t *testing.T, cfg *config.Config) {
@@ -50,4 +52,5 @@ func CheckConfig(t *testing.T, cfg *config.Config) {
 	logtest.CheckTestLogging(t, &cfg.Logging, "gateway")
 	configtest.CheckGateway(t, &cfg.Gateway)
 	configtest.CheckTunnel(t, &cfg.Tunnel)
+	apitest.CheckConfig(t, &cfg.API)
 }
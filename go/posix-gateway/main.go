@@ -16,6 +16,7 @@ package main
 
 import (
 	"context"
+	"io/ioutil"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
@@ -76,6 +77,17 @@ func realMain() error {
 		dataAddress.IP = controlAddress.IP
 		dataAddress.Zone = controlAddress.Zone
 	}
+	var encryptionKey []byte
+	if globalCfg.Gateway.EncryptionKeyFile != "" {
+		encryptionKey, err = ioutil.ReadFile(globalCfg.Gateway.EncryptionKeyFile)
+		if err != nil {
+			return serrors.WrapStr("reading encryption key", err)
+		}
+		if len(encryptionKey) == 0 {
+			return serrors.New("empty encryption key",
+				"file", globalCfg.Gateway.EncryptionKeyFile)
+		}
+	}
 	httpPages := service.StatusPages{
 		"info":      service.NewInfoStatusPage(),
 		"config":    service.NewConfigStatusPage(globalCfg),
@@ -94,16 +106,23 @@ func realMain() error {
 		ProbeClientIP:            controlAddress.IP,
 		DataServerAddr:           dataAddress,
 		DataClientIP:             dataAddress.IP,
+		EncryptionKey:            encryptionKey,
+		FailoverTimeout:          globalCfg.Gateway.FailoverTimeout.Duration,
+		PathMTUDiscovery:         globalCfg.Gateway.PathMTUDiscovery,
 		Dispatcher:               reliable.NewDispatcher(""),
 		Daemon:                   daemon,
 		RouteSourceIPv4:          globalCfg.Tunnel.SrcIPv4,
 		RouteSourceIPv6:          globalCfg.Tunnel.SrcIPv6,
 		TunnelName:               globalCfg.Tunnel.Name,
+		RouteTableID:             globalCfg.Tunnel.RouteTableID,
+		RouteMetric:              globalCfg.Tunnel.RouteMetric,
+		RouteImportTableID:       globalCfg.Tunnel.ImportTableID,
 		RoutingTableReader:       routingTable,
 		RoutingTableSwapper:      routingTable,
 		ConfigReloadTrigger:      reloadConfigTrigger,
 		HTTPEndpoints:            httpPages,
 		HTTPServeMux:             http.DefaultServeMux,
+		APIAddr:                  globalCfg.API.Addr,
 		Logger:                   log.New(),
 		Metrics:                  gateway.NewMetrics(),
 	}
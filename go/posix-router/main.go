@@ -15,15 +15,22 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"sync"
 
+	"github.com/go-chi/chi/v5"
+
+	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/fatal"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/pkg/app/launcher"
 	"github.com/scionproto/scion/go/pkg/router"
+	"github.com/scionproto/scion/go/pkg/router/api"
 	"github.com/scionproto/scion/go/pkg/router/config"
 	"github.com/scionproto/scion/go/pkg/router/control"
 	"github.com/scionproto/scion/go/pkg/service"
@@ -51,6 +58,15 @@ func realMain() error {
 	dp := &router.Connector{
 		DataPlane: router.DataPlane{
 			Metrics: metrics,
+			RunConfig: router.RunConfig{
+				NumProcessors:   globalCfg.Router.NumProcessors,
+				BatchSize:       globalCfg.Router.BatchSize,
+				MaxFlushLatency: globalCfg.Router.MaxFlushLatency.Duration,
+				SCMPRate:        globalCfg.Router.SCMPRate,
+				SCMPBurst:       globalCfg.Router.SCMPBurst,
+				IngressRate:     globalCfg.Router.IngressRate,
+				IngressBurst:    globalCfg.Router.IngressBurst,
+			},
 		},
 	}
 	iaCtx := &control.IACtx{
@@ -61,9 +77,15 @@ func realMain() error {
 	if err := iaCtx.Start(wg); err != nil {
 		return serrors.WrapStr("starting dataplane", err)
 	}
-	if err := setupHTTPHandlers(); err != nil {
+	if err := setupHTTPHandlers(iaCtx); err != nil {
 		return serrors.WrapStr("starting HTTP endpoints", err)
 	}
+	startAPI(&dp.DataPlane)
+	env.SetupEnv(func() {
+		if err := reloadConfig(iaCtx); err != nil {
+			log.Error("Failed to reload configuration", "err", err)
+		}
+	})
 
 	errs := make(chan error, 1)
 	go func() {
@@ -82,7 +104,15 @@ func realMain() error {
 		return err
 	case <-fatal.ShutdownChan():
 		// Whenever we receive a SIGINT or SIGTERM we exit without an error.
-		// Deferred shutdowns for all running servers run now.
+		// The dataplane is drained first, such that the neighbors can fail
+		// over before the process exits. Deferred shutdowns for all running
+		// servers run afterwards.
+		ctx, cancel := context.WithTimeout(context.Background(),
+			globalCfg.Router.DrainTimeout.Duration)
+		defer cancel()
+		if err := dp.DataPlane.Shutdown(ctx); err != nil {
+			log.Info("Dataplane not drained completely", "err", err)
+		}
 		close(stop)
 		wg.Wait()
 		return nil
@@ -99,11 +129,58 @@ func loadControlConfig() (*control.Config, error) {
 	return newConf, nil
 }
 
-func setupHTTPHandlers() error {
+// reloadConfig reloads the topology and the master keys from the config
+// directory, and applies the changes to the running data plane.
+func reloadConfig(iaCtx *control.IACtx) error {
+	newConf, err := loadControlConfig()
+	if err != nil {
+		return err
+	}
+	return iaCtx.UpdateConfig(newConf)
+}
+
+// newReloadStatusPage returns the status page that triggers a configuration
+// reload on POST requests. Only requests from the loopback interface are
+// accepted, because the status pages are served without authentication.
+func newReloadStatusPage(iaCtx *control.IACtx) service.StatusPage {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		if !isLoopback(r.RemoteAddr) {
+			http.Error(w, "only allowed from the local host", http.StatusForbidden)
+			return
+		}
+		log.Info("Received config reload request")
+		if err := reloadConfig(iaCtx); err != nil {
+			log.Error("Failed to reload configuration", "err", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprintln(w, "configuration reloaded")
+	}
+	return service.StatusPage{Handler: handler, Special: true}
+}
+
+// isLoopback returns whether the remote address of a request is a loopback
+// address.
+func isLoopback(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func setupHTTPHandlers(iaCtx *control.IACtx) error {
 	statusPages := service.StatusPages{
 		"info":      service.NewInfoStatusPage(),
 		"config":    service.NewConfigStatusPage(globalCfg),
 		"log/level": service.NewLogLevelStatusPage(),
+		"reload":    newReloadStatusPage(iaCtx),
 		// TODO: Add topology page
 	}
 	if err := statusPages.Register(http.DefaultServeMux, globalCfg.General.ID); err != nil {
@@ -112,3 +189,27 @@ func setupHTTPHandlers() error {
 	globalCfg.Metrics.StartPrometheus()
 	return nil
 }
+
+// startAPI exposes the router API if an address is configured.
+func startAPI(dp *router.DataPlane) {
+	if globalCfg.API.Addr == "" {
+		return
+	}
+	// The API exposes state-changing endpoints, e.g., for setting the log
+	// level, so cross-origin requests are deliberately not allowed.
+	r := chi.NewRouter()
+	server := api.Server{
+		DataPlane: dp,
+		Config:    service.NewConfigStatusPage(globalCfg).Handler,
+		Info:      service.NewInfoStatusPage().Handler,
+		LogLevel:  service.NewLogLevelStatusPage().Handler,
+	}
+	log.Info("Exposing API", "addr", globalCfg.API.Addr)
+	h := api.HandlerFromMux(&server, r)
+	go func() {
+		defer log.HandlePanic()
+		if err := http.ListenAndServe(globalCfg.API.Addr, h); err != nil {
+			fatal.Fatal(serrors.WrapStr("serving HTTP API", err))
+		}
+	}()
+}
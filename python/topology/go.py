@@ -123,6 +123,9 @@ class GoGenerator(object):
             'metrics': self._metrics_entry(infra_elem, CS_PROM_PORT),
             'features': translate_features(self.args.features),
         }
+        sd_ip = sciond_ip(self.args.docker, topo_id, self.args.networks)
+        if sd_ip is not None:
+            raw_entry['drkey'] = {'allowed_hosts': [str(sd_ip)]}
         return raw_entry
 
     def generate_co(self):